@@ -0,0 +1,118 @@
+package bindings
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// LoadABI loads a contract ABI from the given JSON file, to replace the compiled-in one of the given
+// bindings. The loaded ABI may have additional events and methods, but it must keep all the compiled-in
+// ones unchanged, since the generated bindings pack and unpack them by the compiled-in definitions.
+func LoadABI(path string, compiled *bind.MetaData) (*abi.ABI, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ABI file: %w", err)
+	}
+	defer f.Close()
+
+	parsed, err := abi.JSON(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI file %s: %w", path, err)
+	}
+
+	compiledABI, err := compiled.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkABICompatible(compiledABI, &parsed); err != nil {
+		return nil, fmt.Errorf("incompatible ABI file %s: %w", path, err)
+	}
+
+	return &parsed, nil
+}
+
+// checkABICompatible checks whether the given loaded ABI keeps all the methods and events of the given
+// compiled-in ABI unchanged.
+func checkABICompatible(compiled *abi.ABI, loaded *abi.ABI) error {
+	for name, method := range compiled.Methods {
+		loadedMethod, ok := loaded.Methods[name]
+		if !ok {
+			return fmt.Errorf("missing method %s", method.Sig)
+		}
+		if loadedMethod.Sig != method.Sig || argumentTypes(loadedMethod.Outputs) != argumentTypes(method.Outputs) {
+			return fmt.Errorf(
+				"method %s changed to %s returns (%s)",
+				method.Sig, loadedMethod.Sig, argumentTypes(loadedMethod.Outputs),
+			)
+		}
+	}
+
+	for name, event := range compiled.Events {
+		loadedEvent, ok := loaded.Events[name]
+		if !ok {
+			return fmt.Errorf("missing event %s", event.Sig)
+		}
+		if loadedEvent.ID != event.ID || indexedArguments(loadedEvent.Inputs) != indexedArguments(event.Inputs) {
+			return fmt.Errorf("event %s changed to %s", event.Sig, loadedEvent.Sig)
+		}
+	}
+
+	return nil
+}
+
+// argumentTypes returns the comma-separated canonical types of the given arguments.
+func argumentTypes(args abi.Arguments) string {
+	var types string
+	for i, arg := range args {
+		if i != 0 {
+			types += ","
+		}
+		types += arg.Type.String()
+	}
+	return types
+}
+
+// indexedArguments returns the indexes of the indexed ones of the given event arguments.
+func indexedArguments(args abi.Arguments) string {
+	var indexed string
+	for i, arg := range args {
+		if arg.Indexed {
+			indexed += fmt.Sprintf("%d,", i)
+		}
+	}
+	return indexed
+}
+
+// NewTaikoL1ClientWithABI creates a new instance of TaikoL1Client bound to a specific deployed contract,
+// with the given ABI instead of the compiled-in one, see LoadABI.
+func NewTaikoL1ClientWithABI(
+	address common.Address,
+	backend bind.ContractBackend,
+	parsed *abi.ABI,
+) *TaikoL1Client {
+	contract := bind.NewBoundContract(address, *parsed, backend, backend, backend)
+	return &TaikoL1Client{
+		TaikoL1ClientCaller:     TaikoL1ClientCaller{contract: contract},
+		TaikoL1ClientTransactor: TaikoL1ClientTransactor{contract: contract},
+		TaikoL1ClientFilterer:   TaikoL1ClientFilterer{contract: contract},
+	}
+}
+
+// NewTaikoL2ClientWithABI creates a new instance of TaikoL2Client bound to a specific deployed contract,
+// with the given ABI instead of the compiled-in one, see LoadABI.
+func NewTaikoL2ClientWithABI(
+	address common.Address,
+	backend bind.ContractBackend,
+	parsed *abi.ABI,
+) *TaikoL2Client {
+	contract := bind.NewBoundContract(address, *parsed, backend, backend, backend)
+	return &TaikoL2Client{
+		TaikoL2ClientCaller:     TaikoL2ClientCaller{contract: contract},
+		TaikoL2ClientTransactor: TaikoL2ClientTransactor{contract: contract},
+		TaikoL2ClientFilterer:   TaikoL2ClientFilterer{contract: contract},
+	}
+}
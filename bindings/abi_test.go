@@ -0,0 +1,70 @@
+package bindings
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestABI writes the compiled-in TaikoL1 ABI modified by the given function to a temporary file.
+func writeTestABI(t *testing.T, modify func(entries []map[string]interface{}) []map[string]interface{}) string {
+	var entries []map[string]interface{}
+	require.Nil(t, json.Unmarshal([]byte(TaikoL1ClientMetaData.ABI), &entries))
+
+	data, err := json.Marshal(modify(entries))
+	require.Nil(t, err)
+
+	path := filepath.Join(t.TempDir(), "TaikoL1.json")
+	require.Nil(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestLoadABI(t *testing.T) {
+	// An additional event is allowed.
+	path := writeTestABI(t, func(entries []map[string]interface{}) []map[string]interface{} {
+		return append(entries, map[string]interface{}{
+			"type": "event",
+			"name": "ForkedEvent",
+			"inputs": []map[string]interface{}{
+				{"name": "id", "type": "uint256", "indexed": true},
+			},
+		})
+	})
+	parsed, err := LoadABI(path, TaikoL1ClientMetaData)
+	require.Nil(t, err)
+	require.Contains(t, parsed.Events, "ForkedEvent")
+	require.NotNil(t, NewTaikoL1ClientWithABI(common.Address{}, &ethclient.Client{}, parsed))
+
+	// A removed method is rejected.
+	path = writeTestABI(t, func(entries []map[string]interface{}) []map[string]interface{} {
+		var filtered []map[string]interface{}
+		for _, entry := range entries {
+			if entry["name"] != "proveBlock" {
+				filtered = append(filtered, entry)
+			}
+		}
+		return filtered
+	})
+	_, err = LoadABI(path, TaikoL1ClientMetaData)
+	require.ErrorContains(t, err, "missing method proveBlock")
+
+	// A changed event is rejected.
+	path = writeTestABI(t, func(entries []map[string]interface{}) []map[string]interface{} {
+		for _, entry := range entries {
+			if entry["type"] == "event" && entry["name"] == "BlockVerified" {
+				entry["inputs"] = []map[string]interface{}{{"name": "id", "type": "uint256", "indexed": true}}
+			}
+		}
+		return entries
+	})
+	_, err = LoadABI(path, TaikoL1ClientMetaData)
+	require.ErrorContains(t, err, "event BlockVerified")
+
+	_, err = LoadABI(filepath.Join(t.TempDir(), "missing.json"), TaikoL1ClientMetaData)
+	require.ErrorContains(t, err, "failed to open ABI file")
+}
@@ -104,8 +104,14 @@ func ToGethHeader(header *BlockHeader) *types.Header {
 	}
 }
 
-// ToExecutableDataV1 converts a GETH *types.Header to *beacon.ExecutableDataV1.
+// ToExecutableDataV1 converts a GETH *types.Header to *beacon.ExecutableDataV1, a post-Shanghai header
+// has an empty withdrawals list, since there are no withdrawals in L2.
 func ToExecutableDataV1(header *types.Header) *engine.ExecutableData {
+	var withdrawals []*types.Withdrawal
+	if header.WithdrawalsHash != nil {
+		withdrawals = []*types.Withdrawal{}
+	}
+
 	return &engine.ExecutableData{
 		ParentHash:    header.ParentHash,
 		FeeRecipient:  header.Coinbase,
@@ -121,6 +127,7 @@ func ToExecutableDataV1(header *types.Header) *engine.ExecutableData {
 		BaseFeePerGas: header.BaseFee,
 		BlockHash:     header.Hash(),
 		TxHash:        header.TxHash,
+		Withdrawals:   withdrawals,
 	}
 }
 
@@ -117,6 +117,12 @@ func TestToExecutableDataV1(t *testing.T) {
 	require.Equal(t, testHeader.BaseFee, data.BaseFeePerGas)
 	require.Equal(t, testHeader.Hash(), data.BlockHash)
 	require.Equal(t, testHeader.TxHash, data.TxHash)
+	require.Nil(t, data.Withdrawals)
+
+	header := types.CopyHeader(testHeader)
+	header.WithdrawalsHash = &types.EmptyRootHash
+	require.NotNil(t, ToExecutableDataV1(header).Withdrawals)
+	require.Empty(t, ToExecutableDataV1(header).Withdrawals)
 }
 
 // randomHash generates a random blob of data and returns it as a hash.
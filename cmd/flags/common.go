@@ -52,6 +52,22 @@ var (
 		Category: commonCategory,
 	}
 	// Optional flags used by all client softwares.
+	TaikoL1ABIPath = &cli.StringFlag{
+		Name:     "taiko-l1-abi-path",
+		Usage:    "Path of a TaikoL1 ABI JSON file replacing the compiled-in one, for the non-standard deployments",
+		Category: commonCategory,
+	}
+	TaikoL2ABIPath = &cli.StringFlag{
+		Name:     "taiko-l2-abi-path",
+		Usage:    "Path of a TaikoL2 ABI JSON file replacing the compiled-in one, for the non-standard deployments",
+		Category: commonCategory,
+	}
+	L1ArchiveEndpoint = &cli.StringFlag{
+		Name: "l1.archiveEndpoint",
+		Usage: "RPC endpoint of an archive L1 ethereum node, serving the data pruned by the L1 endpoint, " +
+			"and the prover's BlockProposed event backfill queries",
+		Category: commonCategory,
+	}
 	// Logging
 	Verbosity = &cli.IntFlag{
 		Name:     "verbosity",
@@ -64,6 +80,17 @@ var (
 		Usage:    "Format logs with JSON",
 		Category: loggingCategory,
 	}
+	// HTTP server
+	HTTPAddr = &cli.StringFlag{
+		Name:     "http.addr",
+		Usage:    "Listening address of the HTTP server exposing the status APIs, disabled if empty",
+		Category: commonCategory,
+	}
+	AdminToken = &cli.StringFlag{
+		Name:     "admin-token",
+		Usage:    "Bearer token required by the admin APIs of the HTTP server, the admin APIs are disabled if empty",
+		Category: commonCategory,
+	}
 	// Metrics
 	MetricsEnabled = &cli.BoolFlag{
 		Name:     "metrics",
@@ -92,8 +119,11 @@ var CommonFlags = []cli.Flag{
 	TaikoL1Address,
 	TaikoL2Address,
 	// Optional
+	TaikoL1ABIPath,
+	TaikoL2ABIPath,
 	Verbosity,
 	LogJson,
+	HTTPAddr,
 	MetricsEnabled,
 	MetricsAddr,
 	MetricsPort,
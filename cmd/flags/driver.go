@@ -1,6 +1,8 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/urfave/cli/v2"
 )
 
@@ -18,6 +20,13 @@ var (
 		Required: true,
 		Category: driverCategory,
 	}
+	JWTRefreshInterval = &cli.DurationFlag{
+		Name: "l2-engine-jwt-refresh-interval",
+		Usage: "Interval to re-read the --jwtSecret file, so that a rotated JWT secret is used without " +
+			"restarting, 0 to disable",
+		Value:    1 * time.Hour,
+		Category: driverCategory,
+	}
 	SignalServiceAddress = &cli.StringFlag{
 		Name:     "l1.signalService",
 		Usage:    "L1 singal service contract address",
@@ -47,14 +56,108 @@ var (
 		Usage:    "HTTP RPC endpoint of another synced L2 execution engine node",
 		Category: driverCategory,
 	}
+	SyncMode = &cli.StringFlag{
+		Name: "driver.syncMode",
+		Usage: "Strategy to sync the L2 execution engine's chain: `full` (derive all blocks from L1 calldata), " +
+			"`p2p` (P2P sync verified blocks first) or `checkpoint` (fetch verified blocks from the check point node first)",
+		Value:    "full",
+		Category: driverCategory,
+	}
+	CatchUpBatchSize = &cli.Uint64Flag{
+		Name:    "driver.catchUpBatchSize",
+		Aliases: []string{"l2-sync-batch-size"},
+		Usage: "Number of L2 blocks inserted between two L2 execution engine head updates when catching up, " +
+			"0 or 1 means updating the head after each block",
+		Value:    16,
+		Category: driverCategory,
+	}
+	SlowBlockThreshold = &cli.DurationFlag{
+		Name: "driver.slowBlockThreshold",
+		Usage: "Wall-clock budget of deriving a L2 block, once exceeded, the block's stage timings and diagnostics " +
+			"are logged, and then its progress every 30 seconds until it is derived, 0 means disabled",
+		Value:    time.Minute,
+		Category: driverCategory,
+	}
+	MaxSyncGap = &cli.Uint64Flag{
+		Name:     "driver.maxSyncGap",
+		Usage:    "Maximum number of L1 blocks the driver's sync progress can fall behind the L1 head before alerting",
+		Value:    128,
+		Category: driverCategory,
+	}
+	WaitForL1Finality = &cli.BoolFlag{
+		Name: "driver.waitForL1Finality",
+		Usage: "Only derive the L2 blocks proposed in the finalized L1 blocks, which trades latency for " +
+			"safety against the deep L1 reorgs",
+		Value:    false,
+		Category: driverCategory,
+	}
+	AlertWebhookURL = &cli.StringFlag{
+		Name:     "alert-webhook-url",
+		Usage:    "If set, alerts will be posted to this webhook URL as JSON payloads",
+		Category: driverCategory,
+	}
+	DriverBlockFeedSocket = &cli.StringFlag{
+		Name: "driver.blockFeedSocket",
+		Usage: "If set, the freshly derived blocks will be streamed through this unix socket, " +
+			"so that a co-located prover can start proving them without waiting for its L2 node",
+		Category: driverCategory,
+	}
+	EnableMessageRelayer = &cli.BoolFlag{
+		Name:     "enable-message-relayer",
+		Usage:    "Relay the L2-to-L1 bridge messages sent in the verified L2 blocks to L1",
+		Value:    false,
+		Category: driverCategory,
+	}
+	RelayerPrivateKey = &cli.StringFlag{
+		Name:     "relayer-private-key",
+		Usage:    "Private key of the L1 account sending the relayed bridge messages, required by --enable-message-relayer",
+		Category: driverCategory,
+	}
+	L1BridgeAddress = &cli.StringFlag{
+		Name:     "l1.bridge",
+		Usage:    "L1 bridge contract address, required by --enable-message-relayer",
+		Category: driverCategory,
+	}
+	L2BridgeAddress = &cli.StringFlag{
+		Name:     "l2.bridge",
+		Usage:    "L2 bridge contract address, required by --enable-message-relayer",
+		Category: driverCategory,
+	}
+	L2SignalServiceAddress = &cli.StringFlag{
+		Name:     "l2.signalService",
+		Usage:    "L2 signal service contract address, required by --enable-message-relayer",
+		Category: driverCategory,
+	}
+	StateSnapshotPath = &cli.StringFlag{
+		Name: "state-snapshot-path",
+		Usage: "If set, the driver states will be persisted to this file, and used to resume syncing " +
+			"without re-scanning the L1 blocks after restarting",
+		Category: driverCategory,
+	}
 )
 
 // All driver flags.
 var DriverFlags = MergeFlags(CommonFlags, []cli.Flag{
 	L2WSEndpoint,
 	L2AuthEndpoint,
+	L1ArchiveEndpoint,
 	SignalServiceAddress,
 	JWTSecret,
+	JWTRefreshInterval,
 	P2PSyncVerifiedBlocks,
 	P2PSyncTimeout,
+	CheckPointSyncUrl,
+	SyncMode,
+	CatchUpBatchSize,
+	SlowBlockThreshold,
+	MaxSyncGap,
+	WaitForL1Finality,
+	AlertWebhookURL,
+	DriverBlockFeedSocket,
+	EnableMessageRelayer,
+	RelayerPrivateKey,
+	L1BridgeAddress,
+	L2BridgeAddress,
+	L2SignalServiceAddress,
+	StateSnapshotPath,
 })
@@ -2,6 +2,7 @@ package flags
 
 import (
 	"math/rand"
+	"time"
 
 	"github.com/urfave/cli/v2"
 )
@@ -40,11 +41,64 @@ var (
 		Usage:    "Comma separated accounts to treat as locals (priority inclusion)",
 		Category: proposerCategory,
 	}
+	ForbiddenToAddresses = &cli.StringFlag{
+		Name:     "forbidden-to-addresses",
+		Usage:    "Comma separated addresses, transactions sent to which will not be proposed",
+		Category: proposerCategory,
+	}
 	ProposeEmptyBlocksInterval = &cli.StringFlag{
 		Name:     "proposeEmptyBlockInterval",
 		Usage:    "Time interval to propose empty blocks",
 		Category: proposerCategory,
 	}
+	MempoolFillRatioThreshold = &cli.Float64Flag{
+		Name: "mempool-fill-ratio-threshold",
+		Usage: "If set, the proposing interval is doubled, up to --max-proposal-interval, each time the pending " +
+			"transactions are fewer than this ratio of the maximum transactions per block, and reset to " +
+			"--proposeInterval otherwise, 0 means a fixed interval",
+		Category: proposerCategory,
+	}
+	MaxProposalInterval = &cli.DurationFlag{
+		Name:     "max-proposal-interval",
+		Usage:    "Maximum proposing interval when --mempool-fill-ratio-threshold is set",
+		Value:    2 * time.Minute,
+		Category: proposerCategory,
+	}
+	BuilderEndpoint = &cli.StringFlag{
+		Name: "proposer.builderEndpoint",
+		Usage: "If set, the transactions lists will be built by this external block builder API, " +
+			"falling back to L2 execution engine's transaction pool if the builder fails",
+		Category: proposerCategory,
+	}
+	BuilderToken = &cli.StringFlag{
+		Name:     "proposer.builderToken",
+		Usage:    "Bearer token used to authenticate with the external block builder",
+		Category: proposerCategory,
+	}
+	BuilderTimeout = &cli.DurationFlag{
+		Name:     "proposer.builderTimeout",
+		Usage:    "Timeout of each external block builder request",
+		Value:    2 * time.Second,
+		Category: proposerCategory,
+	}
+	ArchiveDir = &cli.StringFlag{
+		Name: "proposer.archiveDir",
+		Usage: "If set, the exact proposeBlock inputs of the latest proposals will be archived in this directory, " +
+			"and exposed by the HTTP server's `/proposals/{blockID}` API",
+		Category: proposerCategory,
+	}
+	ArchiveSize = &cli.UintFlag{
+		Name:     "proposer.archiveSize",
+		Usage:    "Maximum number of the latest proposals kept in the archive",
+		Value:    128,
+		Category: proposerCategory,
+	}
+	ArchiveMaxBytes = &cli.Int64Flag{
+		Name:     "proposer.archiveMaxBytes",
+		Usage:    "Maximum total size in bytes of the archived proposals, 0 means no limit",
+		Value:    256 * 1024 * 1024,
+		Category: proposerCategory,
+	}
 )
 
 // All proposer flags.
@@ -55,5 +109,14 @@ var ProposerFlags = MergeFlags(CommonFlags, []cli.Flag{
 	ProposeInterval,
 	CommitSlot,
 	TxPoolLocals,
+	ForbiddenToAddresses,
 	ProposeEmptyBlocksInterval,
+	MempoolFillRatioThreshold,
+	MaxProposalInterval,
+	BuilderEndpoint,
+	BuilderToken,
+	BuilderTimeout,
+	ArchiveDir,
+	ArchiveSize,
+	ArchiveMaxBytes,
 })
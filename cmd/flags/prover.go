@@ -1,14 +1,17 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/urfave/cli/v2"
 )
 
 // Required flags used by prover.
 var (
-	ZkEvmRpcdEndpoint = &cli.StringFlag{
-		Name:     "zkevmRpcdEndpoint",
-		Usage:    "RPC endpoint of a ZKEVM RPCD service",
+	ZkEvmRpcdEndpoint = &cli.StringSliceFlag{
+		Name: "zkevmRpcdEndpoint",
+		Usage: "RPC endpoint of a ZKEVM RPCD service, if multiple endpoints are given, the latter ones " +
+			"are used in order as the fallbacks when the former ones fail",
 		Required: true,
 		Category: proverCategory,
 	}
@@ -34,12 +37,442 @@ var (
 		Usage:    "If set, prover will start proving blocks from the block with this ID",
 		Category: proverCategory,
 	}
+	StartingBlockHash = &cli.StringFlag{
+		Name:     "prover.startingBlockHash",
+		Usage:    "If set, prover will start proving blocks from the L2 block with this hash",
+		Category: proverCategory,
+	}
+	StartingTimestamp = &cli.Uint64Flag{
+		Name:     "prover.startingTimestamp",
+		Usage:    "If set, prover will start proving blocks from the first block proposed at or after this unix timestamp",
+		Category: proverCategory,
+	}
 	MaxConcurrentProvingJobs = &cli.UintFlag{
 		Name:     "maxConcurrentProvingJobs",
 		Usage:    "Limits the number of concurrent proving blocks jobs",
 		Value:    1,
 		Category: proverCategory,
 	}
+	MaxConcurrentWitnessJobs = &cli.UintFlag{
+		Name: "prover.maxConcurrentWitnessJobs",
+		Usage: "If set, limits the number of concurrent witness preparations separately from the proving jobs, " +
+			"so that the proof producer is kept fed with the prepared jobs",
+		Category: proverCategory,
+	}
+	MaxPreparedWitnesses = &cli.UintFlag{
+		Name:     "prover.maxPreparedWitnesses",
+		Usage:    "Max number of the prepared witnesses waiting for a proving job, default to maxConcurrentProvingJobs",
+		Category: proverCategory,
+	}
+	BlockDedupCacheSize = &cli.UintFlag{
+		Name:     "prover.blockDedupCacheSize",
+		Usage:    "Capacity of the cache used to skip the re-delivered BlockProposed events of already handled blocks",
+		Value:    1024,
+		Category: proverCategory,
+	}
+	ZkEvmRpcdParamsTiers = &cli.StringSliceFlag{
+		Name: "zkevmRpcdParamsTiers",
+		Usage: "Smaller circuit parameter tiers of ZKEVM RPCD, as `maxGas:path`, each block is proved with the smallest " +
+			"tier covering its gas used, or --zkevmRpcdParamsPath",
+		Category: proverCategory,
+	}
+	ZkEvmRpcdHealthPath = &cli.StringFlag{
+		Name: "zkevmRpcdHealthPath",
+		Usage: "Health path of the ZKEVM RPCD service, if set, new proof requests will be deferred " +
+			"while the service responds 429 / 503 or reports a saturated queue depth",
+		Category: proverCategory,
+	}
+	ZkEvmRpcdMaxQueueDepth = &cli.Uint64Flag{
+		Name:     "zkevmRpcdMaxQueueDepth",
+		Usage:    "Queue depth reported by the ZKEVM RPCD health path, at which the service is saturated, 0 means no limit",
+		Category: proverCategory,
+	}
+	ZkEvmRpcdFallbackProbeInterval = &cli.DurationFlag{
+		Name:     "zkevmRpcdFallbackProbeInterval",
+		Usage:    "Interval before a failed ZKEVM RPCD endpoint is tried again, when multiple endpoints are given",
+		Value:    time.Minute,
+		Category: proverCategory,
+	}
+	ZkEvmRpcdConnectTimeout = &cli.DurationFlag{
+		Name:     "zkevmRpcdConnectTimeout",
+		Usage:    "Timeout of connecting to the ZKEVM RPCD service",
+		Value:    10 * time.Second,
+		Category: proverCategory,
+	}
+	ZkEvmRpcdPollTimeout = &cli.DurationFlag{
+		Name:     "zkevmRpcdPollTimeout",
+		Usage:    "Timeout of each proof job poll sent to the ZKEVM RPCD service",
+		Value:    time.Minute,
+		Category: proverCategory,
+	}
+	ZkEvmRpcdProofTimeout = &cli.DurationFlag{
+		Name:     "zkevmRpcdProofTimeout",
+		Usage:    "Timeout of a whole ZKEVM RPCD proof job, after which it is cancelled and retried, 0 means no limit",
+		Category: proverCategory,
+	}
+	ZkEvmRpcdQueueWorkers = &cli.UintFlag{
+		Name: "zkevmRpcdQueueWorkers",
+		Usage: "If set, the proof requests are queued inside the prover and sent to the ZKEVM RPCD service by this " +
+			"many workers, so that a busy service doesn't hold the proving slots, 0 means sending them directly",
+		Category: proverCategory,
+	}
+	ZkEvmRpcdQueueDepth = &cli.UintFlag{
+		Name:     "zkevmRpcdQueueDepth",
+		Usage:    "Capacity of the proof request queue of --zkevmRpcdQueueWorkers, the requests are retried once it is full",
+		Value:    64,
+		Category: proverCategory,
+	}
+	SkipRpcdProbe = &cli.BoolFlag{
+		Name:     "prover.skipRpcdProbe",
+		Usage:    "Start the prover even if the ZKEVM RPCD endpoints can't be reached at startup",
+		Value:    false,
+		Category: proverCategory,
+	}
+	SkipProofValidation = &cli.BoolFlag{
+		Name:     "prover.skipProofValidation",
+		Usage:    "Hand the generated proofs to the submitter without checking their structure, for experimental circuits",
+		Value:    false,
+		Category: proverCategory,
+	}
+	ProofSizes = &cli.StringSliceFlag{
+		Name: "prover.proofSizes",
+		Usage: "Expected byte lengths of the generated proofs by circuit degree, as `degree:bytes`, the proofs of " +
+			"other degrees are only checked to be word-aligned",
+		Category: proverCategory,
+	}
+	ZkEvmRpcdJournal = &cli.StringFlag{
+		Name:     "zkevmRpcdJournal",
+		Usage:    "Path of the on-disk journal of the in-flight ZKEVM RPCD proof jobs, to resume them after restarts",
+		Category: proverCategory,
+	}
+	RpcdCallbackAddr = &cli.StringFlag{
+		Name:     "prover.rpcdCallbackAddr",
+		Usage:    "Address of the listener of the proofs pushed by ZKEVM RPCD, if set, the callback mode replaces polling",
+		Category: proverCategory,
+	}
+	RpcdCallbackURL = &cli.StringFlag{
+		Name:     "prover.rpcdCallbackURL",
+		Usage:    "External base URL of --prover.rpcdCallbackAddr reachable from ZKEVM RPCD, default: http://{addr}",
+		Category: proverCategory,
+	}
+	RpcdCallbackTimeout = &cli.DurationFlag{
+		Name:     "prover.rpcdCallbackTimeout",
+		Usage:    "Timeout of waiting for a proof pushed by ZKEVM RPCD, after which the proof job is retried",
+		Value:    time.Hour,
+		Category: proverCategory,
+	}
+	ZkEvmRpcdCancelMethod = &cli.StringFlag{
+		Name:     "zkevmRpcdCancelMethod",
+		Usage:    "JSON-RPC method of the ZKEVM RPCD service to cancel a proof job, if the service exposes one",
+		Category: proverCategory,
+	}
+	ZkEvmRpcdProgressMethod = &cli.StringFlag{
+		Name:     "zkevmRpcdProgressMethod",
+		Usage:    "JSON-RPC method of the ZKEVM RPCD service reporting a proof job's progress, if the service exposes one",
+		Category: proverCategory,
+	}
+	ZkEvmRpcdProgressInterval = &cli.DurationFlag{
+		Name:     "zkevmRpcdProgressInterval",
+		Usage:    "Interval between the progress polls of each ZKEVM RPCD proof job",
+		Value:    30 * time.Second,
+		Category: proverCategory,
+	}
+	ZkEvmRpcdStallThreshold = &cli.DurationFlag{
+		Name:     "zkevmRpcdStallThreshold",
+		Usage:    "Warn when a ZKEVM RPCD proof job reports no progress for this long, 0 means never",
+		Category: proverCategory,
+	}
+	ZkEvmRpcdStallTimeout = &cli.BoolFlag{
+		Name:     "zkevmRpcdStallTimeout",
+		Usage:    "Fail the stalled ZKEVM RPCD proof jobs as timed out, so that they are cancelled and retried at once",
+		Category: proverCategory,
+	}
+	PriorityQueueSize = &cli.UintFlag{
+		Name:     "priority-queue-size",
+		Usage:    "Maximum number of pending blocks queued for proving, ranked by their remaining proof windows",
+		Value:    1024,
+		Category: proverCategory,
+	}
+	ValidProofWeight = &cli.UintFlag{
+		Name: "prover.validProofWeight",
+		Usage: "Weight of the blocks with a valid transactions list when dequeuing the priority queue, " +
+			"against --prover.invalidProofWeight",
+		Value:    1,
+		Category: proverCategory,
+	}
+	InvalidProofWeight = &cli.UintFlag{
+		Name: "prover.invalidProofWeight",
+		Usage: "Weight of the blocks with an invalid transactions list when dequeuing the priority queue, " +
+			"so that their cheap proofs are not starved behind the long valid ones",
+		Value:    1,
+		Category: proverCategory,
+	}
+	EventChBufferSize = &cli.UintFlag{
+		Name:     "prover.eventChBufferSize",
+		Usage:    "Buffer size of the protocol event channels, oldest BlockProposed/BlockVerified events are dropped if full",
+		Value:    1024,
+		Category: proverCategory,
+	}
+	ProofChBufferSize = &cli.UintFlag{
+		Name:     "prover.proofChBufferSize",
+		Usage:    "Buffer size of the generated proof channels, the proof producers are blocked when full",
+		Value:    1024,
+		Category: proverCategory,
+	}
+	ProofWindow = &cli.DurationFlag{
+		Name:     "prover.proofWindow",
+		Usage:    "Proof window of the proposed blocks, used to rank the pending blocks by their remaining proof windows",
+		Value:    time.Hour,
+		Category: proverCategory,
+	}
+	MaxProvingLag = &cli.Uint64Flag{
+		Name: "prover.maxProvingLag",
+		Usage: "Maximum number of block IDs the proven block can lag behind the latest proposed block, " +
+			"older blocks will be skipped, 0 means no limit",
+		Value:    0,
+		Category: proverCategory,
+	}
+	MinProofRewardGwei = &cli.Uint64Flag{
+		Name:     "min-proof-reward-gwei",
+		Usage:    "If set, prover will skip proving the blocks whose current proof reward (in gwei) is below this value",
+		Category: proverCategory,
+	}
+	ProverConfigFile = &cli.StringFlag{
+		Name: "prover.configFile",
+		Usage: "Path to a JSON file of reloadable flag values (" +
+			"maxConcurrentProvingJobs, prover.maxProvingLag, min-proof-reward-gwei), re-read on SIGHUP",
+		Category: proverCategory,
+	}
+	SubmissionKeysFile = &cli.StringFlag{
+		Name: "prover.submissionKeysFile",
+		Usage: "Path to a file of hex encoded L1 private keys (one per line) used to send the proof " +
+			"submission transactions in a round-robin manner, re-read on SIGHUP, defaults to the prover's private key",
+		Category: proverCategory,
+	}
+	CompetingProofWindow = &cli.DurationFlag{
+		Name: "prover.competingProofWindow",
+		Usage: "If set, when another prover's proof transaction for the same block is pending in L1 mempool, " +
+			"delay the proof submission at most this window, and skip it if the competing one lands",
+		Category: proverCategory,
+	}
+	FeeStrategy = &cli.StringFlag{
+		Name: "prover.feeStrategy",
+		Usage: "Strategy of the proof submission transactions' priority fees: flat (the suggested fee within the " +
+			"bounds) or competition (raised towards --prover.maxPriorityFeeGwei for the contested blocks, the " +
+			"recent losses and the blocks close to the proof window's end, the contested blocks are detected " +
+			"through --prover.competingProofWindow)",
+		Value:    "flat",
+		Category: proverCategory,
+	}
+	MinPriorityFeeGwei = &cli.Uint64Flag{
+		Name:     "prover.minPriorityFeeGwei",
+		Usage:    "If set, the minimum priority fee (in gwei) of the proof submission transactions",
+		Category: proverCategory,
+	}
+	MaxPriorityFeeGwei = &cli.Uint64Flag{
+		Name:     "prover.maxPriorityFeeGwei",
+		Usage:    "If set, the maximum priority fee (in gwei) of the proof submission transactions",
+		Category: proverCategory,
+	}
+	MaxUnprovenBlockAge = &cli.DurationFlag{
+		Name: "prover.maxUnprovenBlockAge",
+		Usage: "If set, an alert will be posted to the webhook when the oldest block which still lacks any " +
+			"fork choice is older than this age",
+		Category: proverCategory,
+	}
+	LogSampling = &cli.Uint64Flag{
+		Name: "log.sampling",
+		Usage: "If set, the repetitive per-block log messages are only logged for their first N occurrences " +
+			"per minute, with a summary line of the suppressed ones, useful during a long catch-up",
+		Category: loggingCategory,
+	}
+	SubmissionRetention = &cli.DurationFlag{
+		Name: "prover.submissionRetention",
+		Usage: "How long the submitted proofs are kept in the `/status` endpoint's table, " +
+			"which tracks whether they finally got verified, 0 means keeping them forever",
+		Value:    24 * time.Hour,
+		Category: proverCategory,
+	}
+	ReconcileWindow = &cli.Uint64Flag{
+		Name: "prover.reconcileWindow",
+		Usage: "Number of the latest L1 blocks whose BlockProven events are scanned on startup, to rebuild " +
+			"the blocks proved by current prover in the previous runs, 0 means disabled",
+		Value:    7200,
+		Category: proverCategory,
+	}
+	ProverBlockFeedSocket = &cli.StringFlag{
+		Name: "prover.blockFeedSocket",
+		Usage: "Unix socket of a co-located driver's block feed (--driver.blockFeedSocket), if set, " +
+			"the freshly derived blocks will be proved without waiting for the L2 node",
+		Category: proverCategory,
+	}
+	ProverWebhookURL = &cli.StringFlag{
+		Name: "prover.webhookURL",
+		Usage: "Webhook URL to receive the prover lifecycle events (proof requested / generated / submitted, " +
+			"submission reverted, subscription lost) as JSON payloads, best-effort delivery",
+		Category: proverCategory,
+	}
+	SafeAddress = &cli.StringFlag{
+		Name: "safe-address",
+		Usage: "Address of a Gnosis Safe multi-sig wallet, if set, the proofs will be submitted through this Safe, " +
+			"the L1 prover's private key should be one of its owners",
+		Category: proverCategory,
+	}
+	SafeServiceURL = &cli.StringFlag{
+		Name: "safe-service-url",
+		Usage: "Safe Transaction Service URL used to collect confirmations, " +
+			"e.g. https://safe-transaction.{network}.gnosis.io",
+		Category: proverCategory,
+	}
+	SafeThreshold = &cli.Uint64Flag{
+		Name:     "safe-threshold",
+		Usage:    "Number of Safe owners' confirmations required before executing a proof submission",
+		Value:    1,
+		Category: proverCategory,
+	}
+	RequestProofMaxAttempts = &cli.Uint64Flag{
+		Name:     "prover.requestProofMaxAttempts",
+		Usage:    "Maximum number of attempts to request a proof for a block when the errors are transient",
+		Value:    5,
+		Category: proverCategory,
+	}
+	RequestProofRetryInterval = &cli.DurationFlag{
+		Name:     "prover.requestProofRetryInterval",
+		Usage:    "Interval between two attempts to request a proof for a block",
+		Value:    10 * time.Second,
+		Category: proverCategory,
+	}
+	ProofProducerType = &cli.StringFlag{
+		Name:     "proof-producer-type",
+		Usage:    "Type of the proof producer to use, supported: zkevmRpcd, grpc",
+		Value:    "zkevmRpcd",
+		Category: proverCategory,
+	}
+	GrpcProofProducerEndpoint = &cli.StringFlag{
+		Name:     "proof-producer-grpc-endpoint",
+		Usage:    "Endpoint of an external gRPC proof producer service, required by --proof-producer-type grpc",
+		Category: proverCategory,
+	}
+	ProofBackend = &cli.StringFlag{
+		Name: "prover.proofBackend",
+		Usage: "Backend generating the proofs, supported: rpcd, grpc, dummy, " +
+			"replaces --proof-producer-type and --dummy, which default to rpcd",
+		Category: proverCategory,
+	}
+	GrpcProofProducerTLS = &cli.BoolFlag{
+		Name:     "prover.grpcTLS",
+		Usage:    "Secure the connection to the gRPC proof producer service with TLS",
+		Category: proverCategory,
+	}
+	GrpcProofProducerCACert = &cli.StringFlag{
+		Name:     "prover.grpcTLSCACert",
+		Usage:    "PEM file of the CA certificates to verify the gRPC proof producer service with, system ones if not set",
+		Category: proverCategory,
+	}
+	GrpcProofProducerAuthToken = &cli.StringFlag{
+		Name:     "prover.grpcAuthToken",
+		Usage:    "Bearer token sent with each request to the gRPC proof producer service",
+		Category: proverCategory,
+	}
+	ProofCacheEndpoint = &cli.StringFlag{
+		Name:     "prover.proofCacheEndpoint",
+		Usage:    "HTTP endpoint of a proof cache service shared across provers, if set, cached proofs will be reused",
+		Category: proverCategory,
+	}
+	ProofCacheToken = &cli.StringFlag{
+		Name:     "prover.proofCacheToken",
+		Usage:    "Bearer token used to authenticate with the proof cache service",
+		Category: proverCategory,
+	}
+	ProofCacheDir = &cli.StringFlag{
+		Name:     "prover.proofCacheDir",
+		Usage:    "Directory of the local cache of the generated proofs, to reuse them after restarts or retries",
+		Category: proverCategory,
+	}
+	ProofCacheMaxAge = &cli.DurationFlag{
+		Name:     "prover.proofCacheMaxAge",
+		Usage:    "Max age of the proofs in --prover.proofCacheDir, 0 means they are only pruned once verified",
+		Value:    24 * time.Hour,
+		Category: proverCategory,
+	}
+	ProofArchiveDir = &cli.StringFlag{
+		Name:     "prover.proofArchiveDir",
+		Usage:    "Directory to archive the artifacts of the submitted proofs for auditing, one JSON file per block",
+		Category: proverCategory,
+	}
+	ProofArchiveMaxSize = &cli.Uint64Flag{
+		Name:     "prover.proofArchiveMaxSize",
+		Usage:    "Max total size in MiB of --prover.proofArchiveDir, the lowest blocks are pruned first, 0 means unlimited",
+		Value:    1024,
+		Category: proverCategory,
+	}
+	ProofTimeHistory = &cli.StringFlag{
+		Name: "prover.proofTimeHistory",
+		Usage: "Path of the on-disk history of the proof generation latencies, " +
+			"to keep the proof time estimates after restarts",
+		Category: proverCategory,
+	}
+	SkipNearlyStaleBlocks = &cli.BoolFlag{
+		Name: "prover.skipNearlyStaleBlocks",
+		Usage: "Also skip the blocks expected to exceed --prover.maxProvingLag before their proofs are generated, " +
+			"by the p90 of the estimated proof time and the protocol's average block time",
+		Value:    false,
+		Category: proverCategory,
+	}
+	RestoreSnapshot = &cli.StringFlag{
+		Name:     "prover.restoreSnapshot",
+		Usage:    "Path of a proof pipeline snapshot to restore, written by the `prover snapshot` command",
+		Category: proverCategory,
+	}
+	LeaseEndpoint = &cli.StringFlag{
+		Name: "prover.leaseEndpoint",
+		Usage: "HTTP endpoint of a lock service shared by the prover replicas using the same prover key, " +
+			"if set, a replica only handles the blocks which leases it holds",
+		Category: proverCategory,
+	}
+	LeaseToken = &cli.StringFlag{
+		Name:     "prover.leaseToken",
+		Usage:    "Bearer token used to authenticate with the lock service",
+		Category: proverCategory,
+	}
+	LeaseTTL = &cli.DurationFlag{
+		Name: "prover.leaseTTL",
+		Usage: "Time to live of a block lease, the lease is renewed while handling the block, " +
+			"a block will be handed over to another replica, if its lease is not renewed within this time",
+		Value:    time.Minute,
+		Category: proverCategory,
+	}
+	LeaseHolderID = &cli.StringFlag{
+		Name:     "prover.leaseHolderID",
+		Usage:    "ID of the current prover replica in the block leases, default to `{hostname}-{pid}`",
+		Category: proverCategory,
+	}
+	PeerL2Endpoints = &cli.StringFlag{
+		Name: "prover.peerL2Endpoints",
+		Usage: "Comma separated HTTP RPC endpoints of the peer L2 nodes, if set, the local L2 node's recent " +
+			"blocks will be compared with the peers' ones, and proving will be paused on divergence",
+		Category: proverCategory,
+	}
+	AdditionalL1Endpoints = &cli.StringFlag{
+		Name: "prover.additionalL1Endpoints",
+		Usage: "Comma separated websocket RPC endpoints of the additional L1 chains sharing the same L2 chain, " +
+			"if set, each successfully submitted proof will be replicated to them",
+		Category: proverCategory,
+	}
+	PollInterval = &cli.DurationFlag{
+		Name: "prover.pollInterval",
+		Usage: "Interval to poll the protocol events, used when the L1 endpoint is a HTTP endpoint " +
+			"which doesn't support subscriptions",
+		Value:    12 * time.Second,
+		Category: proverCategory,
+	}
+	DryRun = &cli.BoolFlag{
+		Name: "prover.dryRun",
+		Usage: "Generate proofs for the proposed blocks without submitting them, the TaikoL1.proveBlock " +
+			"transactions will only be simulated through eth_call to validate the generated proofs",
+		Value:    false,
+		Category: proverCategory,
+	}
 	// Special flags for testing.
 	Dummy = &cli.BoolFlag{
 		Name:     "dummy",
@@ -53,18 +486,131 @@ var (
 			"`lowerBound-upperBound` (e.g. `30m-1h`), testing purposes only",
 		Category: proverCategory,
 	}
+	DummyProofDelaySeed = &cli.Uint64Flag{
+		Name: "dummyProofDelaySeed",
+		Usage: "Derive each dummy proof delay between the --randomDummyProofDelay bounds from the block ID " +
+			"and this seed, instead of randomly, for the reproducible test runs",
+		Category: proverCategory,
+	}
+	DummyProofDelaysFile = &cli.StringFlag{
+		Name: "dummyProofDelaysFile",
+		Usage: "Path of a JSON file of the explicit dummy proof delays by block ID (e.g. `{\"1\": \"30s\"}`), " +
+			"which take precedence over --dummyProofDelaySeed and --randomDummyProofDelay",
+		Category: proverCategory,
+	}
+	DummyProofFallback = &cli.BoolFlag{
+		Name: "dummyProofFallback",
+		Usage: "Prove the blocks whose proof submissions revert with L1_INVALID_PROOF again with dummy proofs, " +
+			"at most 3 times, testing purposes only",
+		Value:    false,
+		Category: proverCategory,
+	}
 )
 
 // All prover flags.
 var ProverFlags = MergeFlags(CommonFlags, []cli.Flag{
 	L1HTTPEndpoint,
+	L1ArchiveEndpoint,
+	AdminToken,
 	L2WSEndpoint,
 	L2HTTPEndpoint,
 	ZkEvmRpcdEndpoint,
 	ZkEvmRpcdParamsPath,
+	ZkEvmRpcdParamsTiers,
+	ZkEvmRpcdHealthPath,
+	ZkEvmRpcdCancelMethod,
+	ZkEvmRpcdMaxQueueDepth,
+	ZkEvmRpcdFallbackProbeInterval,
+	ZkEvmRpcdConnectTimeout,
+	ZkEvmRpcdPollTimeout,
+	ZkEvmRpcdProofTimeout,
+	ZkEvmRpcdQueueWorkers,
+	ZkEvmRpcdQueueDepth,
+	ZkEvmRpcdJournal,
+	ZkEvmRpcdProgressMethod,
+	ZkEvmRpcdProgressInterval,
+	ZkEvmRpcdStallThreshold,
+	ZkEvmRpcdStallTimeout,
+	RpcdCallbackAddr,
+	RpcdCallbackURL,
+	RpcdCallbackTimeout,
+	SkipRpcdProbe,
+	SkipProofValidation,
+	ProofSizes,
 	L1ProverPrivKey,
 	StartingBlockID,
+	StartingBlockHash,
+	StartingTimestamp,
 	MaxConcurrentProvingJobs,
+	MaxConcurrentWitnessJobs,
+	MaxPreparedWitnesses,
+	BlockDedupCacheSize,
+	PriorityQueueSize,
+	ValidProofWeight,
+	InvalidProofWeight,
+	EventChBufferSize,
+	ProofChBufferSize,
+	ProofWindow,
+	MaxProvingLag,
+	MinProofRewardGwei,
+	ProverConfigFile,
+	SubmissionKeysFile,
+	CompetingProofWindow,
+	FeeStrategy,
+	MinPriorityFeeGwei,
+	MaxPriorityFeeGwei,
+	MaxUnprovenBlockAge,
+	SubmissionRetention,
+	ReconcileWindow,
+	LogSampling,
+	ProverBlockFeedSocket,
+	ProverWebhookURL,
+	SafeAddress,
+	SafeServiceURL,
+	SafeThreshold,
+	RequestProofMaxAttempts,
+	RequestProofRetryInterval,
+	ProofProducerType,
+	GrpcProofProducerEndpoint,
+	ProofBackend,
+	GrpcProofProducerTLS,
+	GrpcProofProducerCACert,
+	GrpcProofProducerAuthToken,
+	ProofCacheEndpoint,
+	ProofCacheToken,
+	ProofCacheDir,
+	ProofCacheMaxAge,
+	ProofArchiveDir,
+	ProofArchiveMaxSize,
+	ProofTimeHistory,
+	SkipNearlyStaleBlocks,
+	RestoreSnapshot,
+	LeaseEndpoint,
+	LeaseToken,
+	LeaseTTL,
+	LeaseHolderID,
+	PeerL2Endpoints,
+	AdditionalL1Endpoints,
+	PollInterval,
+	DryRun,
 	Dummy,
 	RandomDummyProofDelay,
+	DummyProofDelaySeed,
+	DummyProofDelaysFile,
+	DummyProofFallback,
 })
+
+// Flags used by the prover snapshot command.
+var (
+	SnapshotOutput = &cli.StringFlag{
+		Name:     "out",
+		Usage:    "Path of the proof pipeline snapshot to write",
+		Value:    "prover-snapshot.tar",
+		Category: proverCategory,
+	}
+)
+
+// All prover snapshot flags, the prover flags are inherited from the parent command.
+var ProverSnapshotFlags = []cli.Flag{
+	SnapshotOutput,
+}
@@ -0,0 +1,23 @@
+package flags
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+const supportBundleCategory = "SUPPORT BUNDLE"
+
+// Flags used by the support bundle command.
+var (
+	SupportBundleOutput = &cli.StringFlag{
+		Name:     "output",
+		Usage:    "Path of the gzipped tarball to write",
+		Value:    "taiko-client-support-bundle.tar.gz",
+		Category: supportBundleCategory,
+	}
+)
+
+// All support bundle flags.
+var SupportBundleFlags = []cli.Flag{
+	ArchiveDir,
+	SupportBundleOutput,
+}
@@ -52,6 +52,22 @@ func main() {
 			Usage:       "Starts the prover software",
 			Description: "Taiko prover software",
 			Action:      utils.SubcommandAction(new(prover.Prover)),
+			Subcommands: []*cli.Command{
+				{
+					Name:        "snapshot",
+					Flags:       flags.ProverSnapshotFlags,
+					Usage:       "Writes a proof pipeline snapshot of a stopped prover",
+					Description: "Writes a tarball of the journaled proof jobs and the cached proofs, for the migrations",
+					Action:      proverSnapshotAction,
+				},
+			},
+		},
+		{
+			Name:        "support-bundle",
+			Flags:       flags.SupportBundleFlags,
+			Usage:       "Writes a support bundle",
+			Description: "Writes a gzipped tarball containing the client version and the archived proposals",
+			Action:      utils.SupportBundleAction,
 		},
 	}
 
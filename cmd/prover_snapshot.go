@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/cmd/flags"
+	producer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+	"github.com/taikoxyz/taiko-client/prover/snapshot"
+	"github.com/taikoxyz/taiko-client/version"
+	"github.com/urfave/cli/v2"
+)
+
+// proverSnapshotAction writes a snapshot of a stopped prover's persisted proof pipeline state, the journaled
+// proof jobs and the locally cached proofs, to be restored by --prover.restoreSnapshot on another machine.
+// The in-memory state of a running prover is captured by its `/admin/snapshot` API instead.
+func proverSnapshotAction(c *cli.Context) error {
+	snap := &snapshot.Snapshot{
+		CreatedAt:      time.Now().UTC(),
+		ClientVersion:  version.VersionWithCommit(),
+		TaikoL1Address: common.HexToAddress(c.String(flags.TaikoL1Address.Name)),
+	}
+
+	if path := c.String(flags.ZkEvmRpcdJournal.Name); len(path) != 0 {
+		journal, err := producer.OpenProofJournal(context.Background(), path)
+		if err != nil {
+			return err
+		}
+		snap.Jobs = journal.Entries()
+	}
+
+	proofDir := c.String(flags.ProofCacheDir.Name)
+	if len(proofDir) != 0 {
+		proofs, err := snapshot.ListProofs(proofDir)
+		if err != nil {
+			return err
+		}
+		snap.Proofs = proofs
+	}
+
+	out := c.String(flags.SnapshotOutput.Name)
+	if err := snapshot.WriteFile(out, snap, proofDir); err != nil {
+		return err
+	}
+
+	log.Info("Proof pipeline snapshot written", "path", out, "jobs", len(snap.Jobs), "proofs", len(snap.Proofs))
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/cmd/flags"
+	"github.com/taikoxyz/taiko-client/proposer/archive"
+	"github.com/taikoxyz/taiko-client/version"
+	"github.com/urfave/cli/v2"
+)
+
+// SupportBundleAction writes a gzipped tarball containing the client version and the archived
+// proposals, which can be attached to the support requests and dispute resolutions.
+func SupportBundleAction(c *cli.Context) error {
+	f, err := os.Create(c.String(flags.SupportBundleOutput.Name))
+	if err != nil {
+		return fmt.Errorf("failed to create support bundle: %w", err)
+	}
+	defer f.Close()
+
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+
+	versionInfo := []byte(version.VersionWithCommit() + "\n")
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    "version",
+		Mode:    0o600,
+		Size:    int64(len(versionInfo)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return fmt.Errorf("failed to write support bundle: %w", err)
+	}
+	if _, err := tw.Write(versionInfo); err != nil {
+		return fmt.Errorf("failed to write support bundle: %w", err)
+	}
+
+	var proposals int
+	if dir := c.String(flags.ArchiveDir.Name); len(dir) != 0 {
+		if proposals, err = archive.WriteBundle(dir, tw); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to write support bundle: %w", err)
+	}
+	if err := gw.Close(); err != nil {
+		return fmt.Errorf("failed to write support bundle: %w", err)
+	}
+
+	log.Info("Support bundle written", "path", f.Name(), "proposals", proposals)
+
+	return nil
+}
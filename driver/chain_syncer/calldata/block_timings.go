@@ -0,0 +1,128 @@
+package calldata
+
+import (
+	"sync"
+	"time"
+)
+
+// Derivation stages of a L2 block, timed by blockTimings.
+const (
+	stageFetch      = "fetch"      // Fetching the L2 parent block and the L1 proposing transaction
+	stageDecode     = "decode"     // Validating and decoding the transactions list
+	stageWait       = "wait"       // Waiting for the timestamp of a future block
+	stageAnchor     = "anchor"     // Assembling the anchor transaction and fetching the L2 base fee
+	stageNewPayload = "newPayload" // Building and executing the payload through the Engine APIs
+	stageForkchoice = "forkchoice" // Updating the L2 execution engine's fork choice
+)
+
+// stageTiming is the time spent on a derivation stage of a L2 block.
+type stageTiming struct {
+	name     string
+	duration time.Duration
+}
+
+// blockTimings records the time spent on each derivation stage of a L2 block, it is written by the
+// derivation loop and can be read concurrently, e.g. by the slow block watchdog. All methods of a nil
+// blockTimings are no-ops.
+type blockTimings struct {
+	mu           sync.Mutex
+	start        time.Time
+	stages       []stageTiming // In the order the stages were first entered
+	current      int           // Index of the current stage in stages, -1 if none
+	currentStart time.Time
+}
+
+// newBlockTimings creates a new blockTimings of a L2 block whose derivation starts now.
+func newBlockTimings() *blockTimings {
+	return &blockTimings{start: time.Now(), current: -1}
+}
+
+// enter ends the current stage and starts the given one, the time spent on a stage entered more than once
+// is accumulated.
+func (t *blockTimings) enter(stage string) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	t.endCurrent(now)
+	for i := range t.stages {
+		if t.stages[i].name == stage {
+			t.current = i
+			t.currentStart = now
+			return
+		}
+	}
+	t.stages = append(t.stages, stageTiming{name: stage})
+	t.current = len(t.stages) - 1
+	t.currentStart = now
+}
+
+// finish ends the current stage, and returns the total time spent on the block.
+func (t *blockTimings) finish() time.Duration {
+	if t == nil {
+		return 0
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	t.endCurrent(now)
+	t.current = -1
+
+	return now.Sub(t.start)
+}
+
+// endCurrent adds the time spent on the current stage until the given time to it.
+func (t *blockTimings) endCurrent(now time.Time) {
+	if t.current >= 0 {
+		t.stages[t.current].duration += now.Sub(t.currentStart)
+	}
+}
+
+// progress returns the stages entered so far and the time spent on them, including the current one, and the
+// name of the current stage, empty if finished.
+func (t *blockTimings) progress() ([]stageTiming, string) {
+	if t == nil {
+		return nil, ""
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stages := make([]stageTiming, len(t.stages))
+	copy(stages, t.stages)
+
+	var current string
+	if t.current >= 0 {
+		stages[t.current].duration += time.Since(t.currentStart)
+		current = stages[t.current].name
+	}
+
+	return stages, current
+}
+
+// elapsed returns the time spent on the block so far.
+func (t *blockTimings) elapsed() time.Duration {
+	if t == nil {
+		return 0
+	}
+
+	return time.Since(t.start)
+}
+
+// logContext returns the stage timings as log key-value pairs.
+func (t *blockTimings) logContext() []interface{} {
+	stages, current := t.progress()
+
+	ctx := []interface{}{"elapsed", t.elapsed(), "stage", current}
+	for _, stage := range stages {
+		ctx = append(ctx, stage.name, stage.duration)
+	}
+
+	return ctx
+}
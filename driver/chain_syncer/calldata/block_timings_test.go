@@ -0,0 +1,69 @@
+package calldata
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+func TestBlockTimings(t *testing.T) {
+	timings := newBlockTimings()
+	timings.enter(stageFetch)
+	time.Sleep(10 * time.Millisecond)
+	timings.enter(stageDecode)
+	time.Sleep(10 * time.Millisecond)
+	timings.enter(stageAnchor)
+	timings.enter(stageDecode)
+
+	stages, current := timings.progress()
+	require.Equal(t, stageDecode, current)
+	require.Len(t, stages, 3)
+	require.Equal(
+		t,
+		[]string{stageFetch, stageDecode, stageAnchor},
+		[]string{stages[0].name, stages[1].name, stages[2].name},
+	)
+	require.GreaterOrEqual(t, stages[0].duration, 10*time.Millisecond)
+	require.GreaterOrEqual(t, stages[1].duration, 10*time.Millisecond)
+
+	total := timings.finish()
+	stages, current = timings.progress()
+	require.Empty(t, current)
+	var sum time.Duration
+	for _, stage := range stages {
+		sum += stage.duration
+	}
+	require.LessOrEqual(t, sum, total)
+	require.GreaterOrEqual(t, total, 20*time.Millisecond)
+
+	// No more time is accumulated once finished.
+	time.Sleep(10 * time.Millisecond)
+	finished, _ := timings.progress()
+	require.Equal(t, stages, finished)
+}
+
+func TestBlockTimingsNil(t *testing.T) {
+	var timings *blockTimings
+	timings.enter(stageFetch)
+	require.Zero(t, timings.finish())
+	require.Zero(t, timings.elapsed())
+	stages, current := timings.progress()
+	require.Empty(t, stages)
+	require.Empty(t, current)
+}
+
+func TestWatchSlowBlockNotSlow(t *testing.T) {
+	event := &bindings.TaikoL1ClientBlockProposed{Id: big.NewInt(1)}
+
+	// Disabled.
+	s := &Syncer{}
+	require.False(t, s.watchSlowBlock(context.Background(), event, newBlockTimings())())
+
+	// Derived within the budget.
+	s.SetSlowBlockThreshold(time.Hour)
+	require.False(t, s.watchSlowBlock(context.Background(), event, newBlockTimings())())
+}
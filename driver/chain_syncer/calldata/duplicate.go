@@ -0,0 +1,114 @@
+package calldata
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-client/metrics"
+)
+
+// duplicateAction is the handling of a BlockProposed event whose block ID has been derived already.
+type duplicateAction int
+
+const (
+	// The same proposal as the derived one, e.g. re-delivered by a re-run iteration.
+	duplicateRedelivered duplicateAction = iota
+	// A proposal superseded by the canonical one stored in TaikoL1.
+	duplicateSuperseded
+	// The canonical proposal, while the derived block was derived from a superseded one.
+	duplicateRederive
+)
+
+// classifyDuplicateProposal classifies a BlockProposed event of an already derived block ID, by its
+// metadata hash, the canonical metadata hash stored in TaikoL1, and the L1 block hash of the proposal
+// which the derived block was derived from.
+func classifyDuplicateProposal(
+	event *bindings.TaikoL1ClientBlockProposed,
+	metaHash common.Hash,
+	canonicalMetaHash common.Hash,
+	derivedL1Hash common.Hash,
+) duplicateAction {
+	if event.Raw.BlockHash == derivedL1Hash {
+		return duplicateRedelivered
+	}
+	if metaHash != canonicalMetaHash {
+		return duplicateSuperseded
+	}
+
+	return duplicateRederive
+}
+
+// checkDuplicateProposal checks a BlockProposed event whose block ID has been derived already, against the
+// canonical proposal stored in TaikoL1, returns true if the derived block was derived from a superseded
+// proposal, then the syncer has been rewound to its parent, so that the given event is derived instead, and
+// the descendants are re-derived from their following events.
+func (s *Syncer) checkDuplicateProposal(ctx context.Context, event *bindings.TaikoL1ClientBlockProposed) (bool, error) {
+	// The verified blocks are final.
+	if verified := s.state.GetLatestVerifiedBlock(); verified != nil && event.Id.Cmp(verified.ID) <= 0 {
+		return false, nil
+	}
+
+	l1Origin, err := s.rpc.L2.L1OriginByID(ctx, event.Id)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch L1Origin of derived block %d: %w", event.Id, err)
+	}
+	// Most duplicates are re-deliveries, which are detected without any L1 requests.
+	if event.Raw.BlockHash == l1Origin.L1BlockHash {
+		return false, nil
+	}
+
+	metaBytes, err := encoding.EncodeBlockMetadata(&event.Meta)
+	if err != nil {
+		return false, err
+	}
+	canonical, err := s.rpc.TaikoL1.GetBlock(nil, event.Id)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch canonical proposal of block %d: %w", event.Id, err)
+	}
+
+	metrics.DriverDuplicateProposalCounter.Inc(1)
+	switch classifyDuplicateProposal(
+		event,
+		crypto.Keccak256Hash(metaBytes),
+		common.BytesToHash(canonical.MetaHash[:]),
+		l1Origin.L1BlockHash,
+	) {
+	case duplicateSuperseded:
+		log.Warn(
+			"Ignore superseded duplicate BlockProposed event",
+			"blockID", event.Id,
+			"l1Height", event.Raw.BlockNumber,
+			"l1Hash", event.Raw.BlockHash,
+			"derivedL1Hash", l1Origin.L1BlockHash,
+		)
+		return false, nil
+	case duplicateRederive:
+		log.Warn(
+			"Derived block was derived from a superseded proposal, re-derive it",
+			"blockID", event.Id,
+			"l1Height", event.Raw.BlockNumber,
+			"l1Hash", event.Raw.BlockHash,
+			"derivedL1Hash", l1Origin.L1BlockHash,
+			"lastInsertedBlockID", s.lastInsertedBlockID,
+		)
+		metrics.DriverProposalRederivedCounter.Inc(1)
+		s.rewindTo(new(big.Int).Sub(event.Id, common.Big1))
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// rewindTo rewinds the syncer to the given derived block, the blocks after it are derived again, and the
+// deferred head update, which might point to a descendant of a superseded block, is dropped.
+func (s *Syncer) rewindTo(blockID *big.Int) {
+	s.lastInsertedBlockID = blockID
+	s.pendingHead = nil
+	s.pendingHeadBlocks = 0
+}
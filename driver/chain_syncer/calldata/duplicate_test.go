@@ -0,0 +1,66 @@
+package calldata
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+)
+
+func newTestProposal(t *testing.T, l1Height uint64, txListHash common.Hash) (
+	*bindings.TaikoL1ClientBlockProposed,
+	common.Hash,
+) {
+	event := &bindings.TaikoL1ClientBlockProposed{
+		Id: common.Big2,
+		Meta: bindings.TaikoDataBlockMetadata{
+			Id:              2,
+			L1Height:        l1Height - 1,
+			TxListHash:      txListHash,
+			TxListByteStart: common.Big0,
+			TxListByteEnd:   common.Big0,
+			GasLimit:        21000,
+		},
+		Raw: types.Log{BlockNumber: l1Height, BlockHash: common.BigToHash(new(big.Int).SetUint64(l1Height))},
+	}
+	metaBytes, err := encoding.EncodeBlockMetadata(&event.Meta)
+	require.Nil(t, err)
+
+	return event, crypto.Keccak256Hash(metaBytes)
+}
+
+func TestClassifyDuplicateProposal(t *testing.T) {
+	// Two proposals of the same block ID, the second one supersedes the first one in TaikoL1.
+	superseded, supersededHash := newTestProposal(t, 10, common.HexToHash("0x01"))
+	canonical, canonicalHash := newTestProposal(t, 12, common.HexToHash("0x02"))
+	require.NotEqual(t, supersededHash, canonicalHash)
+
+	// Derived from the superseded proposal.
+	derived := superseded.Raw.BlockHash
+	require.Equal(t, duplicateRedelivered, classifyDuplicateProposal(superseded, supersededHash, canonicalHash, derived))
+	require.Equal(t, duplicateRederive, classifyDuplicateProposal(canonical, canonicalHash, canonicalHash, derived))
+
+	// Derived from the canonical proposal.
+	derived = canonical.Raw.BlockHash
+	require.Equal(t, duplicateSuperseded, classifyDuplicateProposal(superseded, supersededHash, canonicalHash, derived))
+	require.Equal(t, duplicateRedelivered, classifyDuplicateProposal(canonical, canonicalHash, canonicalHash, derived))
+}
+
+func TestRewindTo(t *testing.T) {
+	s := &Syncer{
+		lastInsertedBlockID: common.Big3,
+		pendingHead:         &engine.ExecutableData{Number: 3},
+		pendingHeadBlocks:   2,
+	}
+
+	s.rewindTo(common.Big1)
+	require.Equal(t, common.Big1, s.lastInsertedBlockID)
+	require.Nil(t, s.pendingHead)
+	require.Zero(t, s.pendingHeadBlocks)
+}
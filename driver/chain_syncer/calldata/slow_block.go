@@ -0,0 +1,137 @@
+package calldata
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/metrics"
+)
+
+var (
+	// slowBlockProgressInterval is the interval between the progress logs of a slow L2 block, until its
+	// derivation finishes.
+	slowBlockProgressInterval = 30 * time.Second
+	// slowBlockStatusTimeout is the timeout of fetching the L2 execution engine's sync status for the
+	// slow block diagnostics.
+	slowBlockStatusTimeout = 5 * time.Second
+)
+
+// SetSlowBlockThreshold sets the wall-clock budget of deriving a L2 block, once exceeded, the block's
+// diagnostics are logged, 0 means disabled.
+func (s *Syncer) SetSlowBlockThreshold(threshold time.Duration) {
+	s.slowBlockThreshold = threshold
+}
+
+// watchSlowBlock starts watching the derivation of the given block with the given timings in background,
+// if it exceeds the slow block threshold, its diagnostics are logged, and then its progress is logged
+// periodically. Returns the function stopping the watch, which reports whether the block was slow.
+func (s *Syncer) watchSlowBlock(
+	ctx context.Context,
+	event *bindings.TaikoL1ClientBlockProposed,
+	timings *blockTimings,
+) func() bool {
+	if s.slowBlockThreshold == 0 {
+		return func() bool { return false }
+	}
+
+	var (
+		slow    bool
+		done    = make(chan struct{})
+		stopped = make(chan struct{})
+	)
+	go func() {
+		defer close(stopped)
+
+		timer := time.NewTimer(s.slowBlockThreshold)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return
+		case <-done:
+			return
+		case <-timer.C:
+		}
+
+		slow = true
+		metrics.DriverSlowBlockCounter.Inc(1)
+		s.logSlowBlock(ctx, event, timings)
+
+		ticker := time.NewTicker(slowBlockProgressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				log.Warn(
+					"Slow L2 block derivation still in progress",
+					append([]interface{}{"blockID", event.Id}, timings.logContext()...)...,
+				)
+			}
+		}
+	}()
+
+	return func() bool {
+		close(done)
+		<-stopped
+		return slow
+	}
+}
+
+// logSlowBlock logs the diagnostics of the given block whose derivation exceeded the slow block threshold,
+// with its stage timings so far, the L2 execution engine's sync status, and its L1 origin.
+func (s *Syncer) logSlowBlock(
+	ctx context.Context,
+	event *bindings.TaikoL1ClientBlockProposed,
+	timings *blockTimings,
+) {
+	logCtx := []interface{}{
+		"blockID", event.Id,
+		"threshold", s.slowBlockThreshold,
+		"l1Height", event.Raw.BlockNumber,
+		"l1Hash", event.Raw.BlockHash,
+		"l1TxIndex", event.Raw.TxIndex,
+		"anchorL1Height", event.Meta.L1Height,
+		"anchorL1Hash", event.Meta.L1Hash,
+	}
+	logCtx = append(logCtx, timings.logContext()...)
+
+	statusCtx, cancel := context.WithTimeout(ctx, slowBlockStatusTimeout)
+	defer cancel()
+	if progress, err := s.rpc.L2ExecutionEngineSyncProgress(statusCtx); err != nil {
+		logCtx = append(logCtx, "engineSyncStatusError", err)
+	} else {
+		logCtx = append(
+			logCtx,
+			"engineSyncing", progress.SyncProgress != nil,
+			"engineCurrentBlockID", progress.CurrentBlockID,
+			"engineHighestBlockID", progress.HighestBlockID,
+		)
+	}
+
+	log.Warn("Slow L2 block derivation", logCtx...)
+}
+
+// finishBlockTimings ends the derivation timings of the given block and stops its slow block watch, the
+// total duration of a slow block is recorded and logged.
+func (s *Syncer) finishBlockTimings(
+	event *bindings.TaikoL1ClientBlockProposed,
+	timings *blockTimings,
+	stopWatch func() bool,
+) {
+	s.timings = nil
+	total := timings.finish()
+	if !stopWatch() {
+		return
+	}
+
+	metrics.DriverSlowBlockDurationHistogram.Update(total.Milliseconds())
+	log.Warn(
+		"Slow L2 block derivation finished",
+		append([]interface{}{"blockID", event.Id}, timings.logContext()...)...,
+	)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/taikoxyz/taiko-client/bindings"
@@ -19,11 +20,17 @@ import (
 	"github.com/taikoxyz/taiko-client/driver/chain_syncer/beaconsync"
 	"github.com/taikoxyz/taiko-client/driver/state"
 	"github.com/taikoxyz/taiko-client/metrics"
+	"github.com/taikoxyz/taiko-client/pkg/blockfeed"
 	eventIterator "github.com/taikoxyz/taiko-client/pkg/chain_iterator/event_iterator"
+	"github.com/taikoxyz/taiko-client/pkg/protocol"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
 	txListValidator "github.com/taikoxyz/taiko-client/pkg/tx_list_validator"
 )
 
+var (
+	errAnchorL1Reorged = errors.New("anchor's L1 block is no longer canonical")
+)
+
 // Syncer responsible for letting the L2 execution engine catching up with protocol's latest
 // pending block through deriving L1 calldata.
 type Syncer struct {
@@ -32,9 +39,23 @@ type Syncer struct {
 	state             *state.State
 	progressTracker   *beaconsync.SyncProgressTracker          // Sync progress tracker
 	anchorConstructor *anchorTxConstructor.AnchorTxConstructor // TaikoL2.anchor transactions constructor
+	protocolConfigs   *protocol.Configs                        // Protocol configs shared with the driver
 	txListValidator   *txListValidator.TxListValidator         // Transactions list validator
+	// Version of the protocol configs the txListValidator is built with
+	txListValidatorVersion uint64
 	// Used by BlockInserter
 	lastInsertedBlockID *big.Int
+	// Fork choice updates batching during the catch-up, the head update of each inserted block is deferred
+	// until `catchUpBatchSize` blocks have been inserted, or the L1 blocks range is processed
+	catchUpBatchSize  uint64
+	l1End             *types.Header          // End of the L1 blocks range being processed
+	pendingHead       *engine.ExecutableData // Latest inserted block whose head update is deferred
+	pendingHeadBlocks uint64                 // Number of the inserted blocks since the last head update
+	// Per-block derivation budget, and the stage timings of the block being derived
+	slowBlockThreshold time.Duration
+	timings            *blockTimings
+	// Freshly derived blocks notification feed
+	derivedBlocksFeed event.Feed
 }
 
 // NewSyncer creates a new syncer instance.
@@ -44,12 +65,8 @@ func NewSyncer(
 	state *state.State,
 	progressTracker *beaconsync.SyncProgressTracker,
 	signalServiceAddress common.Address,
+	protocolConfigs *protocol.Configs,
 ) (*Syncer, error) {
-	configs, err := rpc.TaikoL1.GetConfig(nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get protocol configs: %w", err)
-	}
-
 	constructor, err := anchorTxConstructor.New(rpc, signalServiceAddress)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize anchor constructor: %w", err)
@@ -61,19 +78,22 @@ func NewSyncer(
 		state:             state,
 		progressTracker:   progressTracker,
 		anchorConstructor: constructor,
-		txListValidator: txListValidator.NewTxListValidator(
-			configs.BlockMaxGasLimit.Uint64(),
-			configs.MaxTransactionsPerBlock.Uint64(),
-			configs.MaxBytesPerTxList.Uint64(),
-			configs.MinTxGasLimit.Uint64(),
-			rpc.L2ChainID,
-		),
+		protocolConfigs:   protocolConfigs,
 	}, nil
 }
 
+// SetCatchUpBatchSize sets the number of the blocks inserted during the catch-up between two fork choice
+// updates of the L2 execution engine's head, 0 or 1 means updating the head after each block.
+func (s *Syncer) SetCatchUpBatchSize(size uint64) {
+	s.catchUpBatchSize = size
+}
+
 // ProcessL1Blocks fetches all `TaikoL1.BlockProposed` events between given
 // L1 block heights, and then tries inserting them into L2 execution engine's block chain.
 func (s *Syncer) ProcessL1Blocks(ctx context.Context, l1End *types.Header) error {
+	s.l1End = l1End
+	defer func() { s.l1End = nil }()
+
 	iter, err := eventIterator.NewBlockProposedIterator(ctx, &eventIterator.BlockProposedIteratorConfig{
 		Client:               s.rpc.L1,
 		TaikoL1:              s.rpc.TaikoL1,
@@ -86,8 +106,20 @@ func (s *Syncer) ProcessL1Blocks(ctx context.Context, l1End *types.Header) error
 		return err
 	}
 
-	if err := iter.Iter(); err != nil {
-		return err
+	iterErr := iter.Iter()
+
+	// Always move the head to the latest inserted block, even if the iteration is interrupted.
+	if s.pendingHead != nil {
+		if rpcErr, payloadErr := s.updateHead(ctx, s.pendingHead); rpcErr != nil || payloadErr != nil {
+			if iterErr == nil {
+				iterErr = fmt.Errorf("failed to update the deferred L2 head: %v, %v", rpcErr, payloadErr)
+			}
+			log.Warn("Failed to update the deferred L2 head", "rpcError", rpcErr, "payloadError", payloadErr)
+		}
+	}
+
+	if iterErr != nil {
+		return iterErr
 	}
 
 	s.state.SetL1Current(l1End)
@@ -103,10 +135,16 @@ func (s *Syncer) onBlockProposed(
 	event *bindings.TaikoL1ClientBlockProposed,
 	endIter eventIterator.EndBlockProposedEventIterFunc,
 ) error {
-	// Ignore those already inserted blocks.
-	if event.Id.Cmp(common.Big0) == 0 || (s.lastInsertedBlockID != nil && event.Id.Cmp(s.lastInsertedBlockID) <= 0) {
+	if event.Id.Cmp(common.Big0) == 0 {
 		return nil
 	}
+	// Ignore those already inserted blocks, unless they were derived from superseded proposals.
+	if s.lastInsertedBlockID != nil && event.Id.Cmp(s.lastInsertedBlockID) <= 0 {
+		rederive, err := s.checkDuplicateProposal(ctx, event)
+		if err != nil || !rederive {
+			return err
+		}
+	}
 
 	log.Info(
 		"New BlockProposed event",
@@ -115,7 +153,12 @@ func (s *Syncer) onBlockProposed(
 		"BlockID", event.Id,
 	)
 
+	timings := newBlockTimings()
+	s.timings = timings
+	defer s.finishBlockTimings(event, timings, s.watchSlowBlock(ctx, event, timings))
+
 	// Fetch the L2 parent block.
+	timings.enter(stageFetch)
 	var (
 		parent *types.Header
 		err    error
@@ -137,9 +180,10 @@ func (s *Syncer) onBlockProposed(
 
 	log.Debug("Parent block", "height", parent.Number, "hash", parent.Hash())
 
-	tx, err := s.rpc.L1.TransactionInBlock(
+	tx, err := s.rpc.L1TransactionInBlock(
 		ctx,
 		event.Raw.BlockHash,
+		event.Raw.BlockNumber,
 		event.Raw.TxIndex,
 	)
 	if err != nil {
@@ -147,7 +191,8 @@ func (s *Syncer) onBlockProposed(
 	}
 
 	// Check whether the transactions list is valid.
-	txListBytes, hint, invalidTxIndex, err := s.txListValidator.ValidateTxList(event.Id, tx.Data())
+	timings.enter(stageDecode)
+	txListBytes, hint, invalidTxIndex, err := s.currentTxListValidator().ValidateTxList(event.Id, tx.Data())
 	if err != nil {
 		return fmt.Errorf("failed to validate transactions list: %w", err)
 	}
@@ -166,8 +211,13 @@ func (s *Syncer) onBlockProposed(
 		L1BlockHash:   event.Raw.BlockHash,
 	}
 
+	if err := s.validateBlockTimestamp(ctx, event, parent); err != nil {
+		return err
+	}
+
 	if event.Meta.Timestamp > uint64(time.Now().Unix()) {
 		log.Warn("Future L2 block, waiting", "L2 block timestamp", event.Meta.Timestamp, "now", time.Now().Unix())
+		timings.enter(stageWait)
 		time.Sleep(time.Until(time.Unix(int64(event.Meta.Timestamp), 0)))
 	}
 
@@ -186,8 +236,19 @@ func (s *Syncer) onBlockProposed(
 		l1Origin,
 	)
 
-	// RPC errors are recoverable.
+	// RPC errors are recoverable, if the anchor's L1 block has been reorged, the returned error will make the
+	// iterator re-run its reorg detection, and then re-derive this block from the refreshed events.
 	if rpcError != nil {
+		if errors.Is(rpcError, errAnchorL1Reorged) {
+			log.Warn(
+				"Anchor's L1 block reorged, re-derive the L2 block",
+				"blockID", event.Id,
+				"l1Height", event.Meta.L1Height,
+				"l1Hash", event.Meta.L1Hash,
+			)
+			metrics.DriverAnchorL1ReorgedCounter.Inc(1)
+		}
+
 		return fmt.Errorf("failed to insert new head to L2 execution engine: %w", rpcError)
 	}
 
@@ -212,6 +273,7 @@ func (s *Syncer) onBlockProposed(
 
 	metrics.DriverL1CurrentHeightGauge.Update(int64(event.Raw.BlockNumber))
 	s.lastInsertedBlockID = event.Id
+	s.publishDerivedBlock(payloadData, l1Origin)
 
 	if s.progressTracker.Triggered() {
 		s.progressTracker.ClearMeta()
@@ -220,6 +282,31 @@ func (s *Syncer) onBlockProposed(
 	return nil
 }
 
+// publishDerivedBlock sends the given inserted block to the derived blocks feed.
+func (s *Syncer) publishDerivedBlock(payload *engine.ExecutableData, l1Origin *rawdb.L1Origin) {
+	block, err := engine.ExecutableDataToBlock(*payload)
+	if err != nil {
+		log.Warn("Failed to convert payload to block", "blockID", l1Origin.BlockID, "error", err)
+		return
+	}
+
+	s.derivedBlocksFeed.Send(&blockfeed.Block{
+		BlockID: l1Origin.BlockID,
+		Header:  block.Header(),
+		L1Origin: &rawdb.L1Origin{
+			BlockID:       l1Origin.BlockID,
+			L2BlockHash:   block.Hash(),
+			L1BlockHeight: l1Origin.L1BlockHeight,
+			L1BlockHash:   l1Origin.L1BlockHash,
+		},
+	})
+}
+
+// SubDerivedBlocksFeed registers a subscription of the freshly derived blocks.
+func (s *Syncer) SubDerivedBlocksFeed(ch chan *blockfeed.Block) event.Subscription {
+	return s.derivedBlocksFeed.Subscribe(ch)
+}
+
 // insertNewHead tries to insert a new head block to the L2 execution engine's local
 // block chain through Engine APIs.
 func (s *Syncer) insertNewHead(
@@ -239,6 +326,7 @@ func (s *Syncer) insertNewHead(
 	)
 
 	// Insert a TaikoL2.anchor transaction at transactions list head
+	s.timings.enter(stageDecode)
 	var txList []*types.Transaction
 	if len(txListBytes) != 0 {
 		if err := rlp.DecodeBytes(txListBytes, &txList); err != nil {
@@ -248,6 +336,7 @@ func (s *Syncer) insertNewHead(
 	}
 
 	// Assemble a TaikoL2.anchor transaction
+	s.timings.enter(stageAnchor)
 	anchorTx, err := s.anchorConstructor.AssembleAnchorTx(
 		ctx,
 		new(big.Int).SetUint64(event.Meta.L1Height),
@@ -276,6 +365,7 @@ func (s *Syncer) insertNewHead(
 		return nil, nil, fmt.Errorf("failed to get L2 baseFee: %w", encoding.TryParsingCustomError(err))
 	}
 
+	s.timings.enter(stageNewPayload)
 	payload, rpcErr, payloadErr := s.createExecutionPayloads(
 		ctx,
 		event,
@@ -290,19 +380,45 @@ func (s *Syncer) insertNewHead(
 		return nil, rpcErr, payloadErr
 	}
 
-	fc := &engine.ForkchoiceStateV1{HeadBlockHash: parent.Hash()}
+	// During the catch-up, the next block's payload building sets its parent, i.e. the current block, as the
+	// head, so the head update of the current block can be deferred, saving one engine API round trip.
+	if s.isCatchingUp(event) {
+		s.pendingHead = payload
+		s.pendingHeadBlocks++
+		if s.pendingHeadBlocks < s.catchUpBatchSize {
+			metrics.DriverDeferredHeadUpdateCounter.Inc(1)
+			return payload, nil, nil
+		}
+	}
+
+	if rpcErr, payloadErr := s.updateHead(ctx, payload); rpcErr != nil || payloadErr != nil {
+		return nil, rpcErr, payloadErr
+	}
+
+	return payload, nil, nil
+}
 
-	// Update the fork choice
-	fc.HeadBlockHash = payload.BlockHash
-	fcRes, err := s.rpc.L2Engine.ForkchoiceUpdate(ctx, fc, nil)
+// isCatchingUp checks whether the given event is proposed before the last L1 block of the range being
+// processed, and the head updates batching is enabled, the events in the last L1 block are the live tail.
+func (s *Syncer) isCatchingUp(event *bindings.TaikoL1ClientBlockProposed) bool {
+	return s.catchUpBatchSize > 1 && s.l1End != nil && event.Raw.BlockNumber < s.l1End.Number.Uint64()
+}
+
+// updateHead updates the L2 execution engine's fork choice to the given inserted block.
+func (s *Syncer) updateHead(ctx context.Context, payload *engine.ExecutableData) (error, error) {
+	s.pendingHead = nil
+	s.pendingHeadBlocks = 0
+	s.timings.enter(stageForkchoice)
+
+	fcRes, err := s.rpc.L2Engine.ForkchoiceUpdate(ctx, &engine.ForkchoiceStateV1{HeadBlockHash: payload.BlockHash}, nil)
 	if err != nil {
-		return nil, err, nil
+		return err, nil
 	}
 	if fcRes.PayloadStatus.Status != engine.VALID {
-		return nil, nil, fmt.Errorf("unexpected ForkchoiceUpdate response status: %s", fcRes.PayloadStatus.Status)
+		return nil, fmt.Errorf("unexpected ForkchoiceUpdate response status: %s", fcRes.PayloadStatus.Status)
 	}
 
-	return payload, nil, nil
+	return nil, nil
 }
 
 // createExecutionPayloads creates a new execution payloads through
@@ -353,6 +469,12 @@ func (s *Syncer) createExecutionPayloads(
 		return nil, err, nil
 	}
 
+	// Ensure the anchor's L1 block is still canonical, since L1 might have been reorged after
+	// the event was fetched.
+	if err := s.checkAnchorL1Block(ctx, event); err != nil {
+		return nil, err, nil
+	}
+
 	// Step 3, execute the payload
 	execStatus, err := s.rpc.L2Engine.NewPayload(ctx, payload)
 	if err != nil {
@@ -364,3 +486,36 @@ func (s *Syncer) createExecutionPayloads(
 
 	return payload, nil, nil
 }
+
+// checkAnchorL1Block re-fetches the L1 header at the anchor's L1 height, and checks whether its hash still
+// matches the L1 hash encoded in the anchor transaction.
+func (s *Syncer) checkAnchorL1Block(ctx context.Context, event *bindings.TaikoL1ClientBlockProposed) error {
+	l1Header, err := s.rpc.L1.HeaderByNumber(ctx, new(big.Int).SetUint64(event.Meta.L1Height))
+	if err != nil {
+		return fmt.Errorf("failed to fetch anchor's L1 header: %w", err)
+	}
+
+	if l1Header.Hash() != event.Meta.L1Hash {
+		return fmt.Errorf(
+			"%w, height: %d, expected: %s, canonical: %s",
+			errAnchorL1Reorged,
+			event.Meta.L1Height,
+			event.Meta.L1Hash,
+			l1Header.Hash(),
+		)
+	}
+
+	return nil
+}
+
+// currentTxListValidator returns the transactions list validator of the current protocol configs, it is
+// rebuilt once the configs change, e.g. after a protocol upgrade.
+func (s *Syncer) currentTxListValidator() *txListValidator.TxListValidator {
+	config := s.protocolConfigs.Config()
+	if s.txListValidator == nil || s.txListValidatorVersion != config.Version {
+		s.txListValidator = config.NewTxListValidator(s.rpc.L2ChainID)
+		s.txListValidatorVersion = config.Version
+	}
+
+	return s.txListValidator
+}
@@ -9,10 +9,12 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/suite"
 	"github.com/taikoxyz/taiko-client/bindings"
 	"github.com/taikoxyz/taiko-client/driver/chain_syncer/beaconsync"
 	"github.com/taikoxyz/taiko-client/driver/state"
+	"github.com/taikoxyz/taiko-client/pkg/protocol"
 	"github.com/taikoxyz/taiko-client/testutils"
 )
 
@@ -27,12 +29,16 @@ func (s *CalldataSyncerTestSuite) SetupTest() {
 	state, err := state.New(context.Background(), s.RpcClient)
 	s.Nil(err)
 
+	protocolConfigs, err := protocol.New(context.Background(), s.RpcClient.TaikoL1)
+	s.Nil(err)
+
 	syncer, err := NewSyncer(
 		context.Background(),
 		s.RpcClient,
 		state,
 		beaconsync.NewSyncProgressTracker(s.RpcClient.L2, 1*time.Hour),
 		common.HexToAddress(os.Getenv("L1_SIGNAL_SERVICE_CONTRACT_ADDRESS")),
+		protocolConfigs,
 	)
 	s.Nil(err)
 	s.s = syncer
@@ -44,6 +50,45 @@ func (s *CalldataSyncerTestSuite) TestProcessL1Blocks() {
 	s.Nil(s.s.ProcessL1Blocks(context.Background(), head))
 }
 
+func (s *CalldataSyncerTestSuite) TestProcessL1BlocksWithCatchUpBatch() {
+	s.s.SetCatchUpBatchSize(64)
+	defer s.s.SetCatchUpBatchSize(0)
+
+	head, err := s.s.rpc.L1.HeaderByNumber(context.Background(), nil)
+	s.Nil(err)
+	s.Nil(s.s.ProcessL1Blocks(context.Background(), head))
+	s.Nil(s.s.pendingHead)
+	s.Zero(s.s.pendingHeadBlocks)
+}
+
+func (s *CalldataSyncerTestSuite) TestProcessL1BlocksWithSlowBlocks() {
+	defer func(interval time.Duration) { slowBlockProgressInterval = interval }(slowBlockProgressInterval)
+	slowBlockProgressInterval = time.Millisecond
+	s.s.SetSlowBlockThreshold(time.Nanosecond)
+	defer s.s.SetSlowBlockThreshold(0)
+
+	head, err := s.s.rpc.L1.HeaderByNumber(context.Background(), nil)
+	s.Nil(err)
+	s.Nil(s.s.ProcessL1Blocks(context.Background(), head))
+	s.Nil(s.s.timings)
+}
+
+func (s *CalldataSyncerTestSuite) TestIsCatchingUp() {
+	event := &bindings.TaikoL1ClientBlockProposed{Raw: types.Log{BlockNumber: 1}}
+	s.False(s.s.isCatchingUp(event))
+
+	s.s.SetCatchUpBatchSize(64)
+	defer s.s.SetCatchUpBatchSize(0)
+	s.False(s.s.isCatchingUp(event))
+
+	s.s.l1End = &types.Header{Number: common.Big2}
+	defer func() { s.s.l1End = nil }()
+	s.True(s.s.isCatchingUp(event))
+
+	event.Raw.BlockNumber = 2
+	s.False(s.s.isCatchingUp(event))
+}
+
 func (s *CalldataSyncerTestSuite) TestOnBlockProposed() {
 	s.Nil(s.s.onBlockProposed(context.Background(), &bindings.TaikoL1ClientBlockProposed{Id: common.Big0}, func() {}))
 	s.NotNil(s.s.onBlockProposed(context.Background(), &bindings.TaikoL1ClientBlockProposed{Id: common.Big1}, func() {}))
@@ -82,6 +127,60 @@ func (s *CalldataSyncerTestSuite) TestInsertNewHead() {
 	s.Nil(payloadErr)
 }
 
+func (s *CalldataSyncerTestSuite) TestInsertNewHeadAnchorL1Reorged() {
+	parent, err := s.s.rpc.L2.HeaderByNumber(context.Background(), nil)
+	s.Nil(err)
+
+	var snapshotID string
+	s.Nil(s.s.rpc.L1RawRPC.CallContext(context.Background(), &snapshotID, "evm_snapshot"))
+
+	// Fetch an L1 block, and then reorg it out before building the L2 block.
+	s.Nil(s.s.rpc.L1RawRPC.CallContext(context.Background(), nil, "evm_mine"))
+	l1Head, err := s.s.rpc.L1.HeaderByNumber(context.Background(), nil)
+	s.Nil(err)
+
+	var revertRes bool
+	s.Nil(s.s.rpc.L1RawRPC.CallContext(context.Background(), &revertRes, "evm_revert", snapshotID))
+	s.True(revertRes)
+	s.Nil(s.s.rpc.L1RawRPC.CallContext(context.Background(), nil, "evm_mine", l1Head.Time+1))
+
+	reorgedHead, err := s.s.rpc.L1.HeaderByNumber(context.Background(), l1Head.Number)
+	s.Nil(err)
+	s.NotEqual(l1Head.Hash(), reorgedHead.Hash())
+
+	_, rpcErr, payloadErr := s.s.insertNewHead(
+		context.Background(),
+		&bindings.TaikoL1ClientBlockProposed{
+			Id: common.Big1,
+			Meta: bindings.TaikoDataBlockMetadata{
+				Id:          1,
+				L1Height:    l1Head.Number.Uint64(),
+				L1Hash:      l1Head.Hash(),
+				Beneficiary: common.BytesToAddress(testutils.RandomBytes(1024)),
+				TxListHash:  testutils.RandomHash(),
+				MixHash:     testutils.RandomHash(),
+				GasLimit:    rand.Uint32(),
+				Timestamp:   uint64(time.Now().Unix()),
+			},
+		},
+		parent,
+		common.Big2,
+		[]byte{},
+		&rawdb.L1Origin{
+			BlockID:       common.Big1,
+			L1BlockHeight: l1Head.Number,
+			L1BlockHash:   l1Head.Hash(),
+		},
+	)
+	s.ErrorIs(rpcErr, errAnchorL1Reorged)
+	s.Nil(payloadErr)
+
+	// The L2 block should not be inserted.
+	head, err := s.s.rpc.L2.HeaderByNumber(context.Background(), nil)
+	s.Nil(err)
+	s.Equal(parent.Hash(), head.Hash())
+}
+
 func TestCalldataSyncerTestSuite(t *testing.T) {
 	suite.Run(t, new(CalldataSyncerTestSuite))
 }
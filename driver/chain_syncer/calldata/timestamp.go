@@ -0,0 +1,90 @@
+package calldata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/metrics"
+)
+
+var (
+	errInvalidTimestamp = errors.New("invalid L2 block timestamp")
+)
+
+// Timestamp rules of the derived L2 blocks.
+const (
+	// The L2 block timestamp is set by TaikoL1.proposeBlock to the proposing L1 block's timestamp.
+	timestampRuleProposalL1Block = "proposalL1Block"
+	// The L2 block timestamp must not be earlier than its parent's, otherwise the base fee calculation
+	// which takes the timestamps gap would underflow.
+	timestampRuleMonotonic = "monotonic"
+)
+
+// timestampViolation is a violated timestamp rule of a derived L2 block.
+type timestampViolation struct {
+	Rule     string
+	Got      uint64
+	Expected string
+}
+
+// checkBlockTimestamp checks the given derived L2 block's timestamp against the protocol rules, returns nil
+// if all rules are satisfied.
+func checkBlockTimestamp(
+	event *bindings.TaikoL1ClientBlockProposed,
+	parent *types.Header,
+	proposalL1Header *types.Header,
+) *timestampViolation {
+	if event.Meta.Timestamp != proposalL1Header.Time {
+		return &timestampViolation{
+			Rule:     timestampRuleProposalL1Block,
+			Got:      event.Meta.Timestamp,
+			Expected: fmt.Sprintf("== %d", proposalL1Header.Time),
+		}
+	}
+
+	if event.Meta.Timestamp < parent.Time {
+		return &timestampViolation{
+			Rule:     timestampRuleMonotonic,
+			Got:      event.Meta.Timestamp,
+			Expected: fmt.Sprintf(">= %d", parent.Time),
+		}
+	}
+
+	return nil
+}
+
+// validateBlockTimestamp validates the given derived L2 block's timestamp before inserting it. A violation
+// means the local view of L1 or L2 is inconsistent with the protocol, e.g. a reorged proposal L1 block or a
+// stale parent, so the block is never inserted, and the returned error makes the iterator re-derive it.
+func (s *Syncer) validateBlockTimestamp(
+	ctx context.Context,
+	event *bindings.TaikoL1ClientBlockProposed,
+	parent *types.Header,
+) error {
+	proposalL1Header, err := s.rpc.L1.HeaderByHash(ctx, event.Raw.BlockHash)
+	if err != nil {
+		return fmt.Errorf("failed to fetch proposal L1 header: %w", err)
+	}
+
+	violation := checkBlockTimestamp(event, parent, proposalL1Header)
+	if violation == nil {
+		return nil
+	}
+
+	log.Error(
+		"Derived L2 block timestamp violates the protocol rule, skip inserting",
+		"blockID", event.Id,
+		"rule", violation.Rule,
+		"timestamp", violation.Got,
+		"expected", violation.Expected,
+		"parent", parent.Number,
+		"l1Height", event.Raw.BlockNumber,
+	)
+	metrics.DriverInvalidTimestampCounter.Inc(1)
+
+	return fmt.Errorf("%w, blockID: %d, rule: %s", errInvalidTimestamp, event.Id, violation.Rule)
+}
@@ -0,0 +1,43 @@
+package calldata
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+func TestCheckBlockTimestamp(t *testing.T) {
+	testCases := []struct {
+		name         string
+		timestamp    uint64
+		parentTime   uint64
+		proposalTime uint64
+		rule         string
+	}{
+		{"valid", 100, 88, 100, ""},
+		{"equalToParent", 100, 100, 100, ""},
+		{"earlierThanParent", 100, 101, 100, timestampRuleMonotonic},
+		{"earlierThanProposalL1Block", 99, 88, 100, timestampRuleProposalL1Block},
+		{"laterThanProposalL1Block", 101, 88, 100, timestampRuleProposalL1Block},
+		{"farInTheFuture", 1 << 40, 88, 100, timestampRuleProposalL1Block},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			violation := checkBlockTimestamp(
+				&bindings.TaikoL1ClientBlockProposed{Meta: bindings.TaikoDataBlockMetadata{Timestamp: tc.timestamp}},
+				&types.Header{Time: tc.parentTime},
+				&types.Header{Time: tc.proposalTime},
+			)
+			if tc.rule == "" {
+				require.Nil(t, violation)
+			} else {
+				require.NotNil(t, violation)
+				require.Equal(t, tc.rule, violation.Rule)
+				require.Equal(t, tc.timestamp, violation.Got)
+			}
+		})
+	}
+}
@@ -2,7 +2,7 @@ package chainSyncer
 
 import (
 	"context"
-	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -11,6 +11,8 @@ import (
 	"github.com/taikoxyz/taiko-client/driver/chain_syncer/beaconsync"
 	"github.com/taikoxyz/taiko-client/driver/chain_syncer/calldata"
 	"github.com/taikoxyz/taiko-client/driver/state"
+	phaseTracker "github.com/taikoxyz/taiko-client/pkg/phase_tracker"
+	"github.com/taikoxyz/taiko-client/pkg/protocol"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
 )
 
@@ -28,9 +30,12 @@ type L2ChainSyncer struct {
 	// Monitors
 	progressTracker *beaconsync.SyncProgressTracker
 
-	// If this flag is activated, will try P2P beacon sync if current node is behind of the protocol's
-	// latest verified block head
-	p2pSyncVerifiedBlocks bool
+	// Sync strategy selected by the `--driver.syncMode` flag, and its current active phase
+	strategy SyncStrategy
+	phase    *atomic.Value
+
+	// Driver's startup phases tracker, the startup finishes when the backlog derivation is done
+	startupTracker *phaseTracker.Tracker
 }
 
 // New creates a new chain syncer instance.
@@ -38,98 +43,106 @@ func New(
 	ctx context.Context,
 	rpc *rpc.Client,
 	state *state.State,
-	p2pSyncVerifiedBlocks bool,
+	syncMode SyncMode,
 	p2pSyncTimeout time.Duration,
+	catchUpBatchSize uint64,
+	slowBlockThreshold time.Duration,
 	signalServiceAddress common.Address,
+	protocolConfigs *protocol.Configs,
+	startupTracker *phaseTracker.Tracker,
 ) (*L2ChainSyncer, error) {
+	engineAPIVersion, err := rpc.L2Engine.NegotiateVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	tracker := beaconsync.NewSyncProgressTracker(rpc.L2, p2pSyncTimeout)
 	go tracker.Track(ctx)
 
 	beaconSyncer := beaconsync.NewSyncer(ctx, rpc, state, tracker)
-	calldataSyncer, err := calldata.NewSyncer(ctx, rpc, state, tracker, signalServiceAddress)
+	calldataSyncer, err := calldata.NewSyncer(ctx, rpc, state, tracker, signalServiceAddress, protocolConfigs)
 	if err != nil {
 		return nil, err
 	}
+	calldataSyncer.SetCatchUpBatchSize(catchUpBatchSize)
+	calldataSyncer.SetSlowBlockThreshold(slowBlockThreshold)
+
+	syncer := &L2ChainSyncer{
+		ctx:             ctx,
+		rpc:             rpc,
+		state:           state,
+		beaconSyncer:    beaconSyncer,
+		calldataSyncer:  calldataSyncer,
+		progressTracker: tracker,
+		phase:           new(atomic.Value),
+		startupTracker:  startupTracker,
+	}
 
-	return &L2ChainSyncer{
-		ctx:                   ctx,
-		rpc:                   rpc,
-		state:                 state,
-		beaconSyncer:          beaconSyncer,
-		calldataSyncer:        calldataSyncer,
-		progressTracker:       tracker,
-		p2pSyncVerifiedBlocks: p2pSyncVerifiedBlocks,
-	}, nil
+	if syncer.strategy, err = newSyncStrategy(syncer, syncMode); err != nil {
+		return nil, err
+	}
+
+	log.Info("Chain syncer initialized", "syncMode", syncMode, "engineAPIVersion", engineAPIVersion)
+
+	return syncer, nil
 }
 
 // Sync performs a sync operation to L2 execution engine's local chain.
 func (s *L2ChainSyncer) Sync(l1End *types.Header) error {
-	// If current L2 execution engine's chain is behind of the protocol's latest verified block head, and the
-	// `P2PSyncVerifiedBlocks` flag is set, try triggering a beacon sync in L2 execution engine to catch up the
-	// latest verified block head.
-	if s.needNewBeaconSyncTriggered() {
-		if err := s.beaconSyncer.TriggerBeaconSync(); err != nil {
-			return fmt.Errorf("trigger beacon sync error: %w", err)
-		}
+	// Perform the initial sync phase of the selected strategy at first, e.g. if current L2 execution engine's
+	// chain is behind of the protocol's latest verified block head, and the P2P sync mode is selected, try
+	// triggering a beacon sync in L2 execution engine to catch up the latest verified block head.
+	inProgress, err := s.strategy.InitialSync(s.ctx)
+	if err != nil {
+		return err
+	}
 
+	if inProgress {
 		return nil
 	}
 
-	// We have triggered at least a beacon sync in L2 execution engine, we should reset the L1Current
-	// cursor at first, before start inserting pending L2 blocks one by one.
-	if s.progressTracker.Triggered() {
-		log.Info(
-			"Switch to insert pending blocks one by one",
-			"p2pEnabled", s.p2pSyncVerifiedBlocks,
-			"p2pOutOfSync", s.progressTracker.OutOfSync(),
-		)
-
-		// Get the execution engine's chain head.
-		l2Head, err := s.rpc.L2.HeaderByNumber(s.ctx, nil)
-		if err != nil {
-			return err
-		}
-
-		// Make sure the execution engine's chain head is recorded in protocol.
-		l2HeadHash, err := s.rpc.TaikoL1.GetXchainBlockHash(nil, l2Head.Number)
-		if err != nil {
-			return err
-		}
-
-		heightOrID := &state.HeightOrID{Height: l2Head.Number}
-		// If there is a verified block hash mismatch, log the error and then try to re-sync from genesis one by one.
-		if l2Head.Hash() != l2HeadHash {
-			log.Error(
-				"L2 block hash mismatch, re-sync from genesis",
-				"height", l2Head.Number,
-				"hash in protocol", common.Hash(l2HeadHash),
-				"hash in execution engine", l2Head.Hash(),
-			)
-
-			heightOrID.ID = common.Big0
-			heightOrID.Height = common.Big0
-			if l2HeadHash, err = s.rpc.TaikoL1.GetXchainBlockHash(nil, common.Big0); err != nil {
-				return err
-			}
-		}
-
-		// If the L2 execution engine has synced to latest verified block.
-		if l2HeadHash == s.progressTracker.LastSyncedVerifiedBlockHash() {
-			heightOrID.ID = s.progressTracker.LastSyncedVerifiedBlockID()
-		}
-
-		// Reset the L1Current cursor.
-		blockID, err := s.state.ResetL1Current(s.ctx, heightOrID)
-		if err != nil {
-			return err
-		}
-
-		// Reset to the latest L2 execution engine's chain status.
-		s.progressTracker.UpdateMeta(blockID, heightOrID.Height, l2HeadHash)
+	if err := s.strategy.Derive(s.ctx, l1End); err != nil {
+		return err
+	}
+
+	// All the backlog blocks have been derived, the driver has reached the steady state.
+	s.startupTracker.Finish()
+
+	return nil
+}
+
+// SyncMode returns the sync mode of the selected strategy.
+func (s *L2ChainSyncer) SyncMode() SyncMode {
+	return s.strategy.Mode()
+}
+
+// EngineAPIVersion returns the Engine API version negotiated with the L2 execution engine.
+func (s *L2ChainSyncer) EngineAPIVersion() string {
+	return s.rpc.L2Engine.Version()
+}
+
+// Phase returns the current active phase of the selected sync strategy.
+func (s *L2ChainSyncer) Phase() SyncPhase {
+	phase, ok := s.phase.Load().(SyncPhase)
+	if !ok {
+		return ""
+	}
+
+	return phase
+}
+
+// setPhase updates the current active sync phase, and logs the transition between phases.
+func (s *L2ChainSyncer) setPhase(phase SyncPhase) {
+	if prev := s.Phase(); prev != phase {
+		log.Info("Sync phase changed", "syncMode", s.strategy.Mode(), "from", prev, "to", phase)
+		s.phase.Store(phase)
 	}
 
-	// Insert the proposed block one by one.
-	return s.calldataSyncer.ProcessL1Blocks(s.ctx, l1End)
+	if phase == SyncPhaseDerivation {
+		s.startupTracker.Enter(StartupPhaseBacklogDerivation)
+	} else {
+		s.startupTracker.Enter(string(phase))
+	}
 }
 
 // AheadOfProtocolVerifiedHead checks whether the L2 chain is ahead of verified head in protocol.
@@ -162,8 +175,7 @@ func (s *L2ChainSyncer) AheadOfProtocolVerifiedHead() bool {
 // needNewBeaconSyncTriggered checks wthether the current L2 execution engine needs to trigger
 // another new beacon sync.
 func (s *L2ChainSyncer) needNewBeaconSyncTriggered() bool {
-	return s.p2pSyncVerifiedBlocks &&
-		s.state.GetLatestVerifiedBlock().Height.Uint64() > 0 &&
+	return s.state.GetLatestVerifiedBlock().Height.Uint64() > 0 &&
 		!s.AheadOfProtocolVerifiedHead() &&
 		!s.progressTracker.OutOfSync()
 }
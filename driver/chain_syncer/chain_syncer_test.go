@@ -7,8 +7,11 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/taikoxyz/taiko-client/driver/state"
+	phaseTracker "github.com/taikoxyz/taiko-client/pkg/phase_tracker"
+	"github.com/taikoxyz/taiko-client/pkg/protocol"
 	"github.com/taikoxyz/taiko-client/testutils"
 )
 
@@ -19,17 +22,26 @@ type ChainSyncerTestSuite struct {
 
 func (s *ChainSyncerTestSuite) SetupTest() {
 	s.ClientTestSuite.SetupTest()
+	// Use the same L2 node as the checkpoint, so that the checkpoint sync mode can be tested too.
+	s.RpcClient.L2CheckPoint = s.RpcClient.L2
 
 	state, err := state.New(context.Background(), s.RpcClient)
 	s.Nil(err)
 
+	protocolConfigs, err := protocol.New(context.Background(), s.RpcClient.TaikoL1)
+	s.Nil(err)
+
 	syncer, err := New(
 		context.Background(),
 		s.RpcClient,
 		state,
-		false,
+		SyncModeFull,
 		1*time.Hour,
+		0,
+		0,
 		common.HexToAddress(os.Getenv("L1_SIGNAL_SERVICE_CONTRACT_ADDRESS")),
+		protocolConfigs,
+		phaseTracker.New("driver"),
 	)
 	s.Nil(err)
 	s.s = syncer
@@ -48,6 +60,48 @@ func (s *ChainSyncerTestSuite) TestSync() {
 	head, err := s.RpcClient.L1.HeaderByNumber(context.Background(), nil)
 	s.Nil(err)
 	s.Nil(s.s.Sync(head))
+	s.True(s.s.startupTracker.Finished())
+}
+
+func (s *ChainSyncerTestSuite) TestSyncModes() {
+	protocolConfigs, err := protocol.New(context.Background(), s.RpcClient.TaikoL1)
+	s.Nil(err)
+
+	for _, mode := range []SyncMode{SyncModeFull, SyncModeP2P, SyncModeCheckpoint} {
+		state, err := state.New(context.Background(), s.RpcClient)
+		s.Nil(err)
+
+		syncer, err := New(
+			context.Background(),
+			s.RpcClient,
+			state,
+			mode,
+			1*time.Hour,
+			64,
+			time.Minute,
+			common.HexToAddress(os.Getenv("L1_SIGNAL_SERVICE_CONTRACT_ADDRESS")),
+			protocolConfigs,
+			phaseTracker.New("driver"),
+		)
+		s.Nil(err)
+		s.Equal(mode, syncer.SyncMode())
+
+		head, err := s.RpcClient.L1.HeaderByNumber(context.Background(), nil)
+		s.Nil(err)
+		s.Nil(syncer.Sync(head))
+		s.Equal(SyncPhaseDerivation, syncer.Phase())
+	}
+}
+
+func TestParseSyncMode(t *testing.T) {
+	for _, mode := range []SyncMode{SyncModeFull, SyncModeP2P, SyncModeCheckpoint} {
+		parsed, err := ParseSyncMode(string(mode))
+		require.Nil(t, err)
+		require.Equal(t, mode, parsed)
+	}
+
+	_, err := ParseSyncMode("snap")
+	require.NotNil(t, err)
 }
 
 func TestChainSyncerTestSuite(t *testing.T) {
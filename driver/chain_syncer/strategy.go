@@ -0,0 +1,288 @@
+package chainSyncer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/driver/state"
+)
+
+// SyncMode represents the strategy used to bring the L2 execution engine's local chain in sync with
+// the one in TaikoL1 contract.
+type SyncMode string
+
+// All supported sync modes.
+const (
+	// SyncModeFull derives every L2 block from the TaikoL1.proposeBlock transactions' calldata.
+	SyncModeFull SyncMode = "full"
+	// SyncModeP2P triggers a P2P beacon sync to the protocol's latest verified block head at first,
+	// and then derives the remaining pending blocks from calldata.
+	SyncModeP2P SyncMode = "p2p"
+	// SyncModeCheckpoint inserts all verified blocks fetched from a checkpoint node at first,
+	// and then derives the remaining pending blocks from calldata.
+	SyncModeCheckpoint SyncMode = "checkpoint"
+)
+
+// ParseSyncMode parses the given string to a SyncMode.
+func ParseSyncMode(mode string) (SyncMode, error) {
+	switch SyncMode(mode) {
+	case SyncModeFull, SyncModeP2P, SyncModeCheckpoint:
+		return SyncMode(mode), nil
+	default:
+		return "", fmt.Errorf("invalid sync mode: %s", mode)
+	}
+}
+
+// SyncPhase represents the current active phase of a sync strategy.
+type SyncPhase string
+
+// All sync phases.
+const (
+	SyncPhaseP2PSync        SyncPhase = "p2pSync"
+	SyncPhaseCheckpointSync SyncPhase = "checkpointSync"
+	SyncPhaseDerivation     SyncPhase = "calldataDerivation"
+)
+
+// StartupPhaseBacklogDerivation is the driver's startup phase of deriving the backlog blocks from
+// calldata, the other startup phases of the chain syncer are named after the initial sync phases.
+const StartupPhaseBacklogDerivation = "backlogDerivation"
+
+// SyncStrategy represents a way of keeping the L2 execution engine's local chain in sync with
+// the protocol, which consists of an initial sync phase and a steady-state derivation phase.
+type SyncStrategy interface {
+	// Mode returns the sync mode this strategy implements.
+	Mode() SyncMode
+	// InitialSync performs the strategy's initial sync phase, returns true if the phase is still
+	// in progress, so the steady-state derivation should not start in current round.
+	InitialSync(ctx context.Context) (bool, error)
+	// Derive performs the steady-state derivation until the given L1 block.
+	Derive(ctx context.Context, l1End *types.Header) error
+}
+
+// newSyncStrategy creates a new sync strategy instance for the given mode.
+func newSyncStrategy(s *L2ChainSyncer, mode SyncMode) (SyncStrategy, error) {
+	switch mode {
+	case SyncModeFull, "":
+		return &fullSyncStrategy{s}, nil
+	case SyncModeP2P:
+		return &p2pSyncStrategy{fullSyncStrategy{s}}, nil
+	case SyncModeCheckpoint:
+		if s.rpc.L2CheckPoint == nil {
+			return nil, fmt.Errorf("missing L2 check point client for %s sync mode", mode)
+		}
+		return &checkpointSyncStrategy{fullSyncStrategy{s}}, nil
+	default:
+		return nil, fmt.Errorf("invalid sync mode: %s", mode)
+	}
+}
+
+// fullSyncStrategy derives all L2 blocks from L1 calldata one by one.
+type fullSyncStrategy struct {
+	s *L2ChainSyncer
+}
+
+// Mode implements the SyncStrategy interface.
+func (f *fullSyncStrategy) Mode() SyncMode {
+	return SyncModeFull
+}
+
+// InitialSync implements the SyncStrategy interface.
+func (f *fullSyncStrategy) InitialSync(ctx context.Context) (bool, error) {
+	return false, nil
+}
+
+// Derive implements the SyncStrategy interface.
+func (f *fullSyncStrategy) Derive(ctx context.Context, l1End *types.Header) error {
+	f.s.setPhase(SyncPhaseDerivation)
+
+	// We have synced at least some verified blocks in L2 execution engine, we should reset the L1Current
+	// cursor at first, before start inserting pending L2 blocks one by one.
+	if f.s.progressTracker.Triggered() {
+		if err := f.s.resetL1Current(ctx); err != nil {
+			return err
+		}
+	}
+
+	// Insert the proposed block one by one.
+	return f.s.calldataSyncer.ProcessL1Blocks(ctx, l1End)
+}
+
+// p2pSyncStrategy triggers a P2P beacon sync in the L2 execution engine, if current node is behind of
+// the protocol's latest verified block head.
+type p2pSyncStrategy struct {
+	fullSyncStrategy
+}
+
+// Mode implements the SyncStrategy interface.
+func (p *p2pSyncStrategy) Mode() SyncMode {
+	return SyncModeP2P
+}
+
+// InitialSync implements the SyncStrategy interface.
+func (p *p2pSyncStrategy) InitialSync(ctx context.Context) (bool, error) {
+	if !p.s.needNewBeaconSyncTriggered() {
+		return false, nil
+	}
+
+	p.s.setPhase(SyncPhaseP2PSync)
+	if err := p.s.beaconSyncer.TriggerBeaconSync(); err != nil {
+		return false, fmt.Errorf("trigger beacon sync error: %w", err)
+	}
+
+	return true, nil
+}
+
+// checkpointSyncStrategy fetches all verified blocks from a checkpoint node, and inserts them
+// into the L2 execution engine through Engine APIs.
+type checkpointSyncStrategy struct {
+	fullSyncStrategy
+}
+
+// Mode implements the SyncStrategy interface.
+func (c *checkpointSyncStrategy) Mode() SyncMode {
+	return SyncModeCheckpoint
+}
+
+// InitialSync implements the SyncStrategy interface.
+func (c *checkpointSyncStrategy) InitialSync(ctx context.Context) (bool, error) {
+	if c.s.progressTracker.Triggered() ||
+		c.s.state.GetLatestVerifiedBlock().Height.Uint64() == 0 ||
+		c.s.AheadOfProtocolVerifiedHead() {
+		return false, nil
+	}
+
+	c.s.setPhase(SyncPhaseCheckpointSync)
+
+	latestVerifiedBlock := c.s.state.GetLatestVerifiedBlock()
+	for height := new(big.Int).Add(c.s.state.GetL2Head().Number, common.Big1); height.Cmp(
+		latestVerifiedBlock.Height,
+	) <= 0; height = new(big.Int).Add(height, common.Big1) {
+		if ctx.Err() != nil {
+			return true, ctx.Err()
+		}
+
+		if err := c.insertCheckpointBlock(ctx, height); err != nil {
+			return false, fmt.Errorf("failed to insert checkpoint block %d: %w", height, err)
+		}
+	}
+
+	head, err := c.s.rpc.L2.HeaderByNumber(ctx, latestVerifiedBlock.Height)
+	if err != nil {
+		return false, err
+	}
+
+	if head.Hash() != latestVerifiedBlock.Hash {
+		return false, fmt.Errorf(
+			"latest verified block hash mismatch after checkpoint sync: %s != %s", head.Hash(), latestVerifiedBlock.Hash,
+		)
+	}
+
+	log.Info(
+		"Checkpoint sync finished",
+		"latestVerifiedBlockID", latestVerifiedBlock.ID,
+		"latestVerifiedBlockHeight", latestVerifiedBlock.Height,
+		"latestVerifiedBlockHash", latestVerifiedBlock.Hash,
+	)
+
+	// Reuse the sync progress tracker, so the L1Current cursor will be reset to the latest verified block in
+	// the following steady-state derivation.
+	c.s.progressTracker.UpdateMeta(latestVerifiedBlock.ID, latestVerifiedBlock.Height, latestVerifiedBlock.Hash)
+
+	return false, nil
+}
+
+// insertCheckpointBlock fetches the block with the given height from the checkpoint node, and
+// inserts it into the L2 execution engine.
+func (c *checkpointSyncStrategy) insertCheckpointBlock(ctx context.Context, height *big.Int) error {
+	block, err := c.s.rpc.L2CheckPoint.BlockByNumber(ctx, height)
+	if err != nil {
+		return err
+	}
+
+	payload := engine.BlockToExecutableData(block, common.Big0).ExecutionPayload
+
+	status, err := c.s.rpc.L2Engine.NewPayload(ctx, payload)
+	if err != nil {
+		return err
+	}
+	if status.Status != engine.VALID {
+		return fmt.Errorf("unexpected NewPayload response status: %s", status.Status)
+	}
+
+	fcRes, err := c.s.rpc.L2Engine.ForkchoiceUpdate(ctx, &engine.ForkchoiceStateV1{HeadBlockHash: block.Hash()}, nil)
+	if err != nil {
+		return err
+	}
+	if fcRes.PayloadStatus.Status != engine.VALID {
+		return fmt.Errorf("unexpected ForkchoiceUpdate response status: %s", fcRes.PayloadStatus.Status)
+	}
+
+	log.Info(
+		"🔗 Checkpoint block inserted",
+		"height", block.Number(),
+		"hash", block.Hash(),
+		"transactions", len(block.Transactions()),
+	)
+
+	return nil
+}
+
+// resetL1Current resets the L1Current cursor to the L1 block which proposed the execution engine's chain head,
+// after some verified blocks have been synced in the initial sync phase.
+func (s *L2ChainSyncer) resetL1Current(ctx context.Context) error {
+	log.Info(
+		"Switch to insert pending blocks one by one",
+		"syncMode", s.strategy.Mode(),
+		"p2pOutOfSync", s.progressTracker.OutOfSync(),
+	)
+
+	// Get the execution engine's chain head.
+	l2Head, err := s.rpc.L2.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// Make sure the execution engine's chain head is recorded in protocol.
+	l2HeadHash, err := s.rpc.TaikoL1.GetXchainBlockHash(nil, l2Head.Number)
+	if err != nil {
+		return err
+	}
+
+	heightOrID := &state.HeightOrID{Height: l2Head.Number}
+	// If there is a verified block hash mismatch, log the error and then try to re-sync from genesis one by one.
+	if l2Head.Hash() != l2HeadHash {
+		log.Error(
+			"L2 block hash mismatch, re-sync from genesis",
+			"height", l2Head.Number,
+			"hash in protocol", common.Hash(l2HeadHash),
+			"hash in execution engine", l2Head.Hash(),
+		)
+
+		heightOrID.ID = common.Big0
+		heightOrID.Height = common.Big0
+		if l2HeadHash, err = s.rpc.TaikoL1.GetXchainBlockHash(nil, common.Big0); err != nil {
+			return err
+		}
+	}
+
+	// If the L2 execution engine has synced to latest verified block.
+	if l2HeadHash == s.progressTracker.LastSyncedVerifiedBlockHash() {
+		heightOrID.ID = s.progressTracker.LastSyncedVerifiedBlockID()
+	}
+
+	// Reset the L1Current cursor.
+	blockID, err := s.state.ResetL1Current(ctx, heightOrID)
+	if err != nil {
+		return err
+	}
+
+	// Reset to the latest L2 execution engine's chain status.
+	s.progressTracker.UpdateMeta(blockID, heightOrID.Height, l2HeadHash)
+
+	return nil
+}
@@ -1,28 +1,44 @@
 package driver
 
 import (
-	"errors"
 	"fmt"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/taikoxyz/taiko-client/cmd/flags"
+	chainSyncer "github.com/taikoxyz/taiko-client/driver/chain_syncer"
+	messageRelayer "github.com/taikoxyz/taiko-client/driver/message_relayer"
 	"github.com/taikoxyz/taiko-client/pkg/jwt"
 	"github.com/urfave/cli/v2"
 )
 
 // Config contains the configurations to initialize a Taiko driver.
 type Config struct {
-	L1Endpoint            string
-	L2Endpoint            string
-	L2EngineEndpoint      string
-	L2CheckPoint          string
-	TaikoL1Address        common.Address
-	TaikoL2Address        common.Address
-	SignalServiceAddress  common.Address
-	JwtSecret             string
-	P2PSyncVerifiedBlocks bool
-	P2PSyncTimeout        time.Duration
+	L1Endpoint           string
+	L2Endpoint           string
+	L2EngineEndpoint     string
+	L2CheckPoint         string
+	TaikoL1Address       common.Address
+	TaikoL2Address       common.Address
+	TaikoL1ABIPath       string
+	TaikoL2ABIPath       string
+	L1ArchiveEndpoint    string
+	SignalServiceAddress common.Address
+	JwtSecret            string
+	JwtSecretPath        string
+	JwtRefreshInterval   time.Duration
+	SyncMode             chainSyncer.SyncMode
+	P2PSyncTimeout       time.Duration
+	CatchUpBatchSize     uint64
+	SlowBlockThreshold   time.Duration
+	HTTPAddr             string
+	MaxSyncGap           uint64
+	WaitForL1Finality    bool
+	AlertWebhookURL      string
+	BlockFeedSocket      string
+	MessageRelayer       *messageRelayer.Config
+	StateSnapshotPath    string
 }
 
 // NewConfigFromCliContext creates a new config instance from
@@ -38,20 +54,106 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 		l2CheckPoint          = c.String(flags.CheckPointSyncUrl.Name)
 	)
 
-	if p2pSyncVerifiedBlocks && len(l2CheckPoint) == 0 {
-		return nil, errors.New("empty L2 check point URL")
-	}
-
-	return &Config{
-		L1Endpoint:            c.String(flags.L1WSEndpoint.Name),
-		L2Endpoint:            c.String(flags.L2WSEndpoint.Name),
-		L2EngineEndpoint:      c.String(flags.L2AuthEndpoint.Name),
-		L2CheckPoint:          l2CheckPoint,
-		TaikoL1Address:        common.HexToAddress(c.String(flags.TaikoL1Address.Name)),
-		TaikoL2Address:        common.HexToAddress(c.String(flags.TaikoL2Address.Name)),
-		SignalServiceAddress:  common.HexToAddress(c.String(flags.SignalServiceAddress.Name)),
-		JwtSecret:             string(jwtSecret),
-		P2PSyncVerifiedBlocks: p2pSyncVerifiedBlocks,
-		P2PSyncTimeout:        time.Duration(int64(time.Second) * int64(c.Uint(flags.P2PSyncTimeout.Name))),
-	}, nil
+	syncMode, err := chainSyncer.ParseSyncMode(c.String(flags.SyncMode.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the legacy `--p2p.syncVerifiedBlocks` flag working, it is an alias of `--driver.syncMode p2p`.
+	if p2pSyncVerifiedBlocks {
+		if c.IsSet(flags.SyncMode.Name) && syncMode != chainSyncer.SyncModeP2P {
+			return nil, fmt.Errorf(
+				"--%s is incompatible with --%s %s", flags.P2PSyncVerifiedBlocks.Name, flags.SyncMode.Name, syncMode,
+			)
+		}
+		syncMode = chainSyncer.SyncModeP2P
+	}
+
+	var relayerConfig *messageRelayer.Config
+	if c.Bool(flags.EnableMessageRelayer.Name) {
+		for _, f := range []*cli.StringFlag{
+			flags.RelayerPrivateKey, flags.L1BridgeAddress, flags.L2BridgeAddress, flags.L2SignalServiceAddress,
+		} {
+			if len(c.String(f.Name)) == 0 {
+				return nil, fmt.Errorf("--%s is required by --%s", f.Name, flags.EnableMessageRelayer.Name)
+			}
+		}
+
+		relayerPrivKey, err := crypto.ToECDSA(common.Hex2Bytes(c.String(flags.RelayerPrivateKey.Name)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid message relayer private key: %w", err)
+		}
+
+		relayerConfig = &messageRelayer.Config{
+			PrivateKey:             relayerPrivKey,
+			L1BridgeAddress:        common.HexToAddress(c.String(flags.L1BridgeAddress.Name)),
+			L2BridgeAddress:        common.HexToAddress(c.String(flags.L2BridgeAddress.Name)),
+			L2SignalServiceAddress: common.HexToAddress(c.String(flags.L2SignalServiceAddress.Name)),
+		}
+	}
+
+	cfg := &Config{
+		L1Endpoint:           c.String(flags.L1WSEndpoint.Name),
+		L2Endpoint:           c.String(flags.L2WSEndpoint.Name),
+		L2EngineEndpoint:     c.String(flags.L2AuthEndpoint.Name),
+		L2CheckPoint:         l2CheckPoint,
+		TaikoL1Address:       common.HexToAddress(c.String(flags.TaikoL1Address.Name)),
+		TaikoL2Address:       common.HexToAddress(c.String(flags.TaikoL2Address.Name)),
+		TaikoL1ABIPath:       c.String(flags.TaikoL1ABIPath.Name),
+		TaikoL2ABIPath:       c.String(flags.TaikoL2ABIPath.Name),
+		L1ArchiveEndpoint:    c.String(flags.L1ArchiveEndpoint.Name),
+		SignalServiceAddress: common.HexToAddress(c.String(flags.SignalServiceAddress.Name)),
+		JwtSecret:            string(jwtSecret),
+		JwtSecretPath:        c.String(flags.JWTSecret.Name),
+		JwtRefreshInterval:   c.Duration(flags.JWTRefreshInterval.Name),
+		SyncMode:             syncMode,
+		P2PSyncTimeout:       time.Duration(int64(time.Second) * int64(c.Uint(flags.P2PSyncTimeout.Name))),
+		CatchUpBatchSize:     c.Uint64(flags.CatchUpBatchSize.Name),
+		SlowBlockThreshold:   c.Duration(flags.SlowBlockThreshold.Name),
+		HTTPAddr:             c.String(flags.HTTPAddr.Name),
+		MaxSyncGap:           c.Uint64(flags.MaxSyncGap.Name),
+		WaitForL1Finality:    c.Bool(flags.WaitForL1Finality.Name),
+		AlertWebhookURL:      c.String(flags.AlertWebhookURL.Name),
+		BlockFeedSocket:      c.String(flags.DriverBlockFeedSocket.Name),
+		MessageRelayer:       relayerConfig,
+		StateSnapshotPath:    c.String(flags.StateSnapshotPath.Name),
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Validate checks the configurations which contradict each other.
+func (c *Config) Validate() error {
+	if c.SyncMode != chainSyncer.SyncModeFull && len(c.L2CheckPoint) == 0 {
+		return fmt.Errorf(
+			"empty L2 check point URL, which is required by --%s %s, set it by --%s",
+			flags.SyncMode.Name, c.SyncMode, flags.CheckPointSyncUrl.Name,
+		)
+	}
+
+	if c.SyncMode == chainSyncer.SyncModeP2P && c.P2PSyncTimeout == 0 {
+		return fmt.Errorf("invalid --%s 0 with --%s %s", flags.P2PSyncTimeout.Name, flags.SyncMode.Name, c.SyncMode)
+	}
+
+	if c.MessageRelayer != nil {
+		for _, f := range []struct {
+			name  string
+			unset bool
+		}{
+			{flags.RelayerPrivateKey.Name, c.MessageRelayer.PrivateKey == nil},
+			{flags.L1BridgeAddress.Name, c.MessageRelayer.L1BridgeAddress == (common.Address{})},
+			{flags.L2BridgeAddress.Name, c.MessageRelayer.L2BridgeAddress == (common.Address{})},
+			{flags.L2SignalServiceAddress.Name, c.MessageRelayer.L2SignalServiceAddress == (common.Address{})},
+		} {
+			if f.unset {
+				return fmt.Errorf("--%s is required by --%s", f.name, flags.EnableMessageRelayer.Name)
+			}
+		}
+	}
+
+	return nil
 }
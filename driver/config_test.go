@@ -3,9 +3,15 @@ package driver
 import (
 	"context"
 	"os"
+	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
 	"github.com/taikoxyz/taiko-client/cmd/flags"
+	chainSyncer "github.com/taikoxyz/taiko-client/driver/chain_syncer"
+	messageRelayer "github.com/taikoxyz/taiko-client/driver/message_relayer"
 	"github.com/urfave/cli/v2"
 )
 
@@ -24,9 +30,20 @@ func (s *DriverTestSuite) TestNewConfigFromCliContext() {
 		&cli.StringFlag{Name: flags.L2AuthEndpoint.Name},
 		&cli.StringFlag{Name: flags.TaikoL1Address.Name},
 		&cli.StringFlag{Name: flags.TaikoL2Address.Name},
+		&cli.StringFlag{Name: flags.TaikoL1ABIPath.Name},
+		&cli.StringFlag{Name: flags.TaikoL2ABIPath.Name},
+		&cli.StringFlag{Name: flags.L1ArchiveEndpoint.Name},
 		&cli.StringFlag{Name: flags.SignalServiceAddress.Name},
 		&cli.StringFlag{Name: flags.JWTSecret.Name},
+		&cli.DurationFlag{Name: flags.JWTRefreshInterval.Name},
 		&cli.UintFlag{Name: flags.P2PSyncTimeout.Name},
+		&cli.Uint64Flag{Name: flags.CatchUpBatchSize.Name},
+		&cli.DurationFlag{Name: flags.SlowBlockThreshold.Name},
+		&cli.Uint64Flag{Name: flags.MaxSyncGap.Name},
+		&cli.BoolFlag{Name: flags.WaitForL1Finality.Name},
+		&cli.StringFlag{Name: flags.AlertWebhookURL.Name},
+		&cli.StringFlag{Name: flags.DriverBlockFeedSocket.Name},
+		&cli.StringFlag{Name: flags.StateSnapshotPath.Name},
 	}
 	app.Action = func(ctx *cli.Context) error {
 		c, err := NewConfigFromCliContext(ctx)
@@ -36,9 +53,22 @@ func (s *DriverTestSuite) TestNewConfigFromCliContext() {
 		s.Equal(l2EngineEndpoint, c.L2EngineEndpoint)
 		s.Equal(taikoL1, c.TaikoL1Address.String())
 		s.Equal(taikoL2, c.TaikoL2Address.String())
+		s.Equal("/tmp/TaikoL1.json", c.TaikoL1ABIPath)
+		s.Equal("/tmp/TaikoL2.json", c.TaikoL2ABIPath)
+		s.Equal("http://localhost:18545", c.L1ArchiveEndpoint)
 		s.Equal(l1SignalService, c.SignalServiceAddress.String())
 		s.Equal(120*time.Second, c.P2PSyncTimeout)
+		s.Equal(uint64(64), c.CatchUpBatchSize)
+		s.Equal(2*time.Minute, c.SlowBlockThreshold)
+		s.Equal(uint64(256), c.MaxSyncGap)
+		s.True(c.WaitForL1Finality)
+		s.Equal("http://localhost:8080/alerts", c.AlertWebhookURL)
+		s.Equal("/tmp/taiko-driver-feed.sock", c.BlockFeedSocket)
+		s.Nil(c.MessageRelayer)
+		s.Equal("/tmp/taiko-driver-snapshot.json", c.StateSnapshotPath)
 		s.NotEmpty(c.JwtSecret)
+		s.Equal(os.Getenv("JWT_SECRET"), c.JwtSecretPath)
+		s.Equal(30*time.Minute, c.JwtRefreshInterval)
 		s.Nil(new(Driver).InitFromCli(context.Background(), ctx))
 
 		return err
@@ -51,8 +81,190 @@ func (s *DriverTestSuite) TestNewConfigFromCliContext() {
 		"-" + flags.L2AuthEndpoint.Name, l2EngineEndpoint,
 		"-" + flags.TaikoL1Address.Name, taikoL1,
 		"-" + flags.TaikoL2Address.Name, taikoL2,
+		"-" + flags.TaikoL1ABIPath.Name, "/tmp/TaikoL1.json",
+		"-" + flags.TaikoL2ABIPath.Name, "/tmp/TaikoL2.json",
+		"-" + flags.L1ArchiveEndpoint.Name, "http://localhost:18545",
 		"-" + flags.SignalServiceAddress.Name, l1SignalService,
 		"-" + flags.JWTSecret.Name, os.Getenv("JWT_SECRET"),
+		"-" + flags.JWTRefreshInterval.Name, "30m",
 		"-" + flags.P2PSyncTimeout.Name, "120",
+		"-" + flags.CatchUpBatchSize.Name, "64",
+		"-" + flags.SlowBlockThreshold.Name, "2m",
+		"-" + flags.MaxSyncGap.Name, "256",
+		"-" + flags.WaitForL1Finality.Name,
+		"-" + flags.AlertWebhookURL.Name, "http://localhost:8080/alerts",
+		"-" + flags.DriverBlockFeedSocket.Name, "/tmp/taiko-driver-feed.sock",
+		"-" + flags.StateSnapshotPath.Name, "/tmp/taiko-driver-snapshot.json",
 	}))
 }
+
+func TestCatchUpBatchSizeFlag(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		args     []string
+		expected uint64
+	}{
+		{"default", nil, 16},
+		{"name", []string{"-" + flags.CatchUpBatchSize.Name, "64"}, 64},
+		{"alias", []string{"--l2-sync-batch-size", "32"}, 32},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			app := cli.NewApp()
+			app.Flags = []cli.Flag{flags.CatchUpBatchSize}
+			app.Action = func(ctx *cli.Context) error {
+				require.Equal(t, tc.expected, ctx.Uint64(flags.CatchUpBatchSize.Name))
+				return nil
+			}
+			require.Nil(t, app.Run(append([]string{"TestCatchUpBatchSizeFlag"}, tc.args...)))
+		})
+	}
+}
+
+func (s *DriverTestSuite) TestNewConfigFromCliContextSyncMode() {
+	app := cli.NewApp()
+	app.Flags = []cli.Flag{
+		&cli.StringFlag{Name: flags.JWTSecret.Name},
+		&cli.StringFlag{Name: flags.SyncMode.Name, Value: flags.SyncMode.Value},
+		&cli.BoolFlag{Name: flags.P2PSyncVerifiedBlocks.Name},
+		&cli.StringFlag{Name: flags.CheckPointSyncUrl.Name},
+	}
+	app.Action = func(ctx *cli.Context) error {
+		_, err := NewConfigFromCliContext(ctx)
+		return err
+	}
+
+	// Checkpoint mode without a check point URL.
+	s.ErrorContains(app.Run([]string{
+		"TestNewConfigFromCliContextSyncMode",
+		"-" + flags.JWTSecret.Name, os.Getenv("JWT_SECRET"),
+		"-" + flags.SyncMode.Name, "checkpoint",
+	}), "empty L2 check point URL")
+
+	// Legacy P2P flag with a different sync mode.
+	s.ErrorContains(app.Run([]string{
+		"TestNewConfigFromCliContextSyncMode",
+		"-" + flags.JWTSecret.Name, os.Getenv("JWT_SECRET"),
+		"-" + flags.SyncMode.Name, "checkpoint",
+		"-" + flags.P2PSyncVerifiedBlocks.Name,
+		"-" + flags.CheckPointSyncUrl.Name, os.Getenv("L2_EXECUTION_ENGINE_HTTP_ENDPOINT"),
+	}), "incompatible")
+
+	// Invalid sync mode.
+	s.ErrorContains(app.Run([]string{
+		"TestNewConfigFromCliContextSyncMode",
+		"-" + flags.JWTSecret.Name, os.Getenv("JWT_SECRET"),
+		"-" + flags.SyncMode.Name, "snap",
+	}), "invalid sync mode")
+}
+
+func (s *DriverTestSuite) TestNewConfigFromCliContextMessageRelayer() {
+	l1Bridge := common.BigToAddress(common.Big1).Hex()
+	l2Bridge := common.BigToAddress(common.Big2).Hex()
+	l2SignalService := common.BigToAddress(common.Big3).Hex()
+
+	app := cli.NewApp()
+	app.Flags = []cli.Flag{
+		&cli.StringFlag{Name: flags.JWTSecret.Name},
+		&cli.BoolFlag{Name: flags.EnableMessageRelayer.Name},
+		&cli.StringFlag{Name: flags.RelayerPrivateKey.Name},
+		&cli.StringFlag{Name: flags.L1BridgeAddress.Name},
+		&cli.StringFlag{Name: flags.L2BridgeAddress.Name},
+		&cli.StringFlag{Name: flags.L2SignalServiceAddress.Name},
+	}
+	app.Action = func(ctx *cli.Context) error {
+		c, err := NewConfigFromCliContext(ctx)
+		if err != nil {
+			return err
+		}
+		s.NotNil(c.MessageRelayer.PrivateKey)
+		s.Equal(l1Bridge, c.MessageRelayer.L1BridgeAddress.Hex())
+		s.Equal(l2Bridge, c.MessageRelayer.L2BridgeAddress.Hex())
+		s.Equal(l2SignalService, c.MessageRelayer.L2SignalServiceAddress.Hex())
+		return nil
+	}
+
+	// Missing bridge addresses.
+	s.ErrorContains(app.Run([]string{
+		"TestNewConfigFromCliContextMessageRelayer",
+		"-" + flags.JWTSecret.Name, os.Getenv("JWT_SECRET"),
+		"-" + flags.EnableMessageRelayer.Name,
+		"-" + flags.RelayerPrivateKey.Name, os.Getenv("L1_PROVER_PRIVATE_KEY"),
+	}), "is required by")
+
+	s.Nil(app.Run([]string{
+		"TestNewConfigFromCliContextMessageRelayer",
+		"-" + flags.JWTSecret.Name, os.Getenv("JWT_SECRET"),
+		"-" + flags.EnableMessageRelayer.Name,
+		"-" + flags.RelayerPrivateKey.Name, os.Getenv("L1_PROVER_PRIVATE_KEY"),
+		"-" + flags.L1BridgeAddress.Name, l1Bridge,
+		"-" + flags.L2BridgeAddress.Name, l2Bridge,
+		"-" + flags.L2SignalServiceAddress.Name, l2SignalService,
+	}))
+}
+
+func TestConfigValidate(t *testing.T) {
+	privKey, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	validRelayer := func() *messageRelayer.Config {
+		return &messageRelayer.Config{
+			PrivateKey:             privKey,
+			L1BridgeAddress:        common.BigToAddress(common.Big1),
+			L2BridgeAddress:        common.BigToAddress(common.Big2),
+			L2SignalServiceAddress: common.BigToAddress(common.Big3),
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		modify func(c *Config)
+		err    string
+	}{
+		{"valid", func(c *Config) {}, ""},
+		{
+			"checkpointWithoutURL",
+			func(c *Config) { c.SyncMode = chainSyncer.SyncModeCheckpoint },
+			"empty L2 check point URL, which is required by --driver.syncMode checkpoint",
+		},
+		{
+			"p2pWithoutTimeout",
+			func(c *Config) {
+				c.SyncMode = chainSyncer.SyncModeP2P
+				c.L2CheckPoint = "http://localhost:28545"
+				c.P2PSyncTimeout = 0
+			},
+			"invalid --p2p.syncTimeout 0 with --driver.syncMode p2p",
+		},
+		{"validRelayer", func(c *Config) { c.MessageRelayer = validRelayer() }, ""},
+		{
+			"relayerWithoutPrivateKey",
+			func(c *Config) {
+				c.MessageRelayer = validRelayer()
+				c.MessageRelayer.PrivateKey = nil
+			},
+			"--" + flags.RelayerPrivateKey.Name + " is required by --" + flags.EnableMessageRelayer.Name,
+		},
+		{
+			"relayerWithoutL2SignalService",
+			func(c *Config) {
+				c.MessageRelayer = validRelayer()
+				c.MessageRelayer.L2SignalServiceAddress = common.Address{}
+			},
+			"--" + flags.L2SignalServiceAddress.Name + " is required by --" + flags.EnableMessageRelayer.Name,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Config{SyncMode: chainSyncer.SyncModeFull, P2PSyncTimeout: 10 * time.Minute}
+			tc.modify(c)
+
+			err := c.Validate()
+			if tc.err == "" {
+				require.Nil(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
@@ -2,7 +2,11 @@ package driver
 
 import (
 	"context"
+	"fmt"
+	"math/big"
+	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -10,16 +14,29 @@ import (
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 	chainSyncer "github.com/taikoxyz/taiko-client/driver/chain_syncer"
+	messageRelayer "github.com/taikoxyz/taiko-client/driver/message_relayer"
 	"github.com/taikoxyz/taiko-client/driver/state"
+	"github.com/taikoxyz/taiko-client/metrics"
+	"github.com/taikoxyz/taiko-client/pkg/blockfeed"
+	phaseTracker "github.com/taikoxyz/taiko-client/pkg/phase_tracker"
+	"github.com/taikoxyz/taiko-client/pkg/protocol"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	"github.com/taikoxyz/taiko-client/pkg/server"
+	"github.com/taikoxyz/taiko-client/pkg/webhook"
 	"github.com/urfave/cli/v2"
 )
 
 const (
 	// Time to wait before the next try, when receiving subscription errors.
 	RetryDelay = 10 * time.Second
+	// defaultMaxSyncGap is the default maximum number of L1 blocks the sync progress can fall behind.
+	defaultMaxSyncGap = 128
 )
 
+// StartupPhaseRPCDialing is the driver's startup phase before the chain syncer takes over, the following
+// phases are tracked by the chain syncer.
+const StartupPhaseRPCDialing = "rpcDialing"
+
 // Driver keeps the L2 execution engine's local block chain in sync with the TaikoL1
 // contract.
 type Driver struct {
@@ -27,6 +44,35 @@ type Driver struct {
 	l2ChainSyncer *chainSyncer.L2ChainSyncer
 	state         *state.State
 
+	startupTracker *phaseTracker.Tracker
+	httpServer     *server.Server
+
+	// Freshly derived blocks feed for the co-located prover
+	blockFeedServer *blockfeed.Server
+	derivedBlockCh  chan *blockfeed.Block
+	derivedBlockSub event.Subscription
+
+	// Optional L2-to-L1 bridge message relayer
+	messageRelayer *messageRelayer.MessageRelayer
+
+	// Path of the persisted driver states snapshot
+	stateSnapshotPath string
+
+	// JWT secret file of the L2 execution engine, re-read periodically to pick up a rotated secret
+	jwtSecretPath      string
+	jwtRefreshInterval time.Duration
+
+	// Whether only the blocks proposed in the finalized L1 blocks are derived
+	waitForL1Finality bool
+
+	// Sync gap alerting
+	maxSyncGap     uint64
+	alertNotifier  *webhook.Notifier
+	syncGapAlerted bool
+
+	protocolConfigs *protocol.Configs
+	protocolStatus  atomic.Value // *ProtocolStatus, collected by reportProtocolStatus
+
 	l1HeadCh   chan *types.Header
 	l1HeadSub  event.Subscription
 	syncNotify chan struct{}
@@ -51,15 +97,20 @@ func InitFromConfig(ctx context.Context, d *Driver, cfg *Config) (err error) {
 	d.wg = sync.WaitGroup{}
 	d.syncNotify = make(chan struct{}, 1)
 	d.ctx = ctx
+	d.startupTracker = phaseTracker.New("driver")
 
+	d.startupTracker.Enter(StartupPhaseRPCDialing)
 	if d.rpc, err = rpc.NewClient(d.ctx, &rpc.ClientConfig{
-		L1Endpoint:       cfg.L1Endpoint,
-		L2Endpoint:       cfg.L2Endpoint,
-		L2CheckPoint:     cfg.L2CheckPoint,
-		TaikoL1Address:   cfg.TaikoL1Address,
-		TaikoL2Address:   cfg.TaikoL2Address,
-		L2EngineEndpoint: cfg.L2EngineEndpoint,
-		JwtSecret:        cfg.JwtSecret,
+		L1Endpoint:        cfg.L1Endpoint,
+		L2Endpoint:        cfg.L2Endpoint,
+		L2CheckPoint:      cfg.L2CheckPoint,
+		TaikoL1Address:    cfg.TaikoL1Address,
+		TaikoL2Address:    cfg.TaikoL2Address,
+		TaikoL1ABIPath:    cfg.TaikoL1ABIPath,
+		TaikoL2ABIPath:    cfg.TaikoL2ABIPath,
+		L2EngineEndpoint:  cfg.L2EngineEndpoint,
+		JwtSecret:         cfg.JwtSecret,
+		L1ArchiveEndpoint: cfg.L1ArchiveEndpoint,
 	}); err != nil {
 		return err
 	}
@@ -68,12 +119,25 @@ func InitFromConfig(ctx context.Context, d *Driver, cfg *Config) (err error) {
 		return err
 	}
 
+	if d.protocolConfigs, err = protocol.New(d.ctx, d.rpc.TaikoL1); err != nil {
+		return err
+	}
+
+	d.stateSnapshotPath = cfg.StateSnapshotPath
+	d.jwtSecretPath = cfg.JwtSecretPath
+	d.jwtRefreshInterval = cfg.JwtRefreshInterval
+	if len(d.stateSnapshotPath) != 0 {
+		if err := d.state.LoadSnapshot(d.stateSnapshotPath); err != nil {
+			log.Warn("Failed to load driver state snapshot, ignore it", "path", d.stateSnapshotPath, "error", err)
+		}
+	}
+
 	peers, err := d.rpc.L2.PeerCount(d.ctx)
 	if err != nil {
 		return err
 	}
 
-	if cfg.P2PSyncVerifiedBlocks && peers == 0 {
+	if cfg.SyncMode == chainSyncer.SyncModeP2P && peers == 0 {
 		log.Warn("P2P syncing verified blocks enabled, but no connected peer found in L2 execution engine")
 	}
 
@@ -81,20 +145,80 @@ func InitFromConfig(ctx context.Context, d *Driver, cfg *Config) (err error) {
 		d.ctx,
 		d.rpc,
 		d.state,
-		cfg.P2PSyncVerifiedBlocks,
+		cfg.SyncMode,
 		cfg.P2PSyncTimeout,
+		cfg.CatchUpBatchSize,
+		cfg.SlowBlockThreshold,
 		cfg.SignalServiceAddress,
+		d.protocolConfigs,
+		d.startupTracker,
 	); err != nil {
 		return err
 	}
 
 	d.l1HeadSub = d.state.SubL1HeadsFeed(d.l1HeadCh)
 
+	d.waitForL1Finality = cfg.WaitForL1Finality
+	if d.waitForL1Finality {
+		log.Info("L1 finality wait enabled, only the blocks proposed in the finalized L1 blocks are derived")
+	}
+
+	d.maxSyncGap = cfg.MaxSyncGap
+	if d.maxSyncGap == 0 {
+		d.maxSyncGap = defaultMaxSyncGap
+	}
+	if len(cfg.AlertWebhookURL) != 0 {
+		d.alertNotifier = webhook.New(cfg.AlertWebhookURL, 0, 0)
+	}
+
+	if len(cfg.BlockFeedSocket) != 0 {
+		d.blockFeedServer = blockfeed.NewServer(cfg.BlockFeedSocket)
+		d.derivedBlockCh = make(chan *blockfeed.Block, 1024)
+		d.derivedBlockSub = d.l2ChainSyncer.CalldataSyncer().SubDerivedBlocksFeed(d.derivedBlockCh)
+	}
+
+	if cfg.MessageRelayer != nil {
+		if d.messageRelayer, err = messageRelayer.New(d.ctx, d.rpc, cfg.MessageRelayer); err != nil {
+			return err
+		}
+	}
+
+	if len(cfg.HTTPAddr) != 0 {
+		d.httpServer = server.New(cfg.HTTPAddr)
+		d.httpServer.HandleJSON("/status", func(r *http.Request) (interface{}, error) { return d.Status(), nil })
+	}
+
 	return nil
 }
 
 // Start starts the driver instance.
 func (d *Driver) Start() error {
+	if d.httpServer != nil {
+		if err := d.httpServer.Start(); err != nil {
+			return err
+		}
+	}
+
+	if d.blockFeedServer != nil {
+		if err := d.blockFeedServer.Start(); err != nil {
+			return err
+		}
+
+		d.wg.Add(1)
+		go d.publishDerivedBlocks()
+	}
+
+	if d.messageRelayer != nil {
+		d.messageRelayer.Start()
+	}
+
+	d.protocolConfigs.Start(d.ctx)
+
+	if len(d.jwtSecretPath) != 0 && d.jwtRefreshInterval != 0 {
+		d.wg.Add(1)
+		go d.refreshJWTSecret()
+	}
+
 	d.wg.Add(2)
 	go d.eventLoop()
 	go d.reportProtocolStatus()
@@ -104,8 +228,24 @@ func (d *Driver) Start() error {
 
 // Close closes the driver instance.
 func (d *Driver) Close() {
+	if d.httpServer != nil {
+		if err := d.httpServer.Shutdown(context.Background()); err != nil {
+			log.Error("Failed to shutdown HTTP server", "error", err)
+		}
+	}
+	if d.messageRelayer != nil {
+		d.messageRelayer.Close()
+	}
 	d.state.Close()
 	d.wg.Wait()
+	d.saveStateSnapshot()
+	if d.blockFeedServer != nil {
+		d.derivedBlockSub.Unsubscribe()
+		d.blockFeedServer.Close()
+	}
+	if d.alertNotifier != nil {
+		d.alertNotifier.Close()
+	}
 }
 
 // eventLoop starts the main loop of a L2 execution engine's driver.
@@ -144,6 +284,20 @@ func (d *Driver) eventLoop() {
 	}
 }
 
+// publishDerivedBlocks forwards the freshly derived blocks to the block feed subscribers.
+func (d *Driver) publishDerivedBlocks() {
+	defer d.wg.Done()
+
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case block := <-d.derivedBlockCh:
+			d.blockFeedServer.Publish(block)
+		}
+	}
+}
+
 // doSync fetches all `BlockProposed` events emitted from local
 // L1 sync cursor to the L1 head, and then applies all corresponding
 // L2 blocks into node's local block chain.
@@ -155,63 +309,138 @@ func (d *Driver) doSync() error {
 	}
 
 	l1Head := d.state.GetL1Head()
+	defer d.checkSyncGap(l1Head)
+
+	l1End, err := d.syncTarget(l1Head)
+	if err != nil {
+		return err
+	}
+	if l1End == nil {
+		return nil
+	}
 
-	if err := d.l2ChainSyncer.Sync(l1Head); err != nil {
+	if err := d.l2ChainSyncer.Sync(l1End); err != nil {
 		log.Error("Process new L1 blocks error", "error", err)
 		return err
 	}
 
+	d.saveStateSnapshot()
+
 	return nil
 }
 
+// syncTarget returns the L1 block the L2 chain should be synced to with the given L1 head, which is the
+// latest finalized L1 block if the L1 finality wait is enabled, or nil if there is nothing new to sync.
+func (d *Driver) syncTarget(l1Head *types.Header) (*types.Header, error) {
+	if !d.waitForL1Finality {
+		return l1Head, nil
+	}
+
+	finalized, err := d.rpc.L1FinalizedHeader(d.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch finalized L1 header: %w", err)
+	}
+
+	return finalizedSyncTarget(l1Head, finalized, d.state.GetL1Current()), nil
+}
+
+// finalizedSyncTarget returns the given finalized L1 block, capped by the given L1 head, as the sync target,
+// or nil if the given L1 current cursor has already reached it.
+func finalizedSyncTarget(l1Head, finalized, l1Current *types.Header) *types.Header {
+	if finalized.Number.Cmp(l1Head.Number) > 0 {
+		finalized = l1Head
+	}
+	metrics.DriverL1FinalizedHeightGauge.Update(finalized.Number.Int64())
+
+	// The L1 current might be ahead of the finalized block, e.g. right after enabling the wait.
+	if l1Current != nil && finalized.Number.Cmp(l1Current.Number) <= 0 {
+		log.Debug("Wait for L1 finality", "finalized", finalized.Number, "l1Current", l1Current.Number)
+		return nil
+	}
+
+	return finalized
+}
+
+// saveStateSnapshot persists the driver states, if a snapshot path is given.
+func (d *Driver) saveStateSnapshot() {
+	if len(d.stateSnapshotPath) == 0 {
+		return
+	}
+
+	if err := d.state.SaveSnapshot(d.stateSnapshotPath); err != nil {
+		log.Error("Failed to save driver state snapshot", "path", d.stateSnapshotPath, "error", err)
+	}
+}
+
+// syncGapAlert is the webhook payload of a sync gap alert.
+type syncGapAlert struct {
+	Gap                 uint64 `json:"gap"`
+	MaxSyncGap          uint64 `json:"maxSyncGap"`
+	L1Head              uint64 `json:"l1Head"`
+	LastProcessedHeight uint64 `json:"lastProcessedHeight"`
+}
+
+// checkSyncGap checks the gap between the given L1 head and the last L1 block processed by the driver,
+// alerts once when the gap exceeds the maximum sync gap, until the gap recovers.
+func (d *Driver) checkSyncGap(l1Head *types.Header) {
+	l1Current := d.state.GetL1Current()
+	if l1Head == nil || l1Current == nil {
+		return
+	}
+
+	var gap uint64
+	if l1Head.Number.Cmp(l1Current.Number) > 0 {
+		gap = new(big.Int).Sub(l1Head.Number, l1Current.Number).Uint64()
+	}
+	metrics.DriverSyncGapGauge.Update(int64(gap))
+
+	if gap <= d.maxSyncGap {
+		d.syncGapAlerted = false
+		return
+	}
+
+	log.Warn(
+		"Driver sync gap exceeds the limit",
+		"gap", gap,
+		"maxSyncGap", d.maxSyncGap,
+		"l1Head", l1Head.Number,
+		"lastProcessedHeight", l1Current.Number,
+	)
+
+	if d.syncGapAlerted || d.alertNotifier == nil {
+		return
+	}
+
+	d.syncGapAlerted = d.alertNotifier.Notify(&syncGapAlert{
+		Gap:                 gap,
+		MaxSyncGap:          d.maxSyncGap,
+		L1Head:              l1Head.Number.Uint64(),
+		LastProcessedHeight: l1Current.Number.Uint64(),
+	})
+}
+
 // ChainSyncer returns the driver's chain syncer.
 func (d *Driver) ChainSyncer() *chainSyncer.L2ChainSyncer {
 	return d.l2ChainSyncer
 }
 
-// reportProtocolStatus reports some protocol status intervally.
-func (d *Driver) reportProtocolStatus() {
-	ticker := time.NewTicker(30 * time.Second)
-	defer func() {
-		ticker.Stop()
-		d.wg.Done()
-	}()
-
-	var maxNumBlocks uint64
-	if err := backoff.Retry(
-		func() error {
-			configs, err := d.rpc.TaikoL1.GetConfig(nil)
-			if err != nil {
-				return err
-			}
-
-			maxNumBlocks = configs.MaxNumProposedBlocks.Uint64()
-			return nil
-		},
-		backoff.NewConstantBackOff(RetryDelay),
-	); err != nil {
-		log.Error("Failed to get protocol state variables", "error", err)
-		return
-	}
+// Status contains the driver's current sync status.
+type Status struct {
+	SyncMode         chainSyncer.SyncMode  `json:"syncMode"`
+	SyncPhase        chainSyncer.SyncPhase `json:"syncPhase"`
+	EngineAPIVersion string                `json:"engineAPIVersion"`
+	Startup          *phaseTracker.Status  `json:"startup"`
+	Protocol         *ProtocolStatus       `json:"protocol,omitempty"`
+}
 
-	for {
-		select {
-		case <-d.ctx.Done():
-			return
-		case <-ticker.C:
-			vars, err := d.rpc.GetProtocolStateVariables(nil)
-			if err != nil {
-				log.Error("Failed to get protocol state variables", "error", err)
-				continue
-			}
-
-			log.Info(
-				"📖 Protocol status",
-				"lastVerifiedBlockId", vars.LastVerifiedBlockId,
-				"pendingBlocks", vars.NumBlocks-vars.LastVerifiedBlockId-1,
-				"availableSlots", vars.LastVerifiedBlockId+maxNumBlocks-vars.NumBlocks,
-			)
-		}
+// Status returns the driver's current sync status.
+func (d *Driver) Status() *Status {
+	return &Status{
+		SyncMode:         d.l2ChainSyncer.SyncMode(),
+		SyncPhase:        d.l2ChainSyncer.Phase(),
+		EngineAPIVersion: d.l2ChainSyncer.EngineAPIVersion(),
+		Startup:          d.startupTracker.Status(),
+		Protocol:         d.ProtocolStatus(),
 	}
 }
 
@@ -2,16 +2,22 @@ package driver
 
 import (
 	"context"
+	"encoding/json"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/taikoxyz/taiko-client/bindings/encoding"
 	"github.com/taikoxyz/taiko-client/pkg/jwt"
+	"github.com/taikoxyz/taiko-client/pkg/webhook"
 	"github.com/taikoxyz/taiko-client/proposer"
 	"github.com/taikoxyz/taiko-client/testutils"
 )
@@ -114,12 +120,80 @@ func (s *DriverTestSuite) TestDoSyncNoNewL2Blocks() {
 	s.Nil(s.d.doSync())
 }
 
+func (s *DriverTestSuite) TestCheckSyncGap() {
+	alerts := make(chan *syncGapAlert, 2)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		alert := new(syncGapAlert)
+		s.Nil(json.NewDecoder(r.Body).Decode(alert))
+		alerts <- alert
+	}))
+	defer srv.Close()
+
+	d := &Driver{state: s.d.state, maxSyncGap: 1, alertNotifier: webhook.New(srv.URL, 0, 0)}
+	defer d.alertNotifier.Close()
+
+	l1Current := s.d.state.GetL1Current()
+	l1Head := &types.Header{Number: new(big.Int).Add(l1Current.Number, common.Big2)}
+
+	// Only alert once until the gap recovers.
+	d.checkSyncGap(l1Head)
+	d.checkSyncGap(l1Head)
+	s.True(d.syncGapAlerted)
+
+	select {
+	case alert := <-alerts:
+		s.Equal(uint64(2), alert.Gap)
+		s.Equal(l1Head.Number.Uint64(), alert.L1Head)
+		s.Equal(l1Current.Number.Uint64(), alert.LastProcessedHeight)
+	case <-time.After(5 * time.Second):
+		s.Fail("sync gap alert not received")
+	}
+
+	d.checkSyncGap(l1Current)
+	s.False(d.syncGapAlerted)
+	s.Empty(alerts)
+}
+
 func (s *DriverTestSuite) TestStartClose() {
 	s.Nil(s.d.Start())
 	s.cancel()
 	s.d.Close()
 }
 
+func TestSyncTargetWithoutFinalityWait(t *testing.T) {
+	l1Head := &types.Header{Number: big.NewInt(10)}
+
+	// Neither the L1 node nor the driver states are used.
+	target, err := new(Driver).syncTarget(l1Head)
+	require.Nil(t, err)
+	require.Equal(t, l1Head, target)
+}
+
+func TestFinalizedSyncTarget(t *testing.T) {
+	header := func(number int64) *types.Header { return &types.Header{Number: big.NewInt(number)} }
+
+	testCases := []struct {
+		name      string
+		l1Head    *types.Header
+		finalized *types.Header
+		l1Current *types.Header
+		expected  *types.Header
+	}{
+		{"finalized", header(10), header(8), header(5), header(8)},
+		{"noL1Current", header(10), header(8), nil, header(8)},
+		{"clampedToL1Head", header(10), header(12), header(5), header(10)},
+		{"l1CurrentReachedFinalized", header(10), header(8), header(8), nil},
+		{"l1CurrentAheadOfFinalized", header(10), header(8), header(9), nil},
+		{"l1CurrentReachedL1Head", header(10), header(12), header(10), nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, finalizedSyncTarget(tc.l1Head, tc.finalized, tc.l1Current))
+		})
+	}
+}
+
 func TestDriverTestSuite(t *testing.T) {
 	suite.Run(t, new(DriverTestSuite))
 }
@@ -0,0 +1,46 @@
+package driver
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/pkg/jwt"
+)
+
+// refreshJWTSecret re-reads the JWT secret file periodically, so that a rotated secret is used by the
+// following Engine API requests without restarting the driver.
+func (d *Driver) refreshJWTSecret() {
+	ticker := time.NewTicker(d.jwtRefreshInterval)
+	defer func() {
+		ticker.Stop()
+		d.wg.Done()
+	}()
+
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := d.reloadJWTSecret()
+			if err != nil {
+				log.Warn("Failed to reload JWT secret, keep using the current one", "path", d.jwtSecretPath, "error", err)
+				continue
+			}
+			if changed {
+				log.Info("JWT secret reloaded", "path", d.jwtSecretPath)
+			}
+		}
+	}
+}
+
+// reloadJWTSecret reads the JWT secret file, and updates the L2 execution engine client's credentials,
+// reports whether the secret is changed.
+func (d *Driver) reloadJWTSecret() (bool, error) {
+	secret, err := jwt.ParseSecretFromFile(d.jwtSecretPath)
+	if err != nil {
+		return false, fmt.Errorf("invalid JWT secret file: %w", err)
+	}
+
+	return d.rpc.L2Engine.SetJWTSecret(secret), nil
+}
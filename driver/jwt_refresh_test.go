@@ -0,0 +1,39 @@
+package driver
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+func TestReloadJWTSecret(t *testing.T) {
+	secret := bytes.Repeat([]byte{1}, 32)
+	engine, err := rpc.DialEngineClientWithBackoff(context.Background(), "http://localhost:8551", string(secret))
+	require.Nil(t, err)
+	defer engine.Close()
+
+	d := &Driver{rpc: &rpc.Client{L2Engine: engine}, jwtSecretPath: filepath.Join(t.TempDir(), "jwt.hex")}
+
+	// Missing file, the current secret is kept.
+	_, err = d.reloadJWTSecret()
+	require.ErrorContains(t, err, "invalid JWT secret file")
+
+	require.Nil(t, os.WriteFile(d.jwtSecretPath, []byte(hex.EncodeToString(secret)), 0o600))
+	changed, err := d.reloadJWTSecret()
+	require.Nil(t, err)
+	require.False(t, changed)
+
+	// Rotated.
+	rotated := bytes.Repeat([]byte{2}, 32)
+	require.Nil(t, os.WriteFile(d.jwtSecretPath, []byte("0x"+hex.EncodeToString(rotated)), 0o600))
+	changed, err = d.reloadJWTSecret()
+	require.Nil(t, err)
+	require.True(t, changed)
+	require.False(t, engine.SetJWTSecret(rotated))
+}
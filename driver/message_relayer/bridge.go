@@ -0,0 +1,102 @@
+package messageRelayer
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// MessageStatus is the status of a bridge message on its destination chain.
+type MessageStatus uint8
+
+// Bridge message statuses, see `IBridge.MessageStatus`.
+const (
+	MessageStatusNew MessageStatus = iota
+	MessageStatusRetriable
+	MessageStatusDone
+	MessageStatusFailed
+)
+
+// messageComponents is the ABI tuple of `IBridge.Message`.
+const messageComponents = `[
+	{"name":"id","type":"uint256"},
+	{"name":"sender","type":"address"},
+	{"name":"srcChainId","type":"uint256"},
+	{"name":"destChainId","type":"uint256"},
+	{"name":"owner","type":"address"},
+	{"name":"to","type":"address"},
+	{"name":"refundAddress","type":"address"},
+	{"name":"depositValue","type":"uint256"},
+	{"name":"callValue","type":"uint256"},
+	{"name":"processingFee","type":"uint256"},
+	{"name":"gasLimit","type":"uint256"},
+	{"name":"data","type":"bytes"},
+	{"name":"memo","type":"string"}
+]`
+
+// bridgeABIJSON contains the Bridge contract methods and events used by the message relayer.
+var bridgeABIJSON = `[
+	{"anonymous":false,"inputs":[
+		{"indexed":true,"name":"msgHash","type":"bytes32"},
+		{"indexed":false,"name":"message","type":"tuple","components":` + messageComponents + `}
+	],"name":"MessageSent","type":"event"},
+	{"inputs":[
+		{"name":"message","type":"tuple","components":` + messageComponents + `},
+		{"name":"proof","type":"bytes"}
+	],"name":"processMessage","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"name":"msgHash","type":"bytes32"}],"name":"getMessageStatus",
+	"outputs":[{"type":"uint8"}],"stateMutability":"view","type":"function"}
+]`
+
+// BridgeABI is the partial ABI of the Bridge contract.
+var BridgeABI abi.ABI
+
+func init() {
+	var err error
+	if BridgeABI, err = abi.JSON(strings.NewReader(bridgeABIJSON)); err != nil {
+		panic(err)
+	}
+}
+
+// Message is a cross-chain message sent through the Bridge contract, see `IBridge.Message`.
+type Message struct {
+	Id            *big.Int
+	Sender        common.Address
+	SrcChainId    *big.Int
+	DestChainId   *big.Int
+	Owner         common.Address
+	To            common.Address
+	RefundAddress common.Address
+	DepositValue  *big.Int
+	CallValue     *big.Int
+	ProcessingFee *big.Int
+	GasLimit      *big.Int
+	Data          []byte
+	Memo          string
+}
+
+// MessageSent is a `Bridge.MessageSent` event.
+type MessageSent struct {
+	MsgHash common.Hash
+	Message Message
+	Raw     types.Log
+}
+
+// parseMessageSent parses the given `Bridge.MessageSent` event log.
+func parseMessageSent(log types.Log) (*MessageSent, error) {
+	event := BridgeABI.Events["MessageSent"]
+	if len(log.Topics) != 2 || log.Topics[0] != event.ID {
+		return nil, fmt.Errorf("not a MessageSent event log, txHash: %s", log.TxHash)
+	}
+
+	var out struct{ Message Message }
+	if err := BridgeABI.UnpackIntoInterface(&out, "MessageSent", log.Data); err != nil {
+		return nil, fmt.Errorf("failed to unpack MessageSent event: %w", err)
+	}
+
+	return &MessageSent{MsgHash: log.Topics[1], Message: out.Message, Raw: log}, nil
+}
@@ -0,0 +1,91 @@
+package messageRelayer
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+)
+
+var (
+	bytesType, _       = abi.NewType("bytes", "", nil)
+	signalProofType, _ = abi.NewType("tuple", "SignalService.SignalProof", []abi.ArgumentMarshaling{
+		{Name: "header", Type: "tuple", Components: []abi.ArgumentMarshaling{
+			{Name: "parentHash", Type: "bytes32"},
+			{Name: "ommersHash", Type: "bytes32"},
+			{Name: "beneficiary", Type: "address"},
+			{Name: "stateRoot", Type: "bytes32"},
+			{Name: "transactionsRoot", Type: "bytes32"},
+			{Name: "receiptsRoot", Type: "bytes32"},
+			{Name: "logsBloom", Type: "bytes32[8]"},
+			{Name: "difficulty", Type: "uint256"},
+			{Name: "height", Type: "uint128"},
+			{Name: "gasLimit", Type: "uint64"},
+			{Name: "gasUsed", Type: "uint64"},
+			{Name: "timestamp", Type: "uint64"},
+			{Name: "extraData", Type: "bytes"},
+			{Name: "mixHash", Type: "bytes32"},
+			{Name: "nonce", Type: "uint64"},
+			{Name: "baseFeePerGas", Type: "uint256"},
+		}},
+		{Name: "proof", Type: "bytes"},
+	})
+	storageProofArgs = abi.Arguments{{Type: bytesType}, {Type: bytesType}}
+	signalProofArgs  = abi.Arguments{{Name: "SignalProof", Type: signalProofType}}
+)
+
+// signalProof is the `SignalService.SignalProof` struct.
+type signalProof struct {
+	Header encoding.BlockHeader
+	Proof  []byte
+}
+
+// signalSlot returns the SignalService storage slot of the given signal sent by the given app.
+func signalSlot(app common.Address, signal common.Hash) common.Hash {
+	return crypto.Keccak256Hash(app.Bytes(), signal.Bytes())
+}
+
+// encodeSignalProof encodes the merkle proof of a signal in the SignalService storage at the given
+// header, which can be verified by the destination chain's SignalService.
+func encodeSignalProof(header *types.Header, proof *gethclient.AccountResult) ([]byte, error) {
+	if len(proof.StorageProof) != 1 {
+		return nil, fmt.Errorf("invalid storage proofs length: %d", len(proof.StorageProof))
+	}
+
+	accountProof, err := encodeProofNodes(proof.AccountProof)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode account proof: %w", err)
+	}
+
+	storageProof, err := encodeProofNodes(proof.StorageProof[0].Proof)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode storage proof: %w", err)
+	}
+
+	mkproof, err := storageProofArgs.Pack(accountProof, storageProof)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack storage proof: %w", err)
+	}
+
+	return signalProofArgs.Pack(&signalProof{Header: *encoding.FromGethHeader(header), Proof: mkproof})
+}
+
+// encodeProofNodes RLP encodes the given hex encoded merkle proof nodes.
+func encodeProofNodes(nodes []string) ([]byte, error) {
+	decoded := make([][]byte, 0, len(nodes))
+	for _, node := range nodes {
+		b, err := hexutil.Decode(node)
+		if err != nil {
+			return nil, err
+		}
+		decoded = append(decoded, b)
+	}
+
+	return rlp.EncodeToBytes(decoded)
+}
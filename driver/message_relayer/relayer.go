@@ -0,0 +1,230 @@
+package messageRelayer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+var (
+	// Time to wait before checking the newly verified L2 blocks again.
+	defaultPollInterval = 12 * time.Second
+)
+
+// Config contains the configurations to initialize a MessageRelayer.
+type Config struct {
+	PrivateKey             *ecdsa.PrivateKey
+	L1BridgeAddress        common.Address
+	L2BridgeAddress        common.Address
+	L2SignalServiceAddress common.Address
+	PollInterval           time.Duration
+}
+
+// MessageRelayer relays the L2-to-L1 bridge messages, it listens to the `Bridge.MessageSent` events emitted
+// in the verified L2 blocks, and then processes the messages on L1 with their merkle inclusion proofs.
+type MessageRelayer struct {
+	rpc      *rpc.Client
+	cfg      *Config
+	l1Bridge *bind.BoundContract
+
+	// The last L2 block whose messages have been relayed.
+	lastRelayedHeight uint64
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates a new MessageRelayer instance.
+func New(ctx context.Context, cli *rpc.Client, cfg *Config) (*MessageRelayer, error) {
+	if cfg.PrivateKey == nil {
+		return nil, fmt.Errorf("empty message relayer private key")
+	}
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+
+	stateVars, err := cli.GetProtocolStateVariables(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get protocol state variables: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	return &MessageRelayer{
+		rpc:               cli,
+		cfg:               cfg,
+		l1Bridge:          bind.NewBoundContract(cfg.L1BridgeAddress, BridgeABI, cli.L1, cli.L1, cli.L1),
+		lastRelayedHeight: stateVars.LastVerifiedBlockId,
+		ctx:               ctx,
+		cancel:            cancel,
+	}, nil
+}
+
+// Start starts the main loop of the message relayer.
+func (r *MessageRelayer) Start() {
+	r.wg.Add(1)
+	go r.loop()
+}
+
+// Close stops the message relayer.
+func (r *MessageRelayer) Close() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+// loop relays the messages of the newly verified L2 blocks periodically.
+func (r *MessageRelayer) loop() {
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer func() {
+		ticker.Stop()
+		r.wg.Done()
+	}()
+
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.relayVerifiedMessages(r.ctx); err != nil {
+				log.Error("Failed to relay L2-to-L1 messages", "error", err)
+			}
+		}
+	}
+}
+
+// relayVerifiedMessages relays all messages sent in the L2 blocks verified since the last run. Only
+// the verified blocks are used, since their hashes have been synced to L1 and the signal proofs can
+// be checked against them.
+func (r *MessageRelayer) relayVerifiedMessages(ctx context.Context) error {
+	stateVars, err := r.rpc.GetProtocolStateVariables(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return fmt.Errorf("failed to get protocol state variables: %w", err)
+	}
+
+	verifiedHeight := stateVars.LastVerifiedBlockId
+	if verifiedHeight <= r.lastRelayedHeight {
+		return nil
+	}
+
+	header, err := r.rpc.L2.HeaderByNumber(ctx, new(big.Int).SetUint64(verifiedHeight))
+	if err != nil {
+		return fmt.Errorf("failed to fetch verified L2 header: %w", err)
+	}
+
+	syncedHash, err := r.rpc.TaikoL1.GetXchainBlockHash(&bind.CallOpts{Context: ctx}, header.Number)
+	if err != nil {
+		return fmt.Errorf("failed to fetch synced L2 block hash: %w", err)
+	}
+
+	if syncedHash != header.Hash() {
+		return fmt.Errorf(
+			"L2 block hash mismatch, height: %d, local: %s, TaikoL1: %s",
+			verifiedHeight,
+			header.Hash(),
+			common.Hash(syncedHash),
+		)
+	}
+
+	logs, err := r.rpc.L2.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(r.lastRelayedHeight + 1),
+		ToBlock:   header.Number,
+		Addresses: []common.Address{r.cfg.L2BridgeAddress},
+		Topics:    [][]common.Hash{{BridgeABI.Events["MessageSent"].ID}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to filter MessageSent events: %w", err)
+	}
+
+	for _, l := range logs {
+		event, err := parseMessageSent(l)
+		if err != nil {
+			return err
+		}
+
+		if err := r.relayMessage(ctx, event, header); err != nil {
+			return fmt.Errorf("failed to relay message %s: %w", event.MsgHash, err)
+		}
+	}
+
+	r.lastRelayedHeight = verifiedHeight
+
+	return nil
+}
+
+// relayMessage processes the given message on L1, with a signal proof at the given verified L2 header.
+func (r *MessageRelayer) relayMessage(ctx context.Context, event *MessageSent, header *types.Header) error {
+	if event.Message.DestChainId.Cmp(r.rpc.L1ChainID) != 0 {
+		return nil
+	}
+
+	status, err := r.getMessageStatus(ctx, event.MsgHash)
+	if err != nil {
+		return err
+	}
+
+	if status != MessageStatusNew {
+		log.Debug("Skip processed message", "msgHash", event.MsgHash, "status", status)
+		return nil
+	}
+
+	accountProof, err := r.rpc.L2GethClient.GetProof(
+		ctx,
+		r.cfg.L2SignalServiceAddress,
+		[]string{signalSlot(r.cfg.L2BridgeAddress, event.MsgHash).Hex()},
+		header.Number,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get signal proof: %w", err)
+	}
+
+	proof, err := encodeSignalProof(header, accountProof)
+	if err != nil {
+		return err
+	}
+
+	opts, err := bind.NewKeyedTransactorWithChainID(r.cfg.PrivateKey, r.rpc.L1ChainID)
+	if err != nil {
+		return err
+	}
+	opts.Context = ctx
+
+	tx, err := r.l1Bridge.Transact(opts, "processMessage", event.Message, proof)
+	if err != nil {
+		return fmt.Errorf("failed to send processMessage transaction: %w", err)
+	}
+
+	if _, err := rpc.WaitReceipt(ctx, r.rpc.L1, tx); err != nil {
+		return err
+	}
+
+	log.Info(
+		"✉️ L2-to-L1 message relayed",
+		"msgHash", event.MsgHash,
+		"l2Height", event.Raw.BlockNumber,
+		"txHash", tx.Hash(),
+	)
+
+	return nil
+}
+
+// getMessageStatus fetches the status of the given message from the L1 bridge.
+func (r *MessageRelayer) getMessageStatus(ctx context.Context, msgHash common.Hash) (MessageStatus, error) {
+	var out []interface{}
+	if err := r.l1Bridge.Call(&bind.CallOpts{Context: ctx}, &out, "getMessageStatus", msgHash); err != nil {
+		return 0, fmt.Errorf("failed to get message status: %w", err)
+	}
+
+	return MessageStatus(*abi.ConvertType(out[0], new(uint8)).(*uint8)), nil
+}
@@ -0,0 +1,95 @@
+package messageRelayer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignalSlot(t *testing.T) {
+	app := common.BigToAddress(common.Big1)
+	signal := common.BigToHash(common.Big2)
+
+	require.Equal(
+		t,
+		crypto.Keccak256Hash(append(app.Bytes(), signal.Bytes()...)),
+		signalSlot(app, signal),
+	)
+	require.NotEqual(t, signalSlot(app, signal), signalSlot(common.BigToAddress(common.Big2), signal))
+}
+
+func TestParseMessageSent(t *testing.T) {
+	msg := Message{
+		Id:            common.Big1,
+		Sender:        common.BigToAddress(common.Big1),
+		SrcChainId:    big.NewInt(167),
+		DestChainId:   big.NewInt(31336),
+		Owner:         common.BigToAddress(common.Big2),
+		To:            common.BigToAddress(common.Big3),
+		RefundAddress: common.BigToAddress(common.Big2),
+		DepositValue:  big.NewInt(1000),
+		CallValue:     big.NewInt(1),
+		ProcessingFee: big.NewInt(10),
+		GasLimit:      big.NewInt(100000),
+		Data:          []byte{0x1},
+		Memo:          "withdrawal",
+	}
+	msgHash := common.BigToHash(common.Big3)
+
+	data, err := BridgeABI.Events["MessageSent"].Inputs.NonIndexed().Pack(msg)
+	require.Nil(t, err)
+
+	event, err := parseMessageSent(types.Log{
+		Topics: []common.Hash{BridgeABI.Events["MessageSent"].ID, msgHash},
+		Data:   data,
+	})
+	require.Nil(t, err)
+	require.Equal(t, msgHash, event.MsgHash)
+	require.Equal(t, msg, event.Message)
+
+	_, err = parseMessageSent(types.Log{Topics: []common.Hash{msgHash}, Data: data})
+	require.ErrorContains(t, err, "not a MessageSent event log")
+}
+
+func TestEncodeSignalProof(t *testing.T) {
+	header := &types.Header{
+		Number:     big.NewInt(1),
+		Difficulty: common.Big0,
+		BaseFee:    big.NewInt(1),
+		Extra:      []byte{},
+	}
+	accountNodes := []string{hexutil.Encode([]byte{0x1}), hexutil.Encode([]byte{0x2})}
+	storageNodes := []string{hexutil.Encode([]byte{0x3})}
+
+	_, err := encodeSignalProof(header, &gethclient.AccountResult{AccountProof: accountNodes})
+	require.ErrorContains(t, err, "invalid storage proofs length")
+
+	encoded, err := encodeSignalProof(header, &gethclient.AccountResult{
+		AccountProof: accountNodes,
+		StorageProof: []gethclient.StorageResult{{Proof: storageNodes}},
+	})
+	require.Nil(t, err)
+
+	unpacked, err := signalProofArgs.Unpack(encoded)
+	require.Nil(t, err)
+
+	var decoded struct{ SignalProof signalProof }
+	require.Nil(t, signalProofArgs.Copy(&decoded, unpacked))
+	require.Equal(t, header.Number, decoded.SignalProof.Header.Height)
+
+	proofs, err := storageProofArgs.Unpack(decoded.SignalProof.Proof)
+	require.Nil(t, err)
+
+	var accountProof, storageProof [][]byte
+	require.Nil(t, rlp.DecodeBytes(proofs[0].([]byte), &accountProof))
+	require.Nil(t, rlp.DecodeBytes(proofs[1].([]byte), &storageProof))
+	require.Equal(t, [][]byte{{0x1}, {0x2}}, accountProof)
+	require.Equal(t, [][]byte{{0x3}}, storageProof)
+}
@@ -0,0 +1,89 @@
+package driver
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+var protocolStatusReportInterval = 30 * time.Second
+
+// ProtocolStatus contains the TaikoL1 protocol's current status.
+type ProtocolStatus struct {
+	LastVerifiedBlockID uint64 `json:"lastVerifiedBlockId"`
+	PendingBlocks       uint64 `json:"pendingBlocks"`
+	// Only available when the protocol's maxNumBlocks config is known.
+	MaxNumBlocks   *uint64 `json:"maxNumBlocks,omitempty"`
+	AvailableSlots *uint64 `json:"availableSlots,omitempty"`
+}
+
+// newProtocolStatus computes the protocol status from the given state variables, maxNumBlocks is
+// zero if unknown. Both counters are clamped to zero, in case the state variables are briefly
+// inconsistent with the cached maxNumBlocks, e.g. right after a protocol upgrade.
+func newProtocolStatus(vars *bindings.TaikoDataStateVariables, maxNumBlocks uint64) *ProtocolStatus {
+	status := &ProtocolStatus{LastVerifiedBlockID: vars.LastVerifiedBlockId}
+
+	// Block IDs in [LastVerifiedBlockId + 1, NumBlocks) are pending.
+	if vars.NumBlocks > vars.LastVerifiedBlockId+1 {
+		status.PendingBlocks = vars.NumBlocks - vars.LastVerifiedBlockId - 1
+	}
+
+	if maxNumBlocks != 0 {
+		var availableSlots uint64
+		if vars.LastVerifiedBlockId+maxNumBlocks > vars.NumBlocks {
+			availableSlots = vars.LastVerifiedBlockId + maxNumBlocks - vars.NumBlocks
+		}
+		status.MaxNumBlocks = &maxNumBlocks
+		status.AvailableSlots = &availableSlots
+	}
+
+	return status
+}
+
+// ProtocolStatus returns the latest collected protocol status, nil if not collected yet.
+func (d *Driver) ProtocolStatus() *ProtocolStatus {
+	status, ok := d.protocolStatus.Load().(*ProtocolStatus)
+	if !ok {
+		return nil
+	}
+
+	return status
+}
+
+// reportProtocolStatus collects and reports the protocol status intervally.
+func (d *Driver) reportProtocolStatus() {
+	ticker := time.NewTicker(protocolStatusReportInterval)
+	defer func() {
+		ticker.Stop()
+		d.wg.Done()
+	}()
+
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-ticker.C:
+			vars, err := d.rpc.GetProtocolStateVariables(&bind.CallOpts{Context: d.ctx})
+			if err != nil {
+				log.Error("Failed to get protocol state variables", "error", err)
+				continue
+			}
+
+			status := newProtocolStatus(vars, d.protocolConfigs.Config().MaxNumProposedBlocks)
+			d.protocolStatus.Store(status)
+
+			logCtx := []interface{}{
+				"lastVerifiedBlockId", status.LastVerifiedBlockID,
+				"pendingBlocks", status.PendingBlocks,
+			}
+			if status.AvailableSlots != nil {
+				logCtx = append(logCtx, "availableSlots", *status.AvailableSlots)
+			}
+			logCtx = append(logCtx, "syncMode", d.l2ChainSyncer.SyncMode(), "syncPhase", d.l2ChainSyncer.Phase())
+
+			log.Info("📖 Protocol status", logCtx...)
+		}
+	}
+}
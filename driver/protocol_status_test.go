@@ -0,0 +1,37 @@
+package driver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+func TestNewProtocolStatus(t *testing.T) {
+	status := newProtocolStatus(&bindings.TaikoDataStateVariables{NumBlocks: 10, LastVerifiedBlockId: 4}, 8)
+	require.Equal(t, uint64(4), status.LastVerifiedBlockID)
+	require.Equal(t, uint64(5), status.PendingBlocks)
+	require.Equal(t, uint64(8), *status.MaxNumBlocks)
+	require.Equal(t, uint64(2), *status.AvailableSlots)
+
+	// Unknown maxNumBlocks.
+	status = newProtocolStatus(&bindings.TaikoDataStateVariables{NumBlocks: 10, LastVerifiedBlockId: 4}, 0)
+	require.Equal(t, uint64(5), status.PendingBlocks)
+	require.Nil(t, status.MaxNumBlocks)
+	require.Nil(t, status.AvailableSlots)
+
+	// More pending blocks than the cached maxNumBlocks allows, e.g. right after a protocol upgrade.
+	status = newProtocolStatus(&bindings.TaikoDataStateVariables{NumBlocks: 20, LastVerifiedBlockId: 4}, 8)
+	require.Equal(t, uint64(15), status.PendingBlocks)
+	require.Equal(t, uint64(0), *status.AvailableSlots)
+
+	// No block proposed after genesis yet.
+	status = newProtocolStatus(&bindings.TaikoDataStateVariables{NumBlocks: 1, LastVerifiedBlockId: 0}, 8)
+	require.Equal(t, uint64(0), status.PendingBlocks)
+	require.Equal(t, uint64(7), *status.AvailableSlots)
+
+	// NumBlocks briefly behind, e.g. the state variables are read from a lagging node.
+	status = newProtocolStatus(&bindings.TaikoDataStateVariables{NumBlocks: 4, LastVerifiedBlockId: 4}, 8)
+	require.Equal(t, uint64(0), status.PendingBlocks)
+	require.Equal(t, uint64(8), *status.AvailableSlots)
+}
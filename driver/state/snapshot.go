@@ -0,0 +1,159 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// MaxSnapshotL1Lag is the maximum number of L1 blocks a snapshot's L1 sync cursor can fall behind
+// the L1 head, older snapshots will be ignored.
+const MaxSnapshotL1Lag = 128
+
+// Snapshot contains the driver states which are persisted between restarts, so that the driver can
+// resume syncing from the last processed L1 block.
+type Snapshot struct {
+	L1Current            *types.Header `json:"l1Current"`
+	LatestVerifiedID     *big.Int      `json:"latestVerifiedId"`
+	LatestVerifiedHeight *big.Int      `json:"latestVerifiedHeight"`
+	LatestVerifiedHash   common.Hash   `json:"latestVerifiedHash"`
+	GenesisHeight        *big.Int      `json:"genesisHeight"`
+}
+
+// Snapshot returns a snapshot of the current states.
+func (s *State) Snapshot() *Snapshot {
+	verified := s.GetLatestVerifiedBlock()
+
+	return &Snapshot{
+		L1Current:            s.GetL1Current(),
+		LatestVerifiedID:     verified.ID,
+		LatestVerifiedHeight: verified.Height,
+		LatestVerifiedHash:   verified.Hash,
+		GenesisHeight:        s.GenesisL1Height,
+	}
+}
+
+// SaveSnapshot writes a snapshot of the current states to the given path, the file is replaced atomically.
+func (s *State) SaveSnapshot(path string) error {
+	snapshotBytes, err := json.Marshal(s.Snapshot())
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary snapshot file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(snapshotBytes); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadSnapshot loads the snapshot from the given path, and if it's still valid, sets the L1 sync cursor
+// to the snapshot's one, so that the driver can skip re-scanning the L1 blocks it has already processed.
+// A missing snapshot file is not an error.
+func (s *State) LoadSnapshot(path string) error {
+	snapshotBytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		log.Info("No driver state snapshot found", "path", path)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(snapshotBytes, &snapshot); err != nil {
+		return fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+
+	if err := s.checkSnapshot(context.Background(), &snapshot); err != nil {
+		return fmt.Errorf("invalid snapshot: %w", err)
+	}
+
+	if snapshot.L1Current.Number.Cmp(s.GetL1Current().Number) <= 0 {
+		log.Info(
+			"Snapshot L1 current cursor is not ahead of the current one",
+			"snapshot", snapshot.L1Current.Number,
+			"current", s.GetL1Current().Number,
+		)
+		return nil
+	}
+
+	log.Info("Restore L1 current cursor from snapshot", "height", snapshot.L1Current.Number, "path", path)
+	s.SetL1Current(snapshot.L1Current)
+
+	return nil
+}
+
+// checkSnapshot checks whether the given snapshot belongs to the current chains, and is recent enough.
+func (s *State) checkSnapshot(ctx context.Context, snapshot *Snapshot) error {
+	if snapshot.L1Current == nil ||
+		snapshot.LatestVerifiedID == nil ||
+		snapshot.LatestVerifiedHeight == nil ||
+		snapshot.GenesisHeight == nil {
+		return fmt.Errorf("missing fields")
+	}
+
+	if snapshot.GenesisHeight.Cmp(s.GenesisL1Height) != 0 {
+		return fmt.Errorf("genesis height mismatch, snapshot %s, current %s", snapshot.GenesisHeight, s.GenesisL1Height)
+	}
+
+	if lag := new(big.Int).Sub(s.GetL1Head().Number, snapshot.L1Current.Number); lag.Cmp(
+		big.NewInt(MaxSnapshotL1Lag),
+	) > 0 {
+		return fmt.Errorf("L1 current cursor %d blocks behind the L1 head", lag)
+	}
+
+	l1Current, err := s.rpc.L1.HeaderByNumber(ctx, snapshot.L1Current.Number)
+	if err != nil {
+		return err
+	}
+	if l1Current.Hash() != snapshot.L1Current.Hash() {
+		return fmt.Errorf("L1 current cursor %s has been reorged", snapshot.L1Current.Number)
+	}
+
+	if snapshot.LatestVerifiedID.Cmp(s.GetLatestVerifiedBlock().ID) > 0 {
+		return fmt.Errorf(
+			"latest verified block ID %s ahead of the protocol's %s",
+			snapshot.LatestVerifiedID,
+			s.GetLatestVerifiedBlock().ID,
+		)
+	}
+
+	verifiedHash, err := s.rpc.TaikoL1.GetXchainBlockHash(nil, snapshot.LatestVerifiedID)
+	if err != nil {
+		return err
+	}
+	if verifiedHash != snapshot.LatestVerifiedHash {
+		return fmt.Errorf("latest verified block %s hash mismatch", snapshot.LatestVerifiedID)
+	}
+
+	// The blocks proposed before the snapshot's L1 current cursor will never be inserted again,
+	// make sure the L2 execution engine has at least the verified ones.
+	if s.GetL2Head().Number.Cmp(snapshot.LatestVerifiedHeight) < 0 {
+		return fmt.Errorf(
+			"L2 execution engine head %s behind the latest verified height %s",
+			s.GetL2Head().Number,
+			snapshot.LatestVerifiedHeight,
+		)
+	}
+
+	return nil
+}
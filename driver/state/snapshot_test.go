@@ -0,0 +1,76 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveSnapshot(t *testing.T) {
+	s := &State{l1Current: new(atomic.Value), l2VerifiedHead: new(atomic.Value), GenesisL1Height: common.Big1}
+	s.SetL1Current(&types.Header{Number: big.NewInt(100), Difficulty: common.Big0})
+	s.l2VerifiedHead.Store(&VerifiedHeaderInfo{ID: common.Big2, Height: common.Big3, Hash: common.HexToHash("0x1")})
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.Nil(t, s.SaveSnapshot(path))
+
+	snapshotBytes, err := os.ReadFile(path)
+	require.Nil(t, err)
+
+	var snapshot Snapshot
+	require.Nil(t, json.Unmarshal(snapshotBytes, &snapshot))
+	require.Equal(t, s.GetL1Current().Hash(), snapshot.L1Current.Hash())
+	require.Equal(t, common.Big2, snapshot.LatestVerifiedID)
+	require.Equal(t, common.Big3, snapshot.LatestVerifiedHeight)
+	require.Equal(t, common.HexToHash("0x1"), snapshot.LatestVerifiedHash)
+	require.Equal(t, common.Big1, snapshot.GenesisHeight)
+
+	// Overwrite the existing snapshot.
+	s.SetL1Current(&types.Header{Number: big.NewInt(101), Difficulty: common.Big0})
+	require.Nil(t, s.SaveSnapshot(path))
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.Nil(t, err)
+	require.Equal(t, 1, len(entries))
+}
+
+func (s *DriverStateTestSuite) TestLoadSnapshot() {
+	path := filepath.Join(s.T().TempDir(), "snapshot.json")
+
+	// Missing snapshot file.
+	s.Nil(s.s.LoadSnapshot(path))
+
+	// Valid snapshot.
+	l1Current := s.s.GetL1Current()
+	s.Nil(s.s.SaveSnapshot(path))
+	s.Nil(s.s.LoadSnapshot(path))
+	s.Equal(l1Current.Hash(), s.s.GetL1Current().Hash())
+
+	// Genesis height mismatch.
+	snapshot := s.s.Snapshot()
+	snapshot.GenesisHeight = new(big.Int).Add(s.s.GenesisL1Height, common.Big1)
+	s.ErrorContains(s.s.checkSnapshot(context.Background(), snapshot), "genesis height mismatch")
+
+	// Stale L1 current cursor.
+	if s.s.GetL1Head().Number.Uint64() > MaxSnapshotL1Lag {
+		snapshot = s.s.Snapshot()
+		snapshot.L1Current = &types.Header{
+			Number:     new(big.Int).Sub(s.s.GetL1Head().Number, big.NewInt(MaxSnapshotL1Lag+1)),
+			Difficulty: common.Big0,
+		}
+		s.ErrorContains(s.s.checkSnapshot(context.Background(), snapshot), "behind the L1 head")
+	}
+
+	// Reorged L1 current cursor.
+	snapshot = s.s.Snapshot()
+	snapshot.L1Current = &types.Header{Number: s.s.GetL1Head().Number, Difficulty: common.Big0}
+	s.ErrorContains(s.s.checkSnapshot(context.Background(), snapshot), "reorged")
+}
@@ -3,10 +3,13 @@ package state
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"sync/atomic"
+	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -17,6 +20,16 @@ import (
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
 )
 
+var (
+	errL2HeadNotReached = errors.New("L2 head has not reached the given block number")
+)
+
+// Backoff intervals of WaitForL2BlockNumber.
+const (
+	waitL2HeadInitialInterval = 50 * time.Millisecond
+	waitL2HeadMaxInterval     = 2 * time.Second
+)
+
 // HeightOrID contains a block height or a block ID.
 type HeightOrID struct {
 	Height *big.Int
@@ -239,6 +252,22 @@ func (s *State) GetL2Head() *types.Header {
 	return s.l2Head.Load().(*types.Header)
 }
 
+// WaitForL2BlockNumber blocks until the L2 head's number reaches the given block number, polling the L2 head
+// with an exponential backoff, returns the context's error if the context is cancelled first.
+func (s *State) WaitForL2BlockNumber(ctx context.Context, n uint64) error {
+	exponentialBackoff := backoff.NewExponentialBackOff()
+	exponentialBackoff.InitialInterval = waitL2HeadInitialInterval
+	exponentialBackoff.MaxInterval = waitL2HeadMaxInterval
+	exponentialBackoff.MaxElapsedTime = 0 // Only stopped by the context
+
+	return backoff.Retry(func() error {
+		if s.GetL2Head().Number.Uint64() < n {
+			return errL2HeadNotReached
+		}
+		return nil
+	}, backoff.WithContext(exponentialBackoff, ctx))
+}
+
 // VerifiedHeaderInfo contains information about a verified L2 block header.
 type VerifiedHeaderInfo struct {
 	ID     *big.Int
@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -64,6 +65,32 @@ func (s *DriverStateTestSuite) TestGetL2Head() {
 	s.Equal(testHeight, h.Number.Uint64())
 }
 
+func (s *DriverStateTestSuite) TestWaitForL2BlockNumber() {
+	head := s.s.GetL2Head()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Already reached.
+	s.Nil(s.s.WaitForL2BlockNumber(ctx, head.Number.Uint64()))
+
+	// Reached later.
+	next := new(big.Int).Add(head.Number, common.Big1)
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		s.s.setL2Head(&types.Header{Number: next})
+	}()
+	s.Nil(s.s.WaitForL2BlockNumber(ctx, next.Uint64()))
+	s.s.setL2Head(head)
+}
+
+func (s *DriverStateTestSuite) TestWaitForL2BlockNumberCancelled() {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	err := s.s.WaitForL2BlockNumber(ctx, s.s.GetL2Head().Number.Uint64()+1)
+	s.ErrorIs(err, context.DeadlineExceeded)
+}
+
 func (s *DriverStateTestSuite) TestSubL1HeadsFeed() {
 	s.NotNil(s.s.SubL1HeadsFeed(make(chan *types.Header)))
 }
@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// BucketHistogram is a histogram with fixed bucket upper bounds. The go-ethereum prometheus exporter only
+// exports the histograms as summaries, so besides the quantiles of the underlying histogram, each bucket is
+// also exported as a cumulative counter named `<name>/bucket/le<bound>`, plus `<name>/bucket/inf`.
+type BucketHistogram struct {
+	metrics.Histogram
+	bounds  []int64
+	buckets []metrics.Counter // One more than the bounds, the last one is the +Inf bucket
+}
+
+// NewRegisteredBucketHistogram creates and registers a new BucketHistogram with the given ascending bucket
+// upper bounds.
+func NewRegisteredBucketHistogram(name string, bounds []int64) *BucketHistogram {
+	buckets := make([]metrics.Counter, len(bounds)+1)
+	for i, bound := range bounds {
+		buckets[i] = metrics.NewRegisteredCounter(fmt.Sprintf("%s/bucket/le%d", name, bound), nil)
+	}
+	buckets[len(bounds)] = metrics.NewRegisteredCounter(name+"/bucket/inf", nil)
+
+	return &BucketHistogram{
+		Histogram: metrics.NewRegisteredHistogram(name, nil, metrics.NewExpDecaySample(1028, 0.015)),
+		bounds:    bounds,
+		buckets:   buckets,
+	}
+}
+
+// Update records the given value.
+func (h *BucketHistogram) Update(v int64) {
+	h.Histogram.Update(v)
+	for i, bound := range h.bounds {
+		if v <= bound {
+			h.buckets[i].Inc(1)
+		}
+	}
+	h.buckets[len(h.bounds)].Inc(1)
+}
@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketHistogram(t *testing.T) {
+	// The metrics are no-ops unless enabled before being created.
+	metrics.Enabled = true
+	defer func() { metrics.Enabled = false }()
+
+	h := NewRegisteredBucketHistogram("test/bucketHistogram", []int64{128, 1024})
+
+	for _, v := range []int64{100, 128, 500, 2048} {
+		h.Update(v)
+	}
+
+	require.Equal(t, int64(4), h.Count())
+	for name, count := range map[string]int64{
+		"test/bucketHistogram/bucket/le128":  2,
+		"test/bucketHistogram/bucket/le1024": 3,
+		"test/bucketHistogram/bucket/inf":    4,
+	} {
+		require.Equal(t, count, metrics.Get(name).(metrics.Counter).Count(), name)
+	}
+}
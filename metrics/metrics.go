@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"net/http"
 	"strconv"
@@ -16,16 +17,41 @@ import (
 // Metrics
 var (
 	// Driver
-	DriverL1HeadHeightGauge     = metrics.NewRegisteredGauge("driver/l1Head/height", nil)
-	DriverL2HeadHeightGauge     = metrics.NewRegisteredGauge("driver/l2Head/height", nil)
-	DriverL1CurrentHeightGauge  = metrics.NewRegisteredGauge("driver/l1Current/height", nil)
-	DriverL2HeadIDGauge         = metrics.NewRegisteredGauge("driver/l2Head/id", nil)
-	DriverL2VerifiedHeightGauge = metrics.NewRegisteredGauge("driver/l2Verified/id", nil)
+	DriverL1HeadHeightGauge         = metrics.NewRegisteredGauge("driver/l1Head/height", nil)
+	DriverL2HeadHeightGauge         = metrics.NewRegisteredGauge("driver/l2Head/height", nil)
+	DriverL1CurrentHeightGauge      = metrics.NewRegisteredGauge("driver/l1Current/height", nil)
+	DriverL1FinalizedHeightGauge    = metrics.NewRegisteredGauge("driver/l1Finalized/height", nil)
+	DriverL2HeadIDGauge             = metrics.NewRegisteredGauge("driver/l2Head/id", nil)
+	DriverL2VerifiedHeightGauge     = metrics.NewRegisteredGauge("driver/l2Verified/id", nil)
+	DriverSyncGapGauge              = metrics.NewRegisteredGauge("driver/sync/gap", nil)
+	DriverAnchorL1ReorgedCounter    = metrics.NewRegisteredCounter("driver/anchor/l1/reorged", nil)
+	DriverDeferredHeadUpdateCounter = metrics.NewRegisteredCounter("driver/head/update/deferred", nil)
+	DriverInvalidTimestampCounter   = metrics.NewRegisteredCounter("driver/timestamp/invalid", nil)
+	DriverDuplicateProposalCounter  = metrics.NewRegisteredCounter("driver/proposal/duplicate", nil)
+	DriverProposalRederivedCounter  = metrics.NewRegisteredCounter("driver/proposal/rederived", nil)
+	DriverSlowBlockCounter          = metrics.NewRegisteredCounter("driver/block/slow", nil)
+	// Total derivation durations in milliseconds of the blocks exceeding --driver.slowBlockThreshold.
+	DriverSlowBlockDurationHistogram = NewRegisteredBucketHistogram(
+		"driver/block/slow/duration/ms",
+		[]int64{30000, 60000, 120000, 300000, 600000, 1800000},
+	)
+
+	// RPC
+	RPCL1ArchiveRequestsCounter = metrics.NewRegisteredCounter("rpc/l1/archive/requests", nil)
+	RPCL1ArchiveFallbackCounter = metrics.NewRegisteredCounter("rpc/l1/archive/fallback", nil)
+	RPCL1PruningHorizonGauge    = metrics.NewRegisteredGauge("rpc/l1/pruningHorizon", nil)
 
 	// Proposer
-	ProposerProposeEpochCounter    = metrics.NewRegisteredCounter("proposer/epoch", nil)
-	ProposerProposedTxListsCounter = metrics.NewRegisteredCounter("proposer/proposed/txLists", nil)
-	ProposerProposedTxsCounter     = metrics.NewRegisteredCounter("proposer/proposed/txs", nil)
+	ProposerProposeEpochCounter      = metrics.NewRegisteredCounter("proposer/epoch", nil)
+	ProposerProposedTxListsCounter   = metrics.NewRegisteredCounter("proposer/proposed/txLists", nil)
+	ProposerProposedTxsCounter       = metrics.NewRegisteredCounter("proposer/proposed/txs", nil)
+	ProposerSkippedPausedCounter     = metrics.NewRegisteredCounter("proposer/skipped/paused", nil)
+	ProposerSkippedNotAllowedCounter = metrics.NewRegisteredCounter("proposer/skipped/notAllowed", nil)
+	ProposerBuilderTxListsCounter    = metrics.NewRegisteredCounter("proposer/proposed/txLists/builder", nil)
+	ProposerLocalTxListsCounter      = metrics.NewRegisteredCounter("proposer/proposed/txLists/local", nil)
+	ProposerBuilderFallbackCounter   = metrics.NewRegisteredCounter("proposer/builder/fallback", nil)
+	ProposerBuilderLatencyTimer      = metrics.NewRegisteredTimer("proposer/builder/latency", nil)
+	ProposerProposingIntervalGauge   = metrics.NewRegisteredGauge("proposer/proposingInterval/ms", nil)
 
 	// Prover
 	ProverLatestVerifiedIDGauge       = metrics.NewRegisteredGauge("prover/latestVerified/id", nil)
@@ -40,8 +66,139 @@ var (
 	ProverSentValidProofCounter       = metrics.NewRegisteredCounter("prover/proof/valid/sent", nil)
 	ProverSentInvalidProofCounter     = metrics.NewRegisteredCounter("prover/proof/invalid/sent", nil)
 	ProverReceivedProposedBlockGauge  = metrics.NewRegisteredGauge("prover/proposed/received", nil)
+	ProverValidProofChDepthGauge      = metrics.NewRegisteredGauge("prover/proof/valid/ch/depth", nil)
+	ProverInvalidProofChDepthGauge    = metrics.NewRegisteredGauge("prover/proof/invalid/ch/depth", nil)
+	ProverProofChFullCounter          = metrics.NewRegisteredCounter("prover/proof/ch/full", nil)
+	ProverBlockProposedChDepthGauge   = metrics.NewRegisteredGauge("prover/event/blockProposed/ch/depth", nil)
+	ProverBlockVerifiedChDepthGauge   = metrics.NewRegisteredGauge("prover/event/blockVerified/ch/depth", nil)
+	ProverBlockProvenChDepthGauge     = metrics.NewRegisteredGauge("prover/event/blockProven/ch/depth", nil)
+	ProverBlockProposedDroppedCounter = metrics.NewRegisteredCounter("prover/event/blockProposed/dropped", nil)
+	ProverBlockVerifiedDroppedCounter = metrics.NewRegisteredCounter("prover/event/blockVerified/dropped", nil)
+	// Latencies of each proving stage: BlockProposed event observed -> proof request dispatched,
+	// proof request dispatched -> proof generated, proof generated -> proof submission transaction mined.
+	ProverValidProofDispatchTimer          = metrics.NewRegisteredTimer("prover/proof/valid/dispatch", nil)
+	ProverInvalidProofDispatchTimer        = metrics.NewRegisteredTimer("prover/proof/invalid/dispatch", nil)
+	ProverValidProofGenerationTimer        = metrics.NewRegisteredTimer("prover/proof/valid/generation", nil)
+	ProverInvalidProofGenerationTimer      = metrics.NewRegisteredTimer("prover/proof/invalid/generation", nil)
+	ProverValidProofSubmissionTimer        = metrics.NewRegisteredTimer("prover/proof/valid/submission", nil)
+	ProverInvalidProofSubmissionTimer      = metrics.NewRegisteredTimer("prover/proof/invalid/submission", nil)
+	ProverDryRunProofsCounter              = metrics.NewRegisteredCounter("prover/dry_run/proofs", nil)
+	ProverDryRunRevertedProofsCounter      = metrics.NewRegisteredCounter("prover/dry_run/proofs/reverted", nil)
+	ProverBackendQueueDepthGauge           = metrics.NewRegisteredGauge("prover/backend/queueDepth", nil)
+	ProverBackendSaturatedCounter          = metrics.NewRegisteredCounter("prover/backend/saturated", nil)
+	ProverProofProducerFallbackCounter     = metrics.NewRegisteredCounter("prover/proofProducer/fallback", nil)
+	ProverPriorityQueueDepthGauge          = metrics.NewRegisteredGauge("prover/priorityQueue/depth", nil)
+	ProverPriorityQueueFullCounter         = metrics.NewRegisteredCounter("prover/priorityQueue/full", nil)
+	ProverOldestUnprovenBlockAgeGauge      = metrics.NewRegisteredGauge("prover/oldestUnprovenBlock/age", nil)
+	ProverOldestUnprovenBlockIDGauge       = metrics.NewRegisteredGauge("prover/oldestUnprovenBlock/id", nil)
+	ProverBlockFeedHitCounter              = metrics.NewRegisteredCounter("prover/blockFeed/hit", nil)
+	ProverBlockFeedMismatchCounter         = metrics.NewRegisteredCounter("prover/blockFeed/mismatch", nil)
+	ProverCompetingProofDetectedCounter    = metrics.NewRegisteredCounter("prover/proof/competing/detected", nil)
+	ProverCompetingProofGasSavedCounter    = metrics.NewRegisteredCounter("prover/proof/competing/gasSaved", nil)
+	ProverSubmissionWonCounter             = metrics.NewRegisteredCounter("prover/submission/won", nil)
+	ProverSubmissionLostCounter            = metrics.NewRegisteredCounter("prover/submission/lost", nil)
+	ProverSubmissionWonPriorityFeeGauge    = metrics.NewRegisteredGauge("prover/submission/won/priorityFee", nil)
+	ProverSafeTxProposedCounter            = metrics.NewRegisteredCounter("prover/safe/tx/proposed", nil)
+	ProverDuplicateBlockSkippedCounter     = metrics.NewRegisteredCounter("prover/proposed/duplicate/skipped", nil)
+	ProverSuccessfulProofTxCounter         = metrics.NewRegisteredCounter("prover/proof/tx/successful", nil)
+	ProverRevertedProofTxCounter           = metrics.NewRegisteredCounter("prover/proof/tx/reverted", nil)
+	ProverRevertedProofTxGasUsedCounter    = metrics.NewRegisteredCounter("prover/proof/tx/reverted/gasUsed", nil)
+	ProverStaleBlockSkippedCounter         = metrics.NewRegisteredCounter("prover/proposed/stale/skipped", nil)
+	ProverOutOfWindowBlockSkippedCounter   = metrics.NewRegisteredCounter("prover/proposed/outOfWindow/skipped", nil)
+	ProverLowRewardBlockSkippedCounter     = metrics.NewRegisteredCounter("prover/proposed/lowReward/skipped", nil)
+	ProverUnprovableBlockCounter           = metrics.NewRegisteredCounter("prover/proposed/unprovable", nil)
+	ProverUnprovableBlockRetriedCounter    = metrics.NewRegisteredCounter("prover/proposed/unprovable/retried", nil)
+	ProverRequestProofTransientErrCounter  = metrics.NewRegisteredCounter("prover/proof/request/error/transient", nil)
+	ProverRequestProofPermanentErrCounter  = metrics.NewRegisteredCounter("prover/proof/request/error/permanent", nil)
+	ProverRequestProofExhaustedCounter     = metrics.NewRegisteredCounter("prover/proof/request/exhausted", nil)
+	ProverLeasedBlockDeferredCounter       = metrics.NewRegisteredCounter("prover/proposed/leased/deferred", nil)
+	ProverLeaseTakeoverCounter             = metrics.NewRegisteredCounter("prover/lease/takeover", nil)
+	ProverLeaseErrorCounter                = metrics.NewRegisteredCounter("prover/lease/error", nil)
+	ProverL2DivergedHeightGauge            = metrics.NewRegisteredGauge("prover/l2/diverged/height", nil)
+	ProverProofsVerifiedCounter            = metrics.NewRegisteredCounter("prover/proofs/verified", nil)
+	ProverProofsWastedCounter              = metrics.NewRegisteredCounter("prover/proofs/wasted", nil)
+	ProverReplicatedProofCounter           = metrics.NewRegisteredCounter("prover/proof/replicated", nil)
+	ProverReplicatedProofFailedCounter     = metrics.NewRegisteredCounter("prover/proof/replicated/failed", nil)
+	ProverReconciledProvenBlocksGauge      = metrics.NewRegisteredGauge("prover/reconciled/proven", nil)
+	ProverReconciledOverwrittenGauge       = metrics.NewRegisteredGauge("prover/reconciled/overwritten", nil)
+	ProverSubmissionQueueValidDepthGauge   = metrics.NewRegisteredGauge("prover/submissionQueue/valid/depth", nil)
+	ProverSubmissionQueueInvalidDepthGauge = metrics.NewRegisteredGauge("prover/submissionQueue/invalid/depth", nil)
+	ProverSubmissionQueueEscalatedCounter  = metrics.NewRegisteredCounter("prover/submissionQueue/escalated", nil)
+	ProverSubmissionQueueWaitTimer         = metrics.NewRegisteredTimer("prover/submissionQueue/wait", nil)
+	ProverRpcdTierFallbackCounter          = metrics.NewRegisteredCounter("prover/rpcd/tier/fallback", nil)
+	ProverRpcdProofStalledCounter          = metrics.NewRegisteredCounter("prover/rpcd/proof/stalled", nil)
+	ProverPausedGauge                      = metrics.NewRegisteredGauge("prover/paused", nil)
+	ProverPreparedWitnessesGauge           = metrics.NewRegisteredGauge("prover/witness/prepared", nil)
+	ProverRpcdRequestsCounter              = metrics.NewRegisteredCounter("prover/rpcd/requests", nil)
+	ProverRpcdProofSuccessCounter          = metrics.NewRegisteredCounter("prover/rpcd/proofs/success", nil)
+	ProverRpcdOutstandingJobsGauge         = metrics.NewRegisteredGauge("prover/rpcd/jobs/outstanding", nil)
+	ProverRpcdQueueDepthGauge              = metrics.NewRegisteredGauge("prover/rpcd/queue/depth", nil)
+	ProverRpcdQueueFullCounter             = metrics.NewRegisteredCounter("prover/rpcd/queue/full", nil)
+	ProverRpcdQueuedJobFailedCounter       = metrics.NewRegisteredCounter("prover/rpcd/queue/failed", nil)
+	ProverInvalidProofCounter              = metrics.NewRegisteredCounter("prover/proof/invalid", nil)
+	ProverInvalidProofFallbackCounter      = metrics.NewRegisteredCounter("prover/proof/invalid/fallback", nil)
+	ProverInvalidProofExhaustedCounter     = metrics.NewRegisteredCounter("prover/proof/invalid/fallback/exhausted", nil)
+	ProverProofArchiveWrittenCounter       = metrics.NewRegisteredCounter("prover/proof/archive/written", nil)
+	ProverProofArchiveDroppedCounter       = metrics.NewRegisteredCounter("prover/proof/archive/dropped", nil)
+	ProverProofArchivePrunedCounter        = metrics.NewRegisteredCounter("prover/proof/archive/pruned", nil)
+	// Byte sizes of the submitted zkSNARK proofs, which affect the L1 gas costs.
+	ProverProofSizeHistogram = NewRegisteredBucketHistogram(
+		"prover/proof/size/bytes",
+		[]int64{128, 256, 512, 1024, 4096, 16384},
+	)
+	// End-to-end latencies in seconds of the proofs generated by ZKEVM RPCD, from the request to the result.
+	ProverRpcdProofLatencyHistogram = NewRegisteredBucketHistogram(
+		"prover/rpcd/proof/latency/seconds",
+		[]int64{60, 120, 300, 600, 1200, 1800, 3600},
+	)
 )
 
+// ProverRpcdEndpointUpGauge returns the gauge of whether the i-th ZKEVM RPCD endpoint is up, since the
+// go-ethereum metrics have no labels.
+func ProverRpcdEndpointUpGauge(i int) metrics.Gauge {
+	return metrics.GetOrRegisterGauge(fmt.Sprintf("prover/rpcd/endpoint/%d/up", i), nil)
+}
+
+// ProverRpcdRequestErrorCounter returns the counter of the failed requests sent to ZKEVM RPCD, whose
+// errors are of the given category.
+func ProverRpcdRequestErrorCounter(category string) metrics.Counter {
+	return metrics.GetOrRegisterCounter(fmt.Sprintf("prover/rpcd/requests/errors/%s", category), nil)
+}
+
+// ProverRpcdProofFailureCounter returns the counter of the failed ZKEVM RPCD proof jobs, whose errors are
+// of the given category.
+func ProverRpcdProofFailureCounter(category string) metrics.Counter {
+	return metrics.GetOrRegisterCounter(fmt.Sprintf("prover/rpcd/proofs/failed/%s", category), nil)
+}
+
+// ProverRpcdTierRequestCounter returns the counter of the proof requests sent to ZKEVM RPCD with the circuit
+// parameter tier of the given name.
+func ProverRpcdTierRequestCounter(tier string) metrics.Counter {
+	return metrics.GetOrRegisterCounter(fmt.Sprintf("prover/rpcd/tier/%s/requests", tier), nil)
+}
+
+// ProverSubmissionSenderCounter returns the counter of the proof submission transactions paid by the
+// accounts of the given role, the prover's own key or a separate submission key.
+func ProverSubmissionSenderCounter(role string) metrics.Counter {
+	return metrics.GetOrRegisterCounter(fmt.Sprintf("prover/submission/sender/%s", role), nil)
+}
+
+// ProverProofProgressGauge returns the gauge of the proof generation progress percentage of the given block.
+func ProverProofProgressGauge(blockID uint64) metrics.GaugeFloat64 {
+	return metrics.GetOrRegisterGaugeFloat64(proverProofProgressGaugeName(blockID), nil)
+}
+
+// UnregisterProverProofProgressGauge removes the proof generation progress gauge of the given block, once
+// its proof job is done.
+func UnregisterProverProofProgressGauge(blockID uint64) {
+	metrics.DefaultRegistry.Unregister(proverProofProgressGaugeName(blockID))
+}
+
+// proverProofProgressGaugeName returns the name of the proof generation progress gauge of the given block.
+func proverProofProgressGaugeName(blockID uint64) string {
+	return fmt.Sprintf("prover/proof/progress/%d", blockID)
+}
+
 // Serve starts the metrics server on the given address, will be closed when the given
 // context is cancelled.
 func Serve(ctx context.Context, c *cli.Context) error {
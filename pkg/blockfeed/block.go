@@ -0,0 +1,56 @@
+package blockfeed
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+// Block is a L2 block freshly derived by the driver, which is sent through the block feed.
+type Block struct {
+	BlockID  *big.Int        `json:"blockID"`
+	Header   *types.Header   `json:"header"`
+	L1Origin *rawdb.L1Origin `json:"l1Origin"`
+}
+
+// Verify checks the given block against the `TaikoL1.BlockProposed` event it was derived from, so that
+// a divergent driver can't make the prover prove a wrong block.
+func Verify(block *Block, event *bindings.TaikoL1ClientBlockProposed) error {
+	if block.Header == nil || block.L1Origin == nil || block.BlockID == nil {
+		return fmt.Errorf("incomplete block feed data, blockID: %d", event.Id)
+	}
+
+	if block.BlockID.Cmp(event.Id) != 0 || block.L1Origin.BlockID.Cmp(event.Id) != 0 {
+		return fmt.Errorf("block ID mismatch, expected: %d, feed: %d", event.Id, block.BlockID)
+	}
+
+	if block.L1Origin.L1BlockHash != event.Raw.BlockHash ||
+		block.L1Origin.L1BlockHeight.Uint64() != event.Raw.BlockNumber {
+		return fmt.Errorf(
+			"L1 origin mismatch, blockID: %d, expected: %s, feed: %s",
+			event.Id,
+			event.Raw.BlockHash,
+			block.L1Origin.L1BlockHash,
+		)
+	}
+
+	if block.Header.Hash() != block.L1Origin.L2BlockHash {
+		return fmt.Errorf(
+			"L2 block hash mismatch, blockID: %d, header: %s, L1 origin: %s",
+			event.Id,
+			block.Header.Hash(),
+			block.L1Origin.L2BlockHash,
+		)
+	}
+
+	if block.Header.Time != event.Meta.Timestamp ||
+		block.Header.MixDigest != event.Meta.MixHash ||
+		block.Header.Coinbase != event.Meta.Beneficiary {
+		return fmt.Errorf("block metadata mismatch, blockID: %d", event.Id)
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package blockfeed
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+func newTestBlock(id int64) (*Block, *bindings.TaikoL1ClientBlockProposed) {
+	header := &types.Header{
+		ParentHash: common.BytesToHash([]byte{byte(id - 1)}),
+		Number:     big.NewInt(id),
+		Difficulty: common.Big0,
+		GasLimit:   30_000_000,
+		Time:       uint64(1000 + id),
+		MixDigest:  common.BytesToHash([]byte{0x01}),
+		Coinbase:   common.BytesToAddress([]byte{0x02}),
+		BaseFee:    common.Big1,
+	}
+
+	event := &bindings.TaikoL1ClientBlockProposed{Id: big.NewInt(id)}
+	event.Raw.BlockHash = common.BytesToHash([]byte{0x03, byte(id)})
+	event.Raw.BlockNumber = uint64(100 + id)
+	event.Meta.Timestamp = header.Time
+	event.Meta.MixHash = header.MixDigest
+	event.Meta.Beneficiary = header.Coinbase
+
+	return &Block{
+		BlockID: big.NewInt(id),
+		Header:  header,
+		L1Origin: &rawdb.L1Origin{
+			BlockID:       big.NewInt(id),
+			L2BlockHash:   header.Hash(),
+			L1BlockHeight: new(big.Int).SetUint64(event.Raw.BlockNumber),
+			L1BlockHash:   event.Raw.BlockHash,
+		},
+	}, event
+}
+
+func TestVerify(t *testing.T) {
+	block, event := newTestBlock(1)
+	require.Nil(t, Verify(block, event))
+
+	// L1 origin mismatch.
+	block, event = newTestBlock(1)
+	event.Raw.BlockHash = common.Hash{}
+	require.ErrorContains(t, Verify(block, event), "L1 origin mismatch")
+
+	// L2 block hash mismatch.
+	block, event = newTestBlock(1)
+	block.L1Origin.L2BlockHash = common.Hash{}
+	require.ErrorContains(t, Verify(block, event), "L2 block hash mismatch")
+
+	// Metadata mismatch.
+	block, event = newTestBlock(1)
+	event.Meta.Beneficiary = common.Address{}
+	require.ErrorContains(t, Verify(block, event), "block metadata mismatch")
+
+	// Block ID mismatch.
+	block, event = newTestBlock(1)
+	event.Id = common.Big2
+	require.ErrorContains(t, Verify(block, event), "block ID mismatch")
+}
+
+func TestNilClient(t *testing.T) {
+	var client *Client
+	require.Nil(t, NewClient("", 0))
+
+	block, event := newTestBlock(1)
+	client.Start(context.Background())
+	res, err := client.Wait(context.Background(), event)
+	require.Nil(t, err)
+	require.Nil(t, res)
+	require.Nil(t, client.VerifiedHeader(block.BlockID))
+	client.Close()
+}
+
+func TestClientNotConnected(t *testing.T) {
+	client := NewClient(filepath.Join(t.TempDir(), "feed.sock"), time.Minute)
+	client.Start(context.Background())
+	defer client.Close()
+
+	_, event := newTestBlock(1)
+	res, err := client.Wait(context.Background(), event)
+	require.Nil(t, err)
+	require.Nil(t, res)
+}
+
+func TestServerAndClient(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "feed.sock")
+
+	server := NewServer(path)
+	require.Nil(t, server.Start())
+	defer server.Close()
+
+	client := NewClient(path, 5*time.Second)
+	client.Start(context.Background())
+	defer client.Close()
+
+	require.Eventually(t, func() bool {
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+		return len(server.subscribers) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	block1, event1 := newTestBlock(1)
+	block2, event2 := newTestBlock(2)
+
+	// Published after the waiter starts.
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		server.Publish(block1)
+	}()
+
+	res, err := client.Wait(context.Background(), event1)
+	require.Nil(t, err)
+	require.Equal(t, block1.Header.Hash(), res.Header.Hash())
+	require.Equal(t, block1.Header.Hash(), client.VerifiedHeader(block1.BlockID).Hash())
+
+	// A divergent block is never trusted.
+	event2.Raw.BlockHash = common.Hash{}
+	server.Publish(block2)
+	require.Eventually(t, func() bool {
+		_, err := client.Wait(context.Background(), event2)
+		return err != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Nil(t, client.VerifiedHeader(block2.BlockID))
+}
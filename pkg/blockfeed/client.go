@@ -0,0 +1,235 @@
+package blockfeed
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+const (
+	// reconnectDelay is the time to wait before reconnecting to the block feed server.
+	reconnectDelay = 3 * time.Second
+	// defaultWaitTimeout is the default maximum time to wait for a block which hasn't arrived yet.
+	defaultWaitTimeout = 2 * time.Second
+	// cacheSize is the maximum number of the cached blocks.
+	cacheSize = 1024
+)
+
+// cachedBlock is a block received from the block feed, which is trusted only once it has been verified
+// against its L1 event.
+type cachedBlock struct {
+	block    *Block
+	verified bool
+}
+
+// Client subscribes to a driver's block feed, and caches the received blocks. All methods of a nil Client
+// are no-ops, so that the callers can fall back to their own block lookups when the feed is disabled.
+type Client struct {
+	path        string
+	waitTimeout time.Duration
+
+	mutex     sync.Mutex
+	connected bool
+	blocks    map[uint64]*cachedBlock
+	latest    uint64
+	arrived   chan struct{} // Closed and replaced whenever a new block arrives
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewClient creates a new Client instance subscribing to the given unix socket path, returns nil if the
+// given path is empty.
+func NewClient(path string, waitTimeout time.Duration) *Client {
+	if len(path) == 0 {
+		return nil
+	}
+	if waitTimeout == 0 {
+		waitTimeout = defaultWaitTimeout
+	}
+
+	return &Client{
+		path:        path,
+		waitTimeout: waitTimeout,
+		blocks:      make(map[uint64]*cachedBlock),
+		arrived:     make(chan struct{}),
+	}
+}
+
+// Start keeps the subscription alive in a new goroutine, reconnects if it's lost.
+func (c *Client) Start(ctx context.Context) {
+	if c == nil {
+		return
+	}
+
+	ctx, c.cancel = context.WithCancel(ctx)
+
+	c.wg.Add(1)
+	go c.loop(ctx)
+}
+
+// Close stops the subscription.
+func (c *Client) Close() {
+	if c == nil || c.cancel == nil {
+		return
+	}
+
+	c.cancel()
+	c.wg.Wait()
+}
+
+// Wait waits for the block derived from the given event, returns the block only if it arrives within the
+// wait timeout and matches the event, otherwise returns nil.
+func (c *Client) Wait(ctx context.Context, event *bindings.TaikoL1ClientBlockProposed) (*Block, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	timer := time.NewTimer(c.waitTimeout)
+	defer timer.Stop()
+
+	for {
+		c.mutex.Lock()
+		cached, found := c.blocks[event.Id.Uint64()]
+		connected, arrived := c.connected, c.arrived
+		c.mutex.Unlock()
+
+		if found {
+			if err := Verify(cached.block, event); err != nil {
+				return nil, err
+			}
+
+			c.mutex.Lock()
+			cached.verified = true
+			c.mutex.Unlock()
+
+			return cached.block, nil
+		}
+
+		// No need to wait if the feed is absent.
+		if !connected {
+			return nil, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+			return nil, nil
+		case <-arrived:
+		}
+	}
+}
+
+// VerifiedHeader returns the header of block with the given ID, only if the block has been verified
+// against its L1 event.
+func (c *Client) VerifiedHeader(blockID *big.Int) *types.Header {
+	if c == nil {
+		return nil
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	cached, found := c.blocks[blockID.Uint64()]
+	if !found || !cached.verified {
+		return nil
+	}
+
+	return cached.block.Header
+}
+
+// loop keeps connecting to the block feed server until the context is done.
+func (c *Client) loop(ctx context.Context) {
+	defer c.wg.Done()
+
+	for {
+		if err := c.subscribe(ctx); err != nil && ctx.Err() == nil {
+			log.Debug("Block feed subscription error", "path", c.path, "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// subscribe connects to the block feed server, and caches the received blocks until the connection is
+// lost.
+func (c *Client) subscribe(ctx context.Context) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", c.path)
+	if err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+	defer conn.Close()
+
+	log.Info("Block feed connected", "path", c.path)
+	c.setConnected(true)
+	defer c.setConnected(false)
+
+	decoder := json.NewDecoder(conn)
+	for {
+		var block Block
+		if err := decoder.Decode(&block); err != nil {
+			return err
+		}
+
+		if block.BlockID == nil {
+			continue
+		}
+
+		c.add(&block)
+	}
+}
+
+// setConnected updates the connection status.
+func (c *Client) setConnected(connected bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.connected = connected
+}
+
+// add caches the given block, and wakes up the waiters.
+func (c *Client) add(block *Block) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	id := block.BlockID.Uint64()
+	c.blocks[id] = &cachedBlock{block: block}
+	if id > c.latest {
+		c.latest = id
+	}
+
+	// Evict the oldest blocks.
+	if len(c.blocks) > cacheSize {
+		for cachedID := range c.blocks {
+			if cachedID+cacheSize <= c.latest {
+				delete(c.blocks, cachedID)
+			}
+		}
+	}
+
+	close(c.arrived)
+	c.arrived = make(chan struct{})
+}
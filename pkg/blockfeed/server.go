@@ -0,0 +1,147 @@
+package blockfeed
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// subscriberQueueSize is the maximum number of pending blocks of each subscriber, a subscriber which
+	// can't keep up will be disconnected.
+	subscriberQueueSize = 64
+)
+
+// Server broadcasts the freshly derived blocks to all subscribers connected to a local unix socket, as
+// newline-delimited JSON encoded Block objects.
+type Server struct {
+	path     string
+	listener net.Listener
+
+	mutex       sync.Mutex
+	subscribers map[net.Conn]chan *Block
+	closed      bool
+	wg          sync.WaitGroup
+}
+
+// NewServer creates a new Server instance listening on the given unix socket path.
+func NewServer(path string) *Server {
+	return &Server{path: path, subscribers: make(map[net.Conn]chan *Block)}
+}
+
+// Start starts listening on the unix socket, and accepts subscribers in a new goroutine, the stale
+// socket file left by a previous run will be removed.
+func (s *Server) Start() error {
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	listener, err := net.Listen("unix", s.path)
+	if err != nil {
+		return err
+	}
+	s.listener = listener
+
+	log.Info("Starting block feed server", "path", s.path)
+
+	s.wg.Add(1)
+	go s.accept()
+
+	return nil
+}
+
+// Publish sends the given block to all subscribers, never blocks.
+func (s *Server) Publish(block *Block) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for conn, queue := range s.subscribers {
+		select {
+		case queue <- block:
+		default:
+			log.Warn("Block feed subscriber can't keep up, disconnect it", "subscriber", conn.RemoteAddr())
+			s.removeSubscriber(conn)
+		}
+	}
+}
+
+// Close stops accepting new subscribers, and disconnects all existing ones.
+func (s *Server) Close() {
+	s.mutex.Lock()
+	s.closed = true
+	if s.listener != nil {
+		s.listener.Close()
+	}
+	for conn := range s.subscribers {
+		s.removeSubscriber(conn)
+	}
+	s.mutex.Unlock()
+
+	s.wg.Wait()
+}
+
+// accept keeps accepting new subscribers until the listener is closed.
+func (s *Server) accept() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Error("Block feed server accept error", "error", err)
+			}
+			return
+		}
+
+		s.mutex.Lock()
+		if s.closed {
+			s.mutex.Unlock()
+			conn.Close()
+			return
+		}
+		queue := make(chan *Block, subscriberQueueSize)
+		s.subscribers[conn] = queue
+		s.mutex.Unlock()
+
+		log.Info("New block feed subscriber")
+
+		s.wg.Add(1)
+		go s.serve(conn, queue)
+	}
+}
+
+// serve writes the queued blocks to the given subscriber, until the subscriber is removed.
+func (s *Server) serve(conn net.Conn, queue chan *Block) {
+	defer s.wg.Done()
+
+	encoder := json.NewEncoder(conn)
+	for block := range queue {
+		if err := encoder.Encode(block); err != nil {
+			log.Debug("Failed to write to block feed subscriber", "error", err)
+
+			s.mutex.Lock()
+			s.removeSubscriber(conn)
+			s.mutex.Unlock()
+			// Drain the queue until it's closed.
+			for range queue {
+			}
+			return
+		}
+	}
+}
+
+// removeSubscriber disconnects the given subscriber, the caller must hold the mutex.
+func (s *Server) removeSubscriber(conn net.Conn) {
+	queue, ok := s.subscribers[conn]
+	if !ok {
+		return
+	}
+
+	delete(s.subscribers, conn)
+	close(queue)
+	conn.Close()
+}
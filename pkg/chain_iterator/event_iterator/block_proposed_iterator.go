@@ -12,6 +12,11 @@ import (
 	chainIterator "github.com/taikoxyz/taiko-client/pkg/chain_iterator"
 )
 
+const (
+	// DefaultBlockProposedPageSize is the default maximum number of blocks in each eth_getLogs range.
+	DefaultBlockProposedPageSize = 2048
+)
+
 // EndBlockProposedEventIterFunc ends the current iteration.
 type EndBlockProposedEventIterFunc func()
 
@@ -43,6 +48,10 @@ type BlockProposedIteratorConfig struct {
 	FilterQuery           []*big.Int
 	Reverse               bool
 	OnBlockProposedEvent  OnBlockProposedEvent
+	// PageSize is the maximum number of blocks in each eth_getLogs range, since some RPC providers
+	// cap the range, default to DefaultBlockProposedPageSize. It's also used as the number of blocks
+	// read per epoch, if MaxBlocksReadPerEpoch is not set.
+	PageSize uint64
 }
 
 // NewBlockProposedIterator creates a new instance of BlockProposed event iterator.
@@ -57,10 +66,19 @@ func NewBlockProposedIterator(ctx context.Context, cfg *BlockProposedIteratorCon
 		filterQuery: cfg.FilterQuery,
 	}
 
+	pageSize := cfg.PageSize
+	if pageSize == 0 {
+		pageSize = DefaultBlockProposedPageSize
+	}
+	maxBlocksReadPerEpoch := cfg.MaxBlocksReadPerEpoch
+	if maxBlocksReadPerEpoch == nil {
+		maxBlocksReadPerEpoch = &pageSize
+	}
+
 	// Initialize the inner block iterator.
 	blockIterator, err := chainIterator.NewBlockBatchIterator(ctx, &chainIterator.BlockBatchIteratorConfig{
 		Client:                cfg.Client,
-		MaxBlocksReadPerEpoch: cfg.MaxBlocksReadPerEpoch,
+		MaxBlocksReadPerEpoch: maxBlocksReadPerEpoch,
 		StartHeight:           cfg.StartHeight,
 		EndHeight:             cfg.EndHeight,
 		Reverse:               cfg.Reverse,
@@ -70,6 +88,7 @@ func NewBlockProposedIterator(ctx context.Context, cfg *BlockProposedIteratorCon
 			cfg.FilterQuery,
 			cfg.OnBlockProposedEvent,
 			iterator,
+			pageSize,
 		),
 	})
 	if err != nil {
@@ -100,6 +119,7 @@ func assembleBlockProposedIteratorCallback(
 	filterQuery []*big.Int,
 	callback OnBlockProposedEvent,
 	eventIter *BlockProposedIterator,
+	pageSize uint64,
 ) chainIterator.OnBlocksFunc {
 	return func(
 		ctx context.Context,
@@ -107,25 +127,10 @@ func assembleBlockProposedIteratorCallback(
 		updateCurrentFunc chainIterator.UpdateCurrentFunc,
 		endFunc chainIterator.EndIterFunc,
 	) error {
-		endHeight := end.Number.Uint64()
-		iter, err := taikoL1Client.FilterBlockProposed(
-			&bind.FilterOpts{Start: start.Number.Uint64(), End: &endHeight, Context: ctx},
-			filterQuery,
-		)
-		if err != nil {
-			return err
-		}
-		defer iter.Close()
-
-		for iter.Next() {
-			event := iter.Event
-
-			// Skip if reorged.
-			if event.Raw.Removed {
-				continue
-			}
-
-			if err := callback(ctx, event, eventIter.end); err != nil {
+		for _, page := range splitPages(start.Number.Uint64(), end.Number.Uint64(), pageSize) {
+			if err := filterBlockProposedPage(
+				ctx, client, taikoL1Client, filterQuery, callback, eventIter, page, updateCurrentFunc,
+			); err != nil {
 				return err
 			}
 
@@ -133,15 +138,70 @@ func assembleBlockProposedIteratorCallback(
 				endFunc()
 				return nil
 			}
+		}
 
-			current, err := client.HeaderByHash(ctx, event.Raw.BlockHash)
-			if err != nil {
-				return err
-			}
+		return nil
+	}
+}
 
-			updateCurrentFunc(current)
+// filterBlockProposedPage iterates the BlockProposed events emitted in the given page of blocks.
+func filterBlockProposedPage(
+	ctx context.Context,
+	client *ethclient.Client,
+	taikoL1Client *bindings.TaikoL1Client,
+	filterQuery []*big.Int,
+	callback OnBlockProposedEvent,
+	eventIter *BlockProposedIterator,
+	page [2]uint64,
+	updateCurrentFunc chainIterator.UpdateCurrentFunc,
+) error {
+	iter, err := taikoL1Client.FilterBlockProposed(
+		&bind.FilterOpts{Start: page[0], End: &page[1], Context: ctx},
+		filterQuery,
+	)
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	for iter.Next() {
+		event := iter.Event
+
+		// Skip if reorged.
+		if event.Raw.Removed {
+			continue
 		}
 
-		return nil
+		if err := callback(ctx, event, eventIter.end); err != nil {
+			return err
+		}
+
+		if eventIter.isEnd {
+			return nil
+		}
+
+		current, err := client.HeaderByHash(ctx, event.Raw.BlockHash)
+		if err != nil {
+			return err
+		}
+
+		updateCurrentFunc(current)
+	}
+
+	return iter.Error()
+}
+
+// splitPages splits the given inclusive block range into inclusive pages of at most pageSize blocks.
+func splitPages(start uint64, end uint64, pageSize uint64) [][2]uint64 {
+	pages := make([][2]uint64, 0)
+	for pageStart := start; pageStart <= end; pageStart += pageSize {
+		pageEnd := pageStart + pageSize - 1
+		if pageEnd >= end {
+			pages = append(pages, [2]uint64{pageStart, end})
+			break
+		}
+		pages = append(pages, [2]uint64{pageStart, pageEnd})
 	}
+
+	return pages
 }
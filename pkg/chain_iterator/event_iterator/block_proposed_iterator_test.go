@@ -0,0 +1,15 @@
+package eventiterator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitPages(t *testing.T) {
+	require.Equal(t, [][2]uint64{{0, 0}}, splitPages(0, 0, 2048))
+	require.Equal(t, [][2]uint64{{10, 100}}, splitPages(10, 100, 2048))
+	require.Equal(t, [][2]uint64{{0, 2047}, {2048, 2048}}, splitPages(0, 2048, 2048))
+	require.Equal(t, [][2]uint64{{1, 3}, {4, 6}, {7, 8}}, splitPages(1, 8, 3))
+	require.Equal(t, [][2]uint64{{5, 5}, {6, 6}}, splitPages(5, 6, 1))
+}
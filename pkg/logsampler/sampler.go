@@ -0,0 +1,125 @@
+package logsampler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Sampler bounds the volume of the designated high-frequency log messages, e.g. the per-block ones during
+// a long catch-up. Each message is logged in full detail for its first `limit` occurrences in every
+// interval, the remaining ones are suppressed and summarized by one line when the interval ends. All
+// methods of a nil Sampler log every message.
+type Sampler struct {
+	mutex    sync.Mutex
+	limit    uint64
+	interval time.Duration
+	summary  log.Logger
+	windows  map[string]*window
+}
+
+// window is the sampling state of a message in current interval.
+type window struct {
+	start      time.Time
+	logged     uint64
+	suppressed uint64
+	timer      *time.Timer
+}
+
+// New creates a new Sampler instance logging at most the given number of occurrences of each message
+// per interval, returns nil if the limit is zero, which means sampling is disabled.
+func New(limit uint64, interval time.Duration) *Sampler {
+	if limit == 0 {
+		return nil
+	}
+
+	return &Sampler{
+		limit:    limit,
+		interval: interval,
+		summary:  log.Root(),
+		windows:  make(map[string]*window),
+	}
+}
+
+// Info logs the given message at info level through the given logger, if the message's limit in current
+// interval hasn't been reached.
+func (s *Sampler) Info(logger log.Logger, msg string, ctx ...interface{}) {
+	if s.allow(msg) {
+		logger.Info(msg, ctx...)
+	}
+}
+
+// allow checks whether the given message can be logged in current interval, and counts it otherwise.
+func (s *Sampler) allow(msg string) bool {
+	if s == nil {
+		return true
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	w, ok := s.windows[msg]
+	if ok && time.Since(w.start) >= s.interval {
+		// The interval ended, but its summary timer hasn't fired yet.
+		s.flush(msg, w)
+		ok = false
+	}
+	if !ok {
+		w = &window{start: time.Now()}
+		s.windows[msg] = w
+	}
+
+	if w.logged < s.limit {
+		w.logged++
+		return true
+	}
+
+	if w.suppressed == 0 {
+		w.timer = time.AfterFunc(time.Until(w.start.Add(s.interval)), func() {
+			s.mutex.Lock()
+			defer s.mutex.Unlock()
+
+			if s.windows[msg] == w {
+				s.flush(msg, w)
+			}
+		})
+	}
+	w.suppressed++
+
+	return false
+}
+
+// flush ends the given message's window, and logs the summary line if some occurrences have been
+// suppressed, the caller must hold the mutex.
+func (s *Sampler) flush(msg string, w *window) {
+	delete(s.windows, msg)
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	if w.suppressed == 0 {
+		return
+	}
+
+	s.summary.Info(
+		"Suppressed repetitive log messages",
+		"message", msg,
+		"logged", w.logged,
+		"suppressed", w.suppressed,
+		"interval", s.interval,
+	)
+}
+
+// Close logs the summary lines of all current windows.
+func (s *Sampler) Close() {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for msg, w := range s.windows {
+		s.flush(msg, w)
+	}
+}
@@ -0,0 +1,128 @@
+package logsampler
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder records all the logged records.
+type recorder struct {
+	mutex   sync.Mutex
+	records []*log.Record
+}
+
+func (r *recorder) logger() log.Logger {
+	logger := log.New()
+	logger.SetHandler(log.FuncHandler(func(record *log.Record) error {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		r.records = append(r.records, record)
+		return nil
+	}))
+	return logger
+}
+
+func (r *recorder) count(msg string) int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var n int
+	for _, record := range r.records {
+		if record.Msg == msg {
+			n++
+		}
+	}
+	return n
+}
+
+// summaries returns the suppressed counts of the summary lines of the given message.
+func (r *recorder) summaries(msg string) []uint64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var counts []uint64
+	for _, record := range r.records {
+		if record.Msg != "Suppressed repetitive log messages" {
+			continue
+		}
+		fields := make(map[interface{}]interface{})
+		for i := 0; i+1 < len(record.Ctx); i += 2 {
+			fields[record.Ctx[i]] = record.Ctx[i+1]
+		}
+		if fields["message"] == msg {
+			counts = append(counts, fields["suppressed"].(uint64))
+		}
+	}
+	return counts
+}
+
+func TestSamplerSummary(t *testing.T) {
+	var (
+		logs      = new(recorder)
+		summaries = new(recorder)
+		s         = New(3, 200*time.Millisecond)
+	)
+	s.summary = summaries.logger()
+
+	for i := 0; i < 10; i++ {
+		s.Info(logs.logger(), "Proposed block", "blockID", i)
+	}
+	for i := 0; i < 2; i++ {
+		s.Info(logs.logger(), "Block has been verified", "blockID", i)
+	}
+	require.Equal(t, 3, logs.count("Proposed block"))
+	require.Equal(t, 2, logs.count("Block has been verified"))
+	require.Empty(t, summaries.summaries("Proposed block"))
+
+	// The summary line is logged once the interval ends.
+	require.Eventually(t, func() bool {
+		return len(summaries.summaries("Proposed block")) == 1
+	}, 2*time.Second, 20*time.Millisecond)
+	require.Equal(t, []uint64{7}, summaries.summaries("Proposed block"))
+	require.Empty(t, summaries.summaries("Block has been verified"))
+
+	// A new interval starts.
+	for i := 0; i < 5; i++ {
+		s.Info(logs.logger(), "Proposed block", "blockID", i)
+	}
+	require.Equal(t, 6, logs.count("Proposed block"))
+
+	s.Close()
+	require.Equal(t, []uint64{7, 2}, summaries.summaries("Proposed block"))
+}
+
+func TestSamplerIntervalEndedBeforeTimer(t *testing.T) {
+	var (
+		logs      = new(recorder)
+		summaries = new(recorder)
+		s         = New(1, time.Hour)
+	)
+	s.summary = summaries.logger()
+
+	s.Info(logs.logger(), "Proposed block")
+	s.Info(logs.logger(), "Proposed block")
+
+	// Pretend the interval has ended.
+	s.windows["Proposed block"].start = time.Now().Add(-2 * time.Hour)
+	s.Info(logs.logger(), "Proposed block")
+
+	require.Equal(t, 2, logs.count("Proposed block"))
+	require.Equal(t, []uint64{1}, summaries.summaries("Proposed block"))
+}
+
+func TestNilSampler(t *testing.T) {
+	s := New(0, time.Minute)
+	require.Nil(t, s)
+
+	logs := new(recorder)
+	for i := 0; i < 10; i++ {
+		s.Info(logs.logger(), "Proposed block")
+	}
+	require.Equal(t, 10, logs.count("Proposed block"))
+	require.NotPanics(t, s.Close)
+}
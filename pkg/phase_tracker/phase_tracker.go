@@ -0,0 +1,173 @@
+package phaseTracker
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// Phase records the start and end timestamps of a named startup phase.
+type Phase struct {
+	Name      string     `json:"name"`
+	StartedAt time.Time  `json:"startedAt"`
+	EndedAt   *time.Time `json:"endedAt,omitempty"`
+}
+
+// Duration returns the duration of the phase, if the phase has not ended yet, returns the
+// elapsed time since the phase started.
+func (p *Phase) Duration() time.Duration {
+	if p.EndedAt == nil {
+		return time.Since(p.StartedAt)
+	}
+
+	return p.EndedAt.Sub(p.StartedAt)
+}
+
+// Status represents the current status of a startup process.
+type Status struct {
+	InProgress          bool     `json:"inProgress"`
+	CurrentPhase        string   `json:"currentPhase,omitempty"`
+	CurrentPhaseElapsed string   `json:"currentPhaseElapsed,omitempty"`
+	Elapsed             string   `json:"elapsed"`
+	Phases              []*Phase `json:"phases"`
+}
+
+// Tracker tracks the named phases of a client software's startup process, the phases are
+// sequential, entering a new phase ends the current one.
+type Tracker struct {
+	component  string
+	startedAt  time.Time
+	finishedAt *time.Time
+	phases     []*Phase
+	mutex      sync.RWMutex
+}
+
+// New creates a new Tracker instance for the given component, the startup process is
+// considered to be started when the tracker is created.
+func New(component string) *Tracker {
+	return &Tracker{component: component, startedAt: time.Now()}
+}
+
+// Enter ends the current phase, and then starts a new phase with the given name, it does nothing if
+// the given phase is already the current one, or the startup process has finished.
+func (t *Tracker) Enter(name string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.finishedAt != nil {
+		return
+	}
+
+	now := time.Now()
+	if current := t.current(); current != nil {
+		if current.Name == name {
+			return
+		}
+		t.end(current, now)
+	}
+
+	log.Info("Startup phase started", "component", t.component, "phase", name)
+
+	t.phases = append(t.phases, &Phase{Name: name, StartedAt: now})
+}
+
+// Finish ends the current phase and the whole startup process, then logs a summary table of all phases.
+func (t *Tracker) Finish() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.finishedAt != nil {
+		return
+	}
+
+	now := time.Now()
+	if current := t.current(); current != nil {
+		t.end(current, now)
+	}
+	t.finishedAt = &now
+
+	metrics.GetOrRegisterGauge(t.component+"/startup/duration", nil).Update(now.Sub(t.startedAt).Milliseconds())
+
+	log.Info(fmt.Sprintf("Startup finished, phases:\n%s", t.summary()), "component", t.component)
+}
+
+// Finished returns whether the startup process has finished.
+func (t *Tracker) Finished() bool {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.finishedAt != nil
+}
+
+// Status returns the current status of the startup process.
+func (t *Tracker) Status() *Status {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	status := &Status{InProgress: t.finishedAt == nil, Phases: make([]*Phase, 0, len(t.phases))}
+	for _, phase := range t.phases {
+		copied := *phase
+		status.Phases = append(status.Phases, &copied)
+	}
+
+	if t.finishedAt != nil {
+		status.Elapsed = t.finishedAt.Sub(t.startedAt).String()
+		return status
+	}
+
+	status.Elapsed = time.Since(t.startedAt).String()
+	if current := t.current(); current != nil {
+		status.CurrentPhase = current.Name
+		status.CurrentPhaseElapsed = current.Duration().String()
+	}
+
+	return status
+}
+
+// current returns the current active phase, returns nil if there is no active phase.
+func (t *Tracker) current() *Phase {
+	if len(t.phases) == 0 {
+		return nil
+	}
+
+	if last := t.phases[len(t.phases)-1]; last.EndedAt == nil {
+		return last
+	}
+
+	return nil
+}
+
+// end ends the given phase, and exports its duration as a gauge in milliseconds.
+func (t *Tracker) end(phase *Phase, endedAt time.Time) {
+	phase.EndedAt = &endedAt
+
+	metrics.GetOrRegisterGauge(
+		fmt.Sprintf("%s/startup/phase/%s/duration", t.component, phase.Name), nil,
+	).Update(phase.Duration().Milliseconds())
+
+	log.Info("Startup phase finished", "component", t.component, "phase", phase.Name, "duration", phase.Duration())
+}
+
+// summary renders a summary table of all phases.
+func (t *Tracker) summary() string {
+	var (
+		sb strings.Builder
+		w  = tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	)
+
+	fmt.Fprintln(w, "PHASE\tSTARTED AT\tDURATION")
+	for _, phase := range t.phases {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", phase.Name, phase.StartedAt.Format(time.RFC3339), phase.Duration())
+	}
+	if t.finishedAt != nil {
+		fmt.Fprintf(w, "total\t%s\t%s\n", t.startedAt.Format(time.RFC3339), t.finishedAt.Sub(t.startedAt))
+	}
+	w.Flush()
+
+	return sb.String()
+}
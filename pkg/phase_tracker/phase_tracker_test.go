@@ -0,0 +1,44 @@
+package phaseTracker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracker(t *testing.T) {
+	tracker := New("test")
+
+	status := tracker.Status()
+	require.True(t, status.InProgress)
+	require.Empty(t, status.CurrentPhase)
+	require.Empty(t, status.Phases)
+
+	tracker.Enter("phase1")
+	tracker.Enter("phase1")
+	status = tracker.Status()
+	require.Equal(t, "phase1", status.CurrentPhase)
+	require.NotEmpty(t, status.CurrentPhaseElapsed)
+	require.Equal(t, 1, len(status.Phases))
+
+	tracker.Enter("phase2")
+	status = tracker.Status()
+	require.Equal(t, "phase2", status.CurrentPhase)
+	require.Equal(t, 2, len(status.Phases))
+	require.NotNil(t, status.Phases[0].EndedAt)
+	require.Nil(t, status.Phases[1].EndedAt)
+
+	require.False(t, tracker.Finished())
+	tracker.Finish()
+	require.True(t, tracker.Finished())
+
+	status = tracker.Status()
+	require.False(t, status.InProgress)
+	require.Empty(t, status.CurrentPhase)
+	require.NotNil(t, status.Phases[1].EndedAt)
+
+	// No more phases after finished.
+	tracker.Enter("phase3")
+	require.Equal(t, 2, len(tracker.Status().Phases))
+	require.Contains(t, tracker.summary(), "phase2")
+}
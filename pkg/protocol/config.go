@@ -0,0 +1,82 @@
+package protocol
+
+import (
+	"math/big"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+	txListValidator "github.com/taikoxyz/taiko-client/pkg/tx_list_validator"
+)
+
+// FeeConfig is the typed TaikoData.FeeConfig of the proposing or proving fees.
+type FeeConfig struct {
+	AvgTimeMAF        uint16 `json:"avgTimeMAF"`
+	DampingFactorBips uint16 `json:"dampingFactorBips"`
+}
+
+// Params is the typed TaikoData.Config of the TaikoL1 protocol, comparable with ==.
+type Params struct {
+	ChainID                 uint64    `json:"chainId"`
+	MaxNumProposedBlocks    uint64    `json:"maxNumProposedBlocks"`
+	RingBufferSize          uint64    `json:"ringBufferSize"`
+	MaxNumVerifiedBlocks    uint64    `json:"maxNumVerifiedBlocks"`
+	MaxVerificationsPerTx   uint64    `json:"maxVerificationsPerTx"`
+	BlockMaxGasLimit        uint64    `json:"blockMaxGasLimit"`
+	MaxTransactionsPerBlock uint64    `json:"maxTransactionsPerBlock"`
+	MaxBytesPerTxList       uint64    `json:"maxBytesPerTxList"`
+	MinTxGasLimit           uint64    `json:"minTxGasLimit"`
+	SlotSmoothingFactor     uint64    `json:"slotSmoothingFactor"`
+	RewardBurnBips          uint64    `json:"rewardBurnBips"`
+	ProposerDepositPctg     uint64    `json:"proposerDepositPctg"`
+	FeeBaseMAF              uint64    `json:"feeBaseMAF"`
+	TxListCacheExpiry       uint64    `json:"txListCacheExpiry"`
+	EnableSoloProposer      bool      `json:"enableSoloProposer"`
+	EnableOracleProver      bool      `json:"enableOracleProver"`
+	EnableTokenomics        bool      `json:"enableTokenomics"`
+	SkipZKPVerification     bool      `json:"skipZKPVerification"`
+	ProposingConfig         FeeConfig `json:"proposingConfig"`
+	ProvingConfig           FeeConfig `json:"provingConfig"`
+}
+
+// NewParams converts the given TaikoL1.getConfig result to Params.
+func NewParams(c *bindings.TaikoDataConfig) Params {
+	return Params{
+		ChainID:                 c.ChainId.Uint64(),
+		MaxNumProposedBlocks:    c.MaxNumProposedBlocks.Uint64(),
+		RingBufferSize:          c.RingBufferSize.Uint64(),
+		MaxNumVerifiedBlocks:    c.MaxNumVerifiedBlocks.Uint64(),
+		MaxVerificationsPerTx:   c.MaxVerificationsPerTx.Uint64(),
+		BlockMaxGasLimit:        c.BlockMaxGasLimit.Uint64(),
+		MaxTransactionsPerBlock: c.MaxTransactionsPerBlock.Uint64(),
+		MaxBytesPerTxList:       c.MaxBytesPerTxList.Uint64(),
+		MinTxGasLimit:           c.MinTxGasLimit.Uint64(),
+		SlotSmoothingFactor:     c.SlotSmoothingFactor.Uint64(),
+		RewardBurnBips:          c.RewardBurnBips.Uint64(),
+		ProposerDepositPctg:     c.ProposerDepositPctg.Uint64(),
+		FeeBaseMAF:              c.FeeBaseMAF.Uint64(),
+		TxListCacheExpiry:       c.TxListCacheExpiry.Uint64(),
+		EnableSoloProposer:      c.EnableSoloProposer,
+		EnableOracleProver:      c.EnableOracleProver,
+		EnableTokenomics:        c.EnableTokenomics,
+		SkipZKPVerification:     c.SkipZKPVerification,
+		ProposingConfig:         FeeConfig(c.ProposingConfig),
+		ProvingConfig:           FeeConfig(c.ProvingConfig),
+	}
+}
+
+// Config is a versioned snapshot of the protocol configuration, it is never modified once created, a changed
+// configuration is published as a new snapshot with a higher version.
+type Config struct {
+	Version uint64 `json:"version"` // Starting from 1, increased each time a changed configuration is fetched
+	Params
+}
+
+// NewTxListValidator creates a new transactions list validator with the limits of the configuration.
+func (c *Config) NewTxListValidator(chainID *big.Int) *txListValidator.TxListValidator {
+	return txListValidator.NewTxListValidator(
+		c.BlockMaxGasLimit,
+		c.MaxTransactionsPerBlock,
+		c.MaxBytesPerTxList,
+		c.MinTxGasLimit,
+		chainID,
+	)
+}
@@ -0,0 +1,67 @@
+package protocol
+
+import (
+	"encoding/json"
+	"flag"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// testConfig returns the configuration of the current TaikoL1 contract.
+func testConfig() bindings.TaikoDataConfig {
+	return bindings.TaikoDataConfig{
+		ChainId:                 big.NewInt(167),
+		MaxNumProposedBlocks:    big.NewInt(120960),
+		RingBufferSize:          big.NewInt(120970),
+		MaxNumVerifiedBlocks:    big.NewInt(4096),
+		MaxVerificationsPerTx:   big.NewInt(10),
+		BlockMaxGasLimit:        big.NewInt(6000000),
+		MaxTransactionsPerBlock: big.NewInt(79),
+		MaxBytesPerTxList:       big.NewInt(120000),
+		MinTxGasLimit:           big.NewInt(21000),
+		SlotSmoothingFactor:     big.NewInt(946649),
+		RewardBurnBips:          big.NewInt(100),
+		ProposerDepositPctg:     big.NewInt(25),
+		FeeBaseMAF:              big.NewInt(1024),
+		TxListCacheExpiry:       big.NewInt(0),
+		EnableSoloProposer:      false,
+		EnableOracleProver:      true,
+		EnableTokenomics:        true,
+		SkipZKPVerification:     false,
+		ProposingConfig:         bindings.TaikoDataFeeConfig{AvgTimeMAF: 1024, DampingFactorBips: 2500},
+		ProvingConfig:           bindings.TaikoDataFeeConfig{AvgTimeMAF: 1024, DampingFactorBips: 2500},
+	}
+}
+
+// TestConfigGolden pins the typed values of the current contract configuration, so that a protocol
+// bump shows up as an explicit diff of the golden file, run with -update to regenerate it.
+func TestConfigGolden(t *testing.T) {
+	raw := testConfig()
+	config := &Config{Version: 1, Params: NewParams(&raw)}
+
+	encoded, err := json.MarshalIndent(config, "", "  ")
+	require.Nil(t, err)
+	encoded = append(encoded, '\n')
+
+	path := filepath.Join("testdata", "config.golden.json")
+	if *update {
+		require.Nil(t, os.WriteFile(path, encoded, 0o644))
+	}
+	expected, err := os.ReadFile(path)
+	require.Nil(t, err)
+	require.Equal(t, string(expected), string(encoded))
+}
+
+func TestConfigTxListValidator(t *testing.T) {
+	raw := testConfig()
+	config := &Config{Version: 1, Params: NewParams(&raw)}
+
+	require.NotNil(t, config.NewTxListValidator(big.NewInt(167)))
+}
@@ -0,0 +1,136 @@
+package protocol
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+// refreshInterval is the interval to refresh the protocol configuration, so that a changed configuration
+// after a protocol upgrade will be picked up.
+var refreshInterval = 10 * time.Minute
+
+// ConfigReader reads the TaikoL1 protocol configuration, implemented by *bindings.TaikoL1Client.
+type ConfigReader interface {
+	GetConfig(opts *bind.CallOpts) (bindings.TaikoDataConfig, error)
+}
+
+// Configs owns the latest snapshot of the TaikoL1 protocol configuration shared by the components of a
+// client, and notifies its watchers once the configuration changes.
+type Configs struct {
+	reader   ConfigReader
+	mutex    sync.Mutex
+	current  *Config
+	watchers map[chan *Config]struct{}
+}
+
+// New creates a new Configs instance, with the configuration currently read from the given reader.
+func New(ctx context.Context, reader ConfigReader) (*Configs, error) {
+	raw, err := reader.GetConfig(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get protocol configs: %w", err)
+	}
+
+	return &Configs{
+		reader:   reader,
+		current:  &Config{Version: 1, Params: NewParams(&raw)},
+		watchers: make(map[chan *Config]struct{}),
+	}, nil
+}
+
+// Config returns the latest snapshot of the protocol configuration.
+func (c *Configs) Config() *Config {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.current
+}
+
+// Refresh reads the protocol configuration again, if it is changed, a new snapshot is published to the
+// watchers. Reports whether the configuration is changed.
+func (c *Configs) Refresh(ctx context.Context) (bool, error) {
+	raw, err := c.reader.GetConfig(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return false, fmt.Errorf("failed to get protocol configs: %w", err)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	params := NewParams(&raw)
+	if params == c.current.Params {
+		return false, nil
+	}
+
+	old := c.current
+	c.current = &Config{Version: old.Version + 1, Params: params}
+	log.Info("Protocol configs changed", "version", c.current.Version, "old", old.Params, "new", c.current.Params)
+
+	for ch := range c.watchers {
+		publish(ch, c.current)
+	}
+
+	return true, nil
+}
+
+// Watch returns a channel receiving the new snapshots once the configuration changes, until the given
+// context is done, then the channel is closed. A slow watcher only receives the latest snapshot.
+func (c *Configs) Watch(ctx context.Context) <-chan *Config {
+	ch := make(chan *Config, 1)
+
+	c.mutex.Lock()
+	c.watchers[ch] = struct{}{}
+	c.mutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+
+		delete(c.watchers, ch)
+		close(ch)
+	}()
+
+	return ch
+}
+
+// Start refreshes the protocol configuration intervally in background, until the given context is done.
+func (c *Configs) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(refreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := c.Refresh(ctx); err != nil {
+					log.Error("Failed to refresh protocol configs", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// publish sends the given snapshot to the given watcher channel, replacing the one not received yet.
+func publish(ch chan *Config, config *Config) {
+	for {
+		select {
+		case ch <- config:
+			return
+		default:
+		}
+
+		select {
+		case <-ch:
+		default:
+		}
+	}
+}
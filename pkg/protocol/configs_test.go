@@ -0,0 +1,115 @@
+package protocol
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+// testReader is a ConfigReader returning the configured configuration.
+type testReader struct {
+	mutex  sync.Mutex
+	config bindings.TaikoDataConfig
+	err    error
+}
+
+func (r *testReader) GetConfig(opts *bind.CallOpts) (bindings.TaikoDataConfig, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.config, r.err
+}
+
+func (r *testReader) set(config bindings.TaikoDataConfig, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.config, r.err = config, err
+}
+
+func TestConfigsRefresh(t *testing.T) {
+	reader := &testReader{config: testConfig()}
+	configs, err := New(context.Background(), reader)
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), configs.Config().Version)
+	require.Equal(t, uint64(120960), configs.Config().MaxNumProposedBlocks)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	watch := configs.Watch(ctx)
+
+	// Unchanged configuration.
+	changed, err := configs.Refresh(context.Background())
+	require.Nil(t, err)
+	require.False(t, changed)
+	require.Equal(t, uint64(1), configs.Config().Version)
+
+	// Changed configuration, the old snapshot is kept unmodified.
+	old := configs.Config()
+	upgraded := testConfig()
+	upgraded.MaxNumProposedBlocks = big.NewInt(240000)
+	reader.set(upgraded, nil)
+
+	changed, err = configs.Refresh(context.Background())
+	require.Nil(t, err)
+	require.True(t, changed)
+	require.Equal(t, uint64(2), configs.Config().Version)
+	require.Equal(t, uint64(240000), configs.Config().MaxNumProposedBlocks)
+	require.Equal(t, uint64(120960), old.MaxNumProposedBlocks)
+	require.Equal(t, configs.Config(), <-watch)
+
+	// Failed refresh keeps the current snapshot.
+	reader.set(testConfig(), errors.New("test"))
+	_, err = configs.Refresh(context.Background())
+	require.NotNil(t, err)
+	require.Equal(t, uint64(2), configs.Config().Version)
+
+	cancel()
+	_, ok := <-watch
+	require.False(t, ok)
+}
+
+func TestConfigsWatchLatest(t *testing.T) {
+	reader := &testReader{config: testConfig()}
+	configs, err := New(context.Background(), reader)
+	require.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watch := configs.Watch(ctx)
+
+	// A slow watcher only receives the latest snapshot.
+	for i := int64(1); i <= 3; i++ {
+		upgraded := testConfig()
+		upgraded.MaxBytesPerTxList = big.NewInt(120000 + i)
+		reader.set(upgraded, nil)
+
+		changed, err := configs.Refresh(context.Background())
+		require.Nil(t, err)
+		require.True(t, changed)
+	}
+
+	select {
+	case config := <-watch:
+		require.Equal(t, uint64(4), config.Version)
+		require.Equal(t, uint64(120003), config.MaxBytesPerTxList)
+	case <-time.After(time.Second):
+		t.Fatal("no snapshot received")
+	}
+	select {
+	case config := <-watch:
+		t.Fatalf("unexpected snapshot: %v", config)
+	default:
+	}
+}
+
+func TestNewConfigsError(t *testing.T) {
+	_, err := New(context.Background(), &testReader{err: errors.New("test")})
+	require.ErrorContains(t, err, "failed to get protocol configs")
+}
@@ -0,0 +1,236 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"sync/atomic"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/metrics"
+)
+
+// prunedDataErrors are the error messages of the L1 nodes which no longer serve the requested historical
+// state or history.
+var prunedDataErrors = []string{
+	"missing trie node",
+	"historical state",
+	"pruned",
+	"transaction not found",
+}
+
+// isPrunedDataError checks whether the given error is returned by a L1 node which has pruned the
+// requested data.
+func isPrunedDataError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, pruned := range prunedDataErrors {
+		if strings.Contains(msg, pruned) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// L1Archive routes the L1 requests of the historical data to an archive L1 endpoint, when the primary pruned
+// L1 endpoint is known to no longer serve them. The requests below the pruning horizon, the lowest block whose
+// history the primary endpoint still serves, which is probed on startup, are sent to the archive endpoint
+// directly, and the other requests fall back to the archive endpoint if the primary one returns a pruned data
+// error. All methods of a nil L1Archive use the primary endpoint only.
+type L1Archive struct {
+	primary    *ethclient.Client
+	client     *ethclient.Client
+	gethClient *gethclient.Client
+	taikoL1    *bindings.TaikoL1Client // Bound to the archive endpoint only
+	horizon    uint64                  // Accessed atomically
+}
+
+// NewL1Archive dials the given archive L1 endpoint for the given primary L1 client, and then probes the
+// primary endpoint's pruning horizon, a failed probe is only logged, then all requests try the primary
+// endpoint first.
+func NewL1Archive(ctx context.Context, primary *ethclient.Client, endpoint string) (*L1Archive, error) {
+	var rawRPC *rpc.Client
+	if err := backoff.Retry(
+		func() (err error) {
+			rawRPC, err = rpc.DialContext(ctx, endpoint)
+			return err
+		},
+		backoff.NewExponentialBackOff(),
+	); err != nil {
+		return nil, err
+	}
+
+	archive := &L1Archive{primary: primary, client: ethclient.NewClient(rawRPC), gethClient: gethclient.New(rawRPC)}
+	if err := archive.probeHorizon(ctx); err != nil {
+		log.Warn("Failed to probe the L1 pruning horizon", "error", err)
+	}
+
+	return archive, nil
+}
+
+// Horizon returns the cached pruning horizon of the primary L1 endpoint.
+func (a *L1Archive) Horizon() uint64 {
+	if a == nil {
+		return 0
+	}
+
+	return atomic.LoadUint64(&a.horizon)
+}
+
+// probeHorizon finds the lowest block whose body the primary L1 endpoint still serves through a binary
+// search, assuming that the history is pruned from the genesis on.
+func (a *L1Archive) probeHorizon(ctx context.Context) error {
+	head, err := a.primary.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+
+	served := func(height uint64) (bool, error) {
+		_, err := a.primary.BlockByNumber(ctx, new(big.Int).SetUint64(height))
+		if err == nil {
+			return true, nil
+		}
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		// Unlike the pruned data errors, the connection errors abort the probe.
+		if isPrunedDataError(err) || err == ethereum.NotFound || strings.Contains(err.Error(), "empty transaction") {
+			return false, nil
+		}
+		return false, err
+	}
+
+	// Not pruned at all, which takes only one request.
+	ok, err := served(1)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return nil
+	}
+
+	lo, hi := uint64(1), head
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		ok, err := served(mid)
+		if err != nil {
+			return err
+		}
+		if ok {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+
+	atomic.StoreUint64(&a.horizon, lo)
+	metrics.RPCL1PruningHorizonGauge.Update(int64(lo))
+	log.Info("L1 pruning horizon probed, older data will be fetched from the archive endpoint", "horizon", lo)
+
+	return nil
+}
+
+// route calls the given request at the given height, nil means the latest one, with the primary L1 endpoint,
+// or the archive one if the height is below the pruning horizon, or the primary one returns a pruned data
+// error.
+func (a *L1Archive) route(height *big.Int, request func(archive bool) error) error {
+	if height != nil && height.Sign() >= 0 && height.Uint64() < a.Horizon() {
+		metrics.RPCL1ArchiveRequestsCounter.Inc(1)
+		return request(true)
+	}
+
+	err := request(false)
+	if !isPrunedDataError(err) {
+		return err
+	}
+
+	log.Debug("Primary L1 endpoint returned pruned data error, fall back to archive endpoint", "height", height)
+	metrics.RPCL1ArchiveRequestsCounter.Inc(1)
+	metrics.RPCL1ArchiveFallbackCounter.Inc(1)
+	return request(true)
+}
+
+// ethClient returns the archive or the primary L1 client.
+func (a *L1Archive) ethClient(archive bool) *ethclient.Client {
+	if archive {
+		return a.client
+	}
+
+	return a.primary
+}
+
+// l1ArchiveBackend is a contract backend which routes the historical log filtering and contract calls of
+// the protocol contract bindings through L1Archive.
+type l1ArchiveBackend struct {
+	*ethclient.Client
+	archive *L1Archive
+}
+
+// FilterLogs implements the ethereum.LogFilterer interface.
+func (b *l1ArchiveBackend) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	var logs []types.Log
+	err := b.archive.route(q.FromBlock, func(archive bool) (err error) {
+		logs, err = b.archive.ethClient(archive).FilterLogs(ctx, q)
+		return err
+	})
+
+	return logs, err
+}
+
+// CallContract implements the ethereum.ContractCaller interface.
+func (b *l1ArchiveBackend) CallContract(
+	ctx context.Context,
+	msg ethereum.CallMsg,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	var result []byte
+	err := b.archive.route(blockNumber, func(archive bool) (err error) {
+		result, err = b.archive.ethClient(archive).CallContract(ctx, msg, blockNumber)
+		return err
+	})
+
+	return result, err
+}
+
+// L1TransactionInBlock fetches the L1 transaction at the given index of the given L1 block, from the archive
+// L1 endpoint if the block is older than the primary one's pruning horizon.
+func (c *Client) L1TransactionInBlock(
+	ctx context.Context,
+	blockHash common.Hash,
+	blockNumber uint64,
+	index uint,
+) (*types.Transaction, error) {
+	if c.L1Archive == nil {
+		return c.L1.TransactionInBlock(ctx, blockHash, index)
+	}
+
+	var tx *types.Transaction
+	err := c.L1Archive.route(new(big.Int).SetUint64(blockNumber), func(archive bool) (err error) {
+		tx, err = c.L1Archive.ethClient(archive).TransactionInBlock(ctx, blockHash, index)
+		return err
+	})
+
+	return tx, err
+}
+
+// L1Backfill returns the L1 clients of the historical event backfill queries, e.g. the BlockProposed event
+// iterators starting far in the past. The queries are sent to the archive L1 endpoint exclusively if it is
+// configured, since the other L1 nodes limit eth_getLogs, or else to the L1 endpoint.
+func (c *Client) L1Backfill() (*ethclient.Client, *bindings.TaikoL1Client) {
+	if c.L1Archive == nil || c.L1Archive.taikoL1 == nil {
+		return c.L1, c.TaikoL1
+	}
+
+	return c.L1Archive.client, c.L1Archive.taikoL1
+}
@@ -0,0 +1,161 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+// testEthAPI is a fake eth namespace of a L1 node, which has pruned the blocks below the given horizon.
+type testEthAPI struct {
+	head    uint64
+	horizon uint64
+	result  hexutil.Bytes // Result of all eth_call requests
+	calls   int
+}
+
+func (api *testEthAPI) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(api.head)
+}
+
+func (api *testEthAPI) GetBlockByNumber(number rpc.BlockNumber, _ bool) (map[string]interface{}, error) {
+	if number < 0 || uint64(number) < api.horizon || uint64(number) > api.head {
+		return nil, nil
+	}
+
+	header := &types.Header{
+		Number:     big.NewInt(number.Int64()),
+		Difficulty: common.Big0,
+		UncleHash:  types.EmptyUncleHash,
+		TxHash:     types.EmptyRootHash,
+	}
+	data, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	var block map[string]interface{}
+	if err := json.Unmarshal(data, &block); err != nil {
+		return nil, err
+	}
+	block["transactions"] = []interface{}{}
+	block["uncles"] = []interface{}{}
+
+	return block, nil
+}
+
+func (api *testEthAPI) Call(_ map[string]interface{}, number rpc.BlockNumber) (hexutil.Bytes, error) {
+	api.calls++
+	if number >= 0 && uint64(number) < api.horizon {
+		return nil, errors.New("missing trie node 0x1234 (path )")
+	}
+
+	return api.result, nil
+}
+
+func newTestEthServer(t *testing.T, api *testEthAPI) *ethclient.Client {
+	server := rpc.NewServer()
+	require.Nil(t, server.RegisterName("eth", api))
+
+	httpServer := httptest.NewServer(server)
+
+	client, err := rpc.DialHTTP(httpServer.URL)
+	require.Nil(t, err)
+
+	t.Cleanup(func() {
+		client.Close()
+		httpServer.Close()
+		server.Stop()
+	})
+
+	return ethclient.NewClient(client)
+}
+
+func TestIsPrunedDataError(t *testing.T) {
+	require.False(t, isPrunedDataError(nil))
+	require.False(t, isPrunedDataError(errors.New("connection refused")))
+	require.True(t, isPrunedDataError(errors.New("missing trie node 0x1234 (path )")))
+	require.True(t, isPrunedDataError(errors.New("historical state 0x1234 is not available")))
+	require.True(t, isPrunedDataError(errors.New("Pruned history unavailable")))
+}
+
+func TestProbeHorizon(t *testing.T) {
+	for _, horizon := range []uint64{0, 1, 2, 77, 100} {
+		archive := &L1Archive{primary: newTestEthServer(t, &testEthAPI{head: 100, horizon: horizon})}
+		require.Nil(t, archive.probeHorizon(context.Background()))
+
+		if horizon <= 1 {
+			require.Zero(t, archive.Horizon())
+		} else {
+			require.Equal(t, horizon, archive.Horizon())
+		}
+	}
+
+	// A nil archive has no horizon.
+	require.Zero(t, (*L1Archive)(nil).Horizon())
+}
+
+func TestL1ArchiveRouting(t *testing.T) {
+	primaryAPI := &testEthAPI{head: 100, horizon: 50, result: hexutil.Bytes{0x01}}
+	archiveAPI := &testEthAPI{head: 100, result: hexutil.Bytes{0x02}}
+
+	archive := &L1Archive{
+		primary: newTestEthServer(t, primaryAPI),
+		client:  newTestEthServer(t, archiveAPI),
+	}
+	backend := &l1ArchiveBackend{Client: archive.primary, archive: archive}
+
+	call := func(height *big.Int) []byte {
+		result, err := backend.CallContract(context.Background(), ethereum.CallMsg{}, height)
+		require.Nil(t, err)
+		return result
+	}
+
+	// Without a probed horizon, the pruned requests fall back to the archive endpoint.
+	require.Equal(t, []byte{0x02}, call(big.NewInt(10)))
+	require.Equal(t, 1, primaryAPI.calls)
+	require.Equal(t, []byte{0x01}, call(big.NewInt(60)))
+	require.Equal(t, []byte{0x01}, call(nil))
+
+	// Once the horizon is probed, the older requests are sent to the archive endpoint directly.
+	require.Nil(t, archive.probeHorizon(context.Background()))
+	require.Equal(t, uint64(50), archive.Horizon())
+
+	primaryAPI.calls, archiveAPI.calls = 0, 0
+	require.Equal(t, []byte{0x02}, call(big.NewInt(10)))
+	require.Equal(t, []byte{0x01}, call(big.NewInt(50)))
+	require.Equal(t, 1, primaryAPI.calls)
+	require.Equal(t, 1, archiveAPI.calls)
+}
+
+func TestL1Backfill(t *testing.T) {
+	primary := newTestEthServer(t, &testEthAPI{head: 100})
+	taikoL1, err := bindings.NewTaikoL1Client(common.HexToAddress("0x01"), primary)
+	require.Nil(t, err)
+
+	// Without an archive endpoint, the backfill queries are sent to the L1 endpoint.
+	client := &Client{L1: primary, TaikoL1: taikoL1}
+	l1, l1TaikoL1 := client.L1Backfill()
+	require.Same(t, primary, l1)
+	require.Same(t, taikoL1, l1TaikoL1)
+
+	archive := newTestEthServer(t, &testEthAPI{head: 100})
+	archiveTaikoL1, err := bindings.NewTaikoL1Client(common.HexToAddress("0x01"), archive)
+	require.Nil(t, err)
+
+	client.L1Archive = &L1Archive{primary: primary, client: archive, taikoL1: archiveTaikoL1}
+	l1, l1TaikoL1 = client.L1Backfill()
+	require.Same(t, archive, l1)
+	require.Same(t, archiveTaikoL1, l1TaikoL1)
+}
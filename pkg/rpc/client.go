@@ -2,11 +2,14 @@ package rpc
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/taikoxyz/taiko-client/bindings"
 )
@@ -23,6 +26,8 @@ type Client struct {
 	// Geth raw RPC clients
 	L1RawRPC *rpc.Client
 	L2RawRPC *rpc.Client
+	// L1 archive endpoint for the data pruned by L1 endpoint, nil if not configured
+	L1Archive *L1Archive
 	// Geth Engine API clients
 	L2Engine *EngineClient
 	// Protocol contracts clients
@@ -44,6 +49,10 @@ type ClientConfig struct {
 	TaikoL2Address   common.Address
 	L2EngineEndpoint string
 	JwtSecret        string
+	TaikoL1ABIPath   string // Replaces the compiled-in TaikoL1 ABI if set
+	TaikoL2ABIPath   string // Replaces the compiled-in TaikoL2 ABI if set
+	// L1 archive endpoint for the data older than L1 endpoint's pruning horizon, optional
+	L1ArchiveEndpoint string
 }
 
 // NewClient initializes all RPC clients used by Taiko client softwares.
@@ -53,17 +62,33 @@ func NewClient(ctx context.Context, cfg *ClientConfig) (*Client, error) {
 		return nil, err
 	}
 
-	taikoL1, err := bindings.NewTaikoL1Client(cfg.TaikoL1Address, l1RPC)
+	var (
+		l1Archive *L1Archive
+		l1Backend bind.ContractBackend = l1RPC
+	)
+	if len(cfg.L1ArchiveEndpoint) != 0 {
+		if l1Archive, err = NewL1Archive(ctx, l1RPC, cfg.L1ArchiveEndpoint); err != nil {
+			return nil, err
+		}
+		l1Backend = &l1ArchiveBackend{Client: l1RPC, archive: l1Archive}
+	}
+
+	taikoL1, err := newTaikoL1Client(cfg, l1Backend)
 	if err != nil {
 		return nil, err
 	}
+	if l1Archive != nil {
+		if l1Archive.taikoL1, err = newTaikoL1Client(cfg, l1Archive.client); err != nil {
+			return nil, err
+		}
+	}
 
 	l2RPC, err := DialClientWithBackoff(ctx, cfg.L2Endpoint)
 	if err != nil {
 		return nil, err
 	}
 
-	taikoL2, err := bindings.NewTaikoL2Client(cfg.TaikoL2Address, l2RPC)
+	taikoL2, err := newTaikoL2Client(cfg, l2RPC)
 	if err != nil {
 		return nil, err
 	}
@@ -110,6 +135,7 @@ func NewClient(ctx context.Context, cfg *ClientConfig) (*Client, error) {
 		L2CheckPoint: l2CheckPoint,
 		L1RawRPC:     l1RawRPC,
 		L2RawRPC:     l2RawRPC,
+		L1Archive:    l1Archive,
 		L1GethClient: gethclient.New(l1RawRPC),
 		L2GethClient: gethclient.New(l2RawRPC),
 		L2Engine:     l2AuthRPC,
@@ -125,3 +151,35 @@ func NewClient(ctx context.Context, cfg *ClientConfig) (*Client, error) {
 
 	return client, nil
 }
+
+// newTaikoL1Client creates a new TaikoL1 contract client, with the ABI loaded from the configured ABI
+// file if any, for the non-standard deployments with additional events or methods.
+func newTaikoL1Client(cfg *ClientConfig, backend bind.ContractBackend) (*bindings.TaikoL1Client, error) {
+	if len(cfg.TaikoL1ABIPath) == 0 {
+		return bindings.NewTaikoL1Client(cfg.TaikoL1Address, backend)
+	}
+
+	parsed, err := bindings.LoadABI(cfg.TaikoL1ABIPath, bindings.TaikoL1ClientMetaData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TaikoL1 ABI: %w", err)
+	}
+	log.Info("Custom TaikoL1 ABI loaded", "path", cfg.TaikoL1ABIPath)
+
+	return bindings.NewTaikoL1ClientWithABI(cfg.TaikoL1Address, backend, parsed), nil
+}
+
+// newTaikoL2Client creates a new TaikoL2 contract client, with the ABI loaded from the configured ABI
+// file if any, for the non-standard deployments with additional events or methods.
+func newTaikoL2Client(cfg *ClientConfig, backend bind.ContractBackend) (*bindings.TaikoL2Client, error) {
+	if len(cfg.TaikoL2ABIPath) == 0 {
+		return bindings.NewTaikoL2Client(cfg.TaikoL2Address, backend)
+	}
+
+	parsed, err := bindings.LoadABI(cfg.TaikoL2ABIPath, bindings.TaikoL2ClientMetaData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TaikoL2 ABI: %w", err)
+	}
+	log.Info("Custom TaikoL2 ABI loaded", "path", cfg.TaikoL2ABIPath)
+
+	return bindings.NewTaikoL2ClientWithABI(cfg.TaikoL2Address, backend, parsed), nil
+}
@@ -8,8 +8,6 @@ import (
 	"github.com/cenkalti/backoff/v4"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
-	"github.com/prysmaticlabs/prysm/v4/network"
-	"github.com/prysmaticlabs/prysm/v4/network/authorization"
 )
 
 // DialClientWithBackoff connects a ethereum RPC client at the given URL with
@@ -35,12 +33,13 @@ func DialEngineClientWithBackoff(ctx context.Context, url string, jwtSecret stri
 	var engineClient *EngineClient
 	if err := backoff.Retry(
 		func() (err error) {
-			client, err := DialEngineClient(ctx, url, jwtSecret)
+			auth := NewJWTAuth([]byte(jwtSecret))
+			client, err := DialEngineClient(ctx, url, auth)
 			if err != nil {
 				return err
 			}
 
-			engineClient = &EngineClient{client}
+			engineClient = &EngineClient{Client: client, auth: auth}
 			return nil
 		},
 		backoff.NewExponentialBackOff(),
@@ -51,43 +50,29 @@ func DialEngineClientWithBackoff(ctx context.Context, url string, jwtSecret stri
 	return engineClient, nil
 }
 
-// DialEngineClient initializes an RPC connection with authentication headers.
-// Taken from https://github.com/prysmaticlabs/prysm/blob/v2.1.4/beacon-chain/execution/rpc_connection.go#L151
-func DialEngineClient(ctx context.Context, endpointUrl string, jwtSecret string) (*rpc.Client, error) {
-	endpoint := network.Endpoint{
-		Url: endpointUrl,
-		Auth: network.AuthorizationData{
-			Method: authorization.Bearer,
-			Value:  jwtSecret,
-		},
-	}
-
+// DialEngineClient initializes an RPC connection authenticated by the given JWTAuth, whose secret can be
+// rotated later.
+// Based on https://github.com/prysmaticlabs/prysm/blob/v2.1.4/beacon-chain/execution/rpc_connection.go#L151
+func DialEngineClient(ctx context.Context, endpointUrl string, auth *JWTAuth) (*rpc.Client, error) {
 	// Need to handle ipc and http
 	var client *rpc.Client
-	u, err := url.Parse(endpoint.Url)
+	u, err := url.Parse(endpointUrl)
 	if err != nil {
 		return nil, err
 	}
 	switch u.Scheme {
 	case "http", "https":
-		client, err = rpc.DialOptions(ctx, endpoint.Url, rpc.WithHTTPClient(endpoint.HttpClient()))
+		client, err = rpc.DialOptions(ctx, endpointUrl, rpc.WithHTTPAuth(auth.Header))
 		if err != nil {
 			return nil, err
 		}
 	case "":
-		client, err = rpc.DialIPC(ctx, endpoint.Url)
+		client, err = rpc.DialIPC(ctx, endpointUrl)
 		if err != nil {
 			return nil, err
 		}
 	default:
 		return nil, fmt.Errorf("no known transport for URL scheme %q", u.Scheme)
 	}
-	if endpoint.Auth.Method != authorization.None {
-		header, err := endpoint.Auth.ToHeaderValue()
-		if err != nil {
-			return nil, err
-		}
-		client.SetHeader("Authorization", header)
-	}
 	return client, nil
 }
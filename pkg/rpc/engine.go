@@ -2,17 +2,101 @@ package rpc
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
+// All supported Engine API versions.
+const (
+	EngineAPIV1 = "V1"
+	EngineAPIV2 = "V2" // Post-Shanghai, with the withdrawals fields
+)
+
+var (
+	// engineAPIV2Methods are the methods the L2 execution engine must support to use the V2 Engine APIs.
+	engineAPIV2Methods = []string{"engine_forkchoiceUpdatedV2", "engine_newPayloadV2", "engine_getPayloadV2"}
+	// Error codes of the JSON-RPC errors, ref: https://www.jsonrpc.org/specification#error_object
+	methodNotFoundErrorCode = -32601
+	invalidParamsErrorCode  = -32602
+)
+
 // EngineClient represents a RPC client connecting to an Ethereum Engine API
 // endpoint.
 // ref: https://github.com/ethereum/execution-apis/blob/main/src/engine/specification.md
 type EngineClient struct {
 	*rpc.Client
+	auth *JWTAuth // Nil if not dialed by DialEngineClientWithBackoff
+
+	mutex   sync.Mutex
+	version string // Negotiated by NegotiateVersion, V1 if not negotiated
+	// Whether the payload attributes sent through the V2 APIs carry a withdrawals list, which is required
+	// after Shanghai and rejected before, switched once the execution engine rejects the attributes.
+	withdrawals bool
+}
+
+// NegotiateVersion exchanges the capabilities with the L2 execution engine, and chooses the V2 Engine APIs
+// if they are all supported, otherwise V1. An execution engine which doesn't support the capabilities
+// exchange only supports V1.
+func (c *EngineClient) NegotiateVersion(ctx context.Context) (string, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	version := EngineAPIV2
+	var capabilities []string
+	if err := c.Client.CallContext(
+		timeoutCtx, &capabilities, "engine_exchangeCapabilities", engineAPIV2Methods,
+	); err != nil {
+		if !isRPCError(err, methodNotFoundErrorCode) {
+			return "", fmt.Errorf("failed to exchange Engine API capabilities: %w", err)
+		}
+		version = EngineAPIV1
+	} else {
+		supported := make(map[string]bool, len(capabilities))
+		for _, method := range capabilities {
+			supported[method] = true
+		}
+		for _, method := range engineAPIV2Methods {
+			if !supported[method] {
+				version = EngineAPIV1
+				break
+			}
+		}
+	}
+
+	c.mutex.Lock()
+	c.version = version
+	c.mutex.Unlock()
+
+	return version, nil
+}
+
+// SetJWTSecret replaces the JWT secret authenticating the following requests, reports whether the secret
+// is changed.
+func (c *EngineClient) SetJWTSecret(secret []byte) bool {
+	if c.auth == nil {
+		return false
+	}
+
+	return c.auth.SetSecret(secret)
+}
+
+// Version returns the negotiated Engine API version.
+func (c *EngineClient) Version() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if len(c.version) == 0 {
+		return EngineAPIV1
+	}
+
+	return c.version
 }
 
 // ForkchoiceUpdate updates the forkchoice on the execution client.
@@ -24,8 +108,55 @@ func (c *EngineClient) ForkchoiceUpdate(
 	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
+	if c.Version() == EngineAPIV1 || attributes == nil {
+		var result *engine.ForkChoiceResponse
+		if err := c.Client.CallContext(
+			timeoutCtx, &result, "engine_forkchoiceUpdated"+c.Version(), fc, attributes,
+		); err != nil {
+			return nil, err
+		}
+
+		return result, nil
+	}
+
+	// The withdrawals list depends on whether the new block is after Shanghai, which is only known by the
+	// execution engine, so try the other one once the attributes are rejected.
+	c.mutex.Lock()
+	withdrawals := c.withdrawals
+	c.mutex.Unlock()
+
+	result, err := c.forkchoiceUpdatedV2(timeoutCtx, fc, attributes, withdrawals)
+	if err != nil && isRPCError(err, invalidParamsErrorCode) {
+		if result, err = c.forkchoiceUpdatedV2(timeoutCtx, fc, attributes, !withdrawals); err == nil {
+			log.Info("Switch the withdrawals of the payload attributes", "withdrawals", !withdrawals)
+			c.mutex.Lock()
+			c.withdrawals = !withdrawals
+			c.mutex.Unlock()
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// forkchoiceUpdatedV2 calls engine_forkchoiceUpdatedV2 with a copy of the given payload attributes, which
+// carries an empty withdrawals list if required, since there are no withdrawals in L2.
+func (c *EngineClient) forkchoiceUpdatedV2(
+	ctx context.Context,
+	fc *engine.ForkchoiceStateV1,
+	attributes *engine.PayloadAttributes,
+	withdrawals bool,
+) (*engine.ForkChoiceResponse, error) {
+	attributesV2 := *attributes
+	attributesV2.Withdrawals = nil
+	if withdrawals {
+		attributesV2.Withdrawals = []*types.Withdrawal{}
+	}
+
 	var result *engine.ForkChoiceResponse
-	if err := c.Client.CallContext(timeoutCtx, &result, "engine_forkchoiceUpdatedV1", fc, attributes); err != nil {
+	if err := c.Client.CallContext(ctx, &result, "engine_forkchoiceUpdatedV2", fc, &attributesV2); err != nil {
 		return nil, err
 	}
 
@@ -41,7 +172,7 @@ func (c *EngineClient) NewPayload(
 	defer cancel()
 
 	var result *engine.PayloadStatusV1
-	if err := c.Client.CallContext(timeoutCtx, &result, "engine_newPayloadV1", payload); err != nil {
+	if err := c.Client.CallContext(timeoutCtx, &result, "engine_newPayload"+c.Version(), payload); err != nil {
 		return nil, err
 	}
 
@@ -56,10 +187,25 @@ func (c *EngineClient) GetPayload(
 	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	var result *engine.ExecutableData
-	if err := c.Client.CallContext(timeoutCtx, &result, "engine_getPayloadV1", payloadID); err != nil {
+	if c.Version() == EngineAPIV1 {
+		var result *engine.ExecutableData
+		if err := c.Client.CallContext(timeoutCtx, &result, "engine_getPayloadV1", payloadID); err != nil {
+			return nil, err
+		}
+
+		return result, nil
+	}
+
+	var result *engine.ExecutionPayloadEnvelope
+	if err := c.Client.CallContext(timeoutCtx, &result, "engine_getPayloadV2", payloadID); err != nil {
 		return nil, err
 	}
 
-	return result, nil
+	return result.ExecutionPayload, nil
+}
+
+// isRPCError checks whether the given error is a JSON-RPC error with the given code.
+func isRPCError(err error, code int) bool {
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr) && rpcErr.ErrorCode() == code
 }
@@ -5,7 +5,12 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
 )
 
 func TestL2EngineBorbidden(t *testing.T) {
@@ -30,3 +35,113 @@ func TestL2EngineBorbidden(t *testing.T) {
 	)
 	require.ErrorContains(t, err, "Unauthorized")
 }
+
+// testEngineAPIV1 is a stub of a L2 execution engine only supporting the V1 Engine APIs.
+type testEngineAPIV1 struct{}
+
+func (api *testEngineAPIV1) ForkchoiceUpdatedV1(
+	fc engine.ForkchoiceStateV1,
+	attributes *engine.PayloadAttributes,
+) (*engine.ForkChoiceResponse, error) {
+	return &engine.ForkChoiceResponse{PayloadStatus: engine.PayloadStatusV1{Status: engine.VALID}}, nil
+}
+
+// testEngineAPIV2 is a stub of a L2 execution engine supporting the V2 Engine APIs.
+type testEngineAPIV2 struct {
+	caps        []string
+	shanghai    bool
+	withdrawals []bool // Whether the withdrawals list is set in each received payload attributes
+}
+
+func (api *testEngineAPIV2) ExchangeCapabilities(caps []string) []string {
+	return api.caps
+}
+
+func (api *testEngineAPIV2) ForkchoiceUpdatedV2(
+	fc engine.ForkchoiceStateV1,
+	attributes *engine.PayloadAttributes,
+) (*engine.ForkChoiceResponse, error) {
+	if attributes != nil {
+		api.withdrawals = append(api.withdrawals, attributes.Withdrawals != nil)
+		if (attributes.Withdrawals != nil) != api.shanghai {
+			return nil, engine.InvalidParams
+		}
+	}
+
+	return &engine.ForkChoiceResponse{PayloadStatus: engine.PayloadStatusV1{Status: engine.VALID}}, nil
+}
+
+func (api *testEngineAPIV2) GetPayloadV2(payloadID engine.PayloadID) (*engine.ExecutionPayloadEnvelope, error) {
+	payload := encoding.ToExecutableDataV1(&types.Header{Number: common.Big1, BaseFee: common.Big0})
+	payload.Transactions = [][]byte{}
+
+	return &engine.ExecutionPayloadEnvelope{ExecutionPayload: payload, BlockValue: common.Big0}, nil
+}
+
+func newTestEngineClient(t *testing.T, api interface{}) *EngineClient {
+	server := rpc.NewServer()
+	require.Nil(t, server.RegisterName("engine", api))
+	t.Cleanup(server.Stop)
+
+	return &EngineClient{Client: rpc.DialInProc(server)}
+}
+
+func TestNegotiateVersion(t *testing.T) {
+	c := newTestEngineClient(t, &testEngineAPIV1{})
+	require.Equal(t, EngineAPIV1, c.Version())
+
+	// The capabilities exchange is not supported.
+	version, err := c.NegotiateVersion(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, EngineAPIV1, version)
+
+	// Not all the V2 APIs are supported.
+	c = newTestEngineClient(t, &testEngineAPIV2{caps: engineAPIV2Methods[:1]})
+	version, err = c.NegotiateVersion(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, EngineAPIV1, version)
+
+	c = newTestEngineClient(t, &testEngineAPIV2{caps: append([]string{"engine_newPayloadV1"}, engineAPIV2Methods...)})
+	version, err = c.NegotiateVersion(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, EngineAPIV2, version)
+	require.Equal(t, EngineAPIV2, c.Version())
+
+	payload, err := c.GetPayload(context.Background(), &engine.PayloadID{})
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), payload.Number)
+}
+
+func TestNegotiateVersionError(t *testing.T) {
+	c := newTestEngineClient(t, &testEngineAPIV1{})
+	c.Client.Close()
+
+	_, err := c.NegotiateVersion(context.Background())
+	require.ErrorContains(t, err, "failed to exchange Engine API capabilities")
+}
+
+func TestForkchoiceUpdateV2Withdrawals(t *testing.T) {
+	api := &testEngineAPIV2{caps: engineAPIV2Methods, shanghai: true}
+	c := newTestEngineClient(t, api)
+	_, err := c.NegotiateVersion(context.Background())
+	require.Nil(t, err)
+
+	// The withdrawals list is switched once the attributes are rejected, and then remembered.
+	attributes := &engine.PayloadAttributes{
+		BaseFeePerGas: common.Big0,
+		BlockMetadata: &engine.BlockMetadata{HighestBlockID: common.Big1},
+		L1Origin:      &rawdb.L1Origin{BlockID: common.Big1, L1BlockHeight: common.Big1},
+	}
+	for i := 0; i < 2; i++ {
+		res, err := c.ForkchoiceUpdate(context.Background(), &engine.ForkchoiceStateV1{}, attributes)
+		require.Nil(t, err)
+		require.Equal(t, engine.VALID, res.PayloadStatus.Status)
+	}
+	require.Equal(t, []bool{false, true, true}, api.withdrawals)
+	require.Nil(t, attributes.Withdrawals)
+
+	// No payload attributes.
+	_, err = c.ForkchoiceUpdate(context.Background(), &engine.ForkchoiceStateV1{}, nil)
+	require.Nil(t, err)
+	require.Len(t, api.withdrawals, 3)
+}
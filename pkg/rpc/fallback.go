@@ -4,6 +4,8 @@ import (
 	"errors"
 	"math/big"
 	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 )
 
 // Taken from https://github.com/ethereum-optimism/optimism/blob/develop/bss-core/drivers/max_priority_fee_fallback.go
@@ -26,3 +28,11 @@ func IsMaxPriorityFeePerGasNotFoundError(err error) bool {
 		err.Error(), errMaxPriorityFeePerGasNotFound.Error(),
 	)
 }
+
+// IsMethodNotFoundError returns true if the provided error of a contract view call
+// signals that there is no contract code or the called method is not found, any revert,
+// even a bare one, is not such an error, since it can't be told apart from a genuine failure.
+func IsMethodNotFoundError(err error) bool {
+	return errors.Is(err, bind.ErrNoCode) ||
+		strings.Contains(strings.ToLower(err.Error()), "method not found")
+}
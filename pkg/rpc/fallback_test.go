@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/stretchr/testify/require"
 )
 
@@ -11,3 +12,13 @@ func TestIsMaxPriorityFeePerGasNotFoundError(t *testing.T) {
 	require.False(t, IsMaxPriorityFeePerGasNotFoundError(errors.New("test")))
 	require.True(t, IsMaxPriorityFeePerGasNotFoundError(errMaxPriorityFeePerGasNotFound))
 }
+
+func TestIsMethodNotFoundError(t *testing.T) {
+	require.False(t, IsMethodNotFoundError(errors.New("test")))
+	require.False(t, IsMethodNotFoundError(errors.New("execution reverted: L1_TOO_MANY_BLOCKS")))
+	require.False(t, IsMethodNotFoundError(errors.New("execution reverted")))
+	require.True(t, IsMethodNotFoundError(
+		errors.New("the method eth_call does not exist/is not available: method not found"),
+	))
+	require.True(t, IsMethodNotFoundError(bind.ErrNoCode))
+}
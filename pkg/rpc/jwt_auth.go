@@ -0,0 +1,53 @@
+package rpc
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// JWTAuth authenticates the Engine API requests, with a new JWT signed by the current secret for each
+// request, so that the secret can be rotated without reconnecting.
+// ref: https://github.com/ethereum/execution-apis/blob/main/src/engine/authentication.md
+type JWTAuth struct {
+	mutex  sync.RWMutex
+	secret []byte
+}
+
+// NewJWTAuth creates a new JWTAuth instance with the given secret.
+func NewJWTAuth(secret []byte) *JWTAuth {
+	return &JWTAuth{secret: secret}
+}
+
+// SetSecret replaces the secret used by the following requests, reports whether the secret is changed.
+func (a *JWTAuth) SetSecret(secret []byte) bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if bytes.Equal(a.secret, secret) {
+		return false
+	}
+	a.secret = secret
+
+	return true
+}
+
+// Header sets the authorization header of a request, it implements the rpc.HTTPAuth function type.
+func (a *JWTAuth) Header(h http.Header) error {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	// The "iat" (issued at) claim is required, and must be within +-60 seconds from the server's time.
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"iat": time.Now().Unix()})
+	signed, err := token.SignedString(a.secret)
+	if err != nil {
+		return fmt.Errorf("failed to sign JWT: %w", err)
+	}
+	h.Set("Authorization", "Bearer "+signed)
+
+	return nil
+}
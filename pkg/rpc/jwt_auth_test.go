@@ -0,0 +1,77 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestJWTServer starts a JSON-RPC server only accepting the requests authenticated by the current secret.
+func newTestJWTServer(t *testing.T, secret *[]byte, mutex *sync.Mutex) *httptest.Server {
+	server := rpc.NewServer()
+	require.Nil(t, server.RegisterName("engine", &testEngineAPIV1{}))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		key := *secret
+		mutex.Unlock()
+
+		_, err := jwt.Parse(
+			strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "),
+			func(token *jwt.Token) (interface{}, error) { return key, nil },
+		)
+		if err != nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		server.ServeHTTP(w, r)
+	}))
+	t.Cleanup(func() {
+		srv.Close()
+		server.Stop()
+	})
+
+	return srv
+}
+
+func TestJWTAuthRotation(t *testing.T) {
+	var (
+		mutex     sync.Mutex
+		oldSecret = bytes.Repeat([]byte{1}, 32)
+		newSecret = bytes.Repeat([]byte{2}, 32)
+		secret    = oldSecret
+	)
+	srv := newTestJWTServer(t, &secret, &mutex)
+
+	c, err := DialEngineClientWithBackoff(context.Background(), srv.URL, string(oldSecret))
+	require.Nil(t, err)
+	defer c.Close()
+
+	_, err = c.ForkchoiceUpdate(context.Background(), &engine.ForkchoiceStateV1{}, nil)
+	require.Nil(t, err)
+
+	// The secret is rotated by the server.
+	mutex.Lock()
+	secret = newSecret
+	mutex.Unlock()
+	_, err = c.ForkchoiceUpdate(context.Background(), &engine.ForkchoiceStateV1{}, nil)
+	require.ErrorContains(t, err, "Unauthorized")
+
+	require.True(t, c.SetJWTSecret(newSecret))
+	require.False(t, c.SetJWTSecret(newSecret))
+	_, err = c.ForkchoiceUpdate(context.Background(), &engine.ForkchoiceStateV1{}, nil)
+	require.Nil(t, err)
+}
+
+func TestSetJWTSecretWithoutAuth(t *testing.T) {
+	require.False(t, (&EngineClient{}).SetJWTSecret([]byte{1}))
+}
@@ -14,7 +14,10 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/taikoxyz/taiko-client/bindings"
 	"golang.org/x/sync/errgroup"
 )
@@ -25,8 +28,20 @@ var (
 	// syncProgressRecheckDelay is the time delay of rechecking the L2 execution engine's sync progress again,
 	// if the previous check failed.
 	syncProgressRecheckDelay = 12 * time.Second
+	// l1OriginBatchSize is the maximum number of `taiko_l1OriginByID` calls in one JSON-RPC batch request.
+	l1OriginBatchSize = 100
+	// waitL1OriginPollInterval is the interval to poll the L1Origin in WaitL1Origin.
+	waitL1OriginPollInterval = time.Second
+	// ErrWaitL1OriginTimeout is returned by WaitL1OriginWithTimeout when the L1Origin doesn't appear within
+	// the given timeout, while the caller's context is still alive.
+	ErrWaitL1OriginTimeout = errors.New("timeout waiting for L1Origin")
 )
 
+// batchCaller is a JSON-RPC client which supports batch requests.
+type batchCaller interface {
+	BatchCallContext(ctx context.Context, b []rpc.BatchElem) error
+}
+
 // ensureGenesisMatched fetches the L2 genesis block from TaikoL1 contract,
 // and checks whether the fetched genesis is same to the node local genesis.
 func (c *Client) ensureGenesisMatched(ctx context.Context) error {
@@ -138,6 +153,11 @@ func (c *Client) GetGenesisL1Header(ctx context.Context) (*types.Header, error)
 	return c.L1.HeaderByNumber(ctx, new(big.Int).SetUint64(stateVars.GenesisHeight))
 }
 
+// L1FinalizedHeader fetches the latest finalized L1 block header.
+func (c *Client) L1FinalizedHeader(ctx context.Context) (*types.Header, error) {
+	return c.L1.HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+}
+
 // L2ParentByBlockId fetches the block header from L2 execution engine with the largest block id that
 // smaller than the given `blockId`.
 func (c *Client) L2ParentByBlockId(ctx context.Context, blockID *big.Int) (*types.Header, error) {
@@ -161,22 +181,42 @@ func (c *Client) L2ParentByBlockId(ctx context.Context, blockID *big.Int) (*type
 
 // WaitL1Origin keeps waiting until the L1Origin with given block ID appears on the L2 execution engine.
 func (c *Client) WaitL1Origin(ctx context.Context, blockID *big.Int) (*rawdb.L1Origin, error) {
+	return c.WaitL1OriginWithTimeout(ctx, blockID, 0)
+}
+
+// WaitL1OriginWithTimeout keeps waiting until the L1Origin with given block ID appears on the L2 execution
+// engine, at most the given non-zero timeout, then ErrWaitL1OriginTimeout is returned, without cancelling
+// the given context. The given context's own cancellation or deadline is returned as is.
+func (c *Client) WaitL1OriginWithTimeout(
+	ctx context.Context,
+	blockID *big.Int,
+	timeout time.Duration,
+) (*rawdb.L1Origin, error) {
 	var (
-		l1Origin *rawdb.L1Origin
-		err      error
+		l1Origin   *rawdb.L1Origin
+		err        error
+		waitCtx    = ctx
+		cancelWait = func() {}
 	)
+	if timeout != 0 {
+		waitCtx, cancelWait = context.WithTimeout(ctx, timeout)
+	}
+	defer cancelWait()
 
-	ticker := time.NewTicker(time.Second)
+	ticker := time.NewTicker(waitL1OriginPollInterval)
 	defer ticker.Stop()
 
-	log.Debug("Start fetching L1Origin from L2 execution engine", "blockID", blockID)
+	log.Debug("Start fetching L1Origin from L2 execution engine", "blockID", blockID, "timeout", timeout)
 
 	for {
 		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
+		case <-waitCtx.Done():
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, fmt.Errorf("%w %d after %s", ErrWaitL1OriginTimeout, blockID, timeout)
 		case <-ticker.C:
-			l1Origin, err = c.L2.L1OriginByID(ctx, blockID)
+			l1Origin, err = c.L2.L1OriginByID(waitCtx, blockID)
 			if err != nil {
 				log.Warn("Failed to fetch L1Origin from L2 execution engine", "blockID", blockID, "error", err)
 				continue
@@ -191,6 +231,50 @@ func (c *Client) WaitL1Origin(ctx context.Context, blockID *big.Int) (*rawdb.L1O
 	}
 }
 
+// BatchGetL1OriginByIDs fetches the L1Origins of the given L2 blocks in JSON-RPC batch requests, the
+// result has the same order as the given IDs, with nil for the blocks whose L1Origin doesn't exist yet.
+func (c *Client) BatchGetL1OriginByIDs(ctx context.Context, ids []*big.Int) ([]*rawdb.L1Origin, error) {
+	return batchGetL1OriginByIDs(ctx, c.L2RawRPC, ids)
+}
+
+// batchGetL1OriginByIDs fetches the L1Origins of the given L2 blocks through the given batch caller.
+func batchGetL1OriginByIDs(ctx context.Context, caller batchCaller, ids []*big.Int) ([]*rawdb.L1Origin, error) {
+	l1Origins := make([]*rawdb.L1Origin, len(ids))
+
+	for start := 0; start < len(ids); start += l1OriginBatchSize {
+		end := start + l1OriginBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		batch := make([]rpc.BatchElem, 0, end-start)
+		for i := start; i < end; i++ {
+			batch = append(batch, rpc.BatchElem{
+				Method: "taiko_l1OriginByID",
+				Args:   []interface{}{hexutil.EncodeBig(ids[i])},
+				Result: &l1Origins[i],
+			})
+		}
+
+		if err := caller.BatchCallContext(ctx, batch); err != nil {
+			return nil, fmt.Errorf("failed to batch call taiko_l1OriginByID: %w", err)
+		}
+
+		for i, elem := range batch {
+			if elem.Error == nil {
+				continue
+			}
+			if elem.Error.Error() == ethereum.NotFound.Error() {
+				l1Origins[start+i] = nil
+				continue
+			}
+			return nil, fmt.Errorf("failed to get L1Origin of block %d: %w", ids[start+i], elem.Error)
+		}
+	}
+
+	return l1Origins, nil
+}
+
 // GetPoolContent fetches the transactions list from L2 execution engine's transactions pool with given
 // upper limit.
 func (c *Client) GetPoolContent(
@@ -294,15 +378,63 @@ func (c *Client) GetProtocolStateVariables(opts *bind.CallOpts) (*bindings.Taiko
 
 // GetStorageRoot returns a contract's storage root at the given height.
 func (c *Client) GetStorageRoot(ctx context.Context, contract common.Address, height *big.Int) (common.Hash, error) {
-	proof, err := c.L1GethClient.GetProof(
-		ctx,
-		contract,
-		[]string{"0x0000000000000000000000000000000000000000000000000000000000000000"},
-		height,
+	var (
+		proof *gethclient.AccountResult
+		keys  = []string{"0x0000000000000000000000000000000000000000000000000000000000000000"}
+		err   error
 	)
+	if c.L1Archive == nil {
+		proof, err = c.L1GethClient.GetProof(ctx, contract, keys, height)
+	} else {
+		err = c.L1Archive.route(height, func(archive bool) (err error) {
+			gethClient := c.L1GethClient
+			if archive {
+				gethClient = c.L1Archive.gethClient
+			}
+			proof, err = gethClient.GetProof(ctx, contract, keys, height)
+			return err
+		})
+	}
 	if err != nil {
 		return common.Hash{}, err
 	}
 
 	return proof.StorageHash, nil
 }
+
+// IsProtocolPaused checks whether the given TaikoL1 contract has been paused, if there is no contract code or
+// the `paused()` method is not found, it will be treated as not paused, while a revert is returned as an error.
+func (c *Client) IsProtocolPaused(ctx context.Context, taikoL1Address common.Address) (bool, error) {
+	res, err := c.L1.CallContract(ctx, ethereum.CallMsg{
+		To:   &taikoL1Address,
+		Data: crypto.Keccak256([]byte("paused()"))[:4],
+	}, nil)
+	if err != nil {
+		if IsMethodNotFoundError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if len(res) == 0 {
+		return false, nil
+	}
+
+	return new(big.Int).SetBytes(res).Sign() != 0, nil
+}
+
+// IsProposerAllowed checks whether the given address is currently permitted to propose blocks, when
+// the protocol has a solo proposer configured, only that address is permitted. If there is no contract code
+// or the resolving method is not found, the given address will be treated as permitted, while a revert is
+// returned as an error.
+func (c *Client) IsProposerAllowed(ctx context.Context, proposer common.Address) (bool, error) {
+	soloProposer, err := c.TaikoL1.Resolve(&bind.CallOpts{Context: ctx}, "solo_proposer", true)
+	if err != nil {
+		if IsMethodNotFoundError(err) {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return soloProposer == (common.Address{}) || soloProposer == proposer, nil
+}
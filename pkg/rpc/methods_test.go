@@ -2,9 +2,21 @@ package rpc
 
 import (
 	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"testing"
+	"time"
 
+	ethereum "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
 )
 
@@ -63,3 +75,153 @@ func TestGetProtocolStateVariables(t *testing.T) {
 	_, err := client.GetProtocolStateVariables(nil)
 	require.Nil(t, err)
 }
+
+func TestIsProtocolPaused(t *testing.T) {
+	client := newTestClient(t)
+
+	paused, err := client.IsProtocolPaused(context.Background(), common.HexToAddress(os.Getenv("TAIKO_L1_ADDRESS")))
+
+	require.Nil(t, err)
+	require.False(t, paused)
+}
+
+func TestIsProposerAllowed(t *testing.T) {
+	client := newTestClient(t)
+
+	l1ProposerPrivKey, err := crypto.ToECDSA(common.Hex2Bytes(os.Getenv("L1_PROPOSER_PRIVATE_KEY")))
+	require.Nil(t, err)
+
+	allowed, err := client.IsProposerAllowed(context.Background(), crypto.PubkeyToAddress(l1ProposerPrivKey.PublicKey))
+
+	require.Nil(t, err)
+	require.True(t, allowed)
+}
+
+// testTaikoAPI serves the L1Origins of the L2 blocks with IDs in [1, numBlocks].
+type testTaikoAPI struct{ numBlocks uint64 }
+
+func (api *testTaikoAPI) L1OriginByID(blockID *math.HexOrDecimal256) (*rawdb.L1Origin, error) {
+	id := (*big.Int)(blockID)
+	if id.Sign() == 0 || id.Uint64() > api.numBlocks {
+		return nil, ethereum.NotFound
+	}
+
+	return &rawdb.L1Origin{
+		BlockID:       id,
+		L2BlockHash:   common.BigToHash(id),
+		L1BlockHeight: new(big.Int).Add(id, common.Big1),
+		L1BlockHash:   common.BigToHash(new(big.Int).Add(id, common.Big1)),
+	}, nil
+}
+
+// newTestL1OriginServer starts a JSON-RPC server serving `taiko_l1OriginByID`, every HTTP request
+// is delayed by the given latency to simulate the network round trip.
+func newTestL1OriginServer(t testing.TB, numBlocks uint64, latency time.Duration) *rpc.Client {
+	server := rpc.NewServer()
+	require.Nil(t, server.RegisterName("taiko", &testTaikoAPI{numBlocks: numBlocks}))
+
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(latency)
+		server.ServeHTTP(w, r)
+	}))
+
+	client, err := rpc.DialHTTP(httpServer.URL)
+	require.Nil(t, err)
+
+	t.Cleanup(func() {
+		client.Close()
+		httpServer.Close()
+		server.Stop()
+	})
+
+	return client
+}
+
+func testBlockIDs(from, to uint64) []*big.Int {
+	ids := make([]*big.Int, 0, to-from+1)
+	for id := from; id <= to; id++ {
+		ids = append(ids, new(big.Int).SetUint64(id))
+	}
+	return ids
+}
+
+func TestBatchGetL1OriginByIDs(t *testing.T) {
+	client := newTestL1OriginServer(t, 150, 0)
+
+	// More than one batch, the last two blocks have no L1Origin yet.
+	ids := testBlockIDs(1, 152)
+	l1Origins, err := batchGetL1OriginByIDs(context.Background(), client, ids)
+	require.Nil(t, err)
+	require.Len(t, l1Origins, len(ids))
+
+	for i, l1Origin := range l1Origins {
+		if ids[i].Uint64() > 150 {
+			require.Nil(t, l1Origin)
+			continue
+		}
+		require.Equal(t, ids[i], l1Origin.BlockID)
+		require.Equal(t, common.BigToHash(ids[i]), l1Origin.L2BlockHash)
+	}
+
+	l1Origins, err = batchGetL1OriginByIDs(context.Background(), client, nil)
+	require.Nil(t, err)
+	require.Empty(t, l1Origins)
+}
+
+func TestWaitL1OriginWithTimeout(t *testing.T) {
+	defer func(interval time.Duration) { waitL1OriginPollInterval = interval }(waitL1OriginPollInterval)
+	waitL1OriginPollInterval = 10 * time.Millisecond
+
+	client := &Client{L2: ethclient.NewClient(newTestL1OriginServer(t, 1, 0))}
+
+	// The L1Origin exists.
+	l1Origin, err := client.WaitL1OriginWithTimeout(context.Background(), common.Big1, time.Second)
+	require.Nil(t, err)
+	require.Equal(t, common.BigToHash(common.Big1), l1Origin.L2BlockHash)
+
+	// The L1Origin doesn't appear within the timeout, the caller's context is still alive.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, err = client.WaitL1OriginWithTimeout(ctx, common.Big2, 50*time.Millisecond)
+	require.ErrorIs(t, err, ErrWaitL1OriginTimeout)
+	require.Nil(t, ctx.Err())
+
+	// The caller's deadline is returned as is.
+	deadlineCtx, deadlineCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer deadlineCancel()
+	_, err = client.WaitL1OriginWithTimeout(deadlineCtx, common.Big2, time.Hour)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.NotErrorIs(t, err, ErrWaitL1OriginTimeout)
+
+	// No timeout.
+	cancel()
+	_, err = client.WaitL1Origin(ctx, common.Big2)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+// The sequential lookups take one round trip per block, while the batch lookups take one round trip
+// per 100 blocks, which is more than 20× faster for 128 blocks with a 1ms network latency.
+func BenchmarkL1OriginByIDSequential(b *testing.B) {
+	client := newTestL1OriginServer(b, 128, time.Millisecond)
+	ids := testBlockIDs(1, 128)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, id := range ids {
+			var l1Origin *rawdb.L1Origin
+			err := client.CallContext(context.Background(), &l1Origin, "taiko_l1OriginByID", hexutil.EncodeBig(id))
+			require.Nil(b, err)
+		}
+	}
+}
+
+func BenchmarkBatchGetL1OriginByIDs(b *testing.B) {
+	client := newTestL1OriginServer(b, 128, time.Millisecond)
+	ids := testBlockIDs(1, 128)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_, err := batchGetL1OriginByIDs(context.Background(), client, ids)
+		require.Nil(b, err)
+	}
+}
@@ -0,0 +1,157 @@
+package rpc
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+// IsSubscriptionSupported checks whether the given RPC endpoint supports event subscriptions, based on
+// its scheme, only HTTP endpoints don't support subscriptions.
+func IsSubscriptionSupported(endpoint string) bool {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return true
+	}
+
+	return u.Scheme != "http" && u.Scheme != "https"
+}
+
+// PollEvent creates a polling based event subscription, it fetches the event logs in the new L1 blocks
+// by calling the given filter function at a fixed interval. The filter function won't be called with the
+// same block range twice, unless the previous call failed.
+func PollEvent(
+	eventName string,
+	client *ethclient.Client,
+	interval time.Duration,
+	filter func(ctx context.Context, opts *bind.FilterOpts) error,
+) event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go func() {
+			<-quit
+			cancel()
+		}()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var from *uint64
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				head, err := client.BlockNumber(ctx)
+				if err != nil {
+					log.Warn("Failed to fetch L1 head when polling protocol event", "event", eventName, "error", err)
+					continue
+				}
+
+				// Only the events in blocks after the polling started will be delivered.
+				if from == nil {
+					next := head + 1
+					from = &next
+					continue
+				}
+
+				if head < *from {
+					continue
+				}
+
+				if err := filter(ctx, &bind.FilterOpts{Start: *from, End: &head, Context: ctx}); err != nil {
+					log.Warn("Failed to poll protocol event", "event", eventName, "error", err)
+					continue
+				}
+
+				*from = head + 1
+			}
+		}
+	})
+}
+
+// PollBlockVerified polls the protocol's BlockVerified events at the given interval.
+func PollBlockVerified(
+	client *ethclient.Client,
+	taikoL1 *bindings.TaikoL1Client,
+	ch chan *bindings.TaikoL1ClientBlockVerified,
+	interval time.Duration,
+) event.Subscription {
+	return PollEvent("BlockVerified", client, interval, func(ctx context.Context, opts *bind.FilterOpts) error {
+		iter, err := taikoL1.FilterBlockVerified(opts, nil)
+		if err != nil {
+			return err
+		}
+		defer iter.Close()
+
+		for iter.Next() {
+			select {
+			case ch <- iter.Event:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		return iter.Error()
+	})
+}
+
+// PollBlockProposed polls the protocol's BlockProposed events at the given interval.
+func PollBlockProposed(
+	client *ethclient.Client,
+	taikoL1 *bindings.TaikoL1Client,
+	ch chan *bindings.TaikoL1ClientBlockProposed,
+	interval time.Duration,
+) event.Subscription {
+	return PollEvent("BlockProposed", client, interval, func(ctx context.Context, opts *bind.FilterOpts) error {
+		iter, err := taikoL1.FilterBlockProposed(opts, nil)
+		if err != nil {
+			return err
+		}
+		defer iter.Close()
+
+		for iter.Next() {
+			select {
+			case ch <- iter.Event:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		return iter.Error()
+	})
+}
+
+// PollBlockProven polls the protocol's BlockProven events at the given interval.
+func PollBlockProven(
+	client *ethclient.Client,
+	taikoL1 *bindings.TaikoL1Client,
+	ch chan *bindings.TaikoL1ClientBlockProven,
+	interval time.Duration,
+) event.Subscription {
+	return PollEvent("BlockProven", client, interval, func(ctx context.Context, opts *bind.FilterOpts) error {
+		iter, err := taikoL1.FilterBlockProven(opts, nil)
+		if err != nil {
+			return err
+		}
+		defer iter.Close()
+
+		for iter.Next() {
+			select {
+			case ch <- iter.Event:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		return iter.Error()
+	})
+}
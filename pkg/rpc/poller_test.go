@@ -0,0 +1,31 @@
+package rpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+func TestIsSubscriptionSupported(t *testing.T) {
+	require.True(t, IsSubscriptionSupported("ws://localhost:8546"))
+	require.True(t, IsSubscriptionSupported("wss://localhost:8546"))
+	require.True(t, IsSubscriptionSupported("/tmp/geth.ipc"))
+	require.False(t, IsSubscriptionSupported("http://localhost:8545"))
+	require.False(t, IsSubscriptionSupported("https://localhost:8545"))
+}
+
+func TestPollBlockVerified(t *testing.T) {
+	client := newTestClient(t)
+	sub := PollBlockVerified(client.L1, client.TaikoL1, make(chan *bindings.TaikoL1ClientBlockVerified, 1024), time.Second)
+	require.NotNil(t, sub)
+	sub.Unsubscribe()
+}
+
+func TestPollBlockProposed(t *testing.T) {
+	client := newTestClient(t)
+	sub := PollBlockProposed(client.L1, client.TaikoL1, make(chan *bindings.TaikoL1ClientBlockProposed, 1024), time.Second)
+	require.NotNil(t, sub)
+	sub.Unsubscribe()
+}
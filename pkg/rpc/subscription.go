@@ -11,16 +11,23 @@ import (
 	"github.com/taikoxyz/taiko-client/bindings"
 )
 
+// SubscriptionErrHandler is called when an established subscription failed, before resubscribing.
+type SubscriptionErrHandler func(eventName string, err error)
+
 // SubscribeEvent creates a event subscription, will retry if the established subscription failed.
 func SubscribeEvent(
 	eventName string,
 	handler func(ctx context.Context) (event.Subscription, error),
+	errHandlers ...SubscriptionErrHandler,
 ) event.Subscription {
 	return event.ResubscribeErr(
 		backoff.DefaultMaxInterval,
 		func(ctx context.Context, err error) (event.Subscription, error) {
 			if err != nil {
 				log.Warn("Failed to subscribe protocol event, try resubscribing", "event", eventName, "error", err)
+				for _, errHandler := range errHandlers {
+					errHandler(eventName, err)
+				}
 			}
 
 			return handler(ctx)
@@ -32,6 +39,7 @@ func SubscribeEvent(
 func SubscribeBlockVerified(
 	taikoL1 *bindings.TaikoL1Client,
 	ch chan *bindings.TaikoL1ClientBlockVerified,
+	errHandlers ...SubscriptionErrHandler,
 ) event.Subscription {
 	return SubscribeEvent("BlockVerified", func(ctx context.Context) (event.Subscription, error) {
 		sub, err := taikoL1.WatchBlockVerified(nil, ch, nil)
@@ -43,13 +51,14 @@ func SubscribeBlockVerified(
 		defer sub.Unsubscribe()
 
 		return waitSubErr(ctx, sub)
-	})
+	}, errHandlers...)
 }
 
 // SubscribeBlockProposed subscribes the protocol's BlockProposed events.
 func SubscribeBlockProposed(
 	taikoL1 *bindings.TaikoL1Client,
 	ch chan *bindings.TaikoL1ClientBlockProposed,
+	errHandlers ...SubscriptionErrHandler,
 ) event.Subscription {
 	return SubscribeEvent("BlockProposed", func(ctx context.Context) (event.Subscription, error) {
 		sub, err := taikoL1.WatchBlockProposed(nil, ch, nil)
@@ -61,13 +70,14 @@ func SubscribeBlockProposed(
 		defer sub.Unsubscribe()
 
 		return waitSubErr(ctx, sub)
-	})
+	}, errHandlers...)
 }
 
 // SubscribeXchainSynced subscribes the protocol's XchainSynced events.
 func SubscribeXchainSynced(
 	taikoL1 *bindings.TaikoL1Client,
 	ch chan *bindings.TaikoL1ClientXchainSynced,
+	errHandlers ...SubscriptionErrHandler,
 ) event.Subscription {
 	return SubscribeEvent("XchainSynced", func(ctx context.Context) (event.Subscription, error) {
 		sub, err := taikoL1.WatchXchainSynced(nil, ch, nil)
@@ -79,13 +89,14 @@ func SubscribeXchainSynced(
 		defer sub.Unsubscribe()
 
 		return waitSubErr(ctx, sub)
-	})
+	}, errHandlers...)
 }
 
 // SubscribeBlockProven subscribes the protocol's BlockProven events.
 func SubscribeBlockProven(
 	taikoL1 *bindings.TaikoL1Client,
 	ch chan *bindings.TaikoL1ClientBlockProven,
+	errHandlers ...SubscriptionErrHandler,
 ) event.Subscription {
 	return SubscribeEvent("BlockProven", func(ctx context.Context) (event.Subscription, error) {
 		sub, err := taikoL1.WatchBlockProven(nil, ch, nil)
@@ -97,13 +108,14 @@ func SubscribeBlockProven(
 		defer sub.Unsubscribe()
 
 		return waitSubErr(ctx, sub)
-	})
+	}, errHandlers...)
 }
 
 // SubscribeChainHead subscribes the new chain heads.
 func SubscribeChainHead(
 	client *ethclient.Client,
 	ch chan *types.Header,
+	errHandlers ...SubscriptionErrHandler,
 ) event.Subscription {
 	return SubscribeEvent("ChainHead", func(ctx context.Context) (event.Subscription, error) {
 		sub, err := client.SubscribeNewHead(ctx, ch)
@@ -115,7 +127,7 @@ func SubscribeChainHead(
 		defer sub.Unsubscribe()
 
 		return waitSubErr(ctx, sub)
-	})
+	}, errHandlers...)
 }
 
 // waitSubErr keeps waiting until the given subscription failed.
@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"time"
@@ -15,6 +16,11 @@ import (
 	"github.com/taikoxyz/taiko-client/bindings"
 )
 
+var (
+	// ErrTxReverted is returned by WaitReceipt when the transaction has been mined but reverted.
+	ErrTxReverted = errors.New("transaction reverted")
+)
+
 // GetProtocolStateVariables gets the protocol states from TaikoL1 contract.
 func GetProtocolStateVariables(
 	taikoL1Client *bindings.TaikoL1Client,
@@ -54,7 +60,8 @@ func WaitConfirmations(ctx context.Context, client *ethclient.Client, confirmati
 }
 
 // WaitReceipt keeps waiting until the given transaction has an execution
-// receipt to know whether it was reverted or not.
+// receipt to know whether it was reverted or not, if reverted, the receipt will be
+// returned along with an ErrTxReverted error.
 func WaitReceipt(ctx context.Context, client *ethclient.Client, tx *types.Transaction) (*types.Receipt, error) {
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
@@ -70,7 +77,7 @@ func WaitReceipt(ctx context.Context, client *ethclient.Client, tx *types.Transa
 			}
 
 			if receipt.Status != types.ReceiptStatusSuccessful {
-				return nil, fmt.Errorf("transaction reverted, hash: %s", tx.Hash())
+				return receipt, fmt.Errorf("%w, hash: %s", ErrTxReverted, tx.Hash())
 			}
 
 			return receipt, nil
@@ -0,0 +1,82 @@
+package safe
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// safeABIJSON contains the Safe contract methods used by this package.
+const safeABIJSON = `[
+	{"inputs":[],"name":"nonce","outputs":[{"type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"inputs":[
+		{"name":"to","type":"address"},
+		{"name":"value","type":"uint256"},
+		{"name":"data","type":"bytes"},
+		{"name":"operation","type":"uint8"},
+		{"name":"safeTxGas","type":"uint256"},
+		{"name":"baseGas","type":"uint256"},
+		{"name":"gasPrice","type":"uint256"},
+		{"name":"gasToken","type":"address"},
+		{"name":"refundReceiver","type":"address"},
+		{"name":"signatures","type":"bytes"}
+	],"name":"execTransaction","outputs":[{"type":"bool"}],"stateMutability":"payable","type":"function"}
+]`
+
+// SafeABI is the partial ABI of the Safe contract.
+var SafeABI abi.ABI
+
+func init() {
+	var err error
+	if SafeABI, err = abi.JSON(strings.NewReader(safeABIJSON)); err != nil {
+		panic(err)
+	}
+}
+
+// Nonce fetches the current nonce of the given Safe contract.
+func Nonce(ctx context.Context, caller ethereum.ContractCaller, safeAddress common.Address) (*big.Int, error) {
+	data, err := SafeABI.Pack("nonce")
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := caller.CallContract(ctx, ethereum.CallMsg{To: &safeAddress, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call Safe.nonce: %w", err)
+	}
+
+	out, err := SafeABI.Unpack("nonce", res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack Safe.nonce result: %w", err)
+	}
+
+	return out[0].(*big.Int), nil
+}
+
+// PackExecTransaction packs the `Safe.execTransaction` calldata of the given Safe transaction and
+// the owners' confirmations.
+func PackExecTransaction(tx *Transaction, confirmations []*Confirmation) ([]byte, error) {
+	value := tx.Value
+	if value == nil {
+		value = common.Big0
+	}
+
+	return SafeABI.Pack(
+		"execTransaction",
+		tx.To,
+		value,
+		tx.Data,
+		uint8(tx.Operation),
+		common.Big0,
+		common.Big0,
+		common.Big0,
+		common.Address{},
+		common.Address{},
+		EncodeSignatures(confirmations),
+	)
+}
@@ -0,0 +1,180 @@
+package safe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// defaultTimeout is the default timeout of each Safe Transaction Service HTTP request.
+	defaultTimeout = 10 * time.Second
+	// origin is the origin recorded for the Safe transactions proposed by this client.
+	origin = "taiko-client"
+)
+
+var (
+	// ErrNotFound is returned when the Safe Transaction Service doesn't know the given Safe transaction.
+	ErrNotFound = errors.New("safe transaction not found")
+)
+
+// proposeRequest is the request body of proposing a new Safe transaction.
+type proposeRequest struct {
+	To                      common.Address `json:"to"`
+	Value                   string         `json:"value"`
+	Data                    hexutil.Bytes  `json:"data"`
+	Operation               Operation      `json:"operation"`
+	SafeTxGas               string         `json:"safeTxGas"`
+	BaseGas                 string         `json:"baseGas"`
+	GasPrice                string         `json:"gasPrice"`
+	GasToken                common.Address `json:"gasToken"`
+	RefundReceiver          common.Address `json:"refundReceiver"`
+	Nonce                   uint64         `json:"nonce"`
+	ContractTransactionHash common.Hash    `json:"contractTransactionHash"`
+	Sender                  common.Address `json:"sender"`
+	Signature               hexutil.Bytes  `json:"signature"`
+	Origin                  string         `json:"origin"`
+}
+
+// MultisigTransaction is the status of a proposed Safe transaction in the Safe Transaction Service.
+type MultisigTransaction struct {
+	SafeTxHash      common.Hash     `json:"safeTxHash"`
+	IsExecuted      bool            `json:"isExecuted"`
+	TransactionHash *common.Hash    `json:"transactionHash"`
+	Executor        *common.Address `json:"executor"`
+	Confirmations   []struct {
+		Owner     common.Address `json:"owner"`
+		Signature hexutil.Bytes  `json:"signature"`
+	} `json:"confirmations"`
+}
+
+// ConfirmationList returns the confirmations of the Safe transaction.
+func (tx *MultisigTransaction) ConfirmationList() []*Confirmation {
+	confirmations := make([]*Confirmation, 0, len(tx.Confirmations))
+	for _, c := range tx.Confirmations {
+		confirmations = append(confirmations, &Confirmation{Owner: c.Owner, Signature: c.Signature})
+	}
+
+	return confirmations
+}
+
+// ServiceClient is a client of the Safe Transaction Service, which collects the Safe owners' signatures
+// off-chain, e.g. https://safe-transaction.{network}.gnosis.io.
+type ServiceClient struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+// NewServiceClient creates a new Safe Transaction Service client instance.
+func NewServiceClient(endpoint string, timeout time.Duration) *ServiceClient {
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	return &ServiceClient{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// ProposeTransaction proposes the given Safe transaction along with the sender's signature.
+func (c *ServiceClient) ProposeTransaction(
+	ctx context.Context,
+	safeAddress common.Address,
+	tx *Transaction,
+	safeTxHash common.Hash,
+	sender common.Address,
+	signature []byte,
+) error {
+	value := "0"
+	if tx.Value != nil {
+		value = tx.Value.String()
+	}
+
+	body, err := json.Marshal(&proposeRequest{
+		To:                      tx.To,
+		Value:                   value,
+		Data:                    tx.Data,
+		Operation:               tx.Operation,
+		SafeTxGas:               "0",
+		BaseGas:                 "0",
+		GasPrice:                "0",
+		Nonce:                   tx.Nonce.Uint64(),
+		ContractTransactionHash: safeTxHash,
+		Sender:                  sender,
+		Signature:               signature,
+		Origin:                  origin,
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := c.do(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("/api/v1/safes/%s/multisig-transactions/", safeAddress.Hex()),
+		body,
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusOK {
+		resBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf(
+			"failed to propose safe transaction, safeTxHash: %s, status: %s, body: %s",
+			safeTxHash,
+			res.Status,
+			string(resBytes),
+		)
+	}
+
+	return nil
+}
+
+// GetTransaction fetches the status of the given proposed Safe transaction, returns ErrNotFound if
+// there is no such transaction.
+func (c *ServiceClient) GetTransaction(ctx context.Context, safeTxHash common.Hash) (*MultisigTransaction, error) {
+	res, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/api/v1/multisig-transactions/%s/", safeTxHash.Hex()), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get safe transaction, safeTxHash: %s, status: %s", safeTxHash, res.Status)
+	}
+
+	var tx MultisigTransaction
+	if err := json.NewDecoder(res.Body).Decode(&tx); err != nil {
+		return nil, fmt.Errorf("failed to decode safe transaction: %w", err)
+	}
+
+	return &tx, nil
+}
+
+// do sends a HTTP request to the Safe Transaction Service.
+func (c *ServiceClient) do(ctx context.Context, method string, path string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return c.httpClient.Do(req)
+}
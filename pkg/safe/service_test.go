@@ -0,0 +1,77 @@
+package safe
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceClientProposeTransaction(t *testing.T) {
+	var (
+		safeAddress = common.HexToAddress("0x01")
+		tx          = &Transaction{To: common.HexToAddress("0x02"), Data: []byte{0x01}, Nonce: common.Big2}
+		safeTxHash  = tx.Hash(common.Big1, safeAddress)
+		received    proposeRequest
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/api/v1/safes/"+safeAddress.Hex()+"/multisig-transactions/", r.URL.Path)
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	client := NewServiceClient(srv.URL+"/", 0)
+	require.Nil(t, client.ProposeTransaction(
+		context.Background(),
+		safeAddress,
+		tx,
+		safeTxHash,
+		common.HexToAddress("0x03"),
+		[]byte{0x04},
+	))
+	require.Equal(t, tx.To, received.To)
+	require.Equal(t, "0", received.Value)
+	require.Equal(t, uint64(2), received.Nonce)
+	require.Equal(t, safeTxHash, received.ContractTransactionHash)
+	require.Equal(t, common.HexToAddress("0x03"), received.Sender)
+}
+
+func TestServiceClientGetTransaction(t *testing.T) {
+	safeTxHash := common.HexToHash("0x01")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/multisig-transactions/"+safeTxHash.Hex()+"/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{
+			"safeTxHash": "` + safeTxHash.Hex() + `",
+			"isExecuted": false,
+			"transactionHash": null,
+			"executor": null,
+			"confirmations": [{"owner": "0x0000000000000000000000000000000000000002", "signature": "0x02"}]
+		}`))
+	}))
+	defer srv.Close()
+
+	client := NewServiceClient(srv.URL, 0)
+
+	tx, err := client.GetTransaction(context.Background(), safeTxHash)
+	require.Nil(t, err)
+	require.False(t, tx.IsExecuted)
+	require.Nil(t, tx.TransactionHash)
+	require.Nil(t, tx.Executor)
+	require.Equal(t, []*Confirmation{
+		{Owner: common.HexToAddress("0x02"), Signature: []byte{0x02}},
+	}, tx.ConfirmationList())
+
+	_, err = client.GetTransaction(context.Background(), common.HexToHash("0x02"))
+	require.ErrorIs(t, err, ErrNotFound)
+}
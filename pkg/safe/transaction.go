@@ -0,0 +1,109 @@
+package safe
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// domainSeparatorTypeHash is keccak256("EIP712Domain(uint256 chainId,address verifyingContract)").
+	domainSeparatorTypeHash = crypto.Keccak256Hash([]byte("EIP712Domain(uint256 chainId,address verifyingContract)"))
+	// safeTxTypeHash is the EIP-712 type hash of a Safe transaction.
+	safeTxTypeHash = crypto.Keccak256Hash([]byte(
+		"SafeTx(address to,uint256 value,bytes data,uint8 operation,uint256 safeTxGas,uint256 baseGas," +
+			"uint256 gasPrice,address gasToken,address refundReceiver,uint256 nonce)",
+	))
+)
+
+// Operation is the operation type of a Safe transaction.
+type Operation uint8
+
+const (
+	OperationCall         Operation = 0
+	OperationDelegateCall Operation = 1
+)
+
+// Transaction is a Safe multi-sig transaction, the gas payment related fields are always zero, which
+// means the executor pays for the gas itself.
+type Transaction struct {
+	To        common.Address
+	Value     *big.Int
+	Data      []byte
+	Operation Operation
+	Nonce     *big.Int
+}
+
+// Hash returns the EIP-712 hash of the transaction (i.e. `safeTxHash`), which is signed by the Safe owners,
+// for the Safe contract at the given address, supports Safe contracts >= v1.3.0.
+func (tx *Transaction) Hash(chainID *big.Int, safeAddress common.Address) common.Hash {
+	domainSeparator := crypto.Keccak256(
+		domainSeparatorTypeHash.Bytes(),
+		math.U256Bytes(new(big.Int).Set(chainID)),
+		common.LeftPadBytes(safeAddress.Bytes(), 32),
+	)
+
+	value := tx.Value
+	if value == nil {
+		value = common.Big0
+	}
+
+	structHash := crypto.Keccak256(
+		safeTxTypeHash.Bytes(),
+		common.LeftPadBytes(tx.To.Bytes(), 32),
+		math.U256Bytes(new(big.Int).Set(value)),
+		crypto.Keccak256(tx.Data),
+		common.LeftPadBytes([]byte{byte(tx.Operation)}, 32),
+		make([]byte, 32), // safeTxGas
+		make([]byte, 32), // baseGas
+		make([]byte, 32), // gasPrice
+		make([]byte, 32), // gasToken
+		make([]byte, 32), // refundReceiver
+		math.U256Bytes(new(big.Int).Set(tx.Nonce)),
+	)
+
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator, structHash)
+}
+
+// Confirmation is a Safe owner's signature of a Safe transaction.
+type Confirmation struct {
+	Owner     common.Address
+	Signature []byte
+}
+
+// Sign signs the given Safe transaction hash by the given owner's private key, the returned signature can
+// be submitted to the Safe contract directly.
+func Sign(safeTxHash common.Hash, privKey *ecdsa.PrivateKey) ([]byte, error) {
+	sig, err := crypto.Sign(safeTxHash.Bytes(), privKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Safe contracts expect the recovery ID to be 27 or 28.
+	sig[crypto.RecoveryIDOffset] += 27
+
+	return sig, nil
+}
+
+// EncodeSignatures encodes the given confirmations into the `signatures` parameter of
+// `Safe.execTransaction`, the signatures are sorted by the owner addresses in ascending order
+// as required by the Safe contract.
+func EncodeSignatures(confirmations []*Confirmation) []byte {
+	sorted := make([]*Confirmation, len(confirmations))
+	copy(sorted, confirmations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Owner.Bytes(), sorted[j].Owner.Bytes()) < 0
+	})
+
+	var signatures []byte
+	for _, confirmation := range sorted {
+		signatures = append(signatures, confirmation.Signature...)
+	}
+
+	return signatures
+}
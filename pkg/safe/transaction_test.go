@@ -0,0 +1,74 @@
+package safe
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeHashes(t *testing.T) {
+	require.Equal(
+		t,
+		common.HexToHash("0x47e79534a245952e8b16893a336b85a3d9ea9fa8c573f3d803afb92a79469218"),
+		domainSeparatorTypeHash,
+	)
+	require.Equal(
+		t,
+		common.HexToHash("0xbb8310d486368db6bd6f849402fdd73ad53d316b5a4b2644ad6efe0f941286d8"),
+		safeTxTypeHash,
+	)
+}
+
+func TestTransactionHash(t *testing.T) {
+	var (
+		safeAddress = common.HexToAddress("0x0000000000000000000000000000000000000001")
+		tx          = &Transaction{To: common.HexToAddress("0x02"), Data: []byte{0x01}, Nonce: common.Big1}
+		hash        = tx.Hash(common.Big1, safeAddress)
+	)
+
+	require.Equal(t, hash, (&Transaction{
+		To:    tx.To,
+		Value: common.Big0,
+		Data:  tx.Data,
+		Nonce: common.Big1,
+	}).Hash(common.Big1, safeAddress))
+	require.NotEqual(t, hash, tx.Hash(common.Big2, safeAddress))
+	require.NotEqual(t, hash, tx.Hash(common.Big1, common.HexToAddress("0x03")))
+	require.NotEqual(t, hash, (&Transaction{To: tx.To, Data: tx.Data, Nonce: common.Big2}).Hash(common.Big1, safeAddress))
+}
+
+func TestSign(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	hash := (&Transaction{Nonce: big.NewInt(10)}).Hash(common.Big1, common.Address{})
+	sig, err := Sign(hash, key)
+	require.Nil(t, err)
+	require.Contains(t, []byte{27, 28}, sig[crypto.RecoveryIDOffset])
+
+	sig[crypto.RecoveryIDOffset] -= 27
+	pubKey, err := crypto.SigToPub(hash.Bytes(), sig)
+	require.Nil(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(*pubKey))
+}
+
+func TestEncodeSignatures(t *testing.T) {
+	confirmations := []*Confirmation{
+		{Owner: common.HexToAddress("0x03"), Signature: []byte{3}},
+		{Owner: common.HexToAddress("0x01"), Signature: []byte{1}},
+		{Owner: common.HexToAddress("0x02"), Signature: []byte{2}},
+	}
+
+	require.Equal(t, []byte{1, 2, 3}, EncodeSignatures(confirmations))
+	// The given confirmations should not be reordered.
+	require.Equal(t, common.HexToAddress("0x03"), confirmations[0].Owner)
+}
+
+func TestPackExecTransaction(t *testing.T) {
+	data, err := PackExecTransaction(&Transaction{To: common.HexToAddress("0x02"), Nonce: common.Big0}, nil)
+	require.Nil(t, err)
+	require.Equal(t, SafeABI.Methods["execTransaction"].ID, data[:4])
+}
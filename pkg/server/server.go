@@ -0,0 +1,153 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Server is a simple HTTP server, which is used by the client softwares to expose their
+// status and admin APIs.
+type Server struct {
+	mux      *http.ServeMux
+	server   *http.Server
+	listener net.Listener
+}
+
+// HTTPError is an error with a HTTP status code, which can be returned by the JSON handlers to
+// respond a status code other than 500.
+type HTTPError struct {
+	Code int
+	Err  error
+}
+
+// NewHTTPError creates a new HTTPError instance.
+func NewHTTPError(code int, err error) *HTTPError {
+	return &HTTPError{Code: code, Err: err}
+}
+
+// Error implements the error interface.
+func (e *HTTPError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+// New creates a new Server instance listening on the given address.
+func New(addr string) *Server {
+	mux := http.NewServeMux()
+	return &Server{mux: mux, server: &http.Server{Addr: addr, Handler: mux}}
+}
+
+// HandleFunc registers the handler function for the given pattern.
+func (s *Server) HandleFunc(pattern string, handler http.HandlerFunc) {
+	s.mux.HandleFunc(pattern, handler)
+}
+
+// HandleJSON registers a GET handler for the given pattern, which responds the JSON encoded
+// result of the given function.
+func (s *Server) HandleJSON(pattern string, handler func(r *http.Request) (interface{}, error)) {
+	s.handleJSON(http.MethodGet, pattern, handler)
+}
+
+// HandleJSONPost registers a POST handler for the given pattern, which responds the JSON encoded
+// result of the given function.
+func (s *Server) HandleJSONPost(pattern string, handler func(r *http.Request) (interface{}, error)) {
+	s.handleJSON(http.MethodPost, pattern, handler)
+}
+
+// HandleAdminJSONPost registers a POST handler for the given pattern like HandleJSONPost, which only
+// serves the requests carrying the given admin token as an `Authorization: Bearer` header.
+func (s *Server) HandleAdminJSONPost(pattern string, token string, handler func(r *http.Request) (interface{}, error)) {
+	s.handleJSON(http.MethodPost, pattern, func(r *http.Request) (interface{}, error) {
+		if !authorized(r, token) {
+			return nil, NewHTTPError(http.StatusUnauthorized, errors.New("invalid admin token"))
+		}
+		return handler(r)
+	})
+}
+
+// authorized checks whether the given request carries the given non-empty bearer token.
+func authorized(r *http.Request, token string) bool {
+	header := r.Header.Get("Authorization")
+	if len(token) == 0 || !strings.HasPrefix(header, "Bearer ") {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(header, "Bearer ")), []byte(token)) == 1
+}
+
+// handleJSON registers a handler for the given method and pattern, which responds the JSON encoded
+// result of the given function.
+func (s *Server) handleJSON(method string, pattern string, handler func(r *http.Request) (interface{}, error)) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		result, err := handler(r)
+		if err != nil {
+			code := http.StatusInternalServerError
+			var httpErr *HTTPError
+			if errors.As(err, &httpErr) {
+				code = httpErr.Code
+			}
+			http.Error(w, err.Error(), code)
+			return
+		}
+
+		WriteJSON(w, http.StatusOK, result)
+	})
+}
+
+// Start starts listening on the configured address, and serves the requests in a new goroutine.
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return err
+	}
+	s.listener = listener
+
+	log.Info("Starting HTTP server", "address", listener.Addr())
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("HTTP server error", "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// Addr returns the actual listening address of the server, returns an empty string if not started yet.
+func (s *Server) Addr() string {
+	if s.listener == nil {
+		return ""
+	}
+
+	return s.listener.Addr().String()
+}
+
+// Shutdown gracefully shuts down the server.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+// WriteJSON writes the JSON encoded value with the given status code.
+func WriteJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Error("Failed to encode HTTP response", "error", err)
+	}
+}
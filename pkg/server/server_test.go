@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleJSON(t *testing.T) {
+	s := New("127.0.0.1:0")
+	s.HandleJSON("/status", func(r *http.Request) (interface{}, error) {
+		return map[string]string{"status": "ok"}, nil
+	})
+	s.HandleJSON("/error", func(r *http.Request) (interface{}, error) {
+		return nil, errors.New("test error")
+	})
+	require.Empty(t, s.Addr())
+	require.Nil(t, s.Start())
+	defer s.Shutdown(context.Background())
+
+	res, err := http.Get("http://" + s.Addr() + "/status")
+	require.Nil(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var body map[string]string
+	require.Nil(t, json.NewDecoder(res.Body).Decode(&body))
+	require.Equal(t, "ok", body["status"])
+
+	res, err = http.Post("http://"+s.Addr()+"/status", "application/json", nil)
+	require.Nil(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+
+	res, err = http.Get("http://" + s.Addr() + "/error")
+	require.Nil(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusInternalServerError, res.StatusCode)
+}
+
+func TestHandleJSONPost(t *testing.T) {
+	s := New("127.0.0.1:0")
+	s.HandleJSONPost("/echo", func(r *http.Request) (interface{}, error) {
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			return nil, NewHTTPError(http.StatusBadRequest, err)
+		}
+		return body, nil
+	})
+	require.Nil(t, s.Start())
+	defer s.Shutdown(context.Background())
+
+	res, err := http.Post("http://"+s.Addr()+"/echo", "application/json", strings.NewReader(`{"status":"ok"}`))
+	require.Nil(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var body map[string]string
+	require.Nil(t, json.NewDecoder(res.Body).Decode(&body))
+	require.Equal(t, "ok", body["status"])
+
+	res, err = http.Get("http://" + s.Addr() + "/echo")
+	require.Nil(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+
+	res, err = http.Post("http://"+s.Addr()+"/echo", "application/json", strings.NewReader("invalid"))
+	require.Nil(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func TestHandleAdminJSONPost(t *testing.T) {
+	s := New("127.0.0.1:0")
+	s.HandleAdminJSONPost("/admin", "secret", func(r *http.Request) (interface{}, error) {
+		return map[string]string{"status": "ok"}, nil
+	})
+	require.Nil(t, s.Start())
+	defer s.Shutdown(context.Background())
+
+	post := func(authorization string) int {
+		req, err := http.NewRequest(http.MethodPost, "http://"+s.Addr()+"/admin", nil)
+		require.Nil(t, err)
+		if len(authorization) != 0 {
+			req.Header.Set("Authorization", authorization)
+		}
+		res, err := http.DefaultClient.Do(req)
+		require.Nil(t, err)
+		defer res.Body.Close()
+		return res.StatusCode
+	}
+
+	require.Equal(t, http.StatusOK, post("Bearer secret"))
+	require.Equal(t, http.StatusUnauthorized, post(""))
+	require.Equal(t, http.StatusUnauthorized, post("secret"))
+	require.Equal(t, http.StatusUnauthorized, post("Bearer wrong"))
+
+	// An empty token rejects all requests.
+	s.HandleAdminJSONPost("/admin/empty", "", func(r *http.Request) (interface{}, error) { return nil, nil })
+	req, err := http.NewRequest(http.MethodPost, "http://"+s.Addr()+"/admin/empty", nil)
+	require.Nil(t, err)
+	req.Header.Set("Authorization", "Bearer ")
+	res, err := http.DefaultClient.Do(req)
+	require.Nil(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+}
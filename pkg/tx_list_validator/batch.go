@@ -0,0 +1,53 @@
+package tx_list_validator
+
+import (
+	"context"
+	"math/big"
+	"runtime"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// TxListItem is a transactions list to validate in a batch, in the TaikoL1.proposeBlock transaction's
+// input data.
+type TxListItem struct {
+	BlockID             *big.Int
+	ProposeBlockTxInput []byte
+}
+
+// ValidationResult is the result of validating a TxListItem, same as the ones returned by ValidateTxList.
+type ValidationResult struct {
+	TxListBytes []byte
+	Hint        InvalidTxListReason
+	TxIdx       int
+	Err         error // Error of unpacking the transactions list from the transaction's input data
+}
+
+// ValidateTxListBatch validates the given transactions lists concurrently, up to runtime.NumCPU() lists at
+// a time, the results are in the same order as the given items. An item whose transactions list can not be
+// unpacked does not fail the batch, its error is set in its result instead, an error is only returned if
+// the context is done before all items are validated.
+func (v *TxListValidator) ValidateTxListBatch(ctx context.Context, items []TxListItem) ([]ValidationResult, error) {
+	results := make([]ValidationResult, len(items))
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(runtime.NumCPU())
+	for i := range items {
+		i := i
+		g.Go(func() error {
+			if err := gCtx.Err(); err != nil {
+				return err
+			}
+
+			r := &results[i]
+			r.TxListBytes, r.Hint, r.TxIdx, r.Err = v.ValidateTxList(items[i].BlockID, items[i].ProposeBlockTxInput)
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,106 @@
+package tx_list_validator
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+)
+
+func proposeBlockTxInput(t testing.TB, txListBytes []byte) []byte {
+	input, err := encoding.TaikoL1ABI.Pack("proposeBlock", []byte{}, txListBytes)
+	require.Nil(t, err)
+	return input
+}
+
+func TestValidateTxListBatch(t *testing.T) {
+	v := NewTxListValidator(
+		maxBlocksGasLimit,
+		maxBlockNumTxs,
+		maxTxlistBytes,
+		minTxGasLimit,
+		chainID,
+	)
+
+	items := []TxListItem{
+		{BlockID: big.NewInt(1), ProposeBlockTxInput: proposeBlockTxInput(t, rlpEncodedTransactionBytes(1, true))},
+		{BlockID: big.NewInt(2), ProposeBlockTxInput: randBytes(5)},
+		{BlockID: big.NewInt(3), ProposeBlockTxInput: proposeBlockTxInput(t, []byte{})},
+		{
+			BlockID:             big.NewInt(4),
+			ProposeBlockTxInput: proposeBlockTxInput(t, rlpEncodedTransactionBytes(int(maxBlockNumTxs)+1, true)),
+		},
+	}
+
+	results, err := v.ValidateTxListBatch(context.Background(), items)
+	require.Nil(t, err)
+	require.Len(t, results, len(items))
+
+	for i, item := range items {
+		txListBytes, hint, txIdx, err := v.ValidateTxList(item.BlockID, item.ProposeBlockTxInput)
+		require.Equal(t, txListBytes, results[i].TxListBytes)
+		require.Equal(t, hint, results[i].Hint)
+		require.Equal(t, txIdx, results[i].TxIdx)
+		require.Equal(t, err, results[i].Err)
+	}
+	require.Equal(t, HintOK, results[0].Hint)
+	require.NotNil(t, results[1].Err)
+	require.Equal(t, HintOK, results[2].Hint)
+	require.Equal(t, HintNone, results[3].Hint)
+
+	results, err = v.ValidateTxListBatch(context.Background(), nil)
+	require.Nil(t, err)
+	require.Empty(t, results)
+}
+
+func TestValidateTxListBatchCanceled(t *testing.T) {
+	v := NewTxListValidator(
+		maxBlocksGasLimit,
+		maxBlockNumTxs,
+		maxTxlistBytes,
+		minTxGasLimit,
+		chainID,
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := v.ValidateTxListBatch(ctx, []TxListItem{{BlockID: big.NewInt(1), ProposeBlockTxInput: randBytes(5)}})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func BenchmarkValidateTxList(b *testing.B) {
+	v, items := benchmarkTxListItems(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, item := range items {
+			_, _, _, _ = v.ValidateTxList(item.BlockID, item.ProposeBlockTxInput)
+		}
+	}
+}
+
+func BenchmarkValidateTxListBatch(b *testing.B) {
+	v, items := benchmarkTxListItems(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := v.ValidateTxListBatch(context.Background(), items); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkTxListItems(b *testing.B) (*TxListValidator, []TxListItem) {
+	v := NewTxListValidator(1_000_000_000, 1000, 1_000_000, minTxGasLimit, chainID)
+
+	input := proposeBlockTxInput(b, rlpEncodedTransactionBytes(100, true))
+	items := make([]TxListItem, 1000)
+	for i := range items {
+		items[i] = TxListItem{BlockID: big.NewInt(int64(i)), ProposeBlockTxInput: input}
+	}
+
+	return v, items
+}
@@ -63,6 +63,19 @@ func (v *TxListValidator) ValidateTxList(
 	return txListBytes, hint, txIdx, nil
 }
 
+// ValidateTxListBytes checks whether the given RLP encoded transactions list is valid, used to validate
+// the transactions lists which are not proposed yet.
+func (v *TxListValidator) ValidateTxListBytes(
+	blockID *big.Int,
+	txListBytes []byte,
+) (hint InvalidTxListReason, txIdx int) {
+	if len(txListBytes) == 0 {
+		return HintOK, 0
+	}
+
+	return v.isTxListValid(blockID, txListBytes)
+}
+
 // isTxListValid checks whether the transaction list is valid, must match
 // the validation rule defined in LibInvalidTxList.sol.
 // ref: https://github.com/taikoxyz/taiko-mono/blob/main/packages/bindings/contracts/libs/LibInvalidTxList.sol
@@ -46,6 +46,28 @@ func TestValidateTxList(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+func TestValidateTxListBytes(t *testing.T) {
+	v := NewTxListValidator(
+		maxBlocksGasLimit,
+		maxBlockNumTxs,
+		maxTxlistBytes,
+		minTxGasLimit,
+		chainID,
+	)
+
+	hint, _ := v.ValidateTxListBytes(common.Big0, []byte{})
+	require.Equal(t, HintOK, hint)
+
+	hint, _ = v.ValidateTxListBytes(common.Big0, rlpEncodedTransactionBytes(1, true))
+	require.Equal(t, HintOK, hint)
+
+	hint, _ = v.ValidateTxListBytes(common.Big0, rlpEncodedTransactionBytes(int(maxBlockNumTxs)+1, true))
+	require.Equal(t, HintNone, hint)
+
+	hint, _ = v.ValidateTxListBytes(common.Big0, randBytes(5))
+	require.Equal(t, HintNone, hint)
+}
+
 func TestIsTxListValid(t *testing.T) {
 	v := NewTxListValidator(
 		maxBlocksGasLimit,
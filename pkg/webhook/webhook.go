@@ -0,0 +1,126 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	defaultQueueSize = 64
+	defaultTimeout   = 10 * time.Second
+)
+
+// Notifier posts the JSON encoded payloads to a webhook URL asynchronously, the payloads will be
+// dropped when the queue is full, so that a slow webhook never blocks the caller.
+type Notifier struct {
+	url    string
+	client *http.Client
+	queue  chan interface{}
+	done   chan struct{}
+	once   sync.Once
+	wg     sync.WaitGroup
+}
+
+// New creates a new Notifier instance, and starts a worker posting the queued payloads.
+func New(url string, queueSize int, timeout time.Duration) *Notifier {
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	n := &Notifier{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+		queue:  make(chan interface{}, queueSize),
+		done:   make(chan struct{}),
+	}
+
+	n.wg.Add(1)
+	go n.loop()
+
+	return n
+}
+
+// Notify queues the given payload, returns false if the payload is dropped.
+func (n *Notifier) Notify(payload interface{}) bool {
+	select {
+	case <-n.done:
+		return false
+	default:
+	}
+
+	select {
+	case n.queue <- payload:
+		return true
+	default:
+		log.Warn("Webhook queue is full, drop the payload", "url", n.url)
+		return false
+	}
+}
+
+// Close stops the worker, the payloads still in the queue will be dropped.
+func (n *Notifier) Close() {
+	n.once.Do(func() { close(n.done) })
+	n.wg.Wait()
+}
+
+// loop keeps posting the queued payloads until the notifier is closed.
+func (n *Notifier) loop() {
+	defer n.wg.Done()
+
+	for {
+		select {
+		case <-n.done:
+			return
+		case payload := <-n.queue:
+			if err := n.post(payload); err != nil {
+				log.Warn("Failed to post webhook payload", "url", n.url, "error", err)
+			}
+		}
+	}
+}
+
+// post sends the JSON encoded payload to the webhook URL.
+func (n *Notifier) post(payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-n.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code: %d", res.StatusCode)
+	}
+
+	return nil
+}
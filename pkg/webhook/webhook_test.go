@@ -0,0 +1,52 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotify(t *testing.T) {
+	received := make(chan map[string]uint64, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var payload map[string]uint64
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&payload))
+		received <- payload
+	}))
+	defer srv.Close()
+
+	n := New(srv.URL, 1, time.Second)
+	require.True(t, n.Notify(map[string]uint64{"gap": 129}))
+
+	select {
+	case payload := <-received:
+		require.Equal(t, uint64(129), payload["gap"])
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook payload not received")
+	}
+
+	n.Close()
+	require.False(t, n.Notify(map[string]uint64{"gap": 130}))
+}
+
+func TestNotifyQueueFull(t *testing.T) {
+	blocked := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { <-blocked }))
+	defer srv.Close()
+	defer close(blocked)
+
+	n := New(srv.URL, 1, time.Minute)
+	defer n.Close()
+
+	// The first payload is being posted by the worker, the second one fills the queue.
+	require.True(t, n.Notify(1))
+	require.Eventually(t, func() bool { return len(n.queue) == 0 }, 5*time.Second, 10*time.Millisecond)
+	require.True(t, n.Notify(2))
+	require.False(t, n.Notify(3))
+}
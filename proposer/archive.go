@@ -0,0 +1,80 @@
+package proposer
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-client/pkg/server"
+	"github.com/taikoxyz/taiko-client/proposer/archive"
+)
+
+// archiveProposal archives the exact TaikoL1.proposeBlock inputs of a proposal, along with the
+// BlockProposed event metadata parsed from its receipt.
+func (p *Proposer) archiveProposal(
+	meta *encoding.TaikoL1BlockMetadataInput,
+	inputs []byte,
+	txListBytes []byte,
+	receipt *types.Receipt,
+) {
+	if p.archive == nil {
+		return
+	}
+
+	for _, l := range receipt.Logs {
+		if l.Address != p.taikoL1Address {
+			continue
+		}
+
+		event, err := p.rpc.TaikoL1.ParseBlockProposed(*l)
+		if err != nil {
+			continue
+		}
+
+		p.archive.Put(&archive.Record{
+			BlockID:    event.Id.Uint64(),
+			Meta:       &event.Meta,
+			MetaInput:  meta,
+			Input:      inputs,
+			TxList:     txListBytes,
+			TxHash:     receipt.TxHash,
+			Receipt:    receipt,
+			ProposedAt: time.Now(),
+		})
+		return
+	}
+
+	log.Warn("BlockProposed event not found in the receipt, skip archiving", "txHash", receipt.TxHash)
+}
+
+// handleProposal handles the `/proposals/{blockID}` and `/proposals/latest` requests.
+func (p *Proposer) handleProposal(r *http.Request) (interface{}, error) {
+	var (
+		record *archive.Record
+		err    error
+	)
+	if param := strings.TrimPrefix(r.URL.Path, "/proposals/"); param == "latest" {
+		record, err = p.archive.Latest()
+	} else {
+		blockID, parseErr := strconv.ParseUint(param, 10, 64)
+		if parseErr != nil {
+			return nil, server.NewHTTPError(http.StatusBadRequest, fmt.Errorf("invalid block ID: %s", param))
+		}
+		record, err = p.archive.Get(blockID)
+	}
+
+	if err != nil {
+		if errors.Is(err, archive.ErrNotFound) {
+			return nil, server.NewHTTPError(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	return record, nil
+}
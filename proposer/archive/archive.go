@@ -0,0 +1,289 @@
+package archive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+)
+
+const (
+	// recordFileExt is the file extension of the archived records.
+	recordFileExt = ".json"
+	// queueSize is the maximum number of pending records to be written.
+	queueSize = 64
+)
+
+var (
+	ErrNotFound = errors.New("proposal not found")
+)
+
+// Record contains the exact TaikoL1.proposeBlock inputs of a proposal, and its L1 transaction result.
+// Only the data published on L1 is kept, so that no secrets like the private keys, RPC endpoints or
+// builder tokens will ever be stored.
+type Record struct {
+	BlockID    uint64                              `json:"blockID"`
+	Meta       *bindings.TaikoDataBlockMetadata    `json:"meta"`
+	MetaInput  *encoding.TaikoL1BlockMetadataInput `json:"metaInput"`
+	Input      hexutil.Bytes                       `json:"input"`
+	TxList     hexutil.Bytes                       `json:"txList"`
+	TxHash     common.Hash                         `json:"txHash"`
+	Receipt    *types.Receipt                      `json:"receipt"`
+	ProposedAt time.Time                           `json:"proposedAt"`
+}
+
+// Archive is a bounded on-disk ring of the latest proposals, one file per proposal. The records are
+// written asynchronously, dropped if the writer can't keep up, and the oldest ones are evicted once
+// there are more than the maximum number of records, or their total size is over the maximum bytes.
+type Archive struct {
+	dir        string
+	maxRecords int
+	maxBytes   int64
+
+	mutex sync.Mutex
+	ids   []uint64         // Archived block IDs, in ascending order
+	sizes map[uint64]int64 // blockID -> record file size
+	total int64
+
+	queue chan *Record
+	done  chan struct{}
+	wg    sync.WaitGroup
+}
+
+// New creates a new Archive instance in the given directory, the existing records will be indexed, and
+// a worker writing the new records will be started. A zero maximum bytes means no size limit.
+func New(dir string, maxRecords int, maxBytes int64) (*Archive, error) {
+	if maxRecords <= 0 {
+		return nil, fmt.Errorf("invalid maximum number of archived proposals: %d", maxRecords)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create proposals archive directory: %w", err)
+	}
+
+	a := &Archive{
+		dir:        dir,
+		maxRecords: maxRecords,
+		maxBytes:   maxBytes,
+		sizes:      make(map[uint64]int64),
+		queue:      make(chan *Record, queueSize),
+		done:       make(chan struct{}),
+	}
+	if err := a.index(); err != nil {
+		return nil, err
+	}
+
+	a.wg.Add(1)
+	go a.loop()
+
+	return a, nil
+}
+
+// index loads the block IDs and sizes of the existing records.
+func (a *Archive) index() error {
+	entries, err := os.ReadDir(a.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read proposals archive directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		id, ok := parseRecordFileName(entry.Name())
+		if !ok || entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return fmt.Errorf("failed to stat archived proposal %s: %w", entry.Name(), err)
+		}
+
+		a.ids = append(a.ids, id)
+		a.sizes[id] = info.Size()
+		a.total += info.Size()
+	}
+	sort.Slice(a.ids, func(i, j int) bool { return a.ids[i] < a.ids[j] })
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.evict()
+	return nil
+}
+
+// Put queues the given record to be written, returns false if the record is dropped. All methods of a
+// nil Archive are no-ops.
+func (a *Archive) Put(record *Record) bool {
+	if a == nil {
+		return false
+	}
+
+	select {
+	case <-a.done:
+		return false
+	default:
+	}
+
+	select {
+	case a.queue <- record:
+		return true
+	default:
+		log.Warn("Proposals archive queue is full, drop the record", "blockID", record.BlockID)
+		return false
+	}
+}
+
+// Get returns the archived record of the given block.
+func (a *Archive) Get(blockID uint64) (*Record, error) {
+	a.mutex.Lock()
+	_, ok := a.sizes[blockID]
+	a.mutex.Unlock()
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return readRecord(a.recordPath(blockID))
+}
+
+// Latest returns the archived record with the highest block ID.
+func (a *Archive) Latest() (*Record, error) {
+	a.mutex.Lock()
+	if len(a.ids) == 0 {
+		a.mutex.Unlock()
+		return nil, ErrNotFound
+	}
+	latest := a.ids[len(a.ids)-1]
+	a.mutex.Unlock()
+
+	return a.Get(latest)
+}
+
+// Close stops the worker after all the queued records have been written.
+func (a *Archive) Close() {
+	if a == nil {
+		return
+	}
+
+	close(a.done)
+	a.wg.Wait()
+}
+
+// loop keeps writing the queued records until the archive is closed.
+func (a *Archive) loop() {
+	defer a.wg.Done()
+
+	for {
+		select {
+		case record := <-a.queue:
+			a.write(record)
+		case <-a.done:
+			for {
+				select {
+				case record := <-a.queue:
+					a.write(record)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// write writes the given record to disk, and then evicts the oldest records if needed.
+func (a *Archive) write(record *Record) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		log.Error("Failed to encode archived proposal", "blockID", record.BlockID, "error", err)
+		return
+	}
+
+	// Write to a temporary file first, so that the readers never see a partially written record.
+	path := a.recordPath(record.BlockID)
+	if err := os.WriteFile(path+".tmp", data, 0o600); err != nil {
+		log.Error("Failed to write archived proposal", "blockID", record.BlockID, "error", err)
+		return
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		log.Error("Failed to write archived proposal", "blockID", record.BlockID, "error", err)
+		return
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if size, ok := a.sizes[record.BlockID]; ok {
+		a.total -= size
+	} else {
+		i := sort.Search(len(a.ids), func(i int) bool { return a.ids[i] >= record.BlockID })
+		a.ids = append(a.ids, 0)
+		copy(a.ids[i+1:], a.ids[i:])
+		a.ids[i] = record.BlockID
+	}
+	a.sizes[record.BlockID] = int64(len(data))
+	a.total += int64(len(data))
+
+	a.evict()
+}
+
+// evict removes the oldest records until the limits are satisfied, the latest record is always kept,
+// the caller must hold the mutex.
+func (a *Archive) evict() {
+	for len(a.ids) > 1 && (len(a.ids) > a.maxRecords || (a.maxBytes != 0 && a.total > a.maxBytes)) {
+		oldest := a.ids[0]
+		if err := os.Remove(a.recordPath(oldest)); err != nil && !os.IsNotExist(err) {
+			log.Warn("Failed to evict archived proposal", "blockID", oldest, "error", err)
+			return
+		}
+
+		a.ids = a.ids[1:]
+		a.total -= a.sizes[oldest]
+		delete(a.sizes, oldest)
+	}
+}
+
+// recordPath returns the file path of the given block's record.
+func (a *Archive) recordPath(blockID uint64) string {
+	return filepath.Join(a.dir, strconv.FormatUint(blockID, 10)+recordFileExt)
+}
+
+// readRecord reads and decodes the record file at the given path.
+func readRecord(path string) (*Record, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to read archived proposal: %w", err)
+	}
+
+	var record Record
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to decode archived proposal: %w", err)
+	}
+
+	return &record, nil
+}
+
+// parseRecordFileName parses the block ID from a record file name.
+func parseRecordFileName(name string) (uint64, bool) {
+	if !strings.HasSuffix(name, recordFileExt) {
+		return 0, false
+	}
+
+	id, err := strconv.ParseUint(strings.TrimSuffix(name, recordFileExt), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return id, true
+}
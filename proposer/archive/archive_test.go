@@ -0,0 +1,125 @@
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRecord(blockID uint64) *Record {
+	return &Record{
+		BlockID:    blockID,
+		Input:      []byte{byte(blockID)},
+		TxList:     bytes.Repeat([]byte{0xff}, 32),
+		TxHash:     common.BigToHash(new(big.Int).SetUint64(blockID)),
+		ProposedAt: time.Now(),
+	}
+}
+
+func TestArchive(t *testing.T) {
+	dir := t.TempDir()
+	a, err := New(dir, 2, 0)
+	require.Nil(t, err)
+
+	_, err = a.Latest()
+	require.ErrorIs(t, err, ErrNotFound)
+
+	for i := uint64(1); i <= 3; i++ {
+		require.True(t, a.Put(newTestRecord(i)))
+	}
+	a.Close()
+	require.False(t, a.Put(newTestRecord(4)))
+
+	// The oldest record is evicted.
+	_, err = a.Get(1)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = os.Stat(filepath.Join(dir, "1.json"))
+	require.True(t, os.IsNotExist(err))
+
+	record, err := a.Get(2)
+	require.Nil(t, err)
+	require.Equal(t, uint64(2), record.BlockID)
+	require.Equal(t, []byte{2}, []byte(record.Input))
+
+	record, err = a.Latest()
+	require.Nil(t, err)
+	require.Equal(t, uint64(3), record.BlockID)
+	require.Equal(t, common.BigToHash(big.NewInt(3)), record.TxHash)
+
+	// The existing records are indexed when reopening.
+	a, err = New(dir, 1, 0)
+	require.Nil(t, err)
+	defer a.Close()
+
+	_, err = a.Get(2)
+	require.ErrorIs(t, err, ErrNotFound)
+	record, err = a.Latest()
+	require.Nil(t, err)
+	require.Equal(t, uint64(3), record.BlockID)
+}
+
+func TestArchiveMaxBytes(t *testing.T) {
+	dir := t.TempDir()
+	a, err := New(dir, 10, 1)
+	require.Nil(t, err)
+
+	require.True(t, a.Put(newTestRecord(1)))
+	require.True(t, a.Put(newTestRecord(2)))
+	a.Close()
+
+	// The latest record is always kept.
+	_, err = a.Get(1)
+	require.ErrorIs(t, err, ErrNotFound)
+	record, err := a.Latest()
+	require.Nil(t, err)
+	require.Equal(t, uint64(2), record.BlockID)
+}
+
+func TestNilArchive(t *testing.T) {
+	var a *Archive
+	require.NotPanics(t, func() {
+		require.False(t, a.Put(newTestRecord(1)))
+		a.Close()
+	})
+
+	_, err := New(t.TempDir(), 0, 0)
+	require.NotNil(t, err)
+}
+
+func TestWriteBundle(t *testing.T) {
+	dir := t.TempDir()
+	a, err := New(dir, 10, 0)
+	require.Nil(t, err)
+
+	require.True(t, a.Put(newTestRecord(1)))
+	require.True(t, a.Put(newTestRecord(2)))
+	a.Close()
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "unknown"), []byte{}, 0o600))
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	count, err := WriteBundle(dir, tw)
+	require.Nil(t, err)
+	require.Equal(t, 2, count)
+	require.Nil(t, tw.Close())
+
+	var names []string
+	tr := tar.NewReader(&buf)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.Nil(t, err)
+		names = append(names, header.Name)
+	}
+	require.Equal(t, []string{"proposals/1.json", "proposals/2.json"}, names)
+}
@@ -0,0 +1,53 @@
+package archive
+
+import (
+	"archive/tar"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// WriteBundle writes all the archived records in the given directory to the given tarball under the
+// `proposals/` directory, used by the `support-bundle` command, returns the number of written records.
+func WriteBundle(dir string, tw *tar.Writer) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read proposals archive directory: %w", err)
+	}
+
+	var count int
+	for _, entry := range entries {
+		if _, ok := parseRecordFileName(entry.Name()); !ok || entry.IsDir() {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			// The record might have been evicted in the meantime.
+			if os.IsNotExist(err) {
+				continue
+			}
+			return count, fmt.Errorf("failed to read archived proposal %s: %w", entry.Name(), err)
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return count, fmt.Errorf("failed to stat archived proposal %s: %w", entry.Name(), err)
+		}
+
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    "proposals/" + entry.Name(),
+			Mode:    0o600,
+			Size:    int64(len(data)),
+			ModTime: info.ModTime(),
+		}); err != nil {
+			return count, fmt.Errorf("failed to write bundle: %w", err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return count, fmt.Errorf("failed to write bundle: %w", err)
+		}
+		count++
+	}
+
+	return count, nil
+}
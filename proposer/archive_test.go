@@ -0,0 +1,48 @@
+package proposer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/pkg/server"
+	"github.com/taikoxyz/taiko-client/proposer/archive"
+)
+
+func TestHandleProposal(t *testing.T) {
+	a, err := archive.New(t.TempDir(), 10, 0)
+	require.Nil(t, err)
+	p := &Proposer{archive: a}
+
+	request := func(path string) (*archive.Record, int) {
+		record, err := p.handleProposal(httptest.NewRequest(http.MethodGet, path, nil))
+		if err != nil {
+			httpErr, ok := err.(*server.HTTPError)
+			require.True(t, ok)
+			return nil, httpErr.Code
+		}
+		return record.(*archive.Record), http.StatusOK
+	}
+
+	_, code := request("/proposals/latest")
+	require.Equal(t, http.StatusNotFound, code)
+
+	require.True(t, a.Put(&archive.Record{BlockID: 1}))
+	require.True(t, a.Put(&archive.Record{BlockID: 2}))
+	a.Close()
+
+	record, code := request("/proposals/latest")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, uint64(2), record.BlockID)
+
+	record, code = request("/proposals/1")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, uint64(1), record.BlockID)
+
+	_, code = request("/proposals/3")
+	require.Equal(t, http.StatusNotFound, code)
+
+	_, code = request("/proposals/abc")
+	require.Equal(t, http.StatusBadRequest, code)
+}
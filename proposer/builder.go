@@ -0,0 +1,78 @@
+package proposer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/taikoxyz/taiko-client/metrics"
+	txListValidator "github.com/taikoxyz/taiko-client/pkg/tx_list_validator"
+	"github.com/taikoxyz/taiko-client/proposer/builder"
+)
+
+// buildTxLists requests the transactions lists from the external block builder, and validates them
+// before proposing.
+func (p *Proposer) buildTxLists(ctx context.Context) ([]types.Transactions, error) {
+	config := p.protocolConfigs.Config()
+	// Rebuilt once the protocol configs change, e.g. after a protocol upgrade.
+	if p.txListValidator == nil || p.txListValidatorVersion != config.Version {
+		p.txListValidator = config.NewTxListValidator(p.rpc.L2ChainID)
+		p.txListValidatorVersion = config.Version
+	}
+
+	startedAt := time.Now()
+	res, err := p.builder.BuildTxLists(ctx, &builder.TxListsRequest{
+		Beneficiary:             p.l2SuggestedFeeRecipient,
+		MaxBytesPerTxList:       config.MaxBytesPerTxList,
+		MaxTransactionsPerBlock: config.MaxTransactionsPerBlock,
+		BlockMaxGasLimit:        config.BlockMaxGasLimit,
+		MinTxGasLimit:           config.MinTxGasLimit,
+		ForcedInclusions:        []hexutil.Bytes{},
+	})
+	metrics.ProposerBuilderLatencyTimer.UpdateSince(startedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeBuiltTxLists(p.txListValidator, p.rpc.L2ChainID, res.TxLists)
+}
+
+// decodeBuiltTxLists decodes the transactions lists built by the block builder, and validates them with
+// the protocol's transactions list rules, along with a preflight check of the transactions' signatures,
+// so that a misbehaving builder can't make the proposer propose invalid blocks.
+func decodeBuiltTxLists(
+	validator *txListValidator.TxListValidator,
+	chainID *big.Int,
+	encodedTxLists []hexutil.Bytes,
+) ([]types.Transactions, error) {
+	var (
+		signer  = types.LatestSignerForChainID(chainID)
+		txLists = make([]types.Transactions, 0, len(encodedTxLists))
+	)
+	for i, txListBytes := range encodedTxLists {
+		if hint, txIdx := validator.ValidateTxListBytes(nil, txListBytes); hint != txListValidator.HintOK {
+			return nil, fmt.Errorf("invalid transactions list %d, hint: %d, txIdx: %d", i, hint, txIdx)
+		}
+
+		var txs types.Transactions
+		if err := rlp.DecodeBytes(txListBytes, &txs); err != nil {
+			return nil, fmt.Errorf("failed to decode transactions list %d: %w", i, err)
+		}
+
+		for j, tx := range txs {
+			if _, err := types.Sender(signer, tx); err != nil {
+				return nil, fmt.Errorf("invalid transaction %d in transactions list %d: %w", j, i, err)
+			}
+		}
+
+		if len(txs) != 0 {
+			txLists = append(txLists, txs)
+		}
+	}
+
+	return txLists, nil
+}
@@ -0,0 +1,94 @@
+package builder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// defaultTimeout is the default timeout of each block builder HTTP request.
+	defaultTimeout = 2 * time.Second
+	// maxResponseBytes is the maximum size of a block builder response body.
+	maxResponseBytes = 16 * 1024 * 1024
+)
+
+// TxListsRequest is the request sent to the block builder, which contains the constraints the built
+// transactions lists must satisfy.
+type TxListsRequest struct {
+	Beneficiary             common.Address `json:"beneficiary"`
+	MaxBytesPerTxList       uint64         `json:"maxBytesPerTxList"`
+	MaxTransactionsPerBlock uint64         `json:"maxTransactionsPerBlock"`
+	BlockMaxGasLimit        uint64         `json:"blockMaxGasLimit"`
+	MinTxGasLimit           uint64         `json:"minTxGasLimit"`
+	// RLP encoded transactions which must be included in the first transactions list.
+	ForcedInclusions []hexutil.Bytes `json:"forcedInclusions"`
+}
+
+// TxListsResponse is the block builder's response, every transactions list will be proposed as a block.
+type TxListsResponse struct {
+	// RLP encoded transactions lists.
+	TxLists []hexutil.Bytes `json:"txLists"`
+}
+
+// Client is a client of an external block builder, the protocol is a simple authenticated HTTP API:
+//
+//	POST {endpoint} <- a JSON encoded TxListsRequest, -> 200 with a JSON encoded TxListsResponse.
+//
+// Every request carries an `Authorization: Bearer {token}` header, if a token is given.
+type Client struct {
+	endpoint   string
+	token      string
+	httpClient *http.Client
+}
+
+// New creates a new block builder client instance.
+func New(endpoint string, token string, timeout time.Duration) *Client {
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	return &Client{endpoint: endpoint, token: token, httpClient: &http.Client{Timeout: timeout}}
+}
+
+// BuildTxLists requests the block builder to build the transactions lists under the given constraints.
+func (c *Client) BuildTxLists(ctx context.Context, request *TxListsRequest) (*TxListsResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(c.token) != 0 {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		resBytes, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return nil, fmt.Errorf("failed to build transactions lists, status: %s, body: %s", res.Status, string(resBytes))
+	}
+
+	var response TxListsResponse
+	if err := json.NewDecoder(io.LimitReader(res.Body, maxResponseBytes)).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode block builder response: %w", err)
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,55 @@
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildTxLists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		var req TxListsRequest
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, common.BigToAddress(common.Big1), req.Beneficiary)
+		require.Equal(t, uint64(120000), req.MaxBytesPerTxList)
+		require.Equal(t, []hexutil.Bytes{{0x1}}, req.ForcedInclusions)
+
+		require.Nil(t, json.NewEncoder(w).Encode(&TxListsResponse{TxLists: []hexutil.Bytes{{0xc0}}}))
+	}))
+	defer server.Close()
+
+	req := &TxListsRequest{
+		Beneficiary:       common.BigToAddress(common.Big1),
+		MaxBytesPerTxList: 120000,
+		ForcedInclusions:  []hexutil.Bytes{{0x1}},
+	}
+
+	res, err := New(server.URL, "token", 0).BuildTxLists(context.Background(), req)
+	require.Nil(t, err)
+	require.Equal(t, []hexutil.Bytes{{0xc0}}, res.TxLists)
+
+	_, err = New(server.URL, "invalid", 0).BuildTxLists(context.Background(), req)
+	require.ErrorContains(t, err, "401")
+}
+
+func TestBuildTxListsTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	_, err := New(server.URL, "", 50*time.Millisecond).BuildTxLists(context.Background(), &TxListsRequest{})
+	require.NotNil(t, err)
+}
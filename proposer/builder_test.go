@@ -0,0 +1,59 @@
+package proposer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+	txListValidator "github.com/taikoxyz/taiko-client/pkg/tx_list_validator"
+)
+
+func TestDecodeBuiltTxLists(t *testing.T) {
+	chainID := big.NewInt(167)
+	validator := txListValidator.NewTxListValidator(6000000, 79, 120000, 21000, chainID)
+
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(chainID), &types.LegacyTx{
+		Nonce:    0,
+		To:       &common.Address{},
+		Value:    common.Big0,
+		Gas:      21000,
+		GasPrice: common.Big1,
+	})
+	require.Nil(t, err)
+
+	txListBytes, err := rlp.EncodeToBytes(types.Transactions{tx})
+	require.Nil(t, err)
+	emptyTxListBytes, err := rlp.EncodeToBytes(types.Transactions{})
+	require.Nil(t, err)
+
+	txLists, err := decodeBuiltTxLists(validator, chainID, []hexutil.Bytes{txListBytes, emptyTxListBytes})
+	require.Nil(t, err)
+	require.Equal(t, 1, len(txLists))
+	require.Equal(t, tx.Hash(), txLists[0][0].Hash())
+
+	// Invalid RLP bytes.
+	_, err = decodeBuiltTxLists(validator, chainID, []hexutil.Bytes{{0x1, 0x2}})
+	require.NotNil(t, err)
+
+	// Signed for another chain.
+	otherChainTx, err := types.SignNewTx(key, types.LatestSignerForChainID(common.Big1), &types.LegacyTx{
+		To:       &common.Address{},
+		Value:    common.Big0,
+		Gas:      21000,
+		GasPrice: common.Big1,
+	})
+	require.Nil(t, err)
+	otherChainTxListBytes, err := rlp.EncodeToBytes(types.Transactions{otherChainTx})
+	require.Nil(t, err)
+
+	_, err = decodeBuiltTxLists(validator, chainID, []hexutil.Bytes{otherChainTxListBytes})
+	require.NotNil(t, err)
+}
@@ -18,12 +18,24 @@ type Config struct {
 	L2Endpoint                 string
 	TaikoL1Address             common.Address
 	TaikoL2Address             common.Address
+	TaikoL1ABIPath             string
+	TaikoL2ABIPath             string
 	L1ProposerPrivKey          *ecdsa.PrivateKey
 	L2SuggestedFeeRecipient    common.Address
 	ProposeInterval            *time.Duration
 	CommitSlot                 uint64
 	LocalAddresses             []common.Address
+	ForbiddenToAddresses       []common.Address
 	ProposeEmptyBlocksInterval *time.Duration
+	MempoolFillRatioThreshold  float64
+	MaxProposalInterval        time.Duration
+	BuilderEndpoint            string
+	BuilderToken               string
+	BuilderTimeout             time.Duration
+	HTTPAddr                   string
+	ArchiveDir                 string
+	ArchiveSize                uint
+	ArchiveMaxBytes            int64
 }
 
 // NewConfigFromCliContext initializes a Config instance from
@@ -70,16 +82,105 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 		}
 	}
 
-	return &Config{
+	forbiddenToAddresses := []common.Address{}
+	if c.IsSet(flags.ForbiddenToAddresses.Name) {
+		for _, account := range strings.Split(c.String(flags.ForbiddenToAddresses.Name), ",") {
+			if trimmed := strings.TrimSpace(account); !common.IsHexAddress(trimmed) {
+				return nil, fmt.Errorf("invalid account in --forbidden-to-addresses: %s", trimmed)
+			} else {
+				forbiddenToAddresses = append(forbiddenToAddresses, common.HexToAddress(trimmed))
+			}
+		}
+	}
+
+	cfg := &Config{
 		L1Endpoint:                 c.String(flags.L1WSEndpoint.Name),
 		L2Endpoint:                 c.String(flags.L2HTTPEndpoint.Name),
 		TaikoL1Address:             common.HexToAddress(c.String(flags.TaikoL1Address.Name)),
 		TaikoL2Address:             common.HexToAddress(c.String(flags.TaikoL2Address.Name)),
+		TaikoL1ABIPath:             c.String(flags.TaikoL1ABIPath.Name),
+		TaikoL2ABIPath:             c.String(flags.TaikoL2ABIPath.Name),
 		L1ProposerPrivKey:          l1ProposerPrivKey,
 		L2SuggestedFeeRecipient:    common.HexToAddress(l2SuggestedFeeRecipient),
 		ProposeInterval:            proposingInterval,
 		CommitSlot:                 c.Uint64(flags.CommitSlot.Name),
 		LocalAddresses:             localAddresses,
+		ForbiddenToAddresses:       forbiddenToAddresses,
 		ProposeEmptyBlocksInterval: proposeEmptyBlocksInterval,
-	}, nil
+		MempoolFillRatioThreshold:  c.Float64(flags.MempoolFillRatioThreshold.Name),
+		MaxProposalInterval:        c.Duration(flags.MaxProposalInterval.Name),
+		BuilderEndpoint:            c.String(flags.BuilderEndpoint.Name),
+		BuilderToken:               c.String(flags.BuilderToken.Name),
+		BuilderTimeout:             c.Duration(flags.BuilderTimeout.Name),
+		HTTPAddr:                   c.String(flags.HTTPAddr.Name),
+		ArchiveDir:                 c.String(flags.ArchiveDir.Name),
+		ArchiveSize:                c.Uint(flags.ArchiveSize.Name),
+		ArchiveMaxBytes:            c.Int64(flags.ArchiveMaxBytes.Name),
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Validate checks the configurations which contradict each other.
+func (c *Config) Validate() error {
+	if c.ProposeInterval != nil && *c.ProposeInterval <= 0 {
+		return fmt.Errorf("invalid --%s: %s", flags.ProposeInterval.Name, c.ProposeInterval)
+	}
+
+	if c.ProposeEmptyBlocksInterval != nil {
+		if *c.ProposeEmptyBlocksInterval <= 0 {
+			return fmt.Errorf("invalid --%s: %s", flags.ProposeEmptyBlocksInterval.Name, c.ProposeEmptyBlocksInterval)
+		}
+
+		// Empty blocks are only proposed on the proposing ticks.
+		if c.ProposeInterval != nil && *c.ProposeEmptyBlocksInterval < *c.ProposeInterval {
+			return fmt.Errorf(
+				"--%s %s is shorter than --%s %s",
+				flags.ProposeEmptyBlocksInterval.Name, c.ProposeEmptyBlocksInterval,
+				flags.ProposeInterval.Name, c.ProposeInterval,
+			)
+		}
+	}
+
+	if c.MempoolFillRatioThreshold < 0 || c.MempoolFillRatioThreshold > 1 {
+		return fmt.Errorf("invalid --%s: %v", flags.MempoolFillRatioThreshold.Name, c.MempoolFillRatioThreshold)
+	}
+	if c.MempoolFillRatioThreshold > 0 {
+		// The adaptive interval starts from, and is reset to, the proposing interval.
+		if c.ProposeInterval == nil {
+			return fmt.Errorf(
+				"--%s requires --%s", flags.MempoolFillRatioThreshold.Name, flags.ProposeInterval.Name,
+			)
+		}
+		if c.MaxProposalInterval < *c.ProposeInterval {
+			return fmt.Errorf(
+				"--%s %s is shorter than --%s %s",
+				flags.MaxProposalInterval.Name, c.MaxProposalInterval,
+				flags.ProposeInterval.Name, c.ProposeInterval,
+			)
+		}
+	}
+
+	if len(c.BuilderEndpoint) == 0 {
+		if len(c.BuilderToken) != 0 {
+			return fmt.Errorf("--%s is only used by --%s", flags.BuilderToken.Name, flags.BuilderEndpoint.Name)
+		}
+	} else if c.BuilderTimeout < 0 {
+		return fmt.Errorf("invalid --%s: %s", flags.BuilderTimeout.Name, c.BuilderTimeout)
+	}
+
+	if len(c.ArchiveDir) != 0 {
+		if c.ArchiveSize == 0 {
+			return fmt.Errorf("invalid --%s: %d", flags.ArchiveSize.Name, c.ArchiveSize)
+		}
+		if c.ArchiveMaxBytes < 0 {
+			return fmt.Errorf("invalid --%s: %d", flags.ArchiveMaxBytes.Name, c.ArchiveMaxBytes)
+		}
+	}
+
+	return nil
 }
@@ -4,9 +4,12 @@ import (
 	"context"
 	"os"
 	"strconv"
+	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
 	"github.com/taikoxyz/taiko-client/cmd/flags"
 	"github.com/urfave/cli/v2"
 )
@@ -18,6 +21,7 @@ func (s *ProposerTestSuite) TestNewConfigFromCliContext() {
 	taikoL2 := os.Getenv("TAIKO_L2_ADDRESS")
 	proposeInterval := "10s"
 	commitSlot := 1024
+	archiveDir := s.T().TempDir()
 
 	goldenTouchAddress, err := s.RpcClient.TaikoL2.GOLDENTOUCHADDRESS(nil)
 	s.Nil(err)
@@ -31,11 +35,23 @@ func (s *ProposerTestSuite) TestNewConfigFromCliContext() {
 		&cli.StringFlag{Name: flags.L2HTTPEndpoint.Name},
 		&cli.StringFlag{Name: flags.TaikoL1Address.Name},
 		&cli.StringFlag{Name: flags.TaikoL2Address.Name},
+		&cli.StringFlag{Name: flags.TaikoL1ABIPath.Name},
+		&cli.StringFlag{Name: flags.TaikoL2ABIPath.Name},
 		&cli.StringFlag{Name: flags.L1ProposerPrivKey.Name},
 		&cli.StringFlag{Name: flags.L2SuggestedFeeRecipient.Name},
 		&cli.StringFlag{Name: flags.ProposeInterval.Name},
 		&cli.Uint64Flag{Name: flags.CommitSlot.Name},
 		&cli.StringFlag{Name: flags.TxPoolLocals.Name},
+		&cli.StringFlag{Name: flags.ForbiddenToAddresses.Name},
+		&cli.Float64Flag{Name: flags.MempoolFillRatioThreshold.Name},
+		&cli.DurationFlag{Name: flags.MaxProposalInterval.Name},
+		&cli.StringFlag{Name: flags.BuilderEndpoint.Name},
+		&cli.StringFlag{Name: flags.BuilderToken.Name},
+		&cli.DurationFlag{Name: flags.BuilderTimeout.Name},
+		&cli.StringFlag{Name: flags.HTTPAddr.Name},
+		&cli.StringFlag{Name: flags.ArchiveDir.Name},
+		&cli.UintFlag{Name: flags.ArchiveSize.Name},
+		&cli.Int64Flag{Name: flags.ArchiveMaxBytes.Name},
 	}
 	app.Action = func(ctx *cli.Context) error {
 		c, err := NewConfigFromCliContext(ctx)
@@ -44,12 +60,24 @@ func (s *ProposerTestSuite) TestNewConfigFromCliContext() {
 		s.Equal(l2Endpoint, c.L2Endpoint)
 		s.Equal(taikoL1, c.TaikoL1Address.String())
 		s.Equal(taikoL2, c.TaikoL2Address.String())
+		s.Equal("/tmp/TaikoL1.json", c.TaikoL1ABIPath)
+		s.Equal("/tmp/TaikoL2.json", c.TaikoL2ABIPath)
 		s.Equal(goldenTouchAddress, crypto.PubkeyToAddress(c.L1ProposerPrivKey.PublicKey))
 		s.Equal(goldenTouchAddress, c.L2SuggestedFeeRecipient)
 		s.Equal(float64(10), c.ProposeInterval.Seconds())
 		s.Equal(uint64(commitSlot), c.CommitSlot)
 		s.Equal(1, len(c.LocalAddresses))
 		s.Equal(goldenTouchAddress, c.LocalAddresses[0])
+		s.Equal([]common.Address{common.HexToAddress(taikoL1), common.HexToAddress(taikoL2)}, c.ForbiddenToAddresses)
+		s.Equal(0.5, c.MempoolFillRatioThreshold)
+		s.Equal(time.Minute, c.MaxProposalInterval)
+		s.Equal("http://localhost:18550", c.BuilderEndpoint)
+		s.Equal("token", c.BuilderToken)
+		s.Equal(3*time.Second, c.BuilderTimeout)
+		s.Equal("127.0.0.1:0", c.HTTPAddr)
+		s.Equal(archiveDir, c.ArchiveDir)
+		s.Equal(uint(16), c.ArchiveSize)
+		s.Equal(int64(1024*1024), c.ArchiveMaxBytes)
 		s.Nil(new(Proposer).InitFromCli(context.Background(), ctx))
 
 		return err
@@ -61,10 +89,120 @@ func (s *ProposerTestSuite) TestNewConfigFromCliContext() {
 		"-" + flags.L2HTTPEndpoint.Name, l2Endpoint,
 		"-" + flags.TaikoL1Address.Name, taikoL1,
 		"-" + flags.TaikoL2Address.Name, taikoL2,
+		"-" + flags.TaikoL1ABIPath.Name, "/tmp/TaikoL1.json",
+		"-" + flags.TaikoL2ABIPath.Name, "/tmp/TaikoL2.json",
 		"-" + flags.L1ProposerPrivKey.Name, common.Bytes2Hex(goldenTouchPrivKey.Bytes()),
 		"-" + flags.L2SuggestedFeeRecipient.Name, goldenTouchAddress.Hex(),
 		"-" + flags.ProposeInterval.Name, proposeInterval,
 		"-" + flags.CommitSlot.Name, strconv.Itoa(commitSlot),
 		"-" + flags.TxPoolLocals.Name, goldenTouchAddress.Hex(),
+		"-" + flags.ForbiddenToAddresses.Name, taikoL1 + ", " + taikoL2,
+		"-" + flags.MempoolFillRatioThreshold.Name, "0.5",
+		"-" + flags.MaxProposalInterval.Name, "1m",
+		"-" + flags.BuilderEndpoint.Name, "http://localhost:18550",
+		"-" + flags.BuilderToken.Name, "token",
+		"-" + flags.BuilderTimeout.Name, "3s",
+		"-" + flags.HTTPAddr.Name, "127.0.0.1:0",
+		"-" + flags.ArchiveDir.Name, archiveDir,
+		"-" + flags.ArchiveSize.Name, "16",
+		"-" + flags.ArchiveMaxBytes.Name, "1048576",
 	}))
 }
+
+func TestConfigValidate(t *testing.T) {
+	var (
+		zero       = time.Duration(0)
+		second     = time.Second
+		tenSeconds = 10 * time.Second
+	)
+
+	testCases := []struct {
+		name   string
+		modify func(c *Config)
+		err    string
+	}{
+		{"valid", func(c *Config) {}, ""},
+		{"zeroProposeInterval", func(c *Config) { c.ProposeInterval = &zero }, "invalid --proposeInterval: 0s"},
+		{
+			"zeroProposeEmptyBlocksInterval",
+			func(c *Config) { c.ProposeEmptyBlocksInterval = &zero },
+			"invalid --proposeEmptyBlockInterval: 0s",
+		},
+		{
+			"proposeEmptyBlocksIntervalShorterThanProposeInterval",
+			func(c *Config) {
+				c.ProposeInterval = &tenSeconds
+				c.ProposeEmptyBlocksInterval = &second
+			},
+			"--proposeEmptyBlockInterval 1s is shorter than --proposeInterval 10s",
+		},
+		{
+			"negativeMempoolFillRatioThreshold",
+			func(c *Config) { c.MempoolFillRatioThreshold = -0.5 },
+			"invalid --mempool-fill-ratio-threshold: -0.5",
+		},
+		{
+			"mempoolFillRatioThresholdAboveOne",
+			func(c *Config) { c.MempoolFillRatioThreshold = 1.5 },
+			"invalid --mempool-fill-ratio-threshold: 1.5",
+		},
+		{
+			"mempoolFillRatioThresholdWithoutProposeInterval",
+			func(c *Config) {
+				c.ProposeInterval = nil
+				c.MempoolFillRatioThreshold = 0.5
+			},
+			"--mempool-fill-ratio-threshold requires --proposeInterval",
+		},
+		{
+			"maxProposalIntervalShorterThanProposeInterval",
+			func(c *Config) {
+				c.ProposeInterval = &tenSeconds
+				c.MempoolFillRatioThreshold = 0.5
+				c.MaxProposalInterval = time.Second
+			},
+			"--max-proposal-interval 1s is shorter than --proposeInterval 10s",
+		},
+		{
+			"builderTokenWithoutEndpoint",
+			func(c *Config) { c.BuilderToken = "token" },
+			"--proposer.builderToken is only used by --proposer.builderEndpoint",
+		},
+		{
+			"negativeBuilderTimeout",
+			func(c *Config) {
+				c.BuilderEndpoint = "http://localhost:18550"
+				c.BuilderTimeout = -time.Second
+			},
+			"invalid --proposer.builderTimeout: -1s",
+		},
+		{
+			"zeroArchiveSize",
+			func(c *Config) { c.ArchiveDir = "/tmp/proposals" },
+			"invalid --proposer.archiveSize: 0",
+		},
+		{
+			"negativeArchiveMaxBytes",
+			func(c *Config) {
+				c.ArchiveDir = "/tmp/proposals"
+				c.ArchiveSize = 1
+				c.ArchiveMaxBytes = -1
+			},
+			"invalid --proposer.archiveMaxBytes: -1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Config{ProposeInterval: &second}
+			tc.modify(c)
+
+			err := c.Validate()
+			if tc.err == "" {
+				require.Nil(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package proposer
+
+import (
+	"time"
+)
+
+// nextProposingInterval returns the proposing interval following the given current one, by the number of the
+// pending transactions seen in the last proposing operation. If the pending transactions are fewer than the
+// threshold ratio of the maximum transactions per block, the interval is doubled up to the given maximum,
+// otherwise it is reset to the given minimum.
+func nextProposingInterval(
+	current time.Duration,
+	minInterval time.Duration,
+	maxInterval time.Duration,
+	pendingTxs uint64,
+	maxTxsPerBlock uint64,
+	threshold float64,
+) time.Duration {
+	if float64(pendingTxs) >= threshold*float64(maxTxsPerBlock) {
+		return minInterval
+	}
+
+	if current < minInterval {
+		current = minInterval
+	}
+	if next := 2 * current; next < maxInterval {
+		return next
+	}
+
+	return maxInterval
+}
@@ -0,0 +1,40 @@
+package proposer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextProposingInterval(t *testing.T) {
+	var (
+		minInterval = 10 * time.Second
+		maxInterval = 60 * time.Second
+	)
+
+	testCases := []struct {
+		name       string
+		current    time.Duration
+		pendingTxs uint64
+		expected   time.Duration
+	}{
+		{"belowThreshold", minInterval, 49, 20 * time.Second},
+		{"belowThresholdDoubled", 20 * time.Second, 0, 40 * time.Second},
+		{"belowThresholdCapped", 40 * time.Second, 0, maxInterval},
+		{"belowThresholdAtMax", maxInterval, 0, maxInterval},
+		{"belowThresholdShorterThanMin", time.Second, 0, 20 * time.Second},
+		{"atThreshold", 40 * time.Second, 50, minInterval},
+		{"aboveThreshold", maxInterval, 100, minInterval},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(
+				t,
+				tc.expected,
+				nextProposingInterval(tc.current, minInterval, maxInterval, tc.pendingTxs, 100, 0.5),
+			)
+		})
+	}
+}
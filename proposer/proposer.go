@@ -17,15 +17,23 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
-	"github.com/taikoxyz/taiko-client/bindings"
 	"github.com/taikoxyz/taiko-client/bindings/encoding"
 	"github.com/taikoxyz/taiko-client/metrics"
+	"github.com/taikoxyz/taiko-client/pkg/protocol"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	"github.com/taikoxyz/taiko-client/pkg/server"
+	txListValidator "github.com/taikoxyz/taiko-client/pkg/tx_list_validator"
+	"github.com/taikoxyz/taiko-client/proposer/archive"
+	"github.com/taikoxyz/taiko-client/proposer/builder"
 	"github.com/urfave/cli/v2"
 )
 
 var (
-	errNoNewTxs = errors.New("no new transactions")
+	errNoNewTxs           = errors.New("no new transactions")
+	errProtocolPaused     = errors.New("protocol paused")
+	errProposerNotAllowed = errors.New("proposer not allowed")
+	// readinessCheckTTL is the time to live of a cached proposer readiness check result.
+	readinessCheckTTL = 12 * time.Second
 )
 
 // Proposer keep proposing new transactions from L2 execution engine's tx pool at a fixed interval.
@@ -41,11 +49,30 @@ type Proposer struct {
 	proposingInterval          *time.Duration
 	proposeEmptyBlocksInterval *time.Duration
 	proposingTimer             *time.Timer
+	mempoolFillRatioThreshold  float64
+	maxProposingInterval       time.Duration
+	adaptiveProposingInterval  time.Duration // Current adaptive interval, 0 before the first tick
+	lastPendingTxs             uint64        // Pending transactions seen in the last proposing operation
 	commitSlot                 uint64
 	locals                     []common.Address
+	forbiddenToAddresses       []common.Address
 
 	// Protocol configurations
-	protocolConfigs *bindings.TaikoDataConfig
+	protocolConfigs *protocol.Configs
+	taikoL1Address  common.Address
+
+	// Optional external block builder
+	builder                *builder.Client
+	txListValidator        *txListValidator.TxListValidator
+	txListValidatorVersion uint64 // Version of the protocol configs the txListValidator is built with
+
+	// Optional archive of the latest proposals, and the HTTP server exposing it
+	archive    *archive.Archive
+	httpServer *server.Server
+
+	// Cached readiness check result
+	readinessCheckedAt time.Time
+	readinessErr       error
 
 	// Only for testing purposes
 	CustomProposeOpHook func() error
@@ -71,9 +98,13 @@ func InitFromConfig(ctx context.Context, p *Proposer, cfg *Config) (err error) {
 	p.l2SuggestedFeeRecipient = cfg.L2SuggestedFeeRecipient
 	p.proposingInterval = cfg.ProposeInterval
 	p.proposeEmptyBlocksInterval = cfg.ProposeEmptyBlocksInterval
+	p.mempoolFillRatioThreshold = cfg.MempoolFillRatioThreshold
+	p.maxProposingInterval = cfg.MaxProposalInterval
 	p.wg = sync.WaitGroup{}
 	p.locals = cfg.LocalAddresses
+	p.forbiddenToAddresses = cfg.ForbiddenToAddresses
 	p.commitSlot = cfg.CommitSlot
+	p.taikoL1Address = cfg.TaikoL1Address
 	p.ctx = ctx
 
 	// RPC clients
@@ -82,24 +113,50 @@ func InitFromConfig(ctx context.Context, p *Proposer, cfg *Config) (err error) {
 		L2Endpoint:     cfg.L2Endpoint,
 		TaikoL1Address: cfg.TaikoL1Address,
 		TaikoL2Address: cfg.TaikoL2Address,
+		TaikoL1ABIPath: cfg.TaikoL1ABIPath,
+		TaikoL2ABIPath: cfg.TaikoL2ABIPath,
 	}); err != nil {
 		return fmt.Errorf("initialize rpc clients error: %w", err)
 	}
 
 	// Protocol configs
-	protocolConfigs, err := p.rpc.TaikoL1.GetConfig(nil)
-	if err != nil {
-		return fmt.Errorf("failed to get protocol configs: %w", err)
+	if p.protocolConfigs, err = protocol.New(p.ctx, p.rpc.TaikoL1); err != nil {
+		return err
 	}
-	p.protocolConfigs = &protocolConfigs
 
-	log.Info("Protocol configs", "configs", p.protocolConfigs)
+	log.Info("Protocol configs", "configs", p.protocolConfigs.Config())
+
+	if len(cfg.BuilderEndpoint) != 0 {
+		log.Info("External block builder enabled", "endpoint", cfg.BuilderEndpoint)
+		p.builder = builder.New(cfg.BuilderEndpoint, cfg.BuilderToken, cfg.BuilderTimeout)
+	}
+
+	if len(cfg.ArchiveDir) != 0 {
+		if p.archive, err = archive.New(cfg.ArchiveDir, int(cfg.ArchiveSize), cfg.ArchiveMaxBytes); err != nil {
+			return err
+		}
+	}
+
+	if len(cfg.HTTPAddr) != 0 {
+		p.httpServer = server.New(cfg.HTTPAddr)
+		if p.archive != nil {
+			p.httpServer.HandleJSON("/proposals/", p.handleProposal)
+		}
+	}
 
 	return nil
 }
 
 // Start starts the proposer's main loop.
 func (p *Proposer) Start() error {
+	if p.httpServer != nil {
+		if err := p.httpServer.Start(); err != nil {
+			return err
+		}
+	}
+
+	p.protocolConfigs.Start(p.ctx)
+
 	p.wg.Add(1)
 	go p.eventLoop()
 	return nil
@@ -123,6 +180,18 @@ func (p *Proposer) eventLoop() {
 			metrics.ProposerProposeEpochCounter.Inc(1)
 
 			if err := p.ProposeOp(p.ctx); err != nil {
+				if errors.Is(err, errProtocolPaused) {
+					log.Info("Skip proposing", "reason", err)
+					metrics.ProposerSkippedPausedCounter.Inc(1)
+					continue
+				}
+
+				if errors.Is(err, errProposerNotAllowed) {
+					log.Info("Skip proposing", "reason", err)
+					metrics.ProposerSkippedNotAllowedCounter.Inc(1)
+					continue
+				}
+
 				if !errors.Is(err, errNoNewTxs) {
 					log.Error("Proposing operation error", "error", err)
 					continue
@@ -150,7 +219,13 @@ func (p *Proposer) eventLoop() {
 
 // Close closes the proposer instance.
 func (p *Proposer) Close() {
+	if p.httpServer != nil {
+		if err := p.httpServer.Shutdown(context.Background()); err != nil {
+			log.Error("Failed to shutdown HTTP server", "error", err)
+		}
+	}
 	p.wg.Wait()
+	p.archive.Close()
 }
 
 // ProposeOp performs a proposing operation, fetching transactions
@@ -161,26 +236,29 @@ func (p *Proposer) ProposeOp(ctx context.Context) error {
 		return p.CustomProposeOpHook()
 	}
 
+	// A failed operation is treated as an empty pool, so that the adaptive interval backs off.
+	p.lastPendingTxs = 0
+
+	// Make sure the protocol contract currently accepts proposals from this proposer.
+	if err := p.checkReadiness(ctx); err != nil {
+		return err
+	}
+
 	// Wait until L2 execution engine is synced at first.
 	if err := p.rpc.WaitTillL2Synced(ctx); err != nil {
 		return fmt.Errorf("failed to wait until L2 execution engine synced: %w", err)
 	}
 
-	log.Info("Start fetching L2 execution engine's transaction pool content")
-
-	txLists, err := p.rpc.GetPoolContent(
-		ctx,
-		p.protocolConfigs.MaxTransactionsPerBlock,
-		p.protocolConfigs.BlockMaxGasLimit,
-		p.protocolConfigs.MaxBytesPerTxList,
-		p.protocolConfigs.MinTxGasLimit,
-		p.locals,
-	)
+	txLists, fromBuilder, err := p.fetchTxLists(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to fetch transaction pool content: %w", err)
+		return err
+	}
+
+	for _, txs := range txLists {
+		p.lastPendingTxs += uint64(txs.Len())
 	}
 
-	log.Info("Transactions lists count", "count", len(txLists))
+	log.Info("Transactions lists count", "count", len(txLists), "txs", p.lastPendingTxs, "fromBuilder", fromBuilder)
 
 	if len(txLists) == 0 {
 		return errNoNewTxs
@@ -202,6 +280,12 @@ func (p *Proposer) ProposeOp(ctx context.Context) error {
 		}, txListBytes, uint(txs.Len())); err != nil {
 			return fmt.Errorf("failed to propose transactions: %w", err)
 		}
+
+		if fromBuilder {
+			metrics.ProposerBuilderTxListsCounter.Inc(1)
+		} else {
+			metrics.ProposerLocalTxListsCounter.Inc(1)
+		}
 	}
 
 	if p.AfterCommitHook != nil {
@@ -213,6 +297,79 @@ func (p *Proposer) ProposeOp(ctx context.Context) error {
 	return nil
 }
 
+// fetchTxLists fetches the transactions lists to propose from the external block builder if enabled, if
+// the builder is unreachable or returns invalid lists, falls back to L2 execution engine's transaction pool.
+func (p *Proposer) fetchTxLists(ctx context.Context) ([]types.Transactions, bool, error) {
+	if p.builder != nil {
+		txLists, err := p.buildTxLists(ctx)
+		if err == nil {
+			return filterForbiddenTxs(txLists, p.forbiddenToAddresses, p.txSigner()), true, nil
+		}
+
+		log.Warn("Failed to build transactions lists with the block builder, fall back to local pool", "error", err)
+		metrics.ProposerBuilderFallbackCounter.Inc(1)
+	}
+
+	log.Info("Start fetching L2 execution engine's transaction pool content")
+
+	config := p.protocolConfigs.Config()
+	txLists, err := p.rpc.GetPoolContent(
+		ctx,
+		new(big.Int).SetUint64(config.MaxTransactionsPerBlock),
+		new(big.Int).SetUint64(config.BlockMaxGasLimit),
+		new(big.Int).SetUint64(config.MaxBytesPerTxList),
+		new(big.Int).SetUint64(config.MinTxGasLimit),
+		p.locals,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to fetch transaction pool content: %w", err)
+	}
+
+	return filterForbiddenTxs(txLists, p.forbiddenToAddresses, p.txSigner()), false, nil
+}
+
+// txSigner returns the signer of the L2 transactions.
+func (p *Proposer) txSigner() types.Signer {
+	return types.LatestSignerForChainID(p.rpc.L2ChainID)
+}
+
+// checkReadiness checks whether the protocol is not paused and the proposer is currently permitted
+// to propose, the result will be cached for readinessCheckTTL.
+func (p *Proposer) checkReadiness(ctx context.Context) error {
+	if !p.readinessCheckedAt.IsZero() && time.Since(p.readinessCheckedAt) < readinessCheckTTL {
+		return p.readinessErr
+	}
+
+	paused, err := p.rpc.IsProtocolPaused(ctx, p.taikoL1Address)
+	if err != nil {
+		return fmt.Errorf("failed to check whether the protocol is paused: %w", err)
+	}
+
+	allowed, err := p.rpc.IsProposerAllowed(ctx, crypto.PubkeyToAddress(p.l1ProposerPrivKey.PublicKey))
+	if err != nil {
+		return fmt.Errorf("failed to check whether the proposer is allowed: %w", err)
+	}
+
+	var readinessErr error
+	switch {
+	case paused:
+		readinessErr = errProtocolPaused
+	case !allowed:
+		readinessErr = errProposerNotAllowed
+	}
+
+	if readinessErr != nil {
+		log.Error("🚨 Proposer is not ready to propose", "reason", readinessErr)
+	} else {
+		log.Info("Proposer readiness checked", "paused", paused, "allowed", allowed)
+	}
+
+	p.readinessCheckedAt = time.Now()
+	p.readinessErr = readinessErr
+
+	return readinessErr
+}
+
 // ProposeTxList proposes the given transactions list to TaikoL1 smart contract.
 func (p *Proposer) ProposeTxList(
 	ctx context.Context,
@@ -236,10 +393,13 @@ func (p *Proposer) ProposeTxList(
 		return encoding.TryParsingCustomError(err)
 	}
 
-	if _, err := rpc.WaitReceipt(ctx, p.rpc.L1, proposeTx); err != nil {
+	receipt, err := rpc.WaitReceipt(ctx, p.rpc.L1, proposeTx)
+	if err != nil {
 		return err
 	}
 
+	p.archiveProposal(meta, inputs, txListBytes, receipt)
+
 	log.Info("📝 Propose transactions succeeded", "txs", txNum)
 
 	metrics.ProposerProposedTxListsCounter.Inc(1)
@@ -267,7 +427,9 @@ func (p *Proposer) updateProposingTicker() {
 	}
 
 	var duration time.Duration
-	if p.proposingInterval != nil {
+	if p.proposingInterval != nil && p.mempoolFillRatioThreshold > 0 {
+		duration = p.nextAdaptiveProposingInterval()
+	} else if p.proposingInterval != nil {
 		duration = *p.proposingInterval
 	} else {
 		// Random number between 12 - 60
@@ -278,6 +440,26 @@ func (p *Proposer) updateProposingTicker() {
 	p.proposingTimer = time.NewTimer(duration)
 }
 
+// nextAdaptiveProposingInterval updates the adaptive proposing interval by the mempool fill ratio seen in the
+// last proposing operation, the configured proposing interval is used as the minimum one.
+func (p *Proposer) nextAdaptiveProposingInterval() time.Duration {
+	if p.adaptiveProposingInterval == 0 {
+		p.adaptiveProposingInterval = *p.proposingInterval
+	} else {
+		p.adaptiveProposingInterval = nextProposingInterval(
+			p.adaptiveProposingInterval,
+			*p.proposingInterval,
+			p.maxProposingInterval,
+			p.lastPendingTxs,
+			p.protocolConfigs.Config().MaxTransactionsPerBlock,
+			p.mempoolFillRatioThreshold,
+		)
+	}
+	metrics.ProposerProposingIntervalGauge.Update(p.adaptiveProposingInterval.Milliseconds())
+
+	return p.adaptiveProposingInterval
+}
+
 // Name returns the application name.
 func (p *Proposer) Name() string {
 	return "proposer"
@@ -297,6 +479,59 @@ func sumTxsGasLimit(txs []*types.Transaction) uint64 {
 	return total
 }
 
+// filterForbiddenTxs removes all transactions sent to the given forbidden addresses from the
+// transactions lists, along with the later transactions of the same senders, which would otherwise
+// have nonce gaps. The transactions lists which become empty will also be removed. This is a local
+// proposing policy only, the protocol and so the driver and prover still accept such transactions.
+func filterForbiddenTxs(
+	txLists []types.Transactions,
+	forbiddenToAddresses []common.Address,
+	signer types.Signer,
+) []types.Transactions {
+	if len(forbiddenToAddresses) == 0 {
+		return txLists
+	}
+
+	forbidden := make(map[common.Address]struct{}, len(forbiddenToAddresses))
+	for _, addr := range forbiddenToAddresses {
+		forbidden[addr] = struct{}{}
+	}
+
+	var (
+		filtered = make([]types.Transactions, 0, len(txLists))
+		// Senders whose transactions have been removed, the transactions lists are ordered by nonce.
+		skippedSenders = make(map[common.Address]struct{})
+	)
+	for _, txs := range txLists {
+		allowed := make(types.Transactions, 0, len(txs))
+		for _, tx := range txs {
+			sender, err := types.Sender(signer, tx)
+			if err == nil {
+				if _, ok := skippedSenders[sender]; ok {
+					log.Debug("Skip transaction after a forbidden one", "hash", tx.Hash(), "sender", sender)
+					continue
+				}
+			}
+			if tx.To() != nil {
+				if _, ok := forbidden[*tx.To()]; ok {
+					log.Debug("Skip transaction sent to a forbidden address", "hash", tx.Hash(), "to", tx.To())
+					if err == nil {
+						skippedSenders[sender] = struct{}{}
+					}
+					continue
+				}
+			}
+			allowed = append(allowed, tx)
+		}
+
+		if len(allowed) != 0 {
+			filtered = append(filtered, allowed)
+		}
+	}
+
+	return filtered
+}
+
 // getTxOpts creates a bind.TransactOpts instance using the given private key.
 func getTxOpts(
 	ctx context.Context,
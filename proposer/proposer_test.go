@@ -2,6 +2,7 @@ package proposer
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"math/big"
 	"os"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/taikoxyz/taiko-client/bindings"
 	"github.com/taikoxyz/taiko-client/testutils"
@@ -113,6 +115,19 @@ func (s *ProposerTestSuite) TestProposeOp() {
 	s.Equal(types.ReceiptStatusSuccessful, receipt.Status)
 }
 
+func (s *ProposerTestSuite) TestCheckReadiness() {
+	s.Nil(s.p.checkReadiness(context.Background()))
+	s.False(s.p.readinessCheckedAt.IsZero())
+
+	// Cached result.
+	s.p.readinessErr = errProposerNotAllowed
+	s.ErrorIs(s.p.checkReadiness(context.Background()), errProposerNotAllowed)
+
+	// Expired result.
+	s.p.readinessCheckedAt = time.Now().Add(-readinessCheckTTL)
+	s.Nil(s.p.checkReadiness(context.Background()))
+}
+
 func (s *ProposerTestSuite) TestProposeEmptyBlockOp() {
 	s.Nil(s.p.ProposeEmptyBlockOp(context.Background()))
 }
@@ -138,12 +153,97 @@ func (s *ProposerTestSuite) TestUpdateProposingTicker() {
 	s.NotPanics(s.p.updateProposingTicker)
 }
 
+func (s *ProposerTestSuite) TestUpdateAdaptiveProposingTicker() {
+	defer func() {
+		s.p.proposingInterval = nil
+		s.p.mempoolFillRatioThreshold = 0
+		s.p.adaptiveProposingInterval = 0
+	}()
+
+	minInterval := 10 * time.Second
+	s.p.proposingInterval = &minInterval
+	s.p.mempoolFillRatioThreshold = 0.5
+	s.p.maxProposingInterval = 30 * time.Second
+
+	s.p.lastPendingTxs = 0
+	s.Equal(minInterval, s.p.nextAdaptiveProposingInterval())
+	s.Equal(20*time.Second, s.p.nextAdaptiveProposingInterval())
+	s.Equal(30*time.Second, s.p.nextAdaptiveProposingInterval())
+
+	s.p.lastPendingTxs = s.p.protocolConfigs.Config().MaxTransactionsPerBlock
+	s.Equal(minInterval, s.p.nextAdaptiveProposingInterval())
+	s.NotPanics(s.p.updateProposingTicker)
+}
+
 func (s *ProposerTestSuite) TestStartClose() {
 	s.Nil(s.p.Start())
 	s.cancel()
 	s.NotPanics(s.p.Close)
 }
 
+func TestFilterForbiddenTxs(t *testing.T) {
+	var (
+		forbiddenA = common.HexToAddress("0x0000000000000000000000000000000000000001")
+		forbiddenB = common.HexToAddress("0x0000000000000000000000000000000000000002")
+		allowed    = common.HexToAddress("0x0000000000000000000000000000000000000003")
+		signer     = types.LatestSignerForChainID(common.Big1)
+	)
+
+	newTx := func(nonce uint64, to common.Address, key *ecdsa.PrivateKey) *types.Transaction {
+		tx, err := types.SignTx(types.NewTransaction(nonce, to, common.Big0, 1, common.Big0, []byte{}), signer, key)
+		require.Nil(t, err)
+		return tx
+	}
+	newKey := func() *ecdsa.PrivateKey {
+		key, err := crypto.GenerateKey()
+		require.Nil(t, err)
+		return key
+	}
+
+	// Sender 1 sends to forbiddenA, sender 2 sends to forbiddenB, sender 3 only sends to the allowed address.
+	sender1, sender2, sender3 := newKey(), newKey(), newKey()
+	txLists := []types.Transactions{
+		{
+			newTx(0, allowed, sender1),
+			newTx(1, forbiddenA, sender1),
+			newTx(0, allowed, sender2),
+			newTx(1, forbiddenB, sender2),
+		},
+		{newTx(2, allowed, sender1), newTx(2, allowed, sender2)},
+		{newTx(0, allowed, sender3)},
+	}
+
+	testCases := []struct {
+		name      string
+		forbidden []common.Address
+		expected  []types.Transactions
+	}{
+		{"empty", []common.Address{}, txLists},
+		{
+			"single",
+			[]common.Address{forbiddenA},
+			[]types.Transactions{
+				{txLists[0][0], txLists[0][2], txLists[0][3]},
+				{txLists[1][1]},
+				txLists[2],
+			},
+		},
+		{
+			// The later transactions of both senders are removed too, to avoid nonce gaps, along with the
+			// transactions list which becomes empty.
+			"multiple",
+			[]common.Address{forbiddenA, forbiddenB},
+			[]types.Transactions{{txLists[0][0], txLists[0][2]}, txLists[2]},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, filterForbiddenTxs(txLists, tc.forbidden, signer))
+		})
+	}
+}
+
 func TestProposerTestSuite(t *testing.T) {
 	suite.Run(t, new(ProposerTestSuite))
 }
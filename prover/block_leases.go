@@ -0,0 +1,217 @@
+package prover
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/metrics"
+	"github.com/taikoxyz/taiko-client/prover/lease"
+)
+
+var (
+	defaultLeaseTTL = 1 * time.Minute
+)
+
+// blockLeases coordinates the prover replicas sharing one prover key, a replica only handles the blocks
+// which leases it holds, the leases of the blocks being handled are renewed periodically, so that when
+// a replica dies, its blocks will be handed over to the other replicas after the leases expire.
+type blockLeases struct {
+	backend   lease.Backend
+	holder    string
+	keyPrefix string
+	ttl       time.Duration
+
+	held     map[uint64]*bindings.TaikoL1ClientBlockProposed
+	deferred map[uint64]*bindings.TaikoL1ClientBlockProposed // Blocks leased by the other replicas
+	mutex    sync.Mutex
+}
+
+// newBlockLeases creates a new blockLeases instance, the lease keys are scoped by the prover address.
+func newBlockLeases(
+	backend lease.Backend,
+	holder string,
+	proverAddress common.Address,
+	ttl time.Duration,
+) *blockLeases {
+	if ttl == 0 {
+		ttl = defaultLeaseTTL
+	}
+
+	return &blockLeases{
+		backend:   backend,
+		holder:    holder,
+		keyPrefix: proverAddress.Hex(),
+		ttl:       ttl,
+		held:      make(map[uint64]*bindings.TaikoL1ClientBlockProposed),
+		deferred:  make(map[uint64]*bindings.TaikoL1ClientBlockProposed),
+	}
+}
+
+// key returns the lease key of the given block.
+func (l *blockLeases) key(id uint64) string {
+	return fmt.Sprintf("%s/%d", l.keyPrefix, id)
+}
+
+// acquire tries to claim the lease of the given block, if the lease is held by another replica, the
+// block is deferred until the lease expires.
+func (l *blockLeases) acquire(ctx context.Context, event *bindings.TaikoL1ClientBlockProposed) (bool, error) {
+	id := event.Id.Uint64()
+
+	acquired, err := l.backend.Acquire(ctx, l.key(id), l.holder, l.ttl)
+	if err != nil {
+		return false, err
+	}
+
+	if !acquired {
+		l.deferBlock(event)
+		return false, nil
+	}
+
+	l.mutex.Lock()
+	l.held[id] = event
+	delete(l.deferred, id)
+	l.mutex.Unlock()
+
+	return true, nil
+}
+
+// deferBlock waits for the lease of the given block, until it's released or expired by the holder.
+func (l *blockLeases) deferBlock(event *bindings.TaikoL1ClientBlockProposed) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	delete(l.held, event.Id.Uint64())
+	l.deferred[event.Id.Uint64()] = event
+}
+
+// release releases the lease of the given block, and stops waiting for it.
+func (l *blockLeases) release(ctx context.Context, id uint64) {
+	l.mutex.Lock()
+	_, ok := l.held[id]
+	delete(l.held, id)
+	delete(l.deferred, id)
+	l.mutex.Unlock()
+
+	if !ok {
+		return
+	}
+
+	if err := l.backend.Release(ctx, l.key(id), l.holder); err != nil {
+		log.Warn("Failed to release block lease", "blockID", id, "error", err)
+	}
+}
+
+// releaseUpTo releases the leases of all blocks with IDs not greater than the given one.
+func (l *blockLeases) releaseUpTo(ctx context.Context, id uint64) {
+	l.mutex.Lock()
+	ids := make([]uint64, 0)
+	for heldID := range l.held {
+		if heldID <= id {
+			ids = append(ids, heldID)
+		}
+	}
+	for deferredID := range l.deferred {
+		if deferredID <= id {
+			ids = append(ids, deferredID)
+		}
+	}
+	l.mutex.Unlock()
+
+	for _, id := range ids {
+		l.release(ctx, id)
+	}
+}
+
+// renew extends all held leases, the blocks which leases have been lost are deferred.
+func (l *blockLeases) renew(ctx context.Context) {
+	l.mutex.Lock()
+	events := make([]*bindings.TaikoL1ClientBlockProposed, 0, len(l.held))
+	for _, event := range l.held {
+		events = append(events, event)
+	}
+	l.mutex.Unlock()
+
+	for _, event := range events {
+		acquired, err := l.backend.Acquire(ctx, l.key(event.Id.Uint64()), l.holder, l.ttl)
+		if err != nil {
+			log.Warn("Failed to renew block lease", "blockID", event.Id, "error", err)
+			metrics.ProverLeaseErrorCounter.Inc(1)
+			continue
+		}
+
+		if !acquired {
+			log.Warn("Block lease taken over by another prover replica", "blockID", event.Id)
+			l.deferBlock(event)
+		}
+	}
+}
+
+// takeOver tries to claim the leases of the deferred blocks, returns the blocks which leases have been
+// released or expired by the other replicas, ordered by block ID.
+func (l *blockLeases) takeOver(ctx context.Context) []*bindings.TaikoL1ClientBlockProposed {
+	l.mutex.Lock()
+	events := make([]*bindings.TaikoL1ClientBlockProposed, 0, len(l.deferred))
+	for _, event := range l.deferred {
+		events = append(events, event)
+	}
+	l.mutex.Unlock()
+
+	sort.Slice(events, func(i, j int) bool { return events[i].Id.Cmp(events[j].Id) < 0 })
+
+	acquiredEvents := make([]*bindings.TaikoL1ClientBlockProposed, 0)
+	for _, event := range events {
+		acquired, err := l.acquire(ctx, event)
+		if err != nil {
+			log.Warn("Failed to acquire deferred block lease", "blockID", event.Id, "error", err)
+			metrics.ProverLeaseErrorCounter.Inc(1)
+			continue
+		}
+
+		if acquired {
+			acquiredEvents = append(acquiredEvents, event)
+		}
+	}
+
+	return acquiredEvents
+}
+
+// maintainBlockLeases renews the held leases, and takes over the deferred blocks which leases have
+// expired, periodically.
+func (p *Prover) maintainBlockLeases() {
+	ticker := time.NewTicker(p.blockLeases.ttl / 3)
+	defer func() {
+		ticker.Stop()
+		p.wg.Done()
+	}()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			p.blockLeases.renew(p.ctx)
+
+			for _, event := range p.blockLeases.takeOver(p.ctx) {
+				logger := p.blockLogger(event)
+				logger.Info("Take over the block from another prover replica")
+				metrics.ProverLeaseTakeoverCounter.Inc(1)
+
+				if err := p.proofQueue.Push(p.classifiedProofRequest(p.ctx, event, logger)); err != nil {
+					// Hand the block back, it will be taken over again in the next round.
+					log.Warn("Proof priority queue is full, retry the block later", "blockID", event.Id, "error", err)
+					p.blockLeases.release(p.ctx, event.Id.Uint64())
+					p.blockLeases.deferBlock(event)
+					continue
+				}
+				metrics.ProverPriorityQueueDepthGauge.Update(int64(p.proofQueue.Len()))
+				p.unprovenCandidates.Add(event.Id.Uint64(), time.Unix(int64(event.Meta.Timestamp), 0))
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package prover
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/prover/lease"
+)
+
+func TestBlockLeases(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		backend = lease.NewMemoryBackend()
+		ttl     = 100 * time.Millisecond
+		a       = newBlockLeases(backend, "a", common.Address{}, ttl)
+		b       = newBlockLeases(backend, "b", common.Address{}, ttl)
+		event1  = &bindings.TaikoL1ClientBlockProposed{Id: common.Big1}
+		event2  = &bindings.TaikoL1ClientBlockProposed{Id: common.Big2}
+	)
+
+	acquired, err := a.acquire(ctx, event1)
+	require.Nil(t, err)
+	require.True(t, acquired)
+	acquired, err = a.acquire(ctx, event2)
+	require.Nil(t, err)
+	require.True(t, acquired)
+
+	acquired, err = b.acquire(ctx, event1)
+	require.Nil(t, err)
+	require.False(t, acquired)
+	acquired, err = b.acquire(ctx, event2)
+	require.Nil(t, err)
+	require.False(t, acquired)
+
+	// The renewed leases can't be taken over.
+	time.Sleep(ttl / 2)
+	a.renew(ctx)
+	time.Sleep(ttl / 2)
+	require.Empty(t, b.takeOver(ctx))
+
+	// A released lease is handed over right away.
+	a.release(ctx, 1)
+	events := b.takeOver(ctx)
+	require.Equal(t, 1, len(events))
+	require.Equal(t, common.Big1, events[0].Id)
+
+	// An expired lease is handed over.
+	time.Sleep(ttl)
+	events = b.takeOver(ctx)
+	require.Equal(t, 1, len(events))
+	require.Equal(t, common.Big2, events[0].Id)
+	require.Empty(t, b.deferred)
+
+	// The replica losing the lease waits for it.
+	a.renew(ctx)
+	require.Empty(t, a.held)
+	require.Equal(t, 1, len(a.deferred))
+
+	b.releaseUpTo(ctx, 2)
+	require.Empty(t, b.held)
+	events = a.takeOver(ctx)
+	require.Equal(t, 1, len(events))
+	require.Equal(t, 0, events[0].Id.Cmp(big.NewInt(2)))
+}
+
+func TestBlockLeasesKey(t *testing.T) {
+	l := newBlockLeases(lease.NewMemoryBackend(), "a", common.BigToAddress(common.Big1), 0)
+	require.Equal(t, defaultLeaseTTL, l.ttl)
+	require.Equal(t, common.BigToAddress(common.Big1).Hex()+"/10", l.key(10))
+}
@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// LRU is a thread-safe fixed size LRU cache.
+type LRU[K comparable, V any] struct {
+	capacity int
+	items    map[K]*list.Element
+	order    *list.List // front is the most recently used
+	mutex    sync.Mutex
+}
+
+// entry is an element stored in LRU.order.
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// NewLRU creates a new LRU cache with the given capacity, a capacity less than 1 is treated as 1.
+func NewLRU[K comparable, V any](capacity int) *LRU[K, V] {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &LRU[K, V]{capacity: capacity, items: make(map[K]*list.Element, capacity), order: list.New()}
+}
+
+// Add adds a value to the cache, returns true if an eviction occurred.
+func (c *LRU[K, V]) Add(key K, value V) (evicted bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.order.MoveToFront(elem)
+		elem.Value.(*entry[K, V]).value = value
+		return false
+	}
+
+	c.items[key] = c.order.PushFront(&entry[K, V]{key: key, value: value})
+
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*entry[K, V]).key)
+		return true
+	}
+
+	return false
+}
+
+// Get looks up a key's value from the cache, and marks the key as the most recently used.
+func (c *LRU[K, V]) Get(key K) (value V, ok bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return value, false
+	}
+
+	c.order.MoveToFront(elem)
+	return elem.Value.(*entry[K, V]).value, true
+}
+
+// Contains checks whether the key is in the cache, without updating the recentness.
+func (c *LRU[K, V]) Contains(key K) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	_, ok := c.items[key]
+	return ok
+}
+
+// Remove removes the given key from the cache.
+func (c *LRU[K, V]) Remove(key K) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.order.Remove(elem)
+		delete(c.items, key)
+	}
+}
+
+// Len returns the number of items in the cache.
+func (c *LRU[K, V]) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.order.Len()
+}
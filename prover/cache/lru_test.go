@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRU(t *testing.T) {
+	c := NewLRU[int, string](2)
+
+	require.False(t, c.Add(1, "1"))
+	require.False(t, c.Add(2, "2"))
+	require.Equal(t, 2, c.Len())
+
+	// Mark 1 as the most recently used, so 2 will be evicted.
+	v, ok := c.Get(1)
+	require.True(t, ok)
+	require.Equal(t, "1", v)
+
+	require.True(t, c.Add(3, "3"))
+	require.False(t, c.Contains(2))
+	require.True(t, c.Contains(1))
+	require.True(t, c.Contains(3))
+
+	// Update an existing key.
+	require.False(t, c.Add(3, "three"))
+	v, ok = c.Get(3)
+	require.True(t, ok)
+	require.Equal(t, "three", v)
+
+	c.Remove(3)
+	_, ok = c.Get(3)
+	require.False(t, ok)
+	require.Equal(t, 1, c.Len())
+}
+
+func TestLRUMinCapacity(t *testing.T) {
+	c := NewLRU[int, struct{}](0)
+
+	require.False(t, c.Add(1, struct{}{}))
+	require.True(t, c.Add(2, struct{}{}))
+	require.Equal(t, 1, c.Len())
+}
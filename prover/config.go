@@ -4,15 +4,38 @@ import (
 	"crypto/ecdsa"
 	"fmt"
 	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/taikoxyz/taiko-client/cmd/flags"
+	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+	proofSubmitter "github.com/taikoxyz/taiko-client/prover/proof_submitter"
 	"github.com/urfave/cli/v2"
 )
 
+// All supported proof producer types.
+const (
+	ProofProducerTypeZkevmRpcd = "zkevmRpcd"
+	ProofProducerTypeGrpc      = "grpc"
+)
+
+// All supported proof backends of --prover.proofBackend.
+const (
+	ProofBackendRpcd  = "rpcd"
+	ProofBackendGrpc  = "grpc"
+	ProofBackendDummy = "dummy"
+)
+
 // Config contains the configurations to initialize a Taiko prover.
 type Config struct {
 	L1WsEndpoint                    string
@@ -21,14 +44,95 @@ type Config struct {
 	L2HttpEndpoint                  string
 	TaikoL1Address                  common.Address
 	TaikoL2Address                  common.Address
+	TaikoL1ABIPath                  string
+	TaikoL2ABIPath                  string
+	L1ArchiveEndpoint               string
 	L1ProverPrivKey                 *ecdsa.PrivateKey
-	ZKEvmRpcdEndpoint               string
+	ZKEvmRpcdEndpoints              []string
+	ZkEvmRpcdFallbackProbeInterval  time.Duration
 	ZkEvmRpcdParamsPath             string
+	ZkEvmRpcdParamsTiers            []*proofProducer.ParamsTier
+	ZkEvmRpcdHealthPath             string
+	ZkEvmRpcdCancelMethod           string
+	ZkEvmRpcdMaxQueueDepth          uint64
+	ZkEvmRpcdConnectTimeout         time.Duration
+	ZkEvmRpcdPollTimeout            time.Duration
+	ZkEvmRpcdProofTimeout           time.Duration
+	ZkEvmRpcdQueueWorkers           uint
+	ZkEvmRpcdQueueDepth             uint
+	ZkEvmRpcdJournalPath            string
+	ZkEvmRpcdProgressMethod         string
+	ZkEvmRpcdProgressInterval       time.Duration
+	ZkEvmRpcdStallThreshold         time.Duration
+	ZkEvmRpcdStallTimeout           bool
+	RpcdCallbackAddr                string
+	RpcdCallbackURL                 string
+	RpcdCallbackTimeout             time.Duration
+	SkipRpcdProbe                   bool
+	SkipProofValidation             bool
+	ProofSizes                      map[uint64]int
 	StartingBlockID                 *big.Int
+	StartingBlockHash               *common.Hash
+	StartingTimestamp               uint64
 	MaxConcurrentProvingJobs        uint
+	MaxConcurrentWitnessJobs        uint
+	MaxPreparedWitnesses            uint
+	BlockDedupCacheSize             uint
+	PriorityQueueSize               uint
+	ValidProofWeight                uint
+	InvalidProofWeight              uint
+	EventChBufferSize               uint
+	ProofChBufferSize               uint
+	ProofWindow                     time.Duration
+	MaxProvingLag                   uint64
+	MinProofRewardWei               *big.Int
+	ConfigFile                      string
+	SubmissionKeysFile              string
+	WebhookURL                      string
+	CompetingProofWindow            time.Duration
+	FeeStrategy                     string
+	MinPriorityFeeWei               *big.Int
+	MaxPriorityFeeWei               *big.Int
+	MaxUnprovenBlockAge             time.Duration
+	SubmissionRetention             time.Duration
+	ReconcileWindow                 uint64
+	LogSampling                     uint64
+	BlockFeedSocket                 string
+	SafeAddress                     common.Address
+	SafeServiceURL                  string
+	SafeThreshold                   uint64
+	RequestProofMaxAttempts         uint64
+	RequestProofRetryInterval       time.Duration
+	ProofProducerType               string
+	GrpcProofProducerEndpoint       string
+	GrpcProofProducerTLS            bool
+	GrpcProofProducerCACert         string
+	GrpcProofProducerAuthToken      string
+	ProofCacheEndpoint              string
+	ProofCacheToken                 string
+	ProofCacheDir                   string
+	ProofArchiveDir                 string
+	ProofArchiveMaxSize             int64
+	ProofTimeHistoryPath            string
+	SkipNearlyStaleBlocks           bool
+	ProofCacheMaxAge                time.Duration
+	RestoreSnapshotPath             string
+	LeaseEndpoint                   string
+	LeaseToken                      string
+	LeaseTTL                        time.Duration
+	LeaseHolderID                   string
+	PeerL2Endpoints                 []string
+	AdditionalL1Endpoints           []string
+	PollInterval                    time.Duration
+	DryRun                          bool
+	HTTPAddr                        string
+	AdminToken                      string
 	Dummy                           bool
 	RandomDummyProofDelayLowerBound *time.Duration
 	RandomDummyProofDelayUpperBound *time.Duration
+	DummyProofDelaySeed             *uint64
+	DummyProofDelaysPath            string
+	DummyProofFallback              bool
 }
 
 // NewConfigFromCliContext creates a new config instance from command line flags.
@@ -59,9 +163,6 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 		if err != nil {
 			return nil, fmt.Errorf("invalid random dummy proof delay value: %s, err: %w", flagValue, err)
 		}
-		if lower > upper {
-			return nil, fmt.Errorf("invalid random dummy proof delay value (lower > upper): %s", flagValue)
-		}
 
 		if upper != time.Duration(0) {
 			randomDummyProofDelayLowerBound = &lower
@@ -69,25 +170,515 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 		}
 	}
 
-	var startingBlockID *big.Int
+	var dummyProofDelaySeed *uint64
+	if c.IsSet(flags.DummyProofDelaySeed.Name) {
+		seed := c.Uint64(flags.DummyProofDelaySeed.Name)
+		dummyProofDelaySeed = &seed
+	}
+
+	if c.IsSet(flags.SafeAddress.Name) && !common.IsHexAddress(c.String(flags.SafeAddress.Name)) {
+		return nil, fmt.Errorf("invalid safe address: %s", c.String(flags.SafeAddress.Name))
+	}
+
+	var (
+		startingBlockID   *big.Int
+		startingBlockHash *common.Hash
+	)
 	if c.IsSet(flags.StartingBlockID.Name) {
 		startingBlockID = new(big.Int).SetUint64(c.Uint64(flags.StartingBlockID.Name))
 	}
+	if c.IsSet(flags.StartingBlockHash.Name) {
+		b, err := hexutil.Decode(c.String(flags.StartingBlockHash.Name))
+		if err != nil || len(b) != common.HashLength {
+			return nil, fmt.Errorf("invalid starting block hash: %s", c.String(flags.StartingBlockHash.Name))
+		}
+		hash := common.BytesToHash(b)
+		startingBlockHash = &hash
+	}
+	if c.IsSet(flags.StartingTimestamp.Name) && c.Uint64(flags.StartingTimestamp.Name) == 0 {
+		return nil, fmt.Errorf("invalid --%s: 0", flags.StartingTimestamp.Name)
+	}
+
+	// --prover.proofBackend replaces the legacy --proof-producer-type and --dummy.
+	proofProducerType, dummy := c.String(flags.ProofProducerType.Name), c.Bool(flags.Dummy.Name)
+	if c.IsSet(flags.ProofBackend.Name) {
+		if c.IsSet(flags.ProofProducerType.Name) || c.IsSet(flags.Dummy.Name) {
+			return nil, fmt.Errorf(
+				"--%s conflicts with --%s and --%s",
+				flags.ProofBackend.Name, flags.ProofProducerType.Name, flags.Dummy.Name,
+			)
+		}
+		switch backend := c.String(flags.ProofBackend.Name); backend {
+		case ProofBackendRpcd:
+			proofProducerType, dummy = ProofProducerTypeZkevmRpcd, false
+		case ProofBackendGrpc:
+			proofProducerType, dummy = ProofProducerTypeGrpc, false
+		case ProofBackendDummy:
+			proofProducerType, dummy = ProofProducerTypeZkevmRpcd, true
+		default:
+			return nil, fmt.Errorf("invalid --%s: %s", flags.ProofBackend.Name, backend)
+		}
+	}
+
+	var zkEvmRpcdEndpoints []string
+	for _, endpoint := range c.StringSlice(flags.ZkEvmRpcdEndpoint.Name) {
+		if trimmed := strings.TrimSpace(endpoint); len(trimmed) != 0 {
+			zkEvmRpcdEndpoints = append(zkEvmRpcdEndpoints, trimmed)
+		}
+	}
+
+	var zkEvmRpcdParamsTiers []*proofProducer.ParamsTier
+	for _, value := range c.StringSlice(flags.ZkEvmRpcdParamsTiers.Name) {
+		splitted := strings.SplitN(strings.TrimSpace(value), ":", 2)
+		if len(splitted) != 2 || len(splitted[1]) == 0 {
+			return nil, fmt.Errorf("invalid --%s value: %s", flags.ZkEvmRpcdParamsTiers.Name, value)
+		}
+		maxGas, err := strconv.ParseUint(splitted[0], 10, 64)
+		if err != nil || maxGas == 0 {
+			return nil, fmt.Errorf("invalid --%s max gas: %s", flags.ZkEvmRpcdParamsTiers.Name, value)
+		}
+		zkEvmRpcdParamsTiers = append(zkEvmRpcdParamsTiers, &proofProducer.ParamsTier{
+			MaxGas:     maxGas,
+			ParamsPath: splitted[1],
+		})
+	}
+
+	var proofSizes map[uint64]int
+	for _, value := range c.StringSlice(flags.ProofSizes.Name) {
+		splitted := strings.SplitN(strings.TrimSpace(value), ":", 2)
+		if len(splitted) != 2 {
+			return nil, fmt.Errorf("invalid --%s value: %s", flags.ProofSizes.Name, value)
+		}
+		degree, err := strconv.ParseUint(splitted[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --%s degree: %s", flags.ProofSizes.Name, value)
+		}
+		size, err := strconv.Atoi(splitted[1])
+		if err != nil || size <= 0 {
+			return nil, fmt.Errorf("invalid --%s size: %s", flags.ProofSizes.Name, value)
+		}
+		if proofSizes == nil {
+			proofSizes = make(map[uint64]int)
+		}
+		proofSizes[degree] = size
+	}
+
+	var peerL2Endpoints []string
+	if c.IsSet(flags.PeerL2Endpoints.Name) {
+		for _, endpoint := range strings.Split(c.String(flags.PeerL2Endpoints.Name), ",") {
+			if trimmed := strings.TrimSpace(endpoint); len(trimmed) != 0 {
+				peerL2Endpoints = append(peerL2Endpoints, trimmed)
+			}
+		}
+	}
+
+	var additionalL1Endpoints []string
+	if c.IsSet(flags.AdditionalL1Endpoints.Name) {
+		for _, endpoint := range strings.Split(c.String(flags.AdditionalL1Endpoints.Name), ",") {
+			if trimmed := strings.TrimSpace(endpoint); len(trimmed) != 0 {
+				additionalL1Endpoints = append(additionalL1Endpoints, trimmed)
+			}
+		}
+	}
 
-	return &Config{
+	var minProofRewardWei *big.Int
+	if c.IsSet(flags.MinProofRewardGwei.Name) {
+		minProofRewardWei = new(big.Int).Mul(
+			new(big.Int).SetUint64(c.Uint64(flags.MinProofRewardGwei.Name)),
+			big.NewInt(params.GWei),
+		)
+	}
+
+	var minPriorityFeeWei, maxPriorityFeeWei *big.Int
+	if c.IsSet(flags.MinPriorityFeeGwei.Name) {
+		minPriorityFeeWei = new(big.Int).Mul(
+			new(big.Int).SetUint64(c.Uint64(flags.MinPriorityFeeGwei.Name)),
+			big.NewInt(params.GWei),
+		)
+	}
+	if c.IsSet(flags.MaxPriorityFeeGwei.Name) {
+		maxPriorityFeeWei = new(big.Int).Mul(
+			new(big.Int).SetUint64(c.Uint64(flags.MaxPriorityFeeGwei.Name)),
+			big.NewInt(params.GWei),
+		)
+	}
+
+	cfg := &Config{
 		L1WsEndpoint:                    c.String(flags.L1WSEndpoint.Name),
 		L1HttpEndpoint:                  c.String(flags.L1HTTPEndpoint.Name),
 		L2WsEndpoint:                    c.String(flags.L2WSEndpoint.Name),
 		L2HttpEndpoint:                  c.String(flags.L2HTTPEndpoint.Name),
 		TaikoL1Address:                  common.HexToAddress(c.String(flags.TaikoL1Address.Name)),
 		TaikoL2Address:                  common.HexToAddress(c.String(flags.TaikoL2Address.Name)),
+		TaikoL1ABIPath:                  c.String(flags.TaikoL1ABIPath.Name),
+		TaikoL2ABIPath:                  c.String(flags.TaikoL2ABIPath.Name),
+		L1ArchiveEndpoint:               c.String(flags.L1ArchiveEndpoint.Name),
 		L1ProverPrivKey:                 l1ProverPrivKey,
-		ZKEvmRpcdEndpoint:               c.String(flags.ZkEvmRpcdEndpoint.Name),
+		ZKEvmRpcdEndpoints:              zkEvmRpcdEndpoints,
+		ZkEvmRpcdFallbackProbeInterval:  c.Duration(flags.ZkEvmRpcdFallbackProbeInterval.Name),
 		ZkEvmRpcdParamsPath:             c.String(flags.ZkEvmRpcdParamsPath.Name),
+		ZkEvmRpcdParamsTiers:            zkEvmRpcdParamsTiers,
+		ZkEvmRpcdHealthPath:             c.String(flags.ZkEvmRpcdHealthPath.Name),
+		ZkEvmRpcdCancelMethod:           c.String(flags.ZkEvmRpcdCancelMethod.Name),
+		ZkEvmRpcdMaxQueueDepth:          c.Uint64(flags.ZkEvmRpcdMaxQueueDepth.Name),
+		ZkEvmRpcdConnectTimeout:         c.Duration(flags.ZkEvmRpcdConnectTimeout.Name),
+		ZkEvmRpcdPollTimeout:            c.Duration(flags.ZkEvmRpcdPollTimeout.Name),
+		ZkEvmRpcdProofTimeout:           c.Duration(flags.ZkEvmRpcdProofTimeout.Name),
+		ZkEvmRpcdQueueWorkers:           c.Uint(flags.ZkEvmRpcdQueueWorkers.Name),
+		ZkEvmRpcdQueueDepth:             c.Uint(flags.ZkEvmRpcdQueueDepth.Name),
+		ZkEvmRpcdJournalPath:            c.String(flags.ZkEvmRpcdJournal.Name),
+		ZkEvmRpcdProgressMethod:         c.String(flags.ZkEvmRpcdProgressMethod.Name),
+		ZkEvmRpcdProgressInterval:       c.Duration(flags.ZkEvmRpcdProgressInterval.Name),
+		ZkEvmRpcdStallThreshold:         c.Duration(flags.ZkEvmRpcdStallThreshold.Name),
+		ZkEvmRpcdStallTimeout:           c.Bool(flags.ZkEvmRpcdStallTimeout.Name),
+		RpcdCallbackAddr:                c.String(flags.RpcdCallbackAddr.Name),
+		RpcdCallbackURL:                 c.String(flags.RpcdCallbackURL.Name),
+		RpcdCallbackTimeout:             c.Duration(flags.RpcdCallbackTimeout.Name),
+		SkipRpcdProbe:                   c.Bool(flags.SkipRpcdProbe.Name),
+		SkipProofValidation:             c.Bool(flags.SkipProofValidation.Name),
+		ProofSizes:                      proofSizes,
 		StartingBlockID:                 startingBlockID,
+		StartingBlockHash:               startingBlockHash,
+		StartingTimestamp:               c.Uint64(flags.StartingTimestamp.Name),
 		MaxConcurrentProvingJobs:        c.Uint(flags.MaxConcurrentProvingJobs.Name),
-		Dummy:                           c.Bool(flags.Dummy.Name),
+		MaxConcurrentWitnessJobs:        c.Uint(flags.MaxConcurrentWitnessJobs.Name),
+		MaxPreparedWitnesses:            c.Uint(flags.MaxPreparedWitnesses.Name),
+		BlockDedupCacheSize:             c.Uint(flags.BlockDedupCacheSize.Name),
+		PriorityQueueSize:               c.Uint(flags.PriorityQueueSize.Name),
+		ValidProofWeight:                c.Uint(flags.ValidProofWeight.Name),
+		InvalidProofWeight:              c.Uint(flags.InvalidProofWeight.Name),
+		EventChBufferSize:               c.Uint(flags.EventChBufferSize.Name),
+		ProofChBufferSize:               c.Uint(flags.ProofChBufferSize.Name),
+		ProofWindow:                     c.Duration(flags.ProofWindow.Name),
+		MaxProvingLag:                   c.Uint64(flags.MaxProvingLag.Name),
+		MinProofRewardWei:               minProofRewardWei,
+		ConfigFile:                      c.String(flags.ProverConfigFile.Name),
+		SubmissionKeysFile:              c.String(flags.SubmissionKeysFile.Name),
+		WebhookURL:                      c.String(flags.ProverWebhookURL.Name),
+		CompetingProofWindow:            c.Duration(flags.CompetingProofWindow.Name),
+		FeeStrategy:                     c.String(flags.FeeStrategy.Name),
+		MinPriorityFeeWei:               minPriorityFeeWei,
+		MaxPriorityFeeWei:               maxPriorityFeeWei,
+		MaxUnprovenBlockAge:             c.Duration(flags.MaxUnprovenBlockAge.Name),
+		SubmissionRetention:             c.Duration(flags.SubmissionRetention.Name),
+		ReconcileWindow:                 c.Uint64(flags.ReconcileWindow.Name),
+		LogSampling:                     c.Uint64(flags.LogSampling.Name),
+		BlockFeedSocket:                 c.String(flags.ProverBlockFeedSocket.Name),
+		SafeAddress:                     common.HexToAddress(c.String(flags.SafeAddress.Name)),
+		SafeServiceURL:                  c.String(flags.SafeServiceURL.Name),
+		SafeThreshold:                   c.Uint64(flags.SafeThreshold.Name),
+		RequestProofMaxAttempts:         c.Uint64(flags.RequestProofMaxAttempts.Name),
+		RequestProofRetryInterval:       c.Duration(flags.RequestProofRetryInterval.Name),
+		ProofProducerType:               proofProducerType,
+		GrpcProofProducerEndpoint:       c.String(flags.GrpcProofProducerEndpoint.Name),
+		GrpcProofProducerTLS:            c.Bool(flags.GrpcProofProducerTLS.Name),
+		GrpcProofProducerCACert:         c.String(flags.GrpcProofProducerCACert.Name),
+		GrpcProofProducerAuthToken:      c.String(flags.GrpcProofProducerAuthToken.Name),
+		ProofCacheEndpoint:              c.String(flags.ProofCacheEndpoint.Name),
+		ProofCacheToken:                 c.String(flags.ProofCacheToken.Name),
+		ProofCacheDir:                   c.String(flags.ProofCacheDir.Name),
+		ProofArchiveDir:                 c.String(flags.ProofArchiveDir.Name),
+		ProofArchiveMaxSize:             int64(c.Uint64(flags.ProofArchiveMaxSize.Name)) * 1024 * 1024,
+		ProofTimeHistoryPath:            c.String(flags.ProofTimeHistory.Name),
+		SkipNearlyStaleBlocks:           c.Bool(flags.SkipNearlyStaleBlocks.Name),
+		ProofCacheMaxAge:                c.Duration(flags.ProofCacheMaxAge.Name),
+		RestoreSnapshotPath:             c.String(flags.RestoreSnapshot.Name),
+		LeaseEndpoint:                   c.String(flags.LeaseEndpoint.Name),
+		LeaseToken:                      c.String(flags.LeaseToken.Name),
+		LeaseTTL:                        c.Duration(flags.LeaseTTL.Name),
+		LeaseHolderID:                   c.String(flags.LeaseHolderID.Name),
+		PeerL2Endpoints:                 peerL2Endpoints,
+		AdditionalL1Endpoints:           additionalL1Endpoints,
+		PollInterval:                    c.Duration(flags.PollInterval.Name),
+		DryRun:                          c.Bool(flags.DryRun.Name),
+		HTTPAddr:                        c.String(flags.HTTPAddr.Name),
+		AdminToken:                      c.String(flags.AdminToken.Name),
+		Dummy:                           dummy,
 		RandomDummyProofDelayLowerBound: randomDummyProofDelayLowerBound,
 		RandomDummyProofDelayUpperBound: randomDummyProofDelayUpperBound,
-	}, nil
+		DummyProofDelaySeed:             dummyProofDelaySeed,
+		DummyProofDelaysPath:            c.String(flags.DummyProofDelaysFile.Name),
+		DummyProofFallback:              c.Bool(flags.DummyProofFallback.Name),
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Validate checks the configurations which are missing, invalid or contradict each other, and derives the
+// default values of the dependent configurations. All the problems found are returned as ConfigErrors.
+func (c *Config) Validate() error {
+	var errs ConfigErrors
+
+	for _, endpoint := range []struct {
+		name  string
+		value string
+	}{
+		{flags.L1WSEndpoint.Name, c.L1WsEndpoint},
+		{flags.L1HTTPEndpoint.Name, c.L1HttpEndpoint},
+		{flags.L2WSEndpoint.Name, c.L2WsEndpoint},
+		{flags.L2HTTPEndpoint.Name, c.L2HttpEndpoint},
+	} {
+		if len(endpoint.value) == 0 {
+			errs.addf("--%s is required", endpoint.name)
+		} else if u, err := url.Parse(endpoint.value); err != nil {
+			errs.addf("invalid --%s: %w", endpoint.name, err)
+		} else if len(u.Scheme) != 0 && len(u.Host) == 0 {
+			// The endpoints without a scheme are IPC paths.
+			errs.addf("invalid --%s: %s, host is required", endpoint.name, endpoint.value)
+		}
+	}
+	if c.L1ProverPrivKey == nil {
+		errs.addf("--%s is required", flags.L1ProverPrivKey.Name)
+	}
+	if c.MaxConcurrentProvingJobs == 0 {
+		errs.addf("--%s must be greater than 0", flags.MaxConcurrentProvingJobs.Name)
+	}
+	if c.StartingBlockID != nil && c.StartingBlockID.Sign() < 0 {
+		errs.addf("invalid --%s: %s", flags.StartingBlockID.Name, c.StartingBlockID)
+	}
+
+	switch c.ProofProducerType {
+	case ProofProducerTypeZkevmRpcd:
+	case ProofProducerTypeGrpc:
+		if len(c.GrpcProofProducerEndpoint) == 0 {
+			errs.addf(
+				"--%s is required by --%s %s",
+				flags.GrpcProofProducerEndpoint.Name, flags.ProofProducerType.Name, ProofProducerTypeGrpc,
+			)
+		}
+		if c.Dummy {
+			errs.addf(
+				"--%s conflicts with --%s %s", flags.Dummy.Name, flags.ProofProducerType.Name, ProofProducerTypeGrpc,
+			)
+		}
+	default:
+		errs.addf("invalid --%s: %s", flags.ProofProducerType.Name, c.ProofProducerType)
+	}
+	if c.ProofProducerType != ProofProducerTypeGrpc {
+		for _, flag := range []struct {
+			name string
+			set  bool
+		}{
+			{flags.GrpcProofProducerTLS.Name, c.GrpcProofProducerTLS},
+			{flags.GrpcProofProducerCACert.Name, len(c.GrpcProofProducerCACert) != 0},
+			{flags.GrpcProofProducerAuthToken.Name, len(c.GrpcProofProducerAuthToken) != 0},
+		} {
+			if flag.set {
+				errs.addf("--%s is only used by the %s proof backend", flag.name, ProofBackendGrpc)
+			}
+		}
+	}
+	if len(c.GrpcProofProducerCACert) != 0 && !c.GrpcProofProducerTLS {
+		errs.addf("--%s requires --%s", flags.GrpcProofProducerCACert.Name, flags.GrpcProofProducerTLS.Name)
+	}
+
+	if c.RandomDummyProofDelayLowerBound != nil || c.RandomDummyProofDelayUpperBound != nil {
+		if !c.Dummy {
+			errs.addf("--%s is only used by --%s", flags.RandomDummyProofDelay.Name, flags.Dummy.Name)
+		}
+		if c.RandomDummyProofDelayLowerBound == nil || c.RandomDummyProofDelayUpperBound == nil {
+			errs.addf("both bounds of --%s are required", flags.RandomDummyProofDelay.Name)
+		} else if *c.RandomDummyProofDelayLowerBound > *c.RandomDummyProofDelayUpperBound {
+			errs.addf(
+				"invalid --%s: lower bound %s > upper bound %s",
+				flags.RandomDummyProofDelay.Name,
+				c.RandomDummyProofDelayLowerBound,
+				c.RandomDummyProofDelayUpperBound,
+			)
+		}
+	}
+
+	if c.DummyProofDelaySeed != nil {
+		if !c.Dummy {
+			errs.addf("--%s is only used by --%s", flags.DummyProofDelaySeed.Name, flags.Dummy.Name)
+		}
+		if c.RandomDummyProofDelayLowerBound == nil {
+			errs.addf("--%s requires --%s", flags.DummyProofDelaySeed.Name, flags.RandomDummyProofDelay.Name)
+		}
+	}
+	if len(c.DummyProofDelaysPath) != 0 && !c.Dummy {
+		errs.addf("--%s is only used by --%s", flags.DummyProofDelaysFile.Name, flags.Dummy.Name)
+	}
+	if c.DummyProofFallback && c.Dummy {
+		errs.addf("--%s has no effect with --%s", flags.DummyProofFallback.Name, flags.Dummy.Name)
+	}
+
+	if c.ZkEvmRpcdFallbackProbeInterval < 0 {
+		errs.addf("invalid --%s: %s", flags.ZkEvmRpcdFallbackProbeInterval.Name, c.ZkEvmRpcdFallbackProbeInterval)
+	}
+
+	for _, timeout := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{flags.ZkEvmRpcdConnectTimeout.Name, c.ZkEvmRpcdConnectTimeout},
+		{flags.ZkEvmRpcdPollTimeout.Name, c.ZkEvmRpcdPollTimeout},
+		{flags.ZkEvmRpcdProofTimeout.Name, c.ZkEvmRpcdProofTimeout},
+		{flags.RpcdCallbackTimeout.Name, c.RpcdCallbackTimeout},
+		{flags.ZkEvmRpcdProgressInterval.Name, c.ZkEvmRpcdProgressInterval},
+		{flags.ZkEvmRpcdStallThreshold.Name, c.ZkEvmRpcdStallThreshold},
+	} {
+		if timeout.value < 0 {
+			errs.addf("invalid --%s: %s", timeout.name, timeout.value)
+		}
+	}
+
+	if len(c.RpcdCallbackAddr) == 0 {
+		if len(c.RpcdCallbackURL) != 0 {
+			errs.addf("--%s is only used by --%s", flags.RpcdCallbackURL.Name, flags.RpcdCallbackAddr.Name)
+		}
+	} else if host, _, err := net.SplitHostPort(c.RpcdCallbackAddr); err != nil {
+		errs.addf("invalid --%s: %w", flags.RpcdCallbackAddr.Name, err)
+	} else if (len(host) == 0 || net.ParseIP(host).IsUnspecified()) && len(c.RpcdCallbackURL) == 0 {
+		errs.addf("--%s is required by a --%s without host", flags.RpcdCallbackURL.Name, flags.RpcdCallbackAddr.Name)
+	}
+
+	if len(c.ZkEvmRpcdProgressMethod) == 0 && c.ZkEvmRpcdStallThreshold != 0 {
+		errs.addf("--%s requires --%s", flags.ZkEvmRpcdStallThreshold.Name, flags.ZkEvmRpcdProgressMethod.Name)
+	}
+	if c.ZkEvmRpcdStallTimeout && c.ZkEvmRpcdStallThreshold == 0 {
+		errs.addf("--%s requires --%s", flags.ZkEvmRpcdStallTimeout.Name, flags.ZkEvmRpcdStallThreshold.Name)
+	}
+
+	if c.SkipProofValidation && len(c.ProofSizes) != 0 {
+		errs.addf("--%s has no effect with --%s", flags.ProofSizes.Name, flags.SkipProofValidation.Name)
+	}
+
+	if c.MaxPreparedWitnesses != 0 && c.MaxConcurrentWitnessJobs == 0 {
+		errs.addf("--%s requires --%s", flags.MaxPreparedWitnesses.Name, flags.MaxConcurrentWitnessJobs.Name)
+	}
+
+	if len(c.AdminToken) != 0 && len(c.HTTPAddr) == 0 {
+		errs.addf("--%s requires --%s", flags.AdminToken.Name, flags.HTTPAddr.Name)
+	}
+
+	if c.ZkEvmRpcdMaxQueueDepth != 0 && len(c.ZkEvmRpcdHealthPath) == 0 {
+		errs.addf("--%s requires --%s", flags.ZkEvmRpcdMaxQueueDepth.Name, flags.ZkEvmRpcdHealthPath.Name)
+	}
+
+	var startingOptions []string
+	if c.StartingBlockID != nil {
+		startingOptions = append(startingOptions, "--"+flags.StartingBlockID.Name)
+	}
+	if c.StartingBlockHash != nil {
+		startingOptions = append(startingOptions, "--"+flags.StartingBlockHash.Name)
+	}
+	if c.StartingTimestamp != 0 {
+		startingOptions = append(startingOptions, "--"+flags.StartingTimestamp.Name)
+	}
+	if len(startingOptions) > 1 {
+		errs.addf("conflicting starting options: %s", strings.Join(startingOptions, ", "))
+	}
+
+	if c.SafeAddress != (common.Address{}) {
+		if len(c.SafeServiceURL) == 0 {
+			errs.addf("--%s is required by --%s", flags.SafeServiceURL.Name, flags.SafeAddress.Name)
+		}
+		if c.SafeThreshold == 0 {
+			errs.addf("--%s is required by --%s", flags.SafeThreshold.Name, flags.SafeAddress.Name)
+		}
+		// The Safe transactions are only executed on the primary L1 chain.
+		if len(c.AdditionalL1Endpoints) != 0 {
+			errs.addf("--%s conflicts with --%s", flags.AdditionalL1Endpoints.Name, flags.SafeAddress.Name)
+		}
+	} else if len(c.SafeServiceURL) != 0 {
+		errs.addf("--%s is only used by --%s", flags.SafeServiceURL.Name, flags.SafeAddress.Name)
+	}
+
+	switch c.FeeStrategy {
+	case "", proofSubmitter.FeeStrategyFlat:
+	case proofSubmitter.FeeStrategyCompetition:
+		if c.MaxPriorityFeeWei == nil {
+			errs.addf(
+				"--%s is required by --%s %s",
+				flags.MaxPriorityFeeGwei.Name, flags.FeeStrategy.Name, proofSubmitter.FeeStrategyCompetition,
+			)
+		}
+	default:
+		errs.addf("invalid --%s: %s", flags.FeeStrategy.Name, c.FeeStrategy)
+	}
+	if c.MinPriorityFeeWei != nil && c.MaxPriorityFeeWei != nil && c.MinPriorityFeeWei.Cmp(c.MaxPriorityFeeWei) > 0 {
+		errs.addf("--%s is above --%s", flags.MinPriorityFeeGwei.Name, flags.MaxPriorityFeeGwei.Name)
+	}
+
+	if len(c.ProofCacheToken) != 0 && len(c.ProofCacheEndpoint) == 0 {
+		errs.addf("--%s is only used by --%s", flags.ProofCacheToken.Name, flags.ProofCacheEndpoint.Name)
+	}
+	if c.ProofCacheMaxAge < 0 {
+		errs.addf("--%s must not be negative", flags.ProofCacheMaxAge.Name)
+	}
+	if len(c.ProofArchiveDir) != 0 && filepath.Clean(c.ProofArchiveDir) == filepath.Clean(c.ProofCacheDir) {
+		errs.addf("--%s conflicts with --%s", flags.ProofArchiveDir.Name, flags.ProofCacheDir.Name)
+	}
+
+	if len(c.LeaseEndpoint) == 0 {
+		if len(c.LeaseToken) != 0 {
+			errs.addf("--%s is only used by --%s", flags.LeaseToken.Name, flags.LeaseEndpoint.Name)
+		}
+		if len(c.LeaseHolderID) != 0 {
+			errs.addf("--%s is only used by --%s", flags.LeaseHolderID.Name, flags.LeaseEndpoint.Name)
+		}
+	} else if len(c.LeaseHolderID) == 0 {
+		if holder, err := defaultLeaseHolderID(); err != nil {
+			errs.addf("failed to derive the default --%s: %w", flags.LeaseHolderID.Name, err)
+		} else {
+			c.LeaseHolderID = holder
+			log.Info("Use the default block lease holder ID", "holder", c.LeaseHolderID)
+		}
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// ConfigErrors are all the problems found in a configuration by Config.Validate, so that an operator can fix
+// them at once, rather than one at a time.
+type ConfigErrors []error
+
+// Error implements the error interface.
+func (e ConfigErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return fmt.Sprintf("%d invalid configurations: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// addf adds a problem formatted by fmt.Errorf.
+func (e *ConfigErrors) addf(format string, args ...interface{}) {
+	*e = append(*e, fmt.Errorf(format, args...))
+}
+
+// ProofBackend returns the name of the proof backend generating the proofs.
+func (c *Config) ProofBackend() string {
+	switch {
+	case c.Dummy:
+		return ProofBackendDummy
+	case c.ProofProducerType == ProofProducerTypeGrpc:
+		return ProofBackendGrpc
+	default:
+		return ProofBackendRpcd
+	}
+}
+
+// defaultLeaseHolderID returns the default ID of current prover replica in the block leases.
+func defaultLeaseHolderID() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid()), nil
 }
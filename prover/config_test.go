@@ -2,11 +2,19 @@ package prover
 
 import (
 	"context"
+	"math/big"
 	"os"
+	"path/filepath"
+	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
 	"github.com/taikoxyz/taiko-client/cmd/flags"
+	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+	proofSubmitter "github.com/taikoxyz/taiko-client/prover/proof_submitter"
 	"github.com/urfave/cli/v2"
 )
 
@@ -17,18 +25,103 @@ func (s *ProverTestSuite) TestNewConfigFromCliContext() {
 	l2HttpEndpoint := os.Getenv("L2_EXECUTION_ENGINE_HTTP_ENDPOINT")
 	taikoL1 := os.Getenv("TAIKO_L1_ADDRESS")
 	taikoL2 := os.Getenv("TAIKO_L2_ADDRESS")
+	configFile := filepath.Join(s.T().TempDir(), "config.json")
+	s.Nil(os.WriteFile(configFile, []byte(`{"maxConcurrentProvingJobs": 2}`), 0600))
+	submissionKeysFile := filepath.Join(s.T().TempDir(), "keys")
+	s.Nil(os.WriteFile(submissionKeysFile, []byte(os.Getenv("L1_PROVER_PRIVATE_KEY")), 0600))
 
 	app := cli.NewApp()
 	app.Flags = []cli.Flag{
 		&cli.StringFlag{Name: flags.L1WSEndpoint.Name},
+		&cli.UintFlag{Name: flags.MaxConcurrentProvingJobs.Name, Value: flags.MaxConcurrentProvingJobs.Value},
 		&cli.StringFlag{Name: flags.L1HTTPEndpoint.Name},
 		&cli.StringFlag{Name: flags.L2WSEndpoint.Name},
 		&cli.StringFlag{Name: flags.L2HTTPEndpoint.Name},
 		&cli.StringFlag{Name: flags.TaikoL1Address.Name},
 		&cli.StringFlag{Name: flags.TaikoL2Address.Name},
+		&cli.StringFlag{Name: flags.TaikoL1ABIPath.Name},
+		&cli.StringFlag{Name: flags.TaikoL2ABIPath.Name},
+		&cli.StringFlag{Name: flags.L1ArchiveEndpoint.Name},
 		&cli.StringFlag{Name: flags.L1ProverPrivKey.Name},
 		&cli.BoolFlag{Name: flags.Dummy.Name},
 		&cli.StringFlag{Name: flags.RandomDummyProofDelay.Name},
+		&cli.Uint64Flag{Name: flags.DummyProofDelaySeed.Name},
+		&cli.StringFlag{Name: flags.DummyProofDelaysFile.Name},
+		&cli.BoolFlag{Name: flags.DummyProofFallback.Name},
+		&cli.StringFlag{Name: flags.ProofCacheEndpoint.Name},
+		&cli.StringFlag{Name: flags.ProofCacheToken.Name},
+		&cli.StringFlag{Name: flags.ProofCacheDir.Name},
+		&cli.DurationFlag{Name: flags.ProofCacheMaxAge.Name},
+		&cli.StringFlag{Name: flags.ProofArchiveDir.Name},
+		&cli.Uint64Flag{Name: flags.ProofArchiveMaxSize.Name},
+		&cli.StringFlag{Name: flags.ProofTimeHistory.Name},
+		&cli.BoolFlag{Name: flags.SkipNearlyStaleBlocks.Name},
+		&cli.StringFlag{Name: flags.RestoreSnapshot.Name},
+		&cli.StringFlag{Name: flags.LeaseEndpoint.Name},
+		&cli.StringFlag{Name: flags.LeaseToken.Name},
+		&cli.DurationFlag{Name: flags.LeaseTTL.Name},
+		&cli.StringFlag{Name: flags.LeaseHolderID.Name},
+		&cli.StringFlag{Name: flags.PeerL2Endpoints.Name},
+		&cli.DurationFlag{Name: flags.PollInterval.Name},
+		&cli.BoolFlag{Name: flags.DryRun.Name},
+		&cli.UintFlag{Name: flags.BlockDedupCacheSize.Name},
+		&cli.UintFlag{Name: flags.MaxConcurrentWitnessJobs.Name},
+		&cli.UintFlag{Name: flags.MaxPreparedWitnesses.Name},
+		&cli.UintFlag{Name: flags.PriorityQueueSize.Name},
+		&cli.UintFlag{Name: flags.ValidProofWeight.Name},
+		&cli.UintFlag{Name: flags.InvalidProofWeight.Name},
+		&cli.UintFlag{Name: flags.EventChBufferSize.Name},
+		&cli.UintFlag{Name: flags.ProofChBufferSize.Name},
+		&cli.StringFlag{Name: flags.ZkEvmRpcdHealthPath.Name},
+		&cli.StringFlag{Name: flags.ZkEvmRpcdCancelMethod.Name},
+		&cli.Uint64Flag{Name: flags.ZkEvmRpcdMaxQueueDepth.Name},
+		&cli.StringSliceFlag{Name: flags.ZkEvmRpcdEndpoint.Name},
+		&cli.StringSliceFlag{Name: flags.ZkEvmRpcdParamsTiers.Name},
+		&cli.DurationFlag{Name: flags.ZkEvmRpcdFallbackProbeInterval.Name},
+		&cli.DurationFlag{Name: flags.ZkEvmRpcdConnectTimeout.Name},
+		&cli.DurationFlag{Name: flags.ZkEvmRpcdPollTimeout.Name},
+		&cli.DurationFlag{Name: flags.ZkEvmRpcdProofTimeout.Name},
+		&cli.UintFlag{Name: flags.ZkEvmRpcdQueueWorkers.Name},
+		&cli.UintFlag{Name: flags.ZkEvmRpcdQueueDepth.Name},
+		&cli.StringFlag{Name: flags.ZkEvmRpcdJournal.Name},
+		&cli.StringFlag{Name: flags.ZkEvmRpcdProgressMethod.Name},
+		&cli.DurationFlag{Name: flags.ZkEvmRpcdProgressInterval.Name},
+		&cli.DurationFlag{Name: flags.ZkEvmRpcdStallThreshold.Name},
+		&cli.BoolFlag{Name: flags.ZkEvmRpcdStallTimeout.Name},
+		&cli.StringFlag{Name: flags.RpcdCallbackAddr.Name},
+		&cli.StringFlag{Name: flags.RpcdCallbackURL.Name},
+		&cli.DurationFlag{Name: flags.RpcdCallbackTimeout.Name},
+		&cli.BoolFlag{Name: flags.SkipRpcdProbe.Name},
+		&cli.BoolFlag{Name: flags.SkipProofValidation.Name},
+		&cli.StringSliceFlag{Name: flags.ProofSizes.Name},
+		&cli.DurationFlag{Name: flags.ProofWindow.Name},
+		&cli.Uint64Flag{Name: flags.MaxProvingLag.Name},
+		&cli.Uint64Flag{Name: flags.MinProofRewardGwei.Name},
+		&cli.StringFlag{Name: flags.ProverConfigFile.Name},
+		&cli.StringFlag{Name: flags.SubmissionKeysFile.Name},
+		&cli.StringFlag{Name: flags.ProverWebhookURL.Name},
+		&cli.DurationFlag{Name: flags.CompetingProofWindow.Name},
+		&cli.StringFlag{Name: flags.FeeStrategy.Name},
+		&cli.Uint64Flag{Name: flags.MinPriorityFeeGwei.Name},
+		&cli.Uint64Flag{Name: flags.MaxPriorityFeeGwei.Name},
+		&cli.DurationFlag{Name: flags.MaxUnprovenBlockAge.Name},
+		&cli.DurationFlag{Name: flags.SubmissionRetention.Name},
+		&cli.Uint64Flag{Name: flags.ReconcileWindow.Name},
+		&cli.Uint64Flag{Name: flags.LogSampling.Name},
+		&cli.StringFlag{Name: flags.ProverBlockFeedSocket.Name},
+		&cli.StringFlag{Name: flags.SafeAddress.Name},
+		&cli.StringFlag{Name: flags.SafeServiceURL.Name},
+		&cli.Uint64Flag{Name: flags.SafeThreshold.Name},
+		&cli.Uint64Flag{Name: flags.RequestProofMaxAttempts.Name},
+		&cli.DurationFlag{Name: flags.RequestProofRetryInterval.Name},
+		&cli.StringFlag{Name: flags.ProofProducerType.Name},
+		&cli.StringFlag{Name: flags.GrpcProofProducerEndpoint.Name},
+		&cli.StringFlag{Name: flags.ProofBackend.Name},
+		&cli.BoolFlag{Name: flags.GrpcProofProducerTLS.Name},
+		&cli.StringFlag{Name: flags.GrpcProofProducerCACert.Name},
+		&cli.StringFlag{Name: flags.GrpcProofProducerAuthToken.Name},
+		&cli.StringFlag{Name: flags.HTTPAddr.Name},
+		&cli.StringFlag{Name: flags.AdminToken.Name},
 	}
 	app.Action = func(ctx *cli.Context) error {
 		c, err := NewConfigFromCliContext(ctx)
@@ -39,13 +132,93 @@ func (s *ProverTestSuite) TestNewConfigFromCliContext() {
 		s.Equal(l2HttpEndpoint, c.L2HttpEndpoint)
 		s.Equal(taikoL1, c.TaikoL1Address.String())
 		s.Equal(taikoL2, c.TaikoL2Address.String())
+		s.Equal("/tmp/TaikoL1.json", c.TaikoL1ABIPath)
+		s.Equal("/tmp/TaikoL2.json", c.TaikoL2ABIPath)
+		s.Equal("http://localhost:18545", c.L1ArchiveEndpoint)
 		s.Equal(
 			crypto.PubkeyToAddress(s.p.cfg.L1ProverPrivKey.PublicKey),
 			crypto.PubkeyToAddress(c.L1ProverPrivKey.PublicKey),
 		)
 		s.Equal(30*time.Minute, *c.RandomDummyProofDelayLowerBound)
 		s.Equal(time.Hour, *c.RandomDummyProofDelayUpperBound)
+		s.Equal(uint64(42), *c.DummyProofDelaySeed)
+		s.Equal("/tmp/dummy-proof-delays.json", c.DummyProofDelaysPath)
 		s.True(c.Dummy)
+		s.False(c.DummyProofFallback)
+		s.Equal("http://localhost:28551", c.ProofCacheEndpoint)
+		s.Equal("token", c.ProofCacheToken)
+		s.Equal("/tmp/proofs", c.ProofCacheDir)
+		s.Equal(12*time.Hour, c.ProofCacheMaxAge)
+		s.Equal("/tmp/proof-archive", c.ProofArchiveDir)
+		s.Equal(int64(512*1024*1024), c.ProofArchiveMaxSize)
+		s.Equal("/tmp/proof-times.json", c.ProofTimeHistoryPath)
+		s.True(c.SkipNearlyStaleBlocks)
+		s.Equal("/tmp/prover-snapshot.tar", c.RestoreSnapshotPath)
+		s.Equal("http://localhost:28552", c.LeaseEndpoint)
+		s.Equal("leaseToken", c.LeaseToken)
+		s.Equal(2*time.Minute, c.LeaseTTL)
+		s.Equal("replica-1", c.LeaseHolderID)
+		s.Equal([]string{"http://localhost:28545", "http://localhost:38545"}, c.PeerL2Endpoints)
+		s.Equal(6*time.Second, c.PollInterval)
+		s.True(c.DryRun)
+		s.Equal(uint(2048), c.BlockDedupCacheSize)
+		s.Equal(uint(4), c.MaxConcurrentWitnessJobs)
+		s.Equal(uint(8), c.MaxPreparedWitnesses)
+		s.Equal(uint(512), c.PriorityQueueSize)
+		s.Equal(uint(1), c.ValidProofWeight)
+		s.Equal(uint(3), c.InvalidProofWeight)
+		s.Equal(uint(256), c.EventChBufferSize)
+		s.Equal(uint(128), c.ProofChBufferSize)
+		s.Equal("/health", c.ZkEvmRpcdHealthPath)
+		s.Equal("cancel", c.ZkEvmRpcdCancelMethod)
+		s.Equal(uint64(16), c.ZkEvmRpcdMaxQueueDepth)
+		s.Equal([]string{"http://localhost:18546", "http://localhost:28546"}, c.ZKEvmRpcdEndpoints)
+		s.Equal(
+			[]*proofProducer.ParamsTier{{MaxGas: 3_000_000, ParamsPath: "/params/small"}},
+			c.ZkEvmRpcdParamsTiers,
+		)
+		s.Equal(2*time.Minute, c.ZkEvmRpcdFallbackProbeInterval)
+		s.Equal(5*time.Second, c.ZkEvmRpcdConnectTimeout)
+		s.Equal(30*time.Second, c.ZkEvmRpcdPollTimeout)
+		s.Equal(time.Hour, c.ZkEvmRpcdProofTimeout)
+		s.Equal(uint(4), c.ZkEvmRpcdQueueWorkers)
+		s.Equal(uint(32), c.ZkEvmRpcdQueueDepth)
+		s.Equal("/tmp/rpcd-journal.json", c.ZkEvmRpcdJournalPath)
+		s.Equal("progress", c.ZkEvmRpcdProgressMethod)
+		s.Equal(15*time.Second, c.ZkEvmRpcdProgressInterval)
+		s.Equal(10*time.Minute, c.ZkEvmRpcdStallThreshold)
+		s.True(c.ZkEvmRpcdStallTimeout)
+		s.Equal("0.0.0.0:9001", c.RpcdCallbackAddr)
+		s.Equal("http://prover-1:9001", c.RpcdCallbackURL)
+		s.Equal(2*time.Hour, c.RpcdCallbackTimeout)
+		s.True(c.SkipRpcdProbe)
+		s.False(c.SkipProofValidation)
+		s.Equal(map[uint64]int{19: 4096, 21: 8192}, c.ProofSizes)
+		s.Equal(30*time.Minute, c.ProofWindow)
+		s.Equal(uint64(64), c.MaxProvingLag)
+		s.Equal(big.NewInt(5*params.GWei), c.MinProofRewardWei)
+		s.Equal(configFile, c.ConfigFile)
+		s.Equal(submissionKeysFile, c.SubmissionKeysFile)
+		s.Equal("http://localhost:8080/webhook", c.WebhookURL)
+		s.Equal(5*time.Second, c.CompetingProofWindow)
+		s.Equal(proofSubmitter.FeeStrategyCompetition, c.FeeStrategy)
+		s.Equal(big.NewInt(params.GWei), c.MinPriorityFeeWei)
+		s.Equal(big.NewInt(10*params.GWei), c.MaxPriorityFeeWei)
+		s.Equal(30*time.Minute, c.MaxUnprovenBlockAge)
+		s.Equal(2*time.Hour, c.SubmissionRetention)
+		s.Equal(uint64(256), c.ReconcileWindow)
+		s.Equal(uint64(10), c.LogSampling)
+		s.Equal("/tmp/taiko-driver-feed.sock", c.BlockFeedSocket)
+		s.Equal(common.HexToAddress("0x01"), c.SafeAddress)
+		s.Equal("http://localhost:8000", c.SafeServiceURL)
+		s.Equal(uint64(2), c.SafeThreshold)
+		s.Equal(uint64(3), c.RequestProofMaxAttempts)
+		s.Equal(time.Second, c.RequestProofRetryInterval)
+		s.Equal(ProofProducerTypeZkevmRpcd, c.ProofProducerType)
+		s.Equal(ProofBackendDummy, c.ProofBackend())
+		s.False(c.GrpcProofProducerTLS)
+		s.Equal("127.0.0.1:0", c.HTTPAddr)
+		s.Equal("secret", c.AdminToken)
 		s.Nil(new(Prover).InitFromCli(context.Background(), ctx))
 
 		return err
@@ -59,8 +232,539 @@ func (s *ProverTestSuite) TestNewConfigFromCliContext() {
 		"-" + flags.L2HTTPEndpoint.Name, l2HttpEndpoint,
 		"-" + flags.TaikoL1Address.Name, taikoL1,
 		"-" + flags.TaikoL2Address.Name, taikoL2,
+		"-" + flags.TaikoL1ABIPath.Name, "/tmp/TaikoL1.json",
+		"-" + flags.TaikoL2ABIPath.Name, "/tmp/TaikoL2.json",
+		"-" + flags.L1ArchiveEndpoint.Name, "http://localhost:18545",
 		"-" + flags.L1ProverPrivKey.Name, os.Getenv("L1_PROVER_PRIVATE_KEY"),
 		"-" + flags.Dummy.Name,
 		"-" + flags.RandomDummyProofDelay.Name, "30m-1h",
+		"-" + flags.DummyProofDelaySeed.Name, "42",
+		"-" + flags.DummyProofDelaysFile.Name, "/tmp/dummy-proof-delays.json",
+		"-" + flags.ProofCacheEndpoint.Name, "http://localhost:28551",
+		"-" + flags.ProofCacheToken.Name, "token",
+		"-" + flags.ProofCacheDir.Name, "/tmp/proofs",
+		"-" + flags.ProofCacheMaxAge.Name, "12h",
+		"-" + flags.ProofArchiveDir.Name, "/tmp/proof-archive",
+		"-" + flags.ProofArchiveMaxSize.Name, "512",
+		"-" + flags.ProofTimeHistory.Name, "/tmp/proof-times.json",
+		"-" + flags.SkipNearlyStaleBlocks.Name,
+		"-" + flags.RestoreSnapshot.Name, "/tmp/prover-snapshot.tar",
+		"-" + flags.LeaseEndpoint.Name, "http://localhost:28552",
+		"-" + flags.LeaseToken.Name, "leaseToken",
+		"-" + flags.LeaseTTL.Name, "2m",
+		"-" + flags.LeaseHolderID.Name, "replica-1",
+		"-" + flags.PeerL2Endpoints.Name, "http://localhost:28545, http://localhost:38545",
+		"-" + flags.PollInterval.Name, "6s",
+		"-" + flags.DryRun.Name,
+		"-" + flags.BlockDedupCacheSize.Name, "2048",
+		"-" + flags.MaxConcurrentWitnessJobs.Name, "4",
+		"-" + flags.MaxPreparedWitnesses.Name, "8",
+		"-" + flags.PriorityQueueSize.Name, "512",
+		"-" + flags.ValidProofWeight.Name, "1",
+		"-" + flags.InvalidProofWeight.Name, "3",
+		"-" + flags.EventChBufferSize.Name, "256",
+		"-" + flags.ProofChBufferSize.Name, "128",
+		"-" + flags.ZkEvmRpcdHealthPath.Name, "/health",
+		"-" + flags.ZkEvmRpcdCancelMethod.Name, "cancel",
+		"-" + flags.ZkEvmRpcdMaxQueueDepth.Name, "16",
+		"-" + flags.ZkEvmRpcdEndpoint.Name, "http://localhost:18546",
+		"-" + flags.ZkEvmRpcdEndpoint.Name, "http://localhost:28546",
+		"-" + flags.ZkEvmRpcdParamsTiers.Name, "3000000:/params/small",
+		"-" + flags.ZkEvmRpcdFallbackProbeInterval.Name, "2m",
+		"-" + flags.ZkEvmRpcdConnectTimeout.Name, "5s",
+		"-" + flags.ZkEvmRpcdPollTimeout.Name, "30s",
+		"-" + flags.ZkEvmRpcdProofTimeout.Name, "1h",
+		"-" + flags.ZkEvmRpcdQueueWorkers.Name, "4",
+		"-" + flags.ZkEvmRpcdQueueDepth.Name, "32",
+		"-" + flags.ZkEvmRpcdJournal.Name, "/tmp/rpcd-journal.json",
+		"-" + flags.ZkEvmRpcdProgressMethod.Name, "progress",
+		"-" + flags.ZkEvmRpcdProgressInterval.Name, "15s",
+		"-" + flags.ZkEvmRpcdStallThreshold.Name, "10m",
+		"-" + flags.ZkEvmRpcdStallTimeout.Name,
+		"-" + flags.RpcdCallbackAddr.Name, "0.0.0.0:9001",
+		"-" + flags.RpcdCallbackURL.Name, "http://prover-1:9001",
+		"-" + flags.RpcdCallbackTimeout.Name, "2h",
+		"-" + flags.SkipRpcdProbe.Name,
+		"-" + flags.ProofSizes.Name, "19:4096",
+		"-" + flags.ProofSizes.Name, "21:8192",
+		"-" + flags.ProofWindow.Name, "30m",
+		"-" + flags.MaxProvingLag.Name, "64",
+		"-" + flags.MinProofRewardGwei.Name, "5",
+		"-" + flags.ProverConfigFile.Name, configFile,
+		"-" + flags.SubmissionKeysFile.Name, submissionKeysFile,
+		"-" + flags.ProverWebhookURL.Name, "http://localhost:8080/webhook",
+		"-" + flags.CompetingProofWindow.Name, "5s",
+		"-" + flags.FeeStrategy.Name, proofSubmitter.FeeStrategyCompetition,
+		"-" + flags.MinPriorityFeeGwei.Name, "1",
+		"-" + flags.MaxPriorityFeeGwei.Name, "10",
+		"-" + flags.MaxUnprovenBlockAge.Name, "30m",
+		"-" + flags.SubmissionRetention.Name, "2h",
+		"-" + flags.ReconcileWindow.Name, "256",
+		"-" + flags.LogSampling.Name, "10",
+		"-" + flags.ProverBlockFeedSocket.Name, "/tmp/taiko-driver-feed.sock",
+		"-" + flags.SafeAddress.Name, common.HexToAddress("0x01").Hex(),
+		"-" + flags.SafeServiceURL.Name, "http://localhost:8000",
+		"-" + flags.SafeThreshold.Name, "2",
+		"-" + flags.RequestProofMaxAttempts.Name, "3",
+		"-" + flags.RequestProofRetryInterval.Name, "1s",
+		"-" + flags.ProofProducerType.Name, ProofProducerTypeZkevmRpcd,
+		"-" + flags.HTTPAddr.Name, "127.0.0.1:0",
+		"-" + flags.AdminToken.Name, "secret",
+	}))
+}
+
+// testRequiredFlags returns the flags required by NewConfigFromCliContext, whose values are those of the
+// testing environment.
+func testRequiredFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{Name: flags.L1WSEndpoint.Name, Value: os.Getenv("L1_NODE_WS_ENDPOINT")},
+		&cli.StringFlag{Name: flags.L1HTTPEndpoint.Name, Value: os.Getenv("L1_NODE_HTTP_ENDPOINT")},
+		&cli.StringFlag{Name: flags.L2WSEndpoint.Name, Value: os.Getenv("L2_EXECUTION_ENGINE_WS_ENDPOINT")},
+		&cli.StringFlag{Name: flags.L2HTTPEndpoint.Name, Value: os.Getenv("L2_EXECUTION_ENGINE_HTTP_ENDPOINT")},
+		&cli.StringFlag{Name: flags.L1ProverPrivKey.Name, Value: os.Getenv("L1_PROVER_PRIVATE_KEY")},
+		&cli.UintFlag{Name: flags.MaxConcurrentProvingJobs.Name, Value: flags.MaxConcurrentProvingJobs.Value},
+	}
+}
+
+func (s *ProverTestSuite) TestNewConfigFromCliContextStartingOptions() {
+	app := cli.NewApp()
+	app.Flags = append(
+		testRequiredFlags(),
+		&cli.StringFlag{Name: flags.ProofProducerType.Name, Value: flags.ProofProducerType.Value},
+		&cli.Uint64Flag{Name: flags.StartingBlockID.Name},
+		&cli.StringFlag{Name: flags.StartingBlockHash.Name},
+		&cli.Uint64Flag{Name: flags.StartingTimestamp.Name},
+	)
+
+	var cfg *Config
+	app.Action = func(ctx *cli.Context) (err error) {
+		cfg, err = NewConfigFromCliContext(ctx)
+		return err
+	}
+
+	hash := common.BigToHash(common.Big1)
+	s.Nil(app.Run([]string{
+		"TestNewConfigFromCliContextStartingOptions",
+		"-" + flags.L1ProverPrivKey.Name, os.Getenv("L1_PROVER_PRIVATE_KEY"),
+		"-" + flags.StartingBlockHash.Name, hash.Hex(),
+	}))
+	s.Equal(hash, *cfg.StartingBlockHash)
+	s.Nil(cfg.StartingBlockID)
+
+	s.Nil(app.Run([]string{
+		"TestNewConfigFromCliContextStartingOptions",
+		"-" + flags.L1ProverPrivKey.Name, os.Getenv("L1_PROVER_PRIVATE_KEY"),
+		"-" + flags.StartingTimestamp.Name, "1680000000",
+	}))
+	s.Equal(uint64(1680000000), cfg.StartingTimestamp)
+	s.Nil(cfg.StartingBlockHash)
+
+	s.ErrorContains(app.Run([]string{
+		"TestNewConfigFromCliContextStartingOptions",
+		"-" + flags.L1ProverPrivKey.Name, os.Getenv("L1_PROVER_PRIVATE_KEY"),
+		"-" + flags.StartingBlockHash.Name, "0x01",
+	}), "invalid starting block hash")
+
+	s.ErrorContains(app.Run([]string{
+		"TestNewConfigFromCliContextStartingOptions",
+		"-" + flags.L1ProverPrivKey.Name, os.Getenv("L1_PROVER_PRIVATE_KEY"),
+		"-" + flags.StartingBlockID.Name, "1",
+		"-" + flags.StartingTimestamp.Name, "1680000000",
+	}), "conflicting starting options")
+}
+
+func (s *ProverTestSuite) TestNewConfigFromCliContextProofBackend() {
+	app := cli.NewApp()
+	app.Flags = append(
+		testRequiredFlags(),
+		&cli.StringFlag{Name: flags.ProofProducerType.Name, Value: flags.ProofProducerType.Value},
+		&cli.BoolFlag{Name: flags.Dummy.Name},
+		&cli.StringFlag{Name: flags.ProofBackend.Name},
+		&cli.StringFlag{Name: flags.GrpcProofProducerEndpoint.Name},
+		&cli.BoolFlag{Name: flags.GrpcProofProducerTLS.Name},
+		&cli.StringFlag{Name: flags.GrpcProofProducerCACert.Name},
+		&cli.StringFlag{Name: flags.GrpcProofProducerAuthToken.Name},
+	)
+
+	var cfg *Config
+	app.Action = func(ctx *cli.Context) (err error) {
+		cfg, err = NewConfigFromCliContext(ctx)
+		return err
+	}
+
+	s.Nil(app.Run([]string{
+		"TestNewConfigFromCliContextProofBackend",
+		"-" + flags.L1ProverPrivKey.Name, os.Getenv("L1_PROVER_PRIVATE_KEY"),
+	}))
+	s.Equal(ProofBackendRpcd, cfg.ProofBackend())
+
+	s.Nil(app.Run([]string{
+		"TestNewConfigFromCliContextProofBackend",
+		"-" + flags.L1ProverPrivKey.Name, os.Getenv("L1_PROVER_PRIVATE_KEY"),
+		"-" + flags.ProofBackend.Name, ProofBackendGrpc,
+		"-" + flags.GrpcProofProducerEndpoint.Name, "localhost:50051",
+		"-" + flags.GrpcProofProducerTLS.Name,
+		"-" + flags.GrpcProofProducerCACert.Name, "/tmp/ca.pem",
+		"-" + flags.GrpcProofProducerAuthToken.Name, "secret",
+	}))
+	s.Equal(ProofBackendGrpc, cfg.ProofBackend())
+	s.Equal(ProofProducerTypeGrpc, cfg.ProofProducerType)
+	s.True(cfg.GrpcProofProducerTLS)
+	s.Equal("/tmp/ca.pem", cfg.GrpcProofProducerCACert)
+	s.Equal("secret", cfg.GrpcProofProducerAuthToken)
+
+	s.Nil(app.Run([]string{
+		"TestNewConfigFromCliContextProofBackend",
+		"-" + flags.L1ProverPrivKey.Name, os.Getenv("L1_PROVER_PRIVATE_KEY"),
+		"-" + flags.ProofBackend.Name, ProofBackendDummy,
 	}))
+	s.Equal(ProofBackendDummy, cfg.ProofBackend())
+	s.True(cfg.Dummy)
+
+	s.ErrorContains(app.Run([]string{
+		"TestNewConfigFromCliContextProofBackend",
+		"-" + flags.L1ProverPrivKey.Name, os.Getenv("L1_PROVER_PRIVATE_KEY"),
+		"-" + flags.ProofBackend.Name, ProofBackendRpcd,
+		"-" + flags.Dummy.Name,
+	}), "--prover.proofBackend conflicts with --proof-producer-type and --dummy")
+
+	s.ErrorContains(app.Run([]string{
+		"TestNewConfigFromCliContextProofBackend",
+		"-" + flags.L1ProverPrivKey.Name, os.Getenv("L1_PROVER_PRIVATE_KEY"),
+		"-" + flags.ProofBackend.Name, "sgx",
+	}), "invalid --prover.proofBackend: sgx")
+}
+
+// newValidConfig creates a new minimal Config which passes the validation.
+func newValidConfig(t *testing.T) *Config {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	return &Config{
+		L1WsEndpoint:             "ws://localhost:18546",
+		L1HttpEndpoint:           "http://localhost:18545",
+		L2WsEndpoint:             "ws://localhost:28546",
+		L2HttpEndpoint:           "http://localhost:28545",
+		L1ProverPrivKey:          key,
+		MaxConcurrentProvingJobs: 1,
+		ProofProducerType:        ProofProducerTypeZkevmRpcd,
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	var (
+		lower = 30 * time.Minute
+		upper = time.Hour
+		hash  = common.BigToHash(common.Big1)
+	)
+
+	testCases := []struct {
+		name   string
+		modify func(c *Config)
+		err    string
+	}{
+		{"valid", func(c *Config) {}, ""},
+		{"invalidProofProducerType", func(c *Config) { c.ProofProducerType = "sgx" }, "invalid --proof-producer-type"},
+		{
+			"grpcWithoutEndpoint",
+			func(c *Config) { c.ProofProducerType = ProofProducerTypeGrpc },
+			"--proof-producer-grpc-endpoint is required by --proof-producer-type grpc",
+		},
+		{
+			"dummyWithGrpc",
+			func(c *Config) {
+				c.ProofProducerType = ProofProducerTypeGrpc
+				c.GrpcProofProducerEndpoint = "localhost:50051"
+				c.Dummy = true
+			},
+			"--dummy conflicts with --proof-producer-type grpc",
+		},
+		{"missingL1WsEndpoint", func(c *Config) { c.L1WsEndpoint = "" }, "--l1.ws is required"},
+		{
+			"invalidL2HttpEndpoint",
+			func(c *Config) { c.L2HttpEndpoint = "localhost:8545" },
+			"invalid --l2.http: localhost:8545, host is required",
+		},
+		{"ipcL2WsEndpoint", func(c *Config) { c.L2WsEndpoint = "/tmp/geth.ipc" }, ""},
+		{"missingProverPrivKey", func(c *Config) { c.L1ProverPrivKey = nil }, "--l1.proverPrivKey is required"},
+		{
+			"zeroMaxConcurrentProvingJobs",
+			func(c *Config) { c.MaxConcurrentProvingJobs = 0 },
+			"--maxConcurrentProvingJobs must be greater than 0",
+		},
+		{
+			"negativeStartingBlockID",
+			func(c *Config) { c.StartingBlockID = big.NewInt(-1) },
+			"invalid --startingBlockID: -1",
+		},
+		{
+			"grpcTLSWithoutGrpc",
+			func(c *Config) { c.GrpcProofProducerTLS = true },
+			"--prover.grpcTLS is only used by the grpc proof backend",
+		},
+		{
+			"grpcAuthTokenWithoutGrpc",
+			func(c *Config) { c.GrpcProofProducerAuthToken = "secret" },
+			"--prover.grpcAuthToken is only used by the grpc proof backend",
+		},
+		{
+			"grpcCACertWithoutTLS",
+			func(c *Config) {
+				c.ProofProducerType = ProofProducerTypeGrpc
+				c.GrpcProofProducerEndpoint = "localhost:50051"
+				c.GrpcProofProducerCACert = "/tmp/ca.pem"
+			},
+			"--prover.grpcTLSCACert requires --prover.grpcTLS",
+		},
+		{
+			"randomDummyProofDelayWithoutDummy",
+			func(c *Config) {
+				c.RandomDummyProofDelayLowerBound = &lower
+				c.RandomDummyProofDelayUpperBound = &upper
+			},
+			"--randomDummyProofDelay is only used by --dummy",
+		},
+		{
+			"randomDummyProofDelayMissingBound",
+			func(c *Config) {
+				c.Dummy = true
+				c.RandomDummyProofDelayLowerBound = &lower
+			},
+			"both bounds of --randomDummyProofDelay are required",
+		},
+		{
+			"randomDummyProofDelayLowerGreaterThanUpper",
+			func(c *Config) {
+				c.Dummy = true
+				c.RandomDummyProofDelayLowerBound = &upper
+				c.RandomDummyProofDelayUpperBound = &lower
+			},
+			"lower bound 1h0m0s > upper bound 30m0s",
+		},
+		{
+			"dummyProofDelaySeedWithoutDummy",
+			func(c *Config) { c.DummyProofDelaySeed = new(uint64) },
+			"--dummyProofDelaySeed is only used by --dummy",
+		},
+		{
+			"dummyProofDelaySeedWithoutBounds",
+			func(c *Config) {
+				c.Dummy = true
+				c.DummyProofDelaySeed = new(uint64)
+			},
+			"--dummyProofDelaySeed requires --randomDummyProofDelay",
+		},
+		{
+			"dummyProofDelaysFileWithoutDummy",
+			func(c *Config) { c.DummyProofDelaysPath = "/tmp/dummy-proof-delays.json" },
+			"--dummyProofDelaysFile is only used by --dummy",
+		},
+		{
+			"dummyProofFallbackWithDummy",
+			func(c *Config) {
+				c.Dummy = true
+				c.DummyProofFallback = true
+			},
+			"--dummyProofFallback has no effect with --dummy",
+		},
+		{
+			"maxQueueDepthWithoutHealthPath",
+			func(c *Config) { c.ZkEvmRpcdMaxQueueDepth = 16 },
+			"--zkevmRpcdMaxQueueDepth requires --zkevmRpcdHealthPath",
+		},
+		{
+			"maxPreparedWitnessesWithoutWitnessJobs",
+			func(c *Config) { c.MaxPreparedWitnesses = 8 },
+			"--prover.maxPreparedWitnesses requires --prover.maxConcurrentWitnessJobs",
+		},
+		{
+			"adminTokenWithoutHTTPAddr",
+			func(c *Config) { c.AdminToken = "secret" },
+			"--admin-token requires --http.addr",
+		},
+		{
+			"proofSizesWithoutValidation",
+			func(c *Config) {
+				c.SkipProofValidation = true
+				c.ProofSizes = map[uint64]int{19: 4096}
+			},
+			"--prover.proofSizes has no effect with --prover.skipProofValidation",
+		},
+		{
+			"stallThresholdWithoutProgressMethod",
+			func(c *Config) { c.ZkEvmRpcdStallThreshold = time.Minute },
+			"--zkevmRpcdStallThreshold requires --zkevmRpcdProgressMethod",
+		},
+		{
+			"stallTimeoutWithoutThreshold",
+			func(c *Config) {
+				c.ZkEvmRpcdProgressMethod = "progress"
+				c.ZkEvmRpcdStallTimeout = true
+			},
+			"--zkevmRpcdStallTimeout requires --zkevmRpcdStallThreshold",
+		},
+		{
+			"negativeStallThreshold",
+			func(c *Config) { c.ZkEvmRpcdStallThreshold = -time.Second },
+			"invalid --zkevmRpcdStallThreshold: -1s",
+		},
+		{
+			"negativeFallbackProbeInterval",
+			func(c *Config) { c.ZkEvmRpcdFallbackProbeInterval = -time.Second },
+			"invalid --zkevmRpcdFallbackProbeInterval: -1s",
+		},
+		{
+			"negativeProofTimeout",
+			func(c *Config) { c.ZkEvmRpcdProofTimeout = -time.Second },
+			"invalid --zkevmRpcdProofTimeout: -1s",
+		},
+		{
+			"rpcdCallbackURLWithoutAddr",
+			func(c *Config) { c.RpcdCallbackURL = "http://prover-1:9001" },
+			"--prover.rpcdCallbackURL is only used by --prover.rpcdCallbackAddr",
+		},
+		{
+			"invalidRpcdCallbackAddr",
+			func(c *Config) { c.RpcdCallbackAddr = "prover-1" },
+			"invalid --prover.rpcdCallbackAddr: address prover-1: missing port in address",
+		},
+		{
+			"rpcdCallbackAddrWithoutHost",
+			func(c *Config) { c.RpcdCallbackAddr = ":9001" },
+			"--prover.rpcdCallbackURL is required by a --prover.rpcdCallbackAddr without host",
+		},
+		{
+			"conflictingStartingOptions",
+			func(c *Config) {
+				c.StartingBlockID = common.Big1
+				c.StartingBlockHash = &hash
+				c.StartingTimestamp = 1680000000
+			},
+			"conflicting starting options: --startingBlockID, --prover.startingBlockHash, --prover.startingTimestamp",
+		},
+		{
+			"safeWithoutServiceURL",
+			func(c *Config) {
+				c.SafeAddress = common.HexToAddress("0x01")
+				c.SafeThreshold = 1
+			},
+			"--safe-service-url is required by --safe-address",
+		},
+		{
+			"safeWithoutThreshold",
+			func(c *Config) {
+				c.SafeAddress = common.HexToAddress("0x01")
+				c.SafeServiceURL = "http://localhost:8000"
+			},
+			"--safe-threshold is required by --safe-address",
+		},
+		{
+			"safeWithAdditionalL1Endpoints",
+			func(c *Config) {
+				c.SafeAddress = common.HexToAddress("0x01")
+				c.SafeServiceURL = "http://localhost:8000"
+				c.SafeThreshold = 1
+				c.AdditionalL1Endpoints = []string{"ws://localhost:28546"}
+			},
+			"--prover.additionalL1Endpoints conflicts with --safe-address",
+		},
+		{
+			"safeServiceURLWithoutSafe",
+			func(c *Config) { c.SafeServiceURL = "http://localhost:8000" },
+			"--safe-service-url is only used by --safe-address",
+		},
+		{
+			"invalidFeeStrategy",
+			func(c *Config) { c.FeeStrategy = "auction" },
+			"invalid --prover.feeStrategy: auction",
+		},
+		{
+			"competitionFeeStrategyWithoutMaxPriorityFee",
+			func(c *Config) { c.FeeStrategy = proofSubmitter.FeeStrategyCompetition },
+			"--prover.maxPriorityFeeGwei is required by --prover.feeStrategy competition",
+		},
+		{
+			"minPriorityFeeAboveMax",
+			func(c *Config) {
+				c.MinPriorityFeeWei = big.NewInt(2)
+				c.MaxPriorityFeeWei = big.NewInt(1)
+			},
+			"--prover.minPriorityFeeGwei is above --prover.maxPriorityFeeGwei",
+		},
+		{
+			"proofCacheTokenWithoutEndpoint",
+			func(c *Config) { c.ProofCacheToken = "token" },
+			"--prover.proofCacheToken is only used by --prover.proofCacheEndpoint",
+		},
+		{
+			"proofArchiveDirInProofCacheDir",
+			func(c *Config) {
+				c.ProofCacheDir = "/tmp/proofs"
+				c.ProofArchiveDir = "/tmp/proofs/"
+			},
+			"--prover.proofArchiveDir conflicts with --prover.proofCacheDir",
+		},
+		{
+			"negativeProofCacheMaxAge",
+			func(c *Config) { c.ProofCacheMaxAge = -time.Hour },
+			"--prover.proofCacheMaxAge must not be negative",
+		},
+		{
+			"leaseTokenWithoutEndpoint",
+			func(c *Config) { c.LeaseToken = "token" },
+			"--prover.leaseToken is only used by --prover.leaseEndpoint",
+		},
+		{
+			"leaseHolderIDWithoutEndpoint",
+			func(c *Config) { c.LeaseHolderID = "replica-1" },
+			"--prover.leaseHolderID is only used by --prover.leaseEndpoint",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newValidConfig(t)
+			tc.modify(c)
+
+			err := c.Validate()
+			if tc.err == "" {
+				require.Nil(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestConfigValidateAllErrors(t *testing.T) {
+	c := newValidConfig(t)
+	c.L1HttpEndpoint = ""
+	c.MaxConcurrentProvingJobs = 0
+	c.AdminToken = "secret"
+
+	err := c.Validate()
+
+	var errs ConfigErrors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 3)
+	require.EqualError(
+		t,
+		err,
+		"3 invalid configurations: --l1.http is required; --maxConcurrentProvingJobs must be greater than 0; "+
+			"--admin-token requires --http.addr",
+	)
+}
+
+func TestConfigValidateDefaultLeaseHolderID(t *testing.T) {
+	c := newValidConfig(t)
+	c.LeaseEndpoint = "http://localhost:28552"
+	require.Nil(t, c.Validate())
+
+	holder, err := defaultLeaseHolderID()
+	require.Nil(t, err)
+	require.Equal(t, holder, c.LeaseHolderID)
+
+	c.LeaseHolderID = "replica-1"
+	require.Nil(t, c.Validate())
+	require.Equal(t, "replica-1", c.LeaseHolderID)
 }
@@ -0,0 +1,230 @@
+package divergence
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// defaultDepth is the default number of the most recent blocks compared with the peers.
+	defaultDepth = 8
+)
+
+// HeaderReader reads the L2 block headers, implemented by *ethclient.Client.
+type HeaderReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Peer is a peer L2 node, which chain is compared with the local one.
+type Peer struct {
+	Name   string
+	Client HeaderReader
+}
+
+// Report describes the first differing block between the local L2 node and a peer.
+type Report struct {
+	Peer           string      `json:"peer"`
+	Height         uint64      `json:"height"`
+	LocalHash      common.Hash `json:"localHash"`
+	PeerHash       common.Hash `json:"peerHash"`
+	LocalStateRoot common.Hash `json:"localStateRoot"`
+	PeerStateRoot  common.Hash `json:"peerStateRoot"`
+}
+
+// String implements the fmt.Stringer interface.
+func (r *Report) String() string {
+	return fmt.Sprintf(
+		"L2 chain diverged from peer %s at height %d, local hash %s state root %s, peer hash %s state root %s",
+		r.Peer, r.Height, r.LocalHash, r.LocalStateRoot, r.PeerHash, r.PeerStateRoot,
+	)
+}
+
+// Checker compares the local L2 node's most recent blocks with the peer L2 nodes, to detect the local
+// execution divergence before wasting proofs on a wrong chain.
+type Checker struct {
+	local HeaderReader
+	peers []*Peer
+	depth uint64
+
+	report *Report       // Current divergence, nil if the chains match
+	healed chan struct{} // Closed when the current divergence heals
+	mutex  sync.RWMutex
+}
+
+// New creates a new Checker instance, which compares the given number of the most recent blocks.
+func New(local HeaderReader, peers []*Peer, depth uint64) *Checker {
+	if depth == 0 {
+		depth = defaultDepth
+	}
+
+	return &Checker{local: local, peers: peers, depth: depth}
+}
+
+// Check compares the local chain with all peers, returns the divergence report of the first diverged peer,
+// or nil if the local chain matches all peers' chains.
+func (c *Checker) Check(ctx context.Context) (*Report, error) {
+	var report *Report
+	for _, peer := range c.peers {
+		r, err := c.checkPeer(ctx, peer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare the L2 chain with peer %s: %w", peer.Name, err)
+		}
+
+		if r != nil && (report == nil || r.Height < report.Height) {
+			report = r
+		}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if report != nil && c.report == nil {
+		c.healed = make(chan struct{})
+	}
+	if report == nil && c.report != nil {
+		close(c.healed)
+	}
+	c.report = report
+
+	return report, nil
+}
+
+// Diverged returns the current divergence report, nil if the chains match.
+func (c *Checker) Diverged() *Report {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.report
+}
+
+// IsPaused returns whether proving the block at the given height should be paused, i.e. the block is at
+// or after the first diverged height.
+func (c *Checker) IsPaused(height uint64) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.report != nil && height >= c.report.Height
+}
+
+// Wait blocks until proving the block at the given height is no longer paused.
+func (c *Checker) Wait(ctx context.Context, height uint64) error {
+	for {
+		c.mutex.RLock()
+		paused, healed := c.report != nil && height >= c.report.Height, c.healed
+		c.mutex.RUnlock()
+
+		if !paused {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-healed:
+		}
+	}
+}
+
+// checkPeer compares the most recent blocks of the local chain and the given peer's chain, and finds the
+// first differing height if they have diverged.
+func (c *Checker) checkPeer(ctx context.Context, peer *Peer) (*Report, error) {
+	localHead, err := c.local.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	peerHead, err := peer.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the heights both nodes have reached are comparable.
+	head := localHead.Number.Uint64()
+	if peerHead.Number.Uint64() < head {
+		head = peerHead.Number.Uint64()
+	}
+
+	from := uint64(0)
+	if head+1 > c.depth {
+		from = head + 1 - c.depth
+	}
+
+	var firstDiverged *uint64
+	for height := from; height <= head; height++ {
+		matched, err := c.matches(ctx, peer, height)
+		if err != nil {
+			return nil, err
+		}
+
+		if !matched {
+			h := height
+			firstDiverged = &h
+			break
+		}
+	}
+
+	if firstDiverged == nil {
+		return nil, nil
+	}
+
+	// The chains diverged before the compared blocks, binary search the first differing height, a
+	// block hash commits to all its ancestors, so all blocks after the first differing one differ.
+	if *firstDiverged == from && from > 0 {
+		lo, hi := uint64(0), from
+		for lo < hi {
+			mid := (lo + hi) / 2
+			matched, err := c.matches(ctx, peer, mid)
+			if err != nil {
+				return nil, err
+			}
+
+			if matched {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+		firstDiverged = &lo
+	}
+
+	return c.newReport(ctx, peer, *firstDiverged)
+}
+
+// matches checks whether the local block at the given height matches the peer's one.
+func (c *Checker) matches(ctx context.Context, peer *Peer, height uint64) (bool, error) {
+	local, err := c.local.HeaderByNumber(ctx, new(big.Int).SetUint64(height))
+	if err != nil {
+		return false, err
+	}
+	remote, err := peer.Client.HeaderByNumber(ctx, new(big.Int).SetUint64(height))
+	if err != nil {
+		return false, err
+	}
+
+	return local.Hash() == remote.Hash() && local.Root == remote.Root, nil
+}
+
+// newReport creates a divergence report of the given height.
+func (c *Checker) newReport(ctx context.Context, peer *Peer, height uint64) (*Report, error) {
+	local, err := c.local.HeaderByNumber(ctx, new(big.Int).SetUint64(height))
+	if err != nil {
+		return nil, err
+	}
+	remote, err := peer.Client.HeaderByNumber(ctx, new(big.Int).SetUint64(height))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Report{
+		Peer:           peer.Name,
+		Height:         height,
+		LocalHash:      local.Hash(),
+		PeerHash:       remote.Hash(),
+		LocalStateRoot: local.Root,
+		PeerStateRoot:  remote.Root,
+	}, nil
+}
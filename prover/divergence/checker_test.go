@@ -0,0 +1,154 @@
+package divergence
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryChain is an in-memory L2 chain.
+type memoryChain struct {
+	headers []*types.Header
+	mutex   sync.Mutex
+}
+
+// newMemoryChain creates a chain with the given number of blocks, the blocks after the given fork height
+// will have the state roots derived from the given salt.
+func newMemoryChain(length uint64, forkHeight uint64, salt byte) *memoryChain {
+	c := &memoryChain{}
+	c.extend(length, forkHeight, salt)
+	return c
+}
+
+// extend replaces the blocks from the given fork height, and extends the chain to the given length.
+func (c *memoryChain) extend(length uint64, forkHeight uint64, salt byte) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if uint64(len(c.headers)) > forkHeight {
+		c.headers = c.headers[:forkHeight]
+	}
+
+	for height := uint64(len(c.headers)); height < length; height++ {
+		header := &types.Header{Number: new(big.Int).SetUint64(height), Difficulty: common.Big0}
+		if height > 0 {
+			header.ParentHash = c.headers[height-1].Hash()
+		}
+		if height >= forkHeight {
+			header.Root = common.BytesToHash([]byte{salt, byte(height)})
+		}
+		c.headers = append(c.headers, header)
+	}
+}
+
+// HeaderByNumber implements the HeaderReader interface.
+func (c *memoryChain) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if number == nil {
+		return c.headers[len(c.headers)-1], nil
+	}
+	if number.Uint64() >= uint64(len(c.headers)) {
+		return nil, ethereum.NotFound
+	}
+
+	return c.headers[number.Uint64()], nil
+}
+
+func TestCheckMatched(t *testing.T) {
+	local := newMemoryChain(100, 100, 0)
+	peer := newMemoryChain(120, 100, 0) // The peer is ahead of the local node
+
+	report, err := New(local, []*Peer{{Name: "peer", Client: peer}}, 0).Check(context.Background())
+	require.Nil(t, err)
+	require.Nil(t, report)
+}
+
+func TestCheckDivergedWithinDepth(t *testing.T) {
+	local := newMemoryChain(100, 95, 1)
+	peer := newMemoryChain(100, 95, 2)
+
+	c := New(local, []*Peer{{Name: "peer", Client: peer}}, 8)
+	report, err := c.Check(context.Background())
+	require.Nil(t, err)
+	require.NotNil(t, report)
+	require.Equal(t, uint64(95), report.Height)
+	require.Equal(t, "peer", report.Peer)
+	require.Equal(t, common.BytesToHash([]byte{1, 95}), report.LocalStateRoot)
+	require.Equal(t, common.BytesToHash([]byte{2, 95}), report.PeerStateRoot)
+	require.NotEqual(t, report.LocalHash, report.PeerHash)
+
+	require.False(t, c.IsPaused(94))
+	require.True(t, c.IsPaused(95))
+	require.True(t, c.IsPaused(100))
+}
+
+func TestCheckDivergedBeforeDepth(t *testing.T) {
+	for _, forkHeight := range []uint64{1, 17, 42, 92} {
+		local := newMemoryChain(100, forkHeight, 1)
+		peer := newMemoryChain(100, forkHeight, 2)
+
+		report, err := New(local, []*Peer{{Name: "peer", Client: peer}}, 8).Check(context.Background())
+		require.Nil(t, err)
+		require.Equal(t, forkHeight, report.Height)
+	}
+}
+
+func TestCheckMultiplePeers(t *testing.T) {
+	local := newMemoryChain(100, 60, 1)
+	a := newMemoryChain(100, 60, 1)
+	a.extend(100, 80, 2) // Matches the local chain before 80
+	peers := []*Peer{
+		{Name: "a", Client: a},
+		{Name: "b", Client: newMemoryChain(100, 60, 2)},
+		{Name: "c", Client: newMemoryChain(100, 60, 1)},
+	}
+
+	report, err := New(local, peers, 8).Check(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, "b", report.Peer)
+	require.Equal(t, uint64(60), report.Height)
+}
+
+func TestWaitHealed(t *testing.T) {
+	local := newMemoryChain(100, 90, 1)
+	peer := newMemoryChain(100, 90, 2)
+	c := New(local, []*Peer{{Name: "peer", Client: peer}}, 0)
+
+	report, err := c.Check(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, uint64(90), report.Height)
+
+	// Not paused.
+	require.Nil(t, c.Wait(context.Background(), 89))
+
+	// Paused until the divergence heals.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, c.Wait(ctx, 90), context.DeadlineExceeded)
+
+	waitErr := make(chan error)
+	go func() { waitErr <- c.Wait(context.Background(), 95) }()
+
+	// The local node reorgs to match the peer.
+	local.extend(100, 90, 2)
+	report, err = c.Check(context.Background())
+	require.Nil(t, err)
+	require.Nil(t, report)
+	require.Nil(t, c.Diverged())
+
+	select {
+	case err := <-waitErr:
+		require.Nil(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("wait not returned after the divergence healed")
+	}
+}
@@ -0,0 +1,99 @@
+package prover
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	gethMetrics "github.com/ethereum/go-ethereum/metrics"
+	"github.com/taikoxyz/taiko-client/metrics"
+)
+
+var (
+	channelsMonitorInterval = 10 * time.Second
+)
+
+// sendDropOldest sends the given value to the given channel without blocking, if the channel is full,
+// the oldest buffered value is dropped to make room, returns whether a value has been dropped.
+func sendDropOldest[T any](ch chan T, v T) bool {
+	dropped := false
+	for {
+		select {
+		case ch <- v:
+			return dropped
+		default:
+		}
+
+		select {
+		case <-ch:
+			dropped = true
+		default:
+		}
+	}
+}
+
+// forwardDropOldest forwards the values from the subscription channel to the buffered channel consumed by
+// the event loop, so that a slow event loop never blocks the subscription, the oldest buffered values
+// are dropped on overflow. It's only used for the events which merely trigger a proving operation or
+// state update, where the newer events supersede the older ones.
+func forwardDropOldest[T any](
+	ctx context.Context,
+	name string,
+	src <-chan T,
+	dst chan T,
+	droppedCounter gethMetrics.Counter,
+) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case v := <-src:
+			if sendDropOldest(dst, v) {
+				log.Warn("Event channel full, dropped the oldest event", "event", name, "capacity", cap(dst))
+				droppedCounter.Inc(1)
+			}
+		}
+	}
+}
+
+// checkProofChBackpressure warns if the given proof channel is full, in which case the proof producers
+// are blocked until the buffered proofs are submitted, the proofs are never dropped.
+func checkProofChBackpressure[T any](name string, ch chan T, fullCounter gethMetrics.Counter) {
+	if cap(ch) == 0 || len(ch) < cap(ch) {
+		return
+	}
+
+	log.Warn(
+		"Proof channel full, proof producers are blocked until the buffered proofs are submitted",
+		"channel", name,
+		"capacity", cap(ch),
+	)
+	fullCounter.Inc(1)
+}
+
+// monitorChannels periodically reports the channels occupancy, and warns about the full proof channels,
+// which is checked outside the event loop, since a full proof channel usually means the event loop is
+// blocked by the proof submissions.
+func (p *Prover) monitorChannels() {
+	ticker := time.NewTicker(channelsMonitorInterval)
+	defer func() {
+		ticker.Stop()
+		p.wg.Done()
+	}()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			metrics.ProverBlockProposedChDepthGauge.Update(int64(len(p.blockProposedCh)))
+			metrics.ProverBlockVerifiedChDepthGauge.Update(int64(len(p.blockVerifiedCh)))
+			metrics.ProverBlockProvenChDepthGauge.Update(int64(len(p.blockProvenCh)))
+			metrics.ProverValidProofChDepthGauge.Update(int64(len(p.proveValidProofCh)))
+			metrics.ProverInvalidProofChDepthGauge.Update(int64(len(p.proveInvalidProofCh)))
+
+			checkProofChBackpressure("valid", p.proveValidProofCh, metrics.ProverProofChFullCounter)
+			checkProofChBackpressure("invalid", p.proveInvalidProofCh, metrics.ProverProofChFullCounter)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package prover
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendDropOldest(t *testing.T) {
+	ch := make(chan int, 2)
+
+	require.False(t, sendDropOldest(ch, 1))
+	require.False(t, sendDropOldest(ch, 2))
+	require.True(t, sendDropOldest(ch, 3))
+
+	require.Equal(t, 2, <-ch)
+	require.Equal(t, 3, <-ch)
+}
+
+func TestForwardDropOldest(t *testing.T) {
+	var (
+		src     = make(chan int)
+		dst     = make(chan int, 1)
+		counter = new(metrics.StandardCounter)
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		forwardDropOldest(ctx, "test", src, dst, counter)
+		close(done)
+	}()
+
+	// The forwarding never blocks the source, even if nobody consumes the destination.
+	for i := 0; i < 3; i++ {
+		select {
+		case src <- i:
+		case <-time.After(time.Second):
+			t.Fatal("forwarding blocked")
+		}
+	}
+
+	cancel()
+	<-done
+
+	require.Equal(t, 2, <-dst)
+	require.Equal(t, int64(2), counter.Count())
+}
+
+func TestCheckProofChBackpressure(t *testing.T) {
+	var (
+		ch      = make(chan int, 1)
+		counter = new(metrics.StandardCounter)
+	)
+
+	checkProofChBackpressure("test", ch, counter)
+	require.Equal(t, int64(0), counter.Count())
+
+	ch <- 1
+	checkProofChBackpressure("test", ch, counter)
+	require.Equal(t, int64(1), counter.Count())
+}
@@ -0,0 +1,93 @@
+package prover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/metrics"
+	"github.com/taikoxyz/taiko-client/prover/divergence"
+	"github.com/taikoxyz/taiko-client/prover/lifecycle"
+)
+
+var (
+	defaultDivergenceCheckInterval = 1 * time.Minute
+)
+
+// initDivergenceChecker dials the given peer L2 nodes, and initializes the L2 divergence checker.
+func (p *Prover) initDivergenceChecker(ctx context.Context, peerEndpoints []string) error {
+	peers := make([]*divergence.Peer, 0, len(peerEndpoints))
+	for _, endpoint := range peerEndpoints {
+		client, err := ethclient.DialContext(ctx, endpoint)
+		if err != nil {
+			return fmt.Errorf("failed to dial peer L2 node %s: %w", endpoint, err)
+		}
+		peers = append(peers, &divergence.Peer{Name: endpoint, Client: client})
+	}
+
+	p.divergenceChecker = divergence.New(p.rpc.L2, peers, 0)
+	return nil
+}
+
+// monitorL2Divergence compares the local L2 node's recent blocks with the peer L2 nodes' periodically,
+// the proving of the blocks at or after the first diverged height is paused until the divergence heals.
+func (p *Prover) monitorL2Divergence() {
+	ticker := time.NewTicker(defaultDivergenceCheckInterval)
+	defer func() {
+		ticker.Stop()
+		p.wg.Done()
+	}()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			p.checkL2Divergence(p.ctx)
+		}
+	}
+}
+
+// checkL2Divergence performs a L2 divergence check, and reports the divergence state changes.
+func (p *Prover) checkL2Divergence(ctx context.Context) {
+	previous := p.divergenceChecker.Diverged()
+
+	report, err := p.divergenceChecker.Check(ctx)
+	if err != nil {
+		log.Warn("Failed to check L2 divergence", "error", err)
+		return
+	}
+
+	if report == nil {
+		metrics.ProverL2DivergedHeightGauge.Update(0)
+		if previous != nil {
+			log.Info("L2 divergence healed, resume proving", "height", previous.Height, "peer", previous.Peer)
+			p.lifecycleNotifier.Notify(
+				lifecycle.EventL2DivergenceHealed, new(big.Int).SetUint64(previous.Height), nil, nil,
+			)
+		}
+		return
+	}
+
+	metrics.ProverL2DivergedHeightGauge.Update(int64(report.Height))
+	if previous != nil && previous.Height == report.Height {
+		return
+	}
+
+	log.Error(
+		"🚨 L2 execution divergence detected, pause proving",
+		"peer", report.Peer,
+		"height", report.Height,
+		"localHash", report.LocalHash,
+		"peerHash", report.PeerHash,
+		"localStateRoot", report.LocalStateRoot,
+		"peerStateRoot", report.PeerStateRoot,
+	)
+	p.lifecycleNotifier.Notify(
+		lifecycle.EventL2Diverged, new(big.Int).SetUint64(report.Height), nil, errors.New(report.String()),
+	)
+}
@@ -0,0 +1,133 @@
+package lease
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// leasesPath is the HTTP path prefix of the lock service API.
+	leasesPath = "/leases/"
+	// defaultTimeout is the default timeout of each lock service HTTP request.
+	defaultTimeout = 5 * time.Second
+)
+
+// AcquireRequest is the request body of a lease acquisition.
+type AcquireRequest struct {
+	Holder string `json:"holder"`
+	TTLMs  int64  `json:"ttlMs"`
+}
+
+// AcquireResponse is the response body of a lease acquisition.
+type AcquireResponse struct {
+	Acquired bool `json:"acquired"`
+}
+
+// Client is a Backend implementation backed by an external lock service, the protocol is a simple
+// authenticated HTTP API:
+//
+//	POST   /leases/{key}          <- a JSON encoded AcquireRequest, -> 200 with a JSON encoded AcquireResponse.
+//	DELETE /leases/{key}?holder=  -> 200.
+//
+// Every request carries an `Authorization: Bearer {token}` header, if a token is given.
+type Client struct {
+	endpoint   string
+	token      string
+	httpClient *http.Client
+}
+
+// New creates a new lock service client instance.
+func New(endpoint string, token string, timeout time.Duration) *Client {
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	return &Client{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Acquire implements the Backend interface.
+func (c *Client) Acquire(ctx context.Context, key string, holder string, ttl time.Duration) (bool, error) {
+	body, err := json.Marshal(&AcquireRequest{Holder: holder, TTLMs: ttl.Milliseconds()})
+	if err != nil {
+		return false, err
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, leasesPath+url.PathEscape(key), bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lease %s: %w", key, err)
+	}
+	defer res.Body.Close()
+
+	var response AcquireResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return false, fmt.Errorf("failed to decode lease acquisition response: %w", err)
+	}
+
+	return response.Acquired, nil
+}
+
+// Release implements the Backend interface.
+func (c *Client) Release(ctx context.Context, key string, holder string) error {
+	req, err := c.newRequest(
+		ctx,
+		http.MethodDelete,
+		leasesPath+url.PathEscape(key)+"?holder="+url.QueryEscape(holder),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to release lease %s: %w", key, err)
+	}
+
+	return res.Body.Close()
+}
+
+// newRequest creates a new lock service HTTP request.
+func (c *Client) newRequest(ctx context.Context, method string, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.token) != 0 {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	return req, nil
+}
+
+// do sends the given request, and checks the response status.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		resBytes, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return nil, fmt.Errorf("status: %s, body: %s", res.Status, string(resBytes))
+	}
+
+	return res, nil
+}
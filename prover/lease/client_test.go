@@ -0,0 +1,39 @@
+package lease
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(NewMemoryServer("token"))
+	defer server.Close()
+
+	var (
+		a   = New(server.URL, "token", 0)
+		b   = New(server.URL+"/", "token", 0)
+		ctx = context.Background()
+	)
+
+	acquired, err := a.Acquire(ctx, "0xabc/1", "a", time.Minute)
+	require.Nil(t, err)
+	require.True(t, acquired)
+
+	acquired, err = b.Acquire(ctx, "0xabc/1", "b", time.Minute)
+	require.Nil(t, err)
+	require.False(t, acquired)
+
+	require.Nil(t, a.Release(ctx, "0xabc/1", "a"))
+
+	acquired, err = b.Acquire(ctx, "0xabc/1", "b", time.Minute)
+	require.Nil(t, err)
+	require.True(t, acquired)
+
+	// Unauthorized.
+	_, err = New(server.URL, "invalid", 0).Acquire(ctx, "0xabc/2", "a", time.Minute)
+	require.ErrorContains(t, err, "401")
+}
@@ -0,0 +1,62 @@
+package lease
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Backend is a lease table shared by the prover replicas, a lease is held by one holder at a time,
+// until it's released or expired.
+type Backend interface {
+	// Acquire claims the lease of the given key for the holder, or extends the lease if it's already
+	// held by the holder, returns false if the lease is held by another holder.
+	Acquire(ctx context.Context, key string, holder string, ttl time.Duration) (bool, error)
+	// Release releases the lease of the given key, if it's held by the holder.
+	Release(ctx context.Context, key string, holder string) error
+}
+
+// lease is an entry of the in-memory lease table.
+type lease struct {
+	holder    string
+	expiresAt time.Time
+}
+
+// MemoryBackend is an in-memory Backend implementation, which can only be shared by the replicas in
+// the same process, testing purposes only.
+type MemoryBackend struct {
+	leases map[string]*lease
+	now    func() time.Time
+	mutex  sync.Mutex
+}
+
+// NewMemoryBackend creates a new MemoryBackend instance.
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{leases: make(map[string]*lease), now: time.Now}
+}
+
+// Acquire implements the Backend interface.
+func (b *MemoryBackend) Acquire(_ context.Context, key string, holder string, ttl time.Duration) (bool, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	now := b.now()
+	if l, ok := b.leases[key]; ok && l.holder != holder && now.Before(l.expiresAt) {
+		return false, nil
+	}
+
+	b.leases[key] = &lease{holder: holder, expiresAt: now.Add(ttl)}
+	return true, nil
+}
+
+// Release implements the Backend interface.
+func (b *MemoryBackend) Release(_ context.Context, key string, holder string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if l, ok := b.leases[key]; ok && l.holder == holder {
+		delete(b.leases, key)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package lease
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryBackend(t *testing.T) {
+	var (
+		b   = NewMemoryBackend()
+		now = time.Now()
+		ctx = context.Background()
+	)
+	b.now = func() time.Time { return now }
+
+	acquired, err := b.Acquire(ctx, "1", "a", time.Minute)
+	require.Nil(t, err)
+	require.True(t, acquired)
+
+	// Held by another holder.
+	acquired, err = b.Acquire(ctx, "1", "b", time.Minute)
+	require.Nil(t, err)
+	require.False(t, acquired)
+
+	// Extended by the holder.
+	now = now.Add(50 * time.Second)
+	acquired, err = b.Acquire(ctx, "1", "a", time.Minute)
+	require.Nil(t, err)
+	require.True(t, acquired)
+
+	now = now.Add(50 * time.Second)
+	acquired, err = b.Acquire(ctx, "1", "b", time.Minute)
+	require.Nil(t, err)
+	require.False(t, acquired)
+
+	// Expired, handed over to another holder.
+	now = now.Add(11 * time.Second)
+	acquired, err = b.Acquire(ctx, "1", "b", time.Minute)
+	require.Nil(t, err)
+	require.True(t, acquired)
+
+	// Only the holder can release the lease.
+	require.Nil(t, b.Release(ctx, "1", "a"))
+	acquired, err = b.Acquire(ctx, "1", "a", time.Minute)
+	require.Nil(t, err)
+	require.False(t, acquired)
+
+	require.Nil(t, b.Release(ctx, "1", "b"))
+	acquired, err = b.Acquire(ctx, "1", "a", time.Minute)
+	require.Nil(t, err)
+	require.True(t, acquired)
+}
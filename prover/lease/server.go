@@ -0,0 +1,59 @@
+package lease
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// MemoryServer is an in-memory reference implementation of the lock service API.
+type MemoryServer struct {
+	token   string
+	backend *MemoryBackend
+}
+
+// NewMemoryServer creates a new MemoryServer instance, requests must carry the given bearer token.
+func NewMemoryServer(token string) *MemoryServer {
+	return &MemoryServer{token: token, backend: NewMemoryBackend()}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *MemoryServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.token != "" && r.Header.Get("Authorization") != "Bearer "+s.token {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	key, err := url.PathUnescape(strings.TrimPrefix(r.URL.Path, leasesPath))
+	if !strings.HasPrefix(r.URL.Path, leasesPath) || err != nil || len(key) == 0 {
+		http.Error(w, "invalid lease key", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		var req AcquireRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Holder) == 0 || req.TTLMs <= 0 {
+			http.Error(w, "invalid acquisition request", http.StatusBadRequest)
+			return
+		}
+
+		acquired, err := s.backend.Acquire(r.Context(), key, req.Holder, time.Duration(req.TTLMs)*time.Millisecond)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(&AcquireResponse{Acquired: acquired})
+	case http.MethodDelete:
+		if err := s.backend.Release(r.Context(), key, r.URL.Query().Get("holder")); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
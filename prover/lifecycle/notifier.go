@@ -0,0 +1,92 @@
+package lifecycle
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/taikoxyz/taiko-client/pkg/webhook"
+)
+
+const (
+	// SchemaVersion is the version of the lifecycle event payload schema, which should be bumped
+	// whenever a backward incompatible change is made to the Event struct.
+	SchemaVersion = 1
+	// queueSize is the maximum number of pending lifecycle events.
+	queueSize = 256
+	// timeout is the timeout of each webhook request.
+	timeout = 5 * time.Second
+)
+
+// EventType is the type of a prover lifecycle event.
+type EventType string
+
+const (
+	EventProofRequested       EventType = "proofRequested"
+	EventProofGenerated       EventType = "proofGenerated"
+	EventProofSubmitted       EventType = "proofSubmitted"
+	EventSubmissionReverted   EventType = "submissionReverted"
+	EventSubscriptionLost     EventType = "subscriptionLost"
+	EventUnprovenBlockOverdue EventType = "unprovenBlockOverdue"
+	EventL2Diverged           EventType = "l2Diverged"
+	EventL2DivergenceHealed   EventType = "l2DivergenceHealed"
+)
+
+// Event is the webhook payload of a prover lifecycle event.
+type Event struct {
+	Version   int            `json:"version"`
+	Type      EventType      `json:"type"`
+	Prover    common.Address `json:"prover"`
+	Timestamp int64          `json:"timestamp"`
+	BlockID   *big.Int       `json:"blockID,omitempty"`
+	TxHash    *common.Hash   `json:"txHash,omitempty"`
+	Error     string         `json:"error,omitempty"`
+}
+
+// Notifier posts the prover lifecycle events to a webhook on a best-effort basis, the events will be
+// dropped if the webhook can't keep up, so that a dead webhook never blocks the prover. All methods
+// of a nil Notifier are no-ops.
+type Notifier struct {
+	webhook *webhook.Notifier
+	prover  common.Address
+}
+
+// New creates a new Notifier instance, returns nil if the given webhook URL is empty.
+func New(url string, prover common.Address) *Notifier {
+	if len(url) == 0 {
+		return nil
+	}
+
+	return &Notifier{webhook: webhook.New(url, queueSize, timeout), prover: prover}
+}
+
+// Notify queues a new lifecycle event of the given type, the block ID, transaction hash and error are
+// all optional.
+func (n *Notifier) Notify(eventType EventType, blockID *big.Int, txHash *common.Hash, err error) {
+	if n == nil {
+		return
+	}
+
+	event := &Event{
+		Version:   SchemaVersion,
+		Type:      eventType,
+		Prover:    n.prover,
+		Timestamp: time.Now().Unix(),
+		BlockID:   blockID,
+		TxHash:    txHash,
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+
+	n.webhook.Notify(event)
+}
+
+// Close stops posting the lifecycle events.
+func (n *Notifier) Close() {
+	if n == nil {
+		return
+	}
+
+	n.webhook.Close()
+}
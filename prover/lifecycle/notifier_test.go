@@ -0,0 +1,52 @@
+package lifecycle
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotify(t *testing.T) {
+	received := make(chan *Event, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event Event
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&event))
+		received <- &event
+	}))
+	defer srv.Close()
+
+	prover := common.HexToAddress("0x01")
+	n := New(srv.URL, prover)
+	defer n.Close()
+
+	txHash := common.HexToHash("0x02")
+	n.Notify(EventSubmissionReverted, common.Big1, &txHash, errors.New("L1_INVALID_PROOF"))
+
+	select {
+	case event := <-received:
+		require.Equal(t, SchemaVersion, event.Version)
+		require.Equal(t, EventSubmissionReverted, event.Type)
+		require.Equal(t, prover, event.Prover)
+		require.Equal(t, common.Big1, event.BlockID)
+		require.Equal(t, &txHash, event.TxHash)
+		require.Equal(t, "L1_INVALID_PROOF", event.Error)
+		require.NotZero(t, event.Timestamp)
+	case <-time.After(5 * time.Second):
+		t.Fatal("lifecycle event not received")
+	}
+}
+
+func TestNilNotifier(t *testing.T) {
+	n := New("", common.Address{})
+	require.Nil(t, n)
+	require.NotPanics(t, func() {
+		n.Notify(EventProofRequested, common.Big1, nil, nil)
+		n.Close()
+	})
+}
@@ -0,0 +1,218 @@
+package prover
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/metrics"
+	phaseTracker "github.com/taikoxyz/taiko-client/pkg/phase_tracker"
+	"github.com/taikoxyz/taiko-client/prover/lifecycle"
+	proofSubmitter "github.com/taikoxyz/taiko-client/prover/proof_submitter"
+)
+
+var (
+	defaultUnprovenBlockCheckInterval = 30 * time.Second
+	unprovenBlockCheckTimeout         = 10 * time.Second
+)
+
+// unprovenCandidates is the set of the proposed blocks which current prover is responsible for, and still
+// lack any fork choice.
+type unprovenCandidates struct {
+	mutex  sync.Mutex
+	blocks map[uint64]time.Time // blockID -> proposedAt
+}
+
+// newUnprovenCandidates creates a new empty unprovenCandidates instance.
+func newUnprovenCandidates() *unprovenCandidates {
+	return &unprovenCandidates{blocks: make(map[uint64]time.Time)}
+}
+
+// Add adds the given block to the candidates.
+func (c *unprovenCandidates) Add(id uint64, proposedAt time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.blocks[id] = proposedAt
+}
+
+// Remove removes the given block from the candidates.
+func (c *unprovenCandidates) Remove(id uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.blocks, id)
+}
+
+// RemoveUpTo removes all the blocks with IDs less than or equal to the given one, used when the blocks
+// have been verified.
+func (c *unprovenCandidates) RemoveUpTo(id uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for blockID := range c.blocks {
+		if blockID <= id {
+			delete(c.blocks, blockID)
+		}
+	}
+}
+
+// Oldest returns the candidate with the smallest block ID, which is also the earliest proposed one.
+func (c *unprovenCandidates) Oldest() (uint64, time.Time, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var (
+		oldestID   uint64
+		proposedAt time.Time
+		found      bool
+	)
+	for id, t := range c.blocks {
+		if !found || id < oldestID {
+			oldestID, proposedAt, found = id, t, true
+		}
+	}
+
+	return oldestID, proposedAt, found
+}
+
+// IDs returns the IDs of all the candidates, sorted by block ID.
+func (c *unprovenCandidates) IDs() []uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	ids := make([]uint64, 0, len(c.blocks))
+	for id := range c.blocks {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
+
+// Len returns the number of the candidates.
+func (c *unprovenCandidates) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return len(c.blocks)
+}
+
+// OldestUnprovenBlock is the oldest block current prover is responsible for, which still lacks any fork choice.
+type OldestUnprovenBlock struct {
+	BlockID    uint64    `json:"blockID"`
+	ProposedAt time.Time `json:"proposedAt"`
+	AgeSeconds uint64    `json:"ageSeconds"`
+}
+
+// Status contains the prover's current proving status, which is exposed by the `/status` endpoint.
+type Status struct {
+	Startup             *phaseTracker.Status            `json:"startup"`
+	UnprovenBlocks      int                             `json:"unprovenBlocks"`
+	OldestUnprovenBlock *OldestUnprovenBlock            `json:"oldestUnprovenBlock"`
+	SubmittedProofs     []proofSubmitter.SubmittedProof `json:"submittedProofs"`
+	ProvingPaused       bool                            `json:"provingPaused"`
+	ProofQueue          []QueuedProofRequest            `json:"proofQueue"`
+	InFlightProofs      []InFlightProof                 `json:"inFlightProofs"`
+	UnprovableBlocks    []UnprovableBlock               `json:"unprovableBlocks"`
+}
+
+// Status returns the prover's current proving status.
+func (p *Prover) Status() *Status {
+	oldest, _ := p.oldestUnproven.Load().(*OldestUnprovenBlock)
+
+	return &Status{
+		Startup:             p.startupTracker.Status(),
+		UnprovenBlocks:      p.unprovenCandidates.Len(),
+		OldestUnprovenBlock: oldest,
+		SubmittedProofs:     p.submissions.Entries(),
+		ProvingPaused:       p.ProvingPaused(),
+		ProofQueue:          p.proofQueue.Contents(),
+		InFlightProofs:      p.inFlightProofs(),
+		UnprovableBlocks:    p.unprovableBlocks.Entries(),
+	}
+}
+
+// monitorUnprovenBlocks keeps updating the age of the oldest unproven block periodically. The candidates
+// are rebuilt by the catch-up iteration after a restart, so no persistence is needed.
+func (p *Prover) monitorUnprovenBlocks() {
+	ticker := time.NewTicker(defaultUnprovenBlockCheckInterval)
+	defer func() {
+		ticker.Stop()
+		p.wg.Done()
+	}()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.checkOldestUnprovenBlock(p.ctx); err != nil {
+				log.Warn("Failed to check the oldest unproven block", "error", err)
+			}
+		}
+	}
+}
+
+// checkOldestUnprovenBlock updates the oldest unproven block, and notifies the lifecycle webhook if its
+// age exceeds the configured threshold.
+func (p *Prover) checkOldestUnprovenBlock(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, unprovenBlockCheckTimeout)
+	defer cancel()
+
+	for {
+		id, proposedAt, ok := p.unprovenCandidates.Oldest()
+		if !ok {
+			p.oldestUnproven.Store((*OldestUnprovenBlock)(nil))
+			metrics.ProverOldestUnprovenBlockAgeGauge.Update(0)
+			return nil
+		}
+
+		// Some BlockProven events might have been missed, e.g. the ones emitted before a restart, so double
+		// check the oldest candidate against the chain before reporting it.
+		isVerified, err := p.isBlockVerified(new(big.Int).SetUint64(id))
+		if err != nil {
+			return err
+		}
+		if isVerified {
+			p.unprovenCandidates.Remove(id)
+			continue
+		}
+
+		parent, err := p.getParentHeader(ctx, new(big.Int).SetUint64(id))
+		if err != nil {
+			return err
+		}
+
+		provers, err := p.batchGetForkChoiceProvers(ctx, []uint64{id}, []*types.Header{parent})
+		if err != nil {
+			return err
+		}
+		if provers[0] != (common.Address{}) {
+			p.unprovenCandidates.Remove(id)
+			continue
+		}
+
+		age := time.Since(proposedAt)
+		p.oldestUnproven.Store(&OldestUnprovenBlock{
+			BlockID:    id,
+			ProposedAt: proposedAt,
+			AgeSeconds: uint64(age.Seconds()),
+		})
+		metrics.ProverOldestUnprovenBlockAgeGauge.Update(int64(age.Seconds()))
+		metrics.ProverOldestUnprovenBlockIDGauge.Update(int64(id))
+
+		if p.cfg.MaxUnprovenBlockAge != 0 && age > p.cfg.MaxUnprovenBlockAge && p.overdueAlertedBlockID != id {
+			log.Warn("Oldest unproven block is overdue", "blockID", id, "age", age)
+			p.lifecycleNotifier.Notify(lifecycle.EventUnprovenBlockOverdue, new(big.Int).SetUint64(id), nil, nil)
+			p.overdueAlertedBlockID = id
+		}
+
+		return nil
+	}
+}
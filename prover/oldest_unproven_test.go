@@ -0,0 +1,44 @@
+package prover
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnprovenCandidates(t *testing.T) {
+	c := newUnprovenCandidates()
+	now := time.Now()
+
+	_, _, ok := c.Oldest()
+	require.False(t, ok)
+
+	c.Add(3, now.Add(2*time.Second))
+	c.Add(1, now)
+	c.Add(2, now.Add(time.Second))
+	c.Add(5, now.Add(4*time.Second))
+	require.Equal(t, 4, c.Len())
+
+	id, proposedAt, ok := c.Oldest()
+	require.True(t, ok)
+	require.Equal(t, uint64(1), id)
+	require.Equal(t, now, proposedAt)
+
+	// Proven by some prover.
+	c.Remove(1)
+	id, _, ok = c.Oldest()
+	require.True(t, ok)
+	require.Equal(t, uint64(2), id)
+
+	// Verified.
+	c.RemoveUpTo(3)
+	id, _, ok = c.Oldest()
+	require.True(t, ok)
+	require.Equal(t, uint64(5), id)
+
+	c.Remove(5)
+	_, _, ok = c.Oldest()
+	require.False(t, ok)
+	require.Zero(t, c.Len())
+}
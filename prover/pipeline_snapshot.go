@@ -0,0 +1,170 @@
+package prover
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/prover/snapshot"
+	"github.com/taikoxyz/taiko-client/version"
+)
+
+// SnapshotResult is the summary of a written proof pipeline snapshot.
+type SnapshotResult struct {
+	Path               string `json:"path"`
+	Version            int    `json:"version"`
+	Cursor             uint64 `json:"cursor"`
+	Jobs               int    `json:"jobs"`
+	QueuedBlocks       int    `json:"queuedBlocks"`
+	PendingSubmissions int    `json:"pendingSubmissions"`
+	Proofs             int    `json:"proofs"`
+}
+
+// newSnapshotResult creates the summary of the given snapshot written to the given path.
+func newSnapshotResult(path string, snap *snapshot.Snapshot) *SnapshotResult {
+	return &SnapshotResult{
+		Path:               path,
+		Version:            snap.Version,
+		Cursor:             snap.Cursor(),
+		Jobs:               len(snap.Jobs),
+		QueuedBlocks:       len(snap.QueuedBlocks),
+		PendingSubmissions: len(snap.PendingSubmissions),
+		Proofs:             len(snap.Proofs),
+	}
+}
+
+// WriteSnapshot writes the snapshot of the running prover's proof pipeline to the given path, so that the
+// proving can be resumed on another machine with --prover.restoreSnapshot.
+func (p *Prover) WriteSnapshot(path string) (*SnapshotResult, error) {
+	snap := &snapshot.Snapshot{
+		CreatedAt:          time.Now().UTC(),
+		ClientVersion:      version.VersionWithCommit(),
+		TaikoL1Address:     p.cfg.TaikoL1Address,
+		ProverAddress:      p.proverAddress,
+		Jobs:               p.proofJournal.Entries(),
+		QueuedBlocks:       p.proofQueue.BlockIDs(),
+		PendingSubmissions: p.submissionQueue.BlockIDs(),
+		UnprovenBlocks:     p.unprovenCandidates.IDs(),
+		Submissions:        p.submissions.Entries(),
+	}
+	if len(p.cfg.ProofCacheDir) != 0 {
+		proofs, err := snapshot.ListProofs(p.cfg.ProofCacheDir)
+		if err != nil {
+			return nil, err
+		}
+		snap.Proofs = proofs
+	}
+
+	if err := snapshot.WriteFile(path, snap, p.cfg.ProofCacheDir); err != nil {
+		return nil, err
+	}
+
+	result := newSnapshotResult(path, snap)
+	log.Info(
+		"Proof pipeline snapshot written",
+		"path", path,
+		"cursor", result.Cursor,
+		"jobs", result.Jobs,
+		"queuedBlocks", result.QueuedBlocks,
+		"pendingSubmissions", result.PendingSubmissions,
+		"proofs", result.Proofs,
+	)
+
+	return result, nil
+}
+
+// handleSnapshot handles the `/admin/snapshot` requests, whose body is like {"out": "/path/to/snap.tar"}.
+func (p *Prover) handleSnapshot(r *http.Request) (interface{}, error) {
+	var req struct {
+		Out string `json:"out"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("invalid snapshot request: %w", err)
+	}
+	if len(req.Out) == 0 {
+		return nil, fmt.Errorf("snapshot output path is required")
+	}
+
+	return p.WriteSnapshot(req.Out)
+}
+
+// loadSnapshot reads the snapshot to restore at the given path, and reconciles it against current chain
+// state, dropping the entries of the blocks verified since the snapshot was taken.
+func (p *Prover) loadSnapshot(path string) (*snapshot.Snapshot, error) {
+	snap, err := snapshot.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if snap.TaikoL1Address != p.cfg.TaikoL1Address {
+		return nil, fmt.Errorf(
+			"snapshot of another TaikoL1 contract: %s, expected: %s",
+			snap.TaikoL1Address, p.cfg.TaikoL1Address,
+		)
+	}
+
+	stateVars, err := p.rpc.GetProtocolStateVariables(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch protocol state variables: %w", err)
+	}
+	dropped := snap.Reconcile(stateVars.LastVerifiedBlockId)
+
+	log.Info(
+		"Proof pipeline snapshot loaded",
+		"path", path,
+		"createdAt", snap.CreatedAt,
+		"clientVersion", snap.ClientVersion,
+		"cursor", snap.Cursor(),
+		"latestVerifiedID", stateVars.LastVerifiedBlockId,
+		"dropped", dropped,
+	)
+
+	return snap, nil
+}
+
+// snapshotStartingBlockID returns the block ID the proving should be started from to resume the given
+// snapshot, or nil if the snapshot has nothing left to be proved.
+func snapshotStartingBlockID(snap *snapshot.Snapshot) *big.Int {
+	// Started from the last block not to be proved, like the latest verified block when there is no snapshot.
+	if cursor := snap.Cursor(); cursor > 1 {
+		return new(big.Int).SetUint64(cursor - 1)
+	}
+
+	return nil
+}
+
+// restoreSnapshot restores the in-flight proof jobs, the cached proofs and the tracked submissions of the
+// given loaded snapshot, once the proof journal and the local proof cache are initialized. The queued
+// blocks are proved again since the cursor is restored, their proofs are reused from the cached ones.
+func (p *Prover) restoreSnapshot(snap *snapshot.Snapshot) error {
+	if p.proofJournal != nil {
+		for _, job := range snap.Jobs {
+			if err := p.proofJournal.Put(job); err != nil {
+				return fmt.Errorf("failed to restore journaled proof job: %w", err)
+			}
+		}
+	} else if len(snap.Jobs) != 0 {
+		log.Warn("No proof journal configured, the snapshot's in-flight proof jobs are dropped", "jobs", len(snap.Jobs))
+	}
+
+	if len(p.cfg.ProofCacheDir) != 0 {
+		if err := snap.ExtractProofs(p.cfg.ProofCacheDir); err != nil {
+			return err
+		}
+	} else if len(snap.Proofs) != 0 {
+		log.Warn("No local proof cache configured, the snapshot's cached proofs are dropped", "proofs", len(snap.Proofs))
+	}
+
+	p.submissions.Restore(snap.Submissions)
+
+	log.Info(
+		"Proof pipeline snapshot restored",
+		"jobs", len(snap.Jobs),
+		"proofs", len(snap.Proofs),
+		"submissions", len(snap.Submissions),
+	)
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package prover
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+	proofSubmitter "github.com/taikoxyz/taiko-client/prover/proof_submitter"
+	"github.com/taikoxyz/taiko-client/prover/snapshot"
+)
+
+// newTestSnapshotProver creates a prover with only the proof pipeline components, persisted in the given
+// directory.
+func newTestSnapshotProver(t *testing.T, dir string) *Prover {
+	journal, err := proofProducer.OpenProofJournal(context.Background(), filepath.Join(dir, "journal.json"))
+	require.Nil(t, err)
+
+	return &Prover{
+		cfg: &Config{
+			TaikoL1Address: common.HexToAddress("0x01"),
+			ProofCacheDir:  filepath.Join(dir, "proofs"),
+		},
+		proofJournal:       journal,
+		proofQueue:         NewPriorityQueue(4),
+		submissionQueue:    NewSubmissionQueue(defaultSubmissionEscalationMargin),
+		unprovenCandidates: newUnprovenCandidates(),
+		submissions:        proofSubmitter.NewSubmissionTracker(0),
+	}
+}
+
+func TestWriteRestoreSnapshot(t *testing.T) {
+	p := newTestSnapshotProver(t, t.TempDir())
+	require.Nil(t, p.proofJournal.Put(&proofProducer.JournalEntry{BlockID: 2, RequestID: big.NewInt(2)}))
+	require.Nil(t, p.proofQueue.Push(newTestProofRequest(3, time.Now())))
+	p.submissionQueue.Push(&proofProducer.ProofWithHeader{BlockID: big.NewInt(1)}, proofClassValid, time.Now())
+	for id := uint64(1); id <= 3; id++ {
+		p.unprovenCandidates.Add(id, time.Now())
+	}
+	p.submissions.Record(1, common.Hash{}, common.HexToHash("0x01"), common.HexToHash("0xa1"), common.Address{})
+	require.Nil(t, os.MkdirAll(p.cfg.ProofCacheDir, 0o700))
+	proofName := "1-0x01-0x00.json"
+	require.Nil(t, os.WriteFile(filepath.Join(p.cfg.ProofCacheDir, proofName), []byte("proof"), 0o600))
+
+	path := filepath.Join(t.TempDir(), "snap.tar")
+	result, err := p.WriteSnapshot(path)
+	require.Nil(t, err)
+	require.Equal(t, &SnapshotResult{
+		Path:               path,
+		Version:            snapshot.Version,
+		Cursor:             1,
+		Jobs:               1,
+		QueuedBlocks:       1,
+		PendingSubmissions: 1,
+		Proofs:             1,
+	}, result)
+
+	// Restore the snapshot on a fresh machine, after block 1 has been verified.
+	snap, err := snapshot.ReadFile(path)
+	require.Nil(t, err)
+	snap.Reconcile(1)
+	require.Equal(t, big.NewInt(1), snapshotStartingBlockID(snap))
+
+	restored := newTestSnapshotProver(t, t.TempDir())
+	require.Nil(t, restored.restoreSnapshot(snap))
+	require.Len(t, restored.proofJournal.Entries(), 1)
+	require.Equal(t, uint64(2), restored.proofJournal.Entries()[0].BlockID)
+	require.Empty(t, restored.submissions.Entries())
+	_, err = os.Stat(filepath.Join(restored.cfg.ProofCacheDir, proofName))
+	require.True(t, os.IsNotExist(err))
+
+	// Nothing left to be proved.
+	snap.Reconcile(3)
+	require.Nil(t, snapshotStartingBlockID(snap))
+}
+
+func TestRestoreSnapshotProofs(t *testing.T) {
+	p := newTestSnapshotProver(t, t.TempDir())
+	header := &types.Header{Number: big.NewInt(5), Difficulty: common.Big0}
+	require.Nil(t, os.MkdirAll(p.cfg.ProofCacheDir, 0o700))
+	require.Nil(t, os.WriteFile(
+		filepath.Join(p.cfg.ProofCacheDir, "5-"+header.Hash().Hex()+"-"+header.ParentHash.Hex()+".json"),
+		[]byte("proof"),
+		0o600,
+	))
+
+	path := filepath.Join(t.TempDir(), "snap.tar")
+	_, err := p.WriteSnapshot(path)
+	require.Nil(t, err)
+	snap, err := snapshot.ReadFile(path)
+	require.Nil(t, err)
+	require.Equal(t, 0, snap.Reconcile(4))
+
+	// The proofs are dropped without a local proof cache.
+	restored := newTestSnapshotProver(t, t.TempDir())
+	restored.cfg.ProofCacheDir = ""
+	require.Nil(t, restored.restoreSnapshot(snap))
+
+	restored = newTestSnapshotProver(t, t.TempDir())
+	require.Nil(t, restored.restoreSnapshot(snap))
+	proofs, err := snapshot.ListProofs(restored.cfg.ProofCacheDir)
+	require.Nil(t, err)
+	require.Equal(t, snap.Proofs, proofs)
+}
+
+func TestHandleSnapshot(t *testing.T) {
+	p := newTestSnapshotProver(t, t.TempDir())
+	path := filepath.Join(t.TempDir(), "snap.tar")
+
+	_, err := p.handleSnapshot(httptest.NewRequest("POST", "/admin/snapshot", bytes.NewBufferString(`{}`)))
+	require.ErrorContains(t, err, "output path is required")
+
+	result, err := p.handleSnapshot(
+		httptest.NewRequest("POST", "/admin/snapshot", bytes.NewBufferString(`{"out":"`+path+`"}`)),
+	)
+	require.Nil(t, err)
+	require.Equal(t, path, result.(*SnapshotResult).Path)
+	_, err = os.Stat(path)
+	require.Nil(t, err)
+}
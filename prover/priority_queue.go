@@ -0,0 +1,255 @@
+package prover
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+var (
+	errPriorityQueueFull = errors.New("priority queue is full")
+)
+
+// proofRequest is a pending proof request of a proposed block.
+type proofRequest struct {
+	event      *bindings.TaikoL1ClientBlockProposed
+	class      proofClass
+	observedAt time.Time
+	deadline   time.Time  // proposedAt + proof window
+	logger     log.Logger // Tagged with the block's context
+}
+
+// newProofRequest creates a new proofRequest instance of the given proposed block, observed just now.
+func newProofRequest(
+	event *bindings.TaikoL1ClientBlockProposed,
+	window time.Duration,
+	logger log.Logger,
+) *proofRequest {
+	_, deadline := proofWindow(&event.Meta, window)
+	return &proofRequest{event: event, observedAt: time.Now(), deadline: deadline, logger: logger}
+}
+
+// proofWindow returns the proof window [start, end) of the given proposed block, which starts when the
+// block is proposed, the window length is configured by --prover.proofWindow, since the protocol doesn't
+// define one.
+func proofWindow(meta *bindings.TaikoDataBlockMetadata, window time.Duration) (start, end time.Time) {
+	start = time.Unix(int64(meta.Timestamp), 0)
+	return start, start.Add(window)
+}
+
+// proofRequestHeap implements heap.Interface, the request with the earliest deadline is on the top.
+type proofRequestHeap []*proofRequest
+
+func (h proofRequestHeap) Len() int { return len(h) }
+
+func (h proofRequestHeap) Less(i, j int) bool {
+	if h[i].deadline.Equal(h[j].deadline) {
+		return h[i].event.Id.Cmp(h[j].event.Id) < 0
+	}
+	return h[i].deadline.Before(h[j].deadline)
+}
+
+func (h proofRequestHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *proofRequestHeap) Push(x interface{}) { *h = append(*h, x.(*proofRequest)) }
+
+func (h *proofRequestHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return item
+}
+
+// PriorityQueue is a bounded queue of the pending proof requests. The requests of each proof class are
+// ranked by their remaining proof windows, so that the blocks closest to expiry are proved first, and the
+// classes share the dequeues by their weights, so that neither class starves the other.
+type PriorityQueue struct {
+	mutex   sync.Mutex
+	classes [numProofClasses]proofRequestHeap
+	weights [numProofClasses]int
+	credits [numProofClasses]int // Smooth weighted round-robin state of the classes
+	maxSize int
+	notify  chan struct{}
+}
+
+// NewPriorityQueue creates a new PriorityQueue instance holding at most the given number of requests, the
+// classes are equally weighted.
+func NewPriorityQueue(maxSize int) *PriorityQueue {
+	q := &PriorityQueue{maxSize: maxSize, notify: make(chan struct{}, 1)}
+	for class := range q.weights {
+		q.weights[class] = 1
+	}
+
+	return q
+}
+
+// SetWeights sets the weights of the proof classes, while both classes have queued requests, they are
+// dequeued in the ratio of their weights. Both weights must be positive.
+func (q *PriorityQueue) SetWeights(valid int, invalid int) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.weights[proofClassValid] = valid
+	q.weights[proofClassInvalid] = invalid
+	q.credits = [numProofClasses]int{}
+}
+
+// Push adds the given request to the queue, returns errPriorityQueueFull if the queue is full.
+func (q *PriorityQueue) Push(req *proofRequest) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if q.len() >= q.maxSize {
+		return errPriorityQueueFull
+	}
+
+	heap.Push(&q.classes[req.class], req)
+	q.signal()
+
+	return nil
+}
+
+// Pop blocks until there is a request in the queue, and then removes and returns the most urgent one.
+func (q *PriorityQueue) Pop(ctx context.Context) (*proofRequest, error) {
+	for {
+		q.mutex.Lock()
+		if q.len() != 0 {
+			req := heap.Pop(&q.classes[q.nextClass()]).(*proofRequest)
+			// Wake up the other waiters, if there are still some requests left.
+			if q.len() != 0 {
+				q.signal()
+			}
+			q.mutex.Unlock()
+			return req, nil
+		}
+		q.mutex.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-q.notify:
+		}
+	}
+}
+
+// Wait blocks until there is a request in the queue, without removing it.
+func (q *PriorityQueue) Wait(ctx context.Context) error {
+	for {
+		q.mutex.Lock()
+		if q.len() != 0 {
+			q.mutex.Unlock()
+			return nil
+		}
+		q.mutex.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-q.notify:
+		}
+	}
+}
+
+// Len returns the number of requests in the queue.
+func (q *PriorityQueue) Len() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return q.len()
+}
+
+// BlockIDs returns the IDs of the blocks whose proof requests are in the queue, sorted by block ID.
+func (q *PriorityQueue) BlockIDs() []uint64 {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	ids := make([]uint64, 0, q.len())
+	for _, items := range q.classes {
+		for _, req := range items {
+			ids = append(ids, req.event.Id.Uint64())
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
+
+// QueuedProofRequest is a proof request in the PriorityQueue, exposed by the `/status` endpoint.
+type QueuedProofRequest struct {
+	BlockID  uint64    `json:"blockID"`
+	Class    string    `json:"class"`
+	Deadline time.Time `json:"deadline"`
+}
+
+// Contents returns the requests in the queue, grouped by proof class, in the order they will be dequeued
+// within each class.
+func (q *PriorityQueue) Contents() []QueuedProofRequest {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	contents := make([]QueuedProofRequest, 0, q.len())
+	for _, items := range q.classes {
+		sorted := make(proofRequestHeap, len(items))
+		copy(sorted, items)
+		sort.Sort(sorted)
+
+		for _, req := range sorted {
+			contents = append(contents, QueuedProofRequest{
+				BlockID:  req.event.Id.Uint64(),
+				Class:    req.class.String(),
+				Deadline: req.deadline,
+			})
+		}
+	}
+
+	return contents
+}
+
+// len returns the number of requests in the queue, the caller must hold the mutex.
+func (q *PriorityQueue) len() int {
+	var n int
+	for _, items := range q.classes {
+		n += len(items)
+	}
+
+	return n
+}
+
+// nextClass picks the class to dequeue from among the ones with queued requests, by smooth weighted
+// round-robin, the caller must hold the mutex, and the queue must not be empty.
+func (q *PriorityQueue) nextClass() proofClass {
+	var (
+		next  = proofClass(-1)
+		total int
+	)
+	for class, items := range q.classes {
+		if len(items) == 0 {
+			continue
+		}
+
+		q.credits[class] += q.weights[class]
+		total += q.weights[class]
+		if next < 0 || q.credits[class] > q.credits[next] {
+			next = proofClass(class)
+		}
+	}
+	q.credits[next] -= total
+
+	return next
+}
+
+// signal notifies the waiters without blocking, the caller must hold the mutex.
+func (q *PriorityQueue) signal() {
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
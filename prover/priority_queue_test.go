@@ -0,0 +1,104 @@
+package prover
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+func newTestProofRequest(id int64, deadline time.Time) *proofRequest {
+	return &proofRequest{
+		event:    &bindings.TaikoL1ClientBlockProposed{Id: big.NewInt(id)},
+		deadline: deadline,
+	}
+}
+
+func TestPriorityQueueOrdering(t *testing.T) {
+	q := NewPriorityQueue(4)
+	now := time.Now()
+
+	require.Nil(t, q.Push(newTestProofRequest(1, now.Add(3*time.Minute))))
+	require.Nil(t, q.Push(newTestProofRequest(2, now.Add(time.Minute))))
+	require.Nil(t, q.Push(newTestProofRequest(4, now.Add(2*time.Minute))))
+	require.Nil(t, q.Push(newTestProofRequest(3, now.Add(2*time.Minute))))
+	require.ErrorIs(t, q.Push(newTestProofRequest(5, now)), errPriorityQueueFull)
+	require.Equal(t, 4, q.Len())
+	require.Equal(t, []uint64{1, 2, 3, 4}, q.BlockIDs())
+
+	// Closest to expiry first, ties are broken by the block ID.
+	for _, id := range []int64{2, 3, 4, 1} {
+		req, err := q.Pop(context.Background())
+		require.Nil(t, err)
+		require.Equal(t, id, req.event.Id.Int64())
+	}
+	require.Zero(t, q.Len())
+}
+
+func TestPriorityQueueClassWeights(t *testing.T) {
+	q := NewPriorityQueue(16)
+	q.SetWeights(1, 2)
+	deadline := time.Now().Add(time.Hour)
+
+	for id := int64(1); id <= 4; id++ {
+		require.Nil(t, q.Push(newTestProofRequest(id, deadline)))
+	}
+	for id := int64(5); id <= 10; id++ {
+		req := newTestProofRequest(id, deadline)
+		req.class = proofClassInvalid
+		require.Nil(t, q.Push(req))
+	}
+
+	contents := q.Contents()
+	require.Len(t, contents, 10)
+	require.Equal(t, QueuedProofRequest{BlockID: 1, Class: "valid", Deadline: deadline}, contents[0])
+	require.Equal(t, QueuedProofRequest{BlockID: 5, Class: "invalid", Deadline: deadline}, contents[4])
+
+	// Two invalid proofs for each valid one while both classes are queued, FIFO by block ID within a class,
+	// and the remaining valid proofs once the invalid ones are drained.
+	for _, id := range []int64{5, 1, 6, 7, 2, 8, 9, 3, 10, 4} {
+		req, err := q.Pop(context.Background())
+		require.Nil(t, err)
+		require.Equal(t, id, req.event.Id.Int64())
+	}
+	require.Zero(t, q.Len())
+	require.Empty(t, q.Contents())
+}
+
+func TestPriorityQueuePopBlocking(t *testing.T) {
+	q := NewPriorityQueue(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := q.Pop(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		require.Nil(t, q.Push(newTestProofRequest(1, time.Now())))
+	}()
+
+	req, err := q.Pop(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, int64(1), req.event.Id.Int64())
+}
+
+func TestNewProofRequest(t *testing.T) {
+	event := &bindings.TaikoL1ClientBlockProposed{
+		Id:   common.Big1,
+		Meta: bindings.TaikoDataBlockMetadata{Timestamp: 1680000000},
+	}
+
+	start, end := proofWindow(&event.Meta, time.Hour)
+	require.Equal(t, time.Unix(1680000000, 0), start)
+	require.Equal(t, time.Unix(1680003600, 0), end)
+
+	req := newProofRequest(event, time.Hour, nil)
+	require.Equal(t, event, req.event)
+	require.Equal(t, end, req.deadline)
+	require.False(t, req.observedAt.IsZero())
+}
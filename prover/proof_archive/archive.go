@@ -0,0 +1,271 @@
+package proofArchive
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/metrics"
+)
+
+var (
+	// artifactExt is the file extension of the archived artifacts.
+	artifactExt = ".json"
+	// defaultQueueSize is the default number of the artifacts waiting to be written.
+	defaultQueueSize = 64
+)
+
+// Artifact is the audit record of a submitted proof.
+type Artifact struct {
+	BlockID      *big.Int                         `json:"blockID"`
+	Meta         *bindings.TaikoDataBlockMetadata `json:"meta"`
+	Header       *types.Header                    `json:"header"`
+	PublicInputs *PublicInputs                    `json:"publicInputs"`
+	ZkProof      hexutil.Bytes                    `json:"zkProof"`
+	Job          *Job                             `json:"job"`
+	TxHash       common.Hash                      `json:"txHash"`
+	Submitter    common.Address                   `json:"submitter"`
+	SubmittedAt  time.Time                        `json:"submittedAt"`
+}
+
+// PublicInputs are the fields of the TaikoL1.proveBlock evidence besides the block metadata and the proof.
+type PublicInputs struct {
+	ParentHash common.Hash    `json:"parentHash"`
+	BlockHash  common.Hash    `json:"blockHash"`
+	SignalRoot common.Hash    `json:"signalRoot"`
+	Graffiti   common.Hash    `json:"graffiti"`
+	Prover     common.Address `json:"prover"`
+	VerifierID uint16         `json:"verifierId"`
+}
+
+// Job is the metadata of the proof job which generated the proof.
+type Job struct {
+	Degree uint64 `json:"degree"` // Circuits degree of the proof
+	Origin string `json:"origin"` // Name of the backend which generated the proof, empty if not a fallback one
+}
+
+// Archive writes the artifacts of the submitted proofs to a directory asynchronously, one JSON file named
+// by the block ID per block, the artifacts will be dropped when the queue is full, so that a slow disk never
+// blocks the proving pipeline. Once the total size of the files exceeds the max size, the artifacts of the
+// lowest block IDs are removed. All methods of a nil Archive are no-ops.
+type Archive struct {
+	dir     string
+	maxSize int64 // 0 means unlimited
+	queue   chan *Artifact
+	done    chan struct{}
+	once    sync.Once
+	wg      sync.WaitGroup
+
+	// Sizes of the archived files by block ID, only accessed by the worker.
+	sizes     map[uint64]int64
+	totalSize int64
+}
+
+// New creates a new Archive instance in the given directory, the directory is created if it does not
+// exist, and starts a worker writing the queued artifacts.
+func New(dir string, maxSize int64, queueSize int) (*Archive, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create proof archive directory: %w", err)
+	}
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+
+	a := &Archive{
+		dir:     dir,
+		maxSize: maxSize,
+		queue:   make(chan *Artifact, queueSize),
+		done:    make(chan struct{}),
+		sizes:   make(map[uint64]int64),
+	}
+	if err := a.load(); err != nil {
+		return nil, err
+	}
+
+	a.wg.Add(1)
+	go a.loop()
+
+	return a, nil
+}
+
+// Put queues the given artifact, returns false if the artifact is dropped.
+func (a *Archive) Put(artifact *Artifact) bool {
+	if a == nil {
+		return false
+	}
+
+	select {
+	case <-a.done:
+		return false
+	default:
+	}
+
+	select {
+	case a.queue <- artifact:
+		return true
+	default:
+		log.Warn("Proof archive queue is full, drop the artifact", "blockID", artifact.BlockID)
+		metrics.ProverProofArchiveDroppedCounter.Inc(1)
+		return false
+	}
+}
+
+// Close stops the worker, once the artifacts still in the queue are written.
+func (a *Archive) Close() {
+	if a == nil {
+		return
+	}
+
+	a.once.Do(func() { close(a.done) })
+	a.wg.Wait()
+}
+
+// path returns the path of the archived artifact of the given block.
+func (a *Archive) path(blockID uint64) string {
+	return filepath.Join(a.dir, strconv.FormatUint(blockID, 10)+artifactExt)
+}
+
+// Read reads the archived artifact in the given file.
+func Read(path string) (*Artifact, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read proof artifact: %w", err)
+	}
+
+	var artifact Artifact
+	if err := json.Unmarshal(data, &artifact); err != nil {
+		return nil, fmt.Errorf("failed to decode proof artifact: %w", err)
+	}
+	if artifact.BlockID == nil || artifact.Meta == nil || artifact.Header == nil || len(artifact.ZkProof) == 0 {
+		return nil, fmt.Errorf("incomplete proof artifact: %s", path)
+	}
+
+	return &artifact, nil
+}
+
+// loop keeps writing the queued artifacts until the archive is closed.
+func (a *Archive) loop() {
+	defer a.wg.Done()
+
+	for {
+		select {
+		case <-a.done:
+			for {
+				select {
+				case artifact := <-a.queue:
+					a.archive(artifact)
+				default:
+					return
+				}
+			}
+		case artifact := <-a.queue:
+			a.archive(artifact)
+		}
+	}
+}
+
+// archive writes the given artifact, and then prunes the archive, the failures are only logged.
+func (a *Archive) archive(artifact *Artifact) {
+	if err := a.write(artifact); err != nil {
+		log.Warn("Failed to archive proof artifact", "blockID", artifact.BlockID, "error", err)
+		return
+	}
+	metrics.ProverProofArchiveWrittenCounter.Inc(1)
+
+	if pruned := a.prune(); pruned != 0 {
+		log.Debug("Proof archive pruned", "artifacts", pruned, "size", a.totalSize)
+	}
+}
+
+// write writes the given artifact atomically, replacing the previous one of the same block.
+func (a *Archive) write(artifact *Artifact) error {
+	data, err := json.MarshalIndent(artifact, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode proof artifact: %w", err)
+	}
+
+	blockID := artifact.BlockID.Uint64()
+	path := a.path(blockID)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write proof artifact: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write proof artifact: %w", err)
+	}
+
+	a.totalSize += int64(len(data)) - a.sizes[blockID]
+	a.sizes[blockID] = int64(len(data))
+
+	return nil
+}
+
+// prune removes the artifacts of the lowest block IDs, until the total size is within the max size,
+// returns the number of the removed artifacts. The artifact of the highest block ID is always kept.
+func (a *Archive) prune() int {
+	if a.maxSize == 0 || a.totalSize <= a.maxSize {
+		return 0
+	}
+
+	blockIDs := make([]uint64, 0, len(a.sizes))
+	for blockID := range a.sizes {
+		blockIDs = append(blockIDs, blockID)
+	}
+	sort.Slice(blockIDs, func(i, j int) bool { return blockIDs[i] < blockIDs[j] })
+
+	var pruned int
+	for _, blockID := range blockIDs[:len(blockIDs)-1] {
+		if a.totalSize <= a.maxSize {
+			break
+		}
+		if err := os.Remove(a.path(blockID)); err != nil && !os.IsNotExist(err) {
+			log.Warn("Failed to remove archived proof artifact", "blockID", blockID, "error", err)
+			continue
+		}
+		a.totalSize -= a.sizes[blockID]
+		delete(a.sizes, blockID)
+		pruned++
+	}
+	metrics.ProverProofArchivePrunedCounter.Inc(int64(pruned))
+
+	return pruned
+}
+
+// load indexes the sizes of the artifacts already in the archive directory.
+func (a *Archive) load() error {
+	files, err := os.ReadDir(a.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read proof archive directory: %w", err)
+	}
+
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), artifactExt) {
+			continue
+		}
+		blockID, err := strconv.ParseUint(strings.TrimSuffix(file.Name(), artifactExt), 10, 64)
+		if err != nil {
+			// Not an artifact.
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			continue
+		}
+		a.sizes[blockID] = info.Size()
+		a.totalSize += info.Size()
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package proofArchive
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+func newTestArtifact(blockID int64) *Artifact {
+	header := &types.Header{
+		ParentHash: common.BigToHash(big.NewInt(blockID - 1)),
+		Number:     big.NewInt(blockID),
+		Difficulty: common.Big0,
+		GasLimit:   1024,
+	}
+
+	return &Artifact{
+		BlockID: big.NewInt(blockID),
+		Meta:    &bindings.TaikoDataBlockMetadata{Id: uint64(blockID), GasLimit: 1024},
+		Header:  header,
+		PublicInputs: &PublicInputs{
+			ParentHash: header.ParentHash,
+			BlockHash:  header.Hash(),
+			Prover:     common.HexToAddress("0x01"),
+			VerifierID: 2,
+		},
+		ZkProof:     []byte{0xff},
+		Job:         &Job{Degree: 19, Origin: "rpcd"},
+		TxHash:      common.BigToHash(big.NewInt(blockID)),
+		Submitter:   common.HexToAddress("0x02"),
+		SubmittedAt: time.Unix(1680000000, 0).UTC(),
+	}
+}
+
+func TestArchivePutRead(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "archive")
+	a, err := New(dir, 0, 0)
+	require.Nil(t, err)
+
+	artifact := newTestArtifact(1)
+	require.True(t, a.Put(artifact))
+	a.Close()
+	require.False(t, a.Put(newTestArtifact(2)))
+
+	archived, err := Read(filepath.Join(dir, "1.json"))
+	require.Nil(t, err)
+	require.Equal(t, artifact.BlockID, archived.BlockID)
+	require.Equal(t, artifact.Meta, archived.Meta)
+	require.Equal(t, artifact.Header.Hash(), archived.Header.Hash())
+	require.Equal(t, artifact.PublicInputs, archived.PublicInputs)
+	require.Equal(t, artifact.ZkProof, archived.ZkProof)
+	require.Equal(t, artifact.Job, archived.Job)
+	require.Equal(t, artifact.TxHash, archived.TxHash)
+	require.Equal(t, artifact.Submitter, archived.Submitter)
+	require.Equal(t, artifact.SubmittedAt, archived.SubmittedAt)
+
+	_, err = os.Stat(filepath.Join(dir, "2.json"))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestArchiveReadIncomplete(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "1.json")
+	require.Nil(t, os.WriteFile(path, []byte(`{"blockID":1}`), 0o600))
+
+	_, err := Read(path)
+	require.ErrorContains(t, err, "incomplete proof artifact")
+
+	_, err = Read(filepath.Join(t.TempDir(), "2.json"))
+	require.ErrorContains(t, err, "failed to read proof artifact")
+}
+
+func TestArchivePrune(t *testing.T) {
+	dir := t.TempDir()
+	a, err := New(dir, 0, 0)
+	require.Nil(t, err)
+	require.Nil(t, a.write(newTestArtifact(1)))
+	size := a.totalSize
+	a.Close()
+
+	// The existing artifacts are counted, the lowest block IDs are removed once the max size is exceeded.
+	a, err = New(dir, 2*size, 0)
+	require.Nil(t, err)
+	require.Equal(t, size, a.totalSize)
+	// Two artifacts are still within the max size.
+	require.Nil(t, a.write(newTestArtifact(2)))
+	require.Zero(t, a.prune())
+	require.Nil(t, a.write(newTestArtifact(3)))
+	require.Equal(t, 1, a.prune())
+	a.Close()
+
+	files, err := os.ReadDir(dir)
+	require.Nil(t, err)
+	require.Len(t, files, 2)
+	require.Equal(t, "2.json", files[0].Name())
+	require.Equal(t, "3.json", files[1].Name())
+
+	// The artifact of the highest block ID is always kept.
+	a, err = New(dir, 1, 0)
+	require.Nil(t, err)
+	require.Equal(t, 1, a.prune())
+	a.Close()
+	_, err = os.Stat(filepath.Join(dir, "3.json"))
+	require.Nil(t, err)
+}
+
+func TestArchiveRewrite(t *testing.T) {
+	a, err := New(t.TempDir(), 0, 0)
+	require.Nil(t, err)
+	defer a.Close()
+
+	artifact := newTestArtifact(1)
+	require.Nil(t, a.write(artifact))
+	size := a.totalSize
+
+	// A resubmitted proof of the same block replaces the previous artifact.
+	artifact.ZkProof = []byte{0xff, 0xff}
+	require.Nil(t, a.write(artifact))
+	require.Len(t, a.sizes, 1)
+	require.Equal(t, size+2, a.totalSize)
+}
+
+func TestArchiveQueueFull(t *testing.T) {
+	a := &Archive{queue: make(chan *Artifact, 1), done: make(chan struct{})}
+
+	// No worker is started, the second artifact is dropped.
+	require.True(t, a.Put(newTestArtifact(1)))
+	require.False(t, a.Put(newTestArtifact(2)))
+}
+
+func TestNilArchive(t *testing.T) {
+	var a *Archive
+	require.False(t, a.Put(newTestArtifact(1)))
+	a.Close()
+}
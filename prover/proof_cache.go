@@ -0,0 +1,21 @@
+package prover
+
+import (
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// pruneProofCache removes the locally cached proofs of the verified blocks, and the expired ones.
+func (p *Prover) pruneProofCache() {
+	if p.proofDirCache == nil {
+		return
+	}
+
+	pruned, err := p.proofDirCache.Prune(p.latestVerifiedID)
+	if err != nil {
+		log.Warn("Failed to prune local proof cache", "error", err)
+		return
+	}
+	if pruned != 0 {
+		log.Debug("Pruned local proof cache", "pruned", pruned, "latestVerifiedID", p.latestVerifiedID)
+	}
+}
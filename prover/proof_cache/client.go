@@ -0,0 +1,151 @@
+package proofCache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// proofsPath is the HTTP path prefix of the proof cache API.
+	proofsPath = "/proofs/"
+	// defaultTimeout is the default timeout of each proof cache HTTP request.
+	defaultTimeout = 5 * time.Second
+)
+
+var (
+	// ErrNotFound is returned when there is no cached proof for the given prover and block hash.
+	ErrNotFound = errors.New("proof not found in cache")
+)
+
+// CachedProof represents a proof stored in the proof cache service, a proof is bound to the prover address
+// it was generated for, through the evidence's prover field.
+type CachedProof struct {
+	BlockID *big.Int       `json:"blockID"`
+	Header  *types.Header  `json:"header"`
+	Prover  common.Address `json:"prover"`
+	ZkProof hexutil.Bytes  `json:"zkProof"`
+	Degree  uint64         `json:"degree"`
+}
+
+// Client is a client of the proof cache service shared across a prover fleet, the protocol is a
+// simple authenticated HTTP API:
+//
+//	GET /proofs/{prover}/{blockHash} -> 200 with a JSON encoded CachedProof, or 404 if not found.
+//	PUT /proofs/{prover}/{blockHash} <- a JSON encoded CachedProof.
+//
+// Every request carries an `Authorization: Bearer {token}` header.
+type Client struct {
+	endpoint   string
+	token      string
+	httpClient *http.Client
+}
+
+// New creates a new proof cache client instance.
+func New(endpoint string, token string, timeout time.Duration) *Client {
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	return &Client{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Get fetches the cached proof of the given block hash generated for the given prover, returns ErrNotFound
+// if there is no such proof.
+func (c *Client) Get(ctx context.Context, prover common.Address, blockHash common.Hash) (*CachedProof, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, prover, blockHash, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get cached proof, blockHash: %s, status: %s", blockHash, res.Status)
+	}
+
+	var proof CachedProof
+	if err := json.NewDecoder(res.Body).Decode(&proof); err != nil {
+		return nil, fmt.Errorf("failed to decode cached proof: %w", err)
+	}
+
+	return &proof, nil
+}
+
+// Put publishes the given proof to the proof cache service.
+func (c *Client) Put(ctx context.Context, proof *CachedProof) error {
+	body, err := json.Marshal(proof)
+	if err != nil {
+		return err
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPut, proof.Prover, proof.Header.Hash(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusCreated {
+		resBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf(
+			"failed to put cached proof, blockHash: %s, status: %s, body: %s",
+			proof.Header.Hash(),
+			res.Status,
+			string(resBytes),
+		)
+	}
+
+	return nil
+}
+
+// newRequest creates a new authenticated HTTP request for the given prover and block hash.
+func (c *Client) newRequest(
+	ctx context.Context,
+	method string,
+	prover common.Address,
+	blockHash common.Hash,
+	body io.Reader,
+) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		method,
+		c.endpoint+proofsPath+prover.Hex()+"/"+blockHash.Hex(),
+		body,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	return req, nil
+}
@@ -0,0 +1,66 @@
+package proofCache
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPut(t *testing.T) {
+	srv := httptest.NewServer(NewMemoryServer("token"))
+	defer srv.Close()
+
+	c := New(srv.URL, "token", 0)
+	proof := &CachedProof{
+		BlockID: common.Big1,
+		Header:  &types.Header{Number: common.Big1, Difficulty: common.Big0, GasLimit: 1024},
+		Prover:  common.HexToAddress("0x02"),
+		ZkProof: []byte{0xff},
+		Degree:  19,
+	}
+
+	_, err := c.Get(context.Background(), proof.Prover, proof.Header.Hash())
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.Nil(t, c.Put(context.Background(), proof))
+
+	cached, err := c.Get(context.Background(), proof.Prover, proof.Header.Hash())
+	require.Nil(t, err)
+	require.Equal(t, proof.BlockID, cached.BlockID)
+	require.Equal(t, proof.Header.Hash(), cached.Header.Hash())
+	require.Equal(t, proof.Prover, cached.Prover)
+
+	// The proofs of the other provers are distinct.
+	_, err = c.Get(context.Background(), common.HexToAddress("0x03"), proof.Header.Hash())
+	require.ErrorIs(t, err, ErrNotFound)
+	require.Equal(t, proof.ZkProof, cached.ZkProof)
+	require.Equal(t, proof.Degree, cached.Degree)
+}
+
+func TestUnauthorized(t *testing.T) {
+	srv := httptest.NewServer(NewMemoryServer("token"))
+	defer srv.Close()
+
+	c := New(srv.URL, "wrongToken", 0)
+
+	_, err := c.Get(context.Background(), common.Address{}, common.Hash{})
+	require.NotNil(t, err)
+	require.NotErrorIs(t, err, ErrNotFound)
+
+	require.NotNil(t, c.Put(context.Background(), &CachedProof{
+		BlockID: common.Big1,
+		Header:  &types.Header{Number: common.Big1, Difficulty: common.Big0},
+	}))
+}
+
+func TestUnavailable(t *testing.T) {
+	srv := httptest.NewServer(NewMemoryServer(""))
+	srv.Close()
+
+	_, err := New(srv.URL, "", 0).Get(context.Background(), common.Address{}, common.Hash{})
+	require.NotNil(t, err)
+}
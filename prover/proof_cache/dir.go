@@ -0,0 +1,163 @@
+package proofCache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// dirCacheExt is the file extension of the directory cache entries.
+const dirCacheExt = ".json"
+
+// DirCache is a local on-disk cache of the proofs generated by current prover, so that a proof which has
+// been generated but not submitted is not generated again after a restart or a retry. Each entry is a JSON
+// encoded CachedProof, in a file named by the block ID, the prover address, the block hash and the parent
+// hash. All methods of a nil DirCache are no-ops.
+type DirCache struct {
+	dir    string
+	maxAge time.Duration // 0 means the entries are only pruned once their blocks are verified
+}
+
+// NewDirCache creates a new DirCache instance in the given directory, the directory is created if it does
+// not exist, the entries older than the given non-zero max age are treated as expired.
+func NewDirCache(dir string, maxAge time.Duration) (*DirCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create proof cache directory: %w", err)
+	}
+
+	return &DirCache{dir: dir, maxAge: maxAge}, nil
+}
+
+// Get reads the cached proof of the given block generated for the given prover, returns ErrNotFound if there
+// is no such proof. The expired and the corrupted entries are removed and treated as misses.
+func (c *DirCache) Get(blockID uint64, prover common.Address, header *types.Header) (*CachedProof, error) {
+	if c == nil {
+		return nil, ErrNotFound
+	}
+
+	path := c.path(blockID, prover, header)
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to stat cached proof: %w", err)
+	}
+	if c.expired(info, time.Now()) {
+		c.remove(path)
+		return nil, ErrNotFound
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cached proof: %w", err)
+	}
+
+	var proof CachedProof
+	if err := json.Unmarshal(data, &proof); err != nil || proof.Header == nil || proof.Header.Hash() != header.Hash() {
+		log.Warn("Corrupted cached proof, remove it", "blockID", blockID, "path", path, "error", err)
+		c.remove(path)
+		return nil, ErrNotFound
+	}
+
+	return &proof, nil
+}
+
+// Put writes the given proof to the cache atomically, replacing the previous one of the same block.
+func (c *DirCache) Put(proof *CachedProof) error {
+	if c == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(proof)
+	if err != nil {
+		return fmt.Errorf("failed to encode cached proof: %w", err)
+	}
+
+	path := c.path(proof.BlockID.Uint64(), proof.Prover, proof.Header)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write cached proof: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write cached proof: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the cached proof of the given block generated for the given prover.
+func (c *DirCache) Delete(blockID uint64, prover common.Address, header *types.Header) {
+	if c == nil {
+		return
+	}
+
+	c.remove(c.path(blockID, prover, header))
+}
+
+// Prune removes the cached proofs of the blocks which have been verified, and the expired ones, returns
+// the number of the removed entries.
+func (c *DirCache) Prune(latestVerifiedID uint64) (int, error) {
+	if c == nil {
+		return 0, nil
+	}
+
+	files, err := os.ReadDir(c.dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read proof cache directory: %w", err)
+	}
+
+	var (
+		now    = time.Now()
+		pruned int
+	)
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), dirCacheExt) {
+			continue
+		}
+
+		blockID, err := strconv.ParseUint(strings.SplitN(file.Name(), "-", 2)[0], 10, 64)
+		if err != nil {
+			// Not a cache entry.
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			continue
+		}
+		if blockID <= latestVerifiedID || c.expired(info, now) {
+			c.remove(filepath.Join(c.dir, file.Name()))
+			pruned++
+		}
+	}
+
+	return pruned, nil
+}
+
+// path returns the path of the cache entry of the given block and prover.
+func (c *DirCache) path(blockID uint64, prover common.Address, header *types.Header) string {
+	return filepath.Join(
+		c.dir,
+		fmt.Sprintf("%d-%s-%s-%s%s", blockID, prover.Hex(), header.Hash().Hex(), header.ParentHash.Hex(), dirCacheExt),
+	)
+}
+
+// expired returns whether the given cache entry is older than the max age at the given time.
+func (c *DirCache) expired(info os.FileInfo, now time.Time) bool {
+	return c.maxAge != 0 && now.Sub(info.ModTime()) > c.maxAge
+}
+
+// remove removes the given cache entry file, the failure is only logged.
+func (c *DirCache) remove(path string) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		log.Warn("Failed to remove cached proof", "path", path, "error", err)
+	}
+}
@@ -0,0 +1,129 @@
+package proofCache
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// testProver is the prover address of the test cached proofs.
+var testProver = common.HexToAddress("0x02")
+
+func newTestCachedProof(blockID int64) *CachedProof {
+	return &CachedProof{
+		BlockID: big.NewInt(blockID),
+		Header: &types.Header{
+			ParentHash: common.BigToHash(big.NewInt(blockID - 1)),
+			Number:     big.NewInt(blockID),
+			Difficulty: common.Big0,
+			GasLimit:   1024,
+		},
+		Prover:  testProver,
+		ZkProof: []byte{0xff},
+		Degree:  19,
+	}
+}
+
+func TestDirCacheGetPut(t *testing.T) {
+	c, err := NewDirCache(filepath.Join(t.TempDir(), "proofs"), 0)
+	require.Nil(t, err)
+
+	proof := newTestCachedProof(1)
+	_, err = c.Get(1, testProver, proof.Header)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.Nil(t, c.Put(proof))
+
+	cached, err := c.Get(1, testProver, proof.Header)
+	require.Nil(t, err)
+	require.Equal(t, proof.Header.Hash(), cached.Header.Hash())
+	require.Equal(t, proof.ZkProof, cached.ZkProof)
+	require.Equal(t, proof.Degree, cached.Degree)
+
+	// Keyed by the parent hash too.
+	_, err = c.Get(
+		1,
+		testProver,
+		&types.Header{ParentHash: common.HexToHash("0x01"), Number: common.Big1, Difficulty: common.Big0},
+	)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// Keyed by the prover address too.
+	_, err = c.Get(1, common.HexToAddress("0x03"), proof.Header)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	c.Delete(1, testProver, proof.Header)
+	_, err = c.Get(1, testProver, proof.Header)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestDirCacheCorrupted(t *testing.T) {
+	c, err := NewDirCache(t.TempDir(), 0)
+	require.Nil(t, err)
+
+	proof := newTestCachedProof(1)
+	require.Nil(t, c.Put(proof))
+	require.Nil(t, os.WriteFile(c.path(1, testProver, proof.Header), []byte("{"), 0o600))
+
+	_, err = c.Get(1, testProver, proof.Header)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = os.Stat(c.path(1, testProver, proof.Header))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestDirCachePrune(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDirCache(dir, time.Hour)
+	require.Nil(t, err)
+
+	for i := int64(1); i <= 4; i++ {
+		require.Nil(t, c.Put(newTestCachedProof(i)))
+	}
+	// Expire the entry of block 4.
+	expired := newTestCachedProof(4)
+	old := time.Now().Add(-2 * time.Hour)
+	require.Nil(t, os.Chtimes(c.path(4, testProver, expired.Header), old, old))
+	// Not a cache entry.
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "README"), []byte{}, 0o600))
+
+	pruned, err := c.Prune(2)
+	require.Nil(t, err)
+	require.Equal(t, 3, pruned)
+
+	_, err = c.Get(3, testProver, newTestCachedProof(3).Header)
+	require.Nil(t, err)
+	_, err = os.Stat(filepath.Join(dir, "README"))
+	require.Nil(t, err)
+}
+
+func TestDirCacheExpired(t *testing.T) {
+	c, err := NewDirCache(t.TempDir(), time.Hour)
+	require.Nil(t, err)
+
+	proof := newTestCachedProof(1)
+	require.Nil(t, c.Put(proof))
+	old := time.Now().Add(-2 * time.Hour)
+	require.Nil(t, os.Chtimes(c.path(1, testProver, proof.Header), old, old))
+
+	_, err = c.Get(1, testProver, proof.Header)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestNilDirCache(t *testing.T) {
+	var c *DirCache
+
+	proof := newTestCachedProof(1)
+	require.Nil(t, c.Put(proof))
+	_, err := c.Get(1, testProver, proof.Header)
+	require.ErrorIs(t, err, ErrNotFound)
+	c.Delete(1, testProver, proof.Header)
+	pruned, err := c.Prune(1)
+	require.Nil(t, err)
+	require.Zero(t, pruned)
+}
@@ -0,0 +1,94 @@
+package proofCache
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// proofKey is the key of a cached proof, the proofs of the same block for different provers are distinct.
+type proofKey struct {
+	prover    common.Address
+	blockHash common.Hash
+}
+
+// MemoryServer is an in-memory reference implementation of the proof cache service API.
+type MemoryServer struct {
+	token  string
+	proofs map[proofKey]*CachedProof
+	mutex  sync.RWMutex
+}
+
+// NewMemoryServer creates a new MemoryServer instance, requests must carry the given bearer token.
+func NewMemoryServer(token string) *MemoryServer {
+	return &MemoryServer{token: token, proofs: make(map[proofKey]*CachedProof)}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *MemoryServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.token != "" && r.Header.Get("Authorization") != "Bearer "+s.token {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	segments := strings.Split(strings.TrimPrefix(r.URL.Path, proofsPath), "/")
+	if !strings.HasPrefix(r.URL.Path, proofsPath) || len(segments) != 2 || !common.IsHexAddress(segments[0]) {
+		http.Error(w, "invalid prover address", http.StatusBadRequest)
+		return
+	}
+	if len(common.FromHex(segments[1])) != common.HashLength {
+		http.Error(w, "invalid block hash", http.StatusBadRequest)
+		return
+	}
+	key := proofKey{prover: common.HexToAddress(segments[0]), blockHash: common.HexToHash(segments[1])}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.mutex.RLock()
+		proof, ok := s.proofs[key]
+		s.mutex.RUnlock()
+
+		if !ok {
+			http.Error(w, ErrNotFound.Error(), http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(proof); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	case http.MethodPut:
+		var proof CachedProof
+		if err := json.NewDecoder(r.Body).Decode(&proof); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if proof.Header == nil || proof.Header.Hash() != key.blockHash {
+			http.Error(w, "block hash mismatch", http.StatusBadRequest)
+			return
+		}
+		if proof.Prover != key.prover {
+			http.Error(w, "prover address mismatch", http.StatusBadRequest)
+			return
+		}
+
+		s.mutex.Lock()
+		s.proofs[key] = &proof
+		s.mutex.Unlock()
+
+		w.WriteHeader(http.StatusCreated)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// Len returns the number of proofs in the cache.
+func (s *MemoryServer) Len() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return len(s.proofs)
+}
@@ -0,0 +1,59 @@
+package prover
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/bindings"
+	txListValidator "github.com/taikoxyz/taiko-client/pkg/tx_list_validator"
+)
+
+// proofClass is the class of a proof, the proof requests of the classes share the producer by their weights,
+// see PriorityQueue, and the valid proofs are submitted before the invalid ones, see SubmissionQueue.
+type proofClass int
+
+// Priority classes of the proof requests.
+const (
+	proofClassValid   proofClass = iota // Blocks with a valid transactions list
+	proofClassInvalid                   // Blocks with an invalid transactions list, which are cheap empty blocks
+	numProofClasses
+)
+
+// String implements the fmt.Stringer interface.
+func (c proofClass) String() string {
+	if c == proofClassInvalid {
+		return "invalid"
+	}
+
+	return "valid"
+}
+
+// classifiedProofRequest creates a new proofRequest of the given proposed block, classified by whether its
+// transactions list is valid.
+func (p *Prover) classifiedProofRequest(
+	ctx context.Context,
+	event *bindings.TaikoL1ClientBlockProposed,
+	logger log.Logger,
+) *proofRequest {
+	req := newProofRequest(event, p.cfg.ProofWindow, logger)
+
+	// The classification errors are only logged, the block is handled as a valid one then.
+	tx, err := p.rpc.L1TransactionInBlock(ctx, event.Raw.BlockHash, event.Raw.BlockNumber, event.Raw.TxIndex)
+	if err != nil {
+		logger.Warn("Failed to fetch TaikoL1.proposeBlock transaction to classify the block", "error", err)
+		return req
+	}
+
+	// Built for each block, so that the changed protocol configs take effect right away.
+	validator := p.protocolConfigs.Config().NewTxListValidator(p.rpc.L2ChainID)
+	_, hint, _, err := validator.ValidateTxList(event.Id, tx.Data())
+	if err != nil {
+		logger.Warn("Failed to validate transactions list to classify the block", "error", err)
+		return req
+	}
+	if hint != txListValidator.HintOK {
+		req.class = proofClassInvalid
+	}
+
+	return req
+}
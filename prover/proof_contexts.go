@@ -0,0 +1,39 @@
+package prover
+
+import (
+	"context"
+)
+
+// proofRequestContext derives the context of a proof request for the given block from the given context,
+// which should be the prover's lifetime context rather than a short-lived one like an event iterator's,
+// since the proof generation might outlive the caller. A previous in-flight request for the same block
+// is cancelled.
+func (p *Prover) proofRequestContext(ctx context.Context, blockID uint64) context.Context {
+	ctx, cancel := context.WithCancel(ctx)
+	if previous, ok := p.proofCancels.Load(blockID); ok {
+		previous.(context.CancelFunc)()
+	}
+	p.proofCancels.Store(blockID, cancel)
+
+	return ctx
+}
+
+// releaseProofRequest releases the context of the given block's proof request, once its proof has been
+// received or the request has failed.
+func (p *Prover) releaseProofRequest(blockID uint64) {
+	p.proofRequestEvents.Delete(blockID)
+	if cancel, ok := p.proofCancels.LoadAndDelete(blockID); ok {
+		cancel.(context.CancelFunc)()
+	}
+}
+
+// cancelProofRequestsUpTo cancels the in-flight proof requests of all the blocks with IDs less than or
+// equal to the given one, used when the blocks have been verified.
+func (p *Prover) cancelProofRequestsUpTo(blockID uint64) {
+	p.proofCancels.Range(func(key, _ interface{}) bool {
+		if key.(uint64) <= blockID {
+			p.releaseProofRequest(key.(uint64))
+		}
+		return true
+	})
+}
@@ -0,0 +1,42 @@
+package prover
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProofRequestContext(t *testing.T) {
+	p := new(Prover)
+	lifetimeCtx, stop := context.WithCancel(context.Background())
+	defer stop()
+
+	// An iterator's context being cancelled never affects the proof requests.
+	iterCtx, endIter := context.WithCancel(context.Background())
+	ctx1 := p.proofRequestContext(lifetimeCtx, 1)
+	endIter()
+	require.Nil(t, ctx1.Err())
+	require.NotNil(t, iterCtx.Err())
+
+	// Re-requesting the same block cancels the previous request.
+	ctx1Again := p.proofRequestContext(lifetimeCtx, 1)
+	require.ErrorIs(t, ctx1.Err(), context.Canceled)
+	require.Nil(t, ctx1Again.Err())
+
+	// Released once the proof is received.
+	p.releaseProofRequest(1)
+	require.ErrorIs(t, ctx1Again.Err(), context.Canceled)
+	p.releaseProofRequest(1)
+
+	// Cancelled once the blocks are verified.
+	ctx2 := p.proofRequestContext(lifetimeCtx, 2)
+	ctx3 := p.proofRequestContext(lifetimeCtx, 3)
+	p.cancelProofRequestsUpTo(2)
+	require.ErrorIs(t, ctx2.Err(), context.Canceled)
+	require.Nil(t, ctx3.Err())
+
+	// Cancelled when the prover stops.
+	stop()
+	require.ErrorIs(t, ctx3.Err(), context.Canceled)
+}
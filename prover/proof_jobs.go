@@ -0,0 +1,31 @@
+package prover
+
+import (
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// resumeProofJobs resumes the proverd proof jobs journaled in the previous runs, the resumed proofs are
+// delivered to the valid proofs channel as usual. The jobs of the verified blocks and of the endpoints which
+// are no longer configured are discarded.
+func (p *Prover) resumeProofJobs() {
+	if p.proofJournal == nil {
+		return
+	}
+
+	discarded, err := p.proofJournal.Discard(p.latestVerifiedID, p.cfg.ZKEvmRpcdEndpoints)
+	if err != nil {
+		log.Warn("Failed to discard journaled proof jobs", "error", err)
+	}
+
+	var resumed int
+	for _, producer := range p.rpcdProducers {
+		resumed += producer.ResumeJobs(p.ctx, p.proveValidProofCh)
+	}
+
+	log.Info(
+		"Resumed the proof jobs journaled in the previous runs",
+		"resumed", resumed,
+		"discarded", discarded,
+		"latestVerifiedID", p.latestVerifiedID,
+	)
+}
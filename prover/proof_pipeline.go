@@ -0,0 +1,90 @@
+package prover
+
+import (
+	"context"
+
+	"github.com/taikoxyz/taiko-client/metrics"
+)
+
+// proofRequestSlot is the concurrency slot held by a dispatched proof request. If the witness preparation
+// stage, the block's checks and fetching its header, is bounded separately by --prover.maxConcurrentWitnessJobs,
+// the request holds a witness slot until its witness is prepared, and then a proving slot for the proof
+// computation, so that the producer is kept fed with prepared requests while the slow proofs are running.
+// Otherwise, the request holds one proving slot for both stages. All methods of a nil proofRequestSlot
+// are no-ops.
+type proofRequestSlot struct {
+	p       *Prover
+	proving bool // Whether a proving slot is held, or else a witness slot
+	lost    bool // Whether no slot is held, after the context is done while waiting for the next stage
+}
+
+// acquireProofRequestSlot blocks until a slot of the first stage of a proof request is acquired, or the
+// given context is done.
+func (p *Prover) acquireProofRequestSlot(ctx context.Context) (*proofRequestSlot, error) {
+	if p.witnessConcurrencyGuard == nil {
+		if err := p.proposeConcurrencyGuard.Acquire(ctx); err != nil {
+			return nil, err
+		}
+		return &proofRequestSlot{p: p, proving: true}, nil
+	}
+
+	if err := p.witnessConcurrencyGuard.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	return &proofRequestSlot{p: p}, nil
+}
+
+// toProving moves the request to the proof computation stage once its witness is prepared. The prepared
+// request holds a prepared witness slot while waiting for a proving slot, so that its witness slot is only
+// released while the prepared requests kept in memory are within the cap.
+func (s *proofRequestSlot) toProving(ctx context.Context) error {
+	if s == nil || s.proving {
+		return nil
+	}
+
+	if err := s.p.preparedWitnessGuard.Acquire(ctx); err != nil {
+		return err
+	}
+	s.p.witnessConcurrencyGuard.Release()
+	metrics.ProverPreparedWitnessesGauge.Update(int64(s.p.preparedWitnessGuard.InUse()))
+
+	err := s.p.proposeConcurrencyGuard.Acquire(ctx)
+	s.p.preparedWitnessGuard.Release()
+	metrics.ProverPreparedWitnessesGauge.Update(int64(s.p.preparedWitnessGuard.InUse()))
+	if err != nil {
+		s.lost = true
+		return err
+	}
+	s.proving = true
+
+	return nil
+}
+
+// toWitness moves the request back to the witness preparation stage, e.g. before a retry.
+func (s *proofRequestSlot) toWitness(ctx context.Context) error {
+	if s == nil || !s.proving || s.p.witnessConcurrencyGuard == nil {
+		return nil
+	}
+
+	s.p.proposeConcurrencyGuard.Release()
+	s.proving = false
+	if err := s.p.witnessConcurrencyGuard.Acquire(ctx); err != nil {
+		s.lost = true
+		return err
+	}
+
+	return nil
+}
+
+// release releases the slot of the request's current stage.
+func (s *proofRequestSlot) release() {
+	if s == nil || s.lost {
+		return
+	}
+
+	if s.proving {
+		s.p.proposeConcurrencyGuard.Release()
+	} else {
+		s.p.witnessConcurrencyGuard.Release()
+	}
+}
@@ -0,0 +1,113 @@
+package prover
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProofRequestSlotUnsplit(t *testing.T) {
+	p := &Prover{proposeConcurrencyGuard: newResizableSemaphore(1)}
+
+	// Both stages share one proving slot.
+	slot, err := p.acquireProofRequestSlot(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, uint(1), p.proposeConcurrencyGuard.InUse())
+	require.Nil(t, slot.toProving(context.Background()))
+	require.Nil(t, slot.toWitness(context.Background()))
+	require.Equal(t, uint(1), p.proposeConcurrencyGuard.InUse())
+	slot.release()
+	require.Zero(t, p.proposeConcurrencyGuard.InUse())
+
+	var nilSlot *proofRequestSlot
+	require.NotPanics(t, func() {
+		require.Nil(t, nilSlot.toProving(context.Background()))
+		require.Nil(t, nilSlot.toWitness(context.Background()))
+		nilSlot.release()
+	})
+}
+
+func TestProofRequestSlotStages(t *testing.T) {
+	p := &Prover{
+		proposeConcurrencyGuard: newResizableSemaphore(1),
+		witnessConcurrencyGuard: newResizableSemaphore(2),
+		preparedWitnessGuard:    newResizableSemaphore(1),
+	}
+
+	// The witnesses are prepared while a proof is running.
+	proving, err := p.acquireProofRequestSlot(context.Background())
+	require.Nil(t, err)
+	require.Nil(t, proving.toProving(context.Background()))
+	require.Equal(t, uint(1), p.proposeConcurrencyGuard.InUse())
+	require.Zero(t, p.witnessConcurrencyGuard.InUse())
+
+	first, err := p.acquireProofRequestSlot(context.Background())
+	require.Nil(t, err)
+	second, err := p.acquireProofRequestSlot(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, uint(2), p.witnessConcurrencyGuard.InUse())
+
+	// The first prepared request waits for a proving slot, releasing its witness slot.
+	firstProving := make(chan struct{})
+	go func() {
+		require.Nil(t, first.toProving(context.Background()))
+		close(firstProving)
+	}()
+	require.Eventually(t, func() bool { return p.witnessConcurrencyGuard.InUse() == 1 }, time.Second, 10*time.Millisecond)
+	require.Equal(t, uint(1), p.preparedWitnessGuard.InUse())
+
+	// The second one keeps its witness slot, since the prepared requests are capped.
+	secondProving := make(chan struct{})
+	go func() {
+		require.Nil(t, second.toProving(context.Background()))
+		close(secondProving)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, uint(1), p.witnessConcurrencyGuard.InUse())
+
+	proving.release()
+	<-firstProving
+	require.Eventually(t, func() bool { return p.witnessConcurrencyGuard.InUse() == 0 }, time.Second, 10*time.Millisecond)
+
+	// A retried request goes back to the witness preparation stage.
+	require.Nil(t, first.toWitness(context.Background()))
+	<-secondProving
+	require.Equal(t, uint(1), p.witnessConcurrencyGuard.InUse())
+	require.Zero(t, p.preparedWitnessGuard.InUse())
+
+	first.release()
+	second.release()
+	require.Zero(t, p.witnessConcurrencyGuard.InUse())
+	require.Zero(t, p.proposeConcurrencyGuard.InUse())
+}
+
+func TestProofRequestSlotCancelled(t *testing.T) {
+	p := &Prover{
+		proposeConcurrencyGuard: newResizableSemaphore(1),
+		witnessConcurrencyGuard: newResizableSemaphore(1),
+		preparedWitnessGuard:    newResizableSemaphore(1),
+	}
+
+	proving, err := p.acquireProofRequestSlot(context.Background())
+	require.Nil(t, err)
+	require.Nil(t, proving.toProving(context.Background()))
+	waiting, err := p.acquireProofRequestSlot(context.Background())
+	require.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = p.acquireProofRequestSlot(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// The slot waiting for a proving slot holds nothing once cancelled.
+	require.ErrorIs(t, waiting.toProving(ctx), context.Canceled)
+	waiting.release()
+	require.Zero(t, p.witnessConcurrencyGuard.InUse())
+	require.Zero(t, p.preparedWitnessGuard.InUse())
+	require.Equal(t, uint(1), p.proposeConcurrencyGuard.InUse())
+
+	proving.release()
+	require.Zero(t, p.proposeConcurrencyGuard.InUse())
+}
@@ -0,0 +1,207 @@
+package producer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/bindings"
+	proofCache "github.com/taikoxyz/taiko-client/prover/proof_cache"
+)
+
+// CachedProofProducer wraps another ProofProducer, it looks up the local proof cache directory and then the
+// shared proof cache service before requesting a new proof, and stores each newly generated proof to both
+// caches. Any proof cache error will only be logged, and the request will fall back to the wrapped
+// ProofProducer. Both caches are optional.
+type CachedProofProducer struct {
+	producer ProofProducer
+	cache    *proofCache.Client
+	local    *proofCache.DirCache
+}
+
+// NewCachedProofProducer creates a new CachedProofProducer instance.
+func NewCachedProofProducer(
+	producer ProofProducer,
+	cache *proofCache.Client,
+	local *proofCache.DirCache,
+) *CachedProofProducer {
+	return &CachedProofProducer{producer: producer, cache: cache, local: local}
+}
+
+// RequestProof implements the ProofProducer interface.
+func (p *CachedProofProducer) RequestProof(
+	ctx context.Context,
+	opts *ProofRequestOptions,
+	blockID *big.Int,
+	meta *bindings.TaikoDataBlockMetadata,
+	header *types.Header,
+	resultCh chan *ProofWithHeader,
+) error {
+	if proof := p.fetchCachedProof(ctx, blockID, opts.ProverAddress, header); proof != nil {
+		logger := LoggerFromContext(ctx)
+		logger.Info("Proof found in cache", "blockID", blockID, "hash", header.Hash())
+
+		go func() {
+			select {
+			case <-ctx.Done():
+			case resultCh <- &ProofWithHeader{
+				BlockID: blockID,
+				Meta:    meta,
+				Header:  header,
+				ZkProof: proof.ZkProof,
+				Degree:  proof.Degree,
+				Logger:  logger,
+				Cached:  true,
+			}:
+			}
+		}()
+
+		return nil
+	}
+
+	producedCh := make(chan *ProofWithHeader, 1)
+	if err := p.producer.RequestProof(ctx, opts, blockID, meta, header, producedCh); err != nil {
+		return err
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			return
+		case proofWithHeader := <-producedCh:
+			p.storeProof(proofWithHeader, opts.ProverAddress)
+			select {
+			case <-ctx.Done():
+				return
+			case resultCh <- proofWithHeader:
+			}
+			p.publishProof(proofWithHeader, opts.ProverAddress)
+		}
+	}()
+
+	return nil
+}
+
+// fetchCachedProof fetches the proof of the given block generated for the given prover from the local proof
+// cache directory, or else from the proof cache service, and verifies the proof's header fields and prover
+// locally, returns nil if there is no usable cached proof.
+func (p *CachedProofProducer) fetchCachedProof(
+	ctx context.Context,
+	blockID *big.Int,
+	prover common.Address,
+	header *types.Header,
+) *proofCache.CachedProof {
+	if proof := p.fetchLocalProof(blockID, prover, header); proof != nil {
+		return proof
+	}
+	if p.cache == nil {
+		return nil
+	}
+
+	proof, err := p.cache.Get(ctx, prover, header.Hash())
+	if err != nil {
+		if !errors.Is(err, proofCache.ErrNotFound) {
+			log.Warn("Failed to fetch proof from cache", "blockID", blockID, "hash", header.Hash(), "error", err)
+		}
+		return nil
+	}
+
+	if err := verifyCachedProof(proof, blockID, prover, header); err != nil {
+		log.Warn("Invalid proof in cache", "blockID", blockID, "hash", header.Hash(), "error", err)
+		return nil
+	}
+	if err := p.local.Put(proof); err != nil {
+		log.Warn("Failed to store proof to local cache", "blockID", blockID, "error", err)
+	}
+
+	return proof
+}
+
+// fetchLocalProof reads the proof of the given block generated for the given prover from the local proof
+// cache directory, an invalid cached proof is removed, returns nil if there is no usable cached proof.
+func (p *CachedProofProducer) fetchLocalProof(
+	blockID *big.Int,
+	prover common.Address,
+	header *types.Header,
+) *proofCache.CachedProof {
+	proof, err := p.local.Get(blockID.Uint64(), prover, header)
+	if err != nil {
+		if !errors.Is(err, proofCache.ErrNotFound) {
+			log.Warn("Failed to read proof from local cache", "blockID", blockID, "hash", header.Hash(), "error", err)
+		}
+		return nil
+	}
+
+	if err := verifyCachedProof(proof, blockID, prover, header); err != nil {
+		log.Warn("Invalid proof in local cache, remove it", "blockID", blockID, "hash", header.Hash(), "error", err)
+		p.local.Delete(blockID.Uint64(), prover, header)
+		return nil
+	}
+
+	return proof
+}
+
+// storeProof stores the given proof generated for the given prover to the local proof cache directory.
+func (p *CachedProofProducer) storeProof(proofWithHeader *ProofWithHeader, prover common.Address) {
+	if err := p.local.Put(toCachedProof(proofWithHeader, prover)); err != nil {
+		log.Warn("Failed to store proof to local cache", "blockID", proofWithHeader.BlockID, "error", err)
+	}
+}
+
+// publishProof publishes the given proof generated for the given prover to the proof cache service. The
+// request's context is not used, since the request is usually released once its proof is received, the
+// publication is still bounded by the proof cache client's timeout.
+func (p *CachedProofProducer) publishProof(proofWithHeader *ProofWithHeader, prover common.Address) {
+	if p.cache == nil {
+		return
+	}
+
+	if err := p.cache.Put(context.Background(), toCachedProof(proofWithHeader, prover)); err != nil {
+		log.Warn("Failed to publish proof to cache", "blockID", proofWithHeader.BlockID, "error", err)
+		return
+	}
+
+	log.Debug("Proof published to cache", "blockID", proofWithHeader.BlockID, "hash", proofWithHeader.Header.Hash())
+}
+
+// toCachedProof converts the given proof generated for the given prover to a cached proof.
+func toCachedProof(proofWithHeader *ProofWithHeader, prover common.Address) *proofCache.CachedProof {
+	return &proofCache.CachedProof{
+		BlockID: proofWithHeader.BlockID,
+		Header:  proofWithHeader.Header,
+		Prover:  prover,
+		ZkProof: proofWithHeader.ZkProof,
+		Degree:  proofWithHeader.Degree,
+	}
+}
+
+// verifyCachedProof checks whether the given cached proof matches the local block header, and was generated
+// for the given prover, since a proof of another prover will be rejected by TaikoL1.
+func verifyCachedProof(
+	proof *proofCache.CachedProof,
+	blockID *big.Int,
+	prover common.Address,
+	header *types.Header,
+) error {
+	if proof.BlockID == nil || proof.BlockID.Cmp(blockID) != 0 {
+		return fmt.Errorf("block ID mismatch: %v != %d", proof.BlockID, blockID)
+	}
+	if proof.Header == nil || proof.Header.Hash() != header.Hash() {
+		return errors.New("block header mismatch")
+	}
+	if proof.Prover != prover {
+		return fmt.Errorf("prover mismatch: %s != %s", proof.Prover, prover)
+	}
+	if len(proof.ZkProof) == 0 {
+		return errors.New("empty proof")
+	}
+	if _, err := DegreeToCircuitsIdx(proof.Degree); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package producer
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+	proofCache "github.com/taikoxyz/taiko-client/prover/proof_cache"
+)
+
+// testProver is the prover address of the test proof requests.
+var testProver = common.HexToAddress("0x02")
+
+func TestCachedProofProducerRequestProof(t *testing.T) {
+	cacheServer := proofCache.NewMemoryServer("token")
+	srv := httptest.NewServer(cacheServer)
+	defer srv.Close()
+
+	producer := NewCachedProofProducer(NewZeroDelayDummyProofProducer(), proofCache.New(srv.URL, "token", 0), nil)
+
+	header := &types.Header{
+		ParentHash: randHash(),
+		Root:       randHash(),
+		Difficulty: common.Big0,
+		Number:     common.Big256,
+		GasLimit:   1024,
+		Time:       uint64(time.Now().Unix()),
+	}
+
+	// Cache miss, the proof should be produced by the wrapped producer, and then published.
+	opts := &ProofRequestOptions{ProverAddress: testProver}
+	resCh := make(chan *ProofWithHeader, 1)
+	require.Nil(t, producer.RequestProof(
+		context.Background(), opts, common.Big32, &bindings.TaikoDataBlockMetadata{}, header, resCh,
+	))
+	res := <-resCh
+	require.Equal(t, common.Big32, res.BlockID)
+	require.Equal(t, header, res.Header)
+	require.False(t, res.Cached)
+	require.Eventually(t, func() bool { return cacheServer.Len() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	// Cache hit.
+	producer.producer = nil
+	require.Nil(t, producer.RequestProof(
+		context.Background(), opts, common.Big32, &bindings.TaikoDataBlockMetadata{}, header, resCh,
+	))
+	cached := <-resCh
+	require.Equal(t, res.ZkProof, cached.ZkProof)
+	require.Equal(t, res.Degree, cached.Degree)
+	require.True(t, cached.Cached)
+
+	// The proof published by another prover is a miss.
+	require.Nil(t, producer.fetchCachedProof(context.Background(), common.Big32, common.HexToAddress("0x03"), header))
+}
+
+func TestCachedProofProducerPublishAfterRelease(t *testing.T) {
+	// The publication is only served after the request is released, unless it has been aborted meanwhile.
+	var (
+		cacheServer = proofCache.NewMemoryServer("")
+		released    = make(chan struct{})
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			// The aborted request is only noticed after its body is read.
+			body, err := io.ReadAll(r.Body)
+			require.Nil(t, err)
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			<-released
+			time.Sleep(100 * time.Millisecond)
+			if r.Context().Err() != nil {
+				return
+			}
+		}
+		cacheServer.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	producer := NewCachedProofProducer(NewZeroDelayDummyProofProducer(), proofCache.New(srv.URL, "", 0), nil)
+
+	// The request is released right after its proof is received, the proof should still be published.
+	ctx, cancel := context.WithCancel(context.Background())
+	resCh := make(chan *ProofWithHeader)
+	require.Nil(t, producer.RequestProof(
+		ctx,
+		&ProofRequestOptions{ProverAddress: testProver},
+		common.Big32,
+		&bindings.TaikoDataBlockMetadata{},
+		&types.Header{Difficulty: common.Big0, Number: common.Big256},
+		resCh,
+	))
+	require.NotEmpty(t, (<-resCh).ZkProof)
+	cancel()
+	close(released)
+
+	require.Eventually(t, func() bool { return cacheServer.Len() == 1 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestCachedProofProducerCacheUnavailable(t *testing.T) {
+	srv := httptest.NewServer(proofCache.NewMemoryServer(""))
+	srv.Close()
+
+	producer := NewCachedProofProducer(NewZeroDelayDummyProofProducer(), proofCache.New(srv.URL, "", time.Second), nil)
+
+	resCh := make(chan *ProofWithHeader, 1)
+	require.Nil(t, producer.RequestProof(
+		context.Background(),
+		&ProofRequestOptions{ProverAddress: testProver},
+		common.Big32,
+		&bindings.TaikoDataBlockMetadata{},
+		&types.Header{Difficulty: common.Big0, Number: common.Big256},
+		resCh,
+	))
+	require.NotEmpty(t, (<-resCh).ZkProof)
+}
+
+func TestCachedProofProducerLocalCache(t *testing.T) {
+	local, err := proofCache.NewDirCache(t.TempDir(), 0)
+	require.Nil(t, err)
+
+	producer := NewCachedProofProducer(NewZeroDelayDummyProofProducer(), nil, local)
+	header := &types.Header{ParentHash: randHash(), Difficulty: common.Big0, Number: common.Big256}
+
+	// Cache miss, the produced proof should be stored locally before it is delivered.
+	opts := &ProofRequestOptions{ProverAddress: testProver}
+	resCh := make(chan *ProofWithHeader, 1)
+	require.Nil(t, producer.RequestProof(
+		context.Background(), opts, common.Big32, &bindings.TaikoDataBlockMetadata{}, header, resCh,
+	))
+	res := <-resCh
+	_, err = local.Get(32, testProver, header)
+	require.Nil(t, err)
+
+	// Cache hit.
+	producer.producer = nil
+	require.Nil(t, producer.RequestProof(
+		context.Background(), opts, common.Big32, &bindings.TaikoDataBlockMetadata{}, header, resCh,
+	))
+	cached := <-resCh
+	require.Equal(t, res.ZkProof, cached.ZkProof)
+	require.Equal(t, res.Degree, cached.Degree)
+
+	// An invalid cached proof is a miss, and is removed.
+	require.Nil(t, local.Put(&proofCache.CachedProof{
+		BlockID: common.Big32,
+		Header:  header,
+		Prover:  testProver,
+		Degree:  res.Degree,
+	}))
+	require.Nil(t, producer.fetchCachedProof(context.Background(), common.Big32, testProver, header))
+	_, err = local.Get(32, testProver, header)
+	require.ErrorIs(t, err, proofCache.ErrNotFound)
+}
+
+func TestVerifyCachedProof(t *testing.T) {
+	header := &types.Header{Difficulty: common.Big0, Number: common.Big256}
+	proof := &proofCache.CachedProof{
+		BlockID: common.Big32,
+		Header:  header,
+		Prover:  testProver,
+		ZkProof: []byte{0xff},
+		Degree:  CircuitsDegree10Txs,
+	}
+
+	require.Nil(t, verifyCachedProof(proof, common.Big32, testProver, header))
+	require.NotNil(t, verifyCachedProof(proof, common.Big1, testProver, header))
+	require.NotNil(t, verifyCachedProof(
+		proof,
+		common.Big32,
+		testProver,
+		&types.Header{Difficulty: common.Big0, Number: common.Big1},
+	))
+	// A proof of another prover will be rejected by TaikoL1.
+	require.ErrorContains(
+		t,
+		verifyCachedProof(proof, common.Big32, common.HexToAddress("0x03"), header),
+		"prover mismatch",
+	)
+	require.NotNil(t, verifyCachedProof(
+		&proofCache.CachedProof{BlockID: common.Big32, Header: header, Prover: testProver, Degree: CircuitsDegree10Txs},
+		common.Big32,
+		testProver,
+		header,
+	))
+	require.NotNil(t, verifyCachedProof(
+		&proofCache.CachedProof{BlockID: common.Big32, Header: header, Prover: testProver, ZkProof: []byte{0xff}},
+		common.Big32,
+		testProver,
+		header,
+	))
+}
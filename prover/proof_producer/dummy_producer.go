@@ -2,19 +2,70 @@ package producer
 
 import (
 	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
 	"math/big"
 	"math/rand"
+	"os"
+	"strconv"
 	"time"
 
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/taikoxyz/taiko-client/bindings"
 )
 
-// DummyProofProducer always returns a dummy proof.
+// DummyProofProducer always returns a dummy proof. The delay of each block's proof is, in the order of
+// precedence: its delay in BlockDelays, a hash of DelaySeed and its ID between the bounds, its ID modulo
+// the bounds range if DeterministicDelay is set, or a random delay between the bounds.
 type DummyProofProducer struct {
 	RandomDummyProofDelayLowerBound *time.Duration
 	RandomDummyProofDelayUpperBound *time.Duration
+	// If set, the delay between the bounds is derived from the block ID instead, so that the proofs
+	// order is reproducible.
+	DeterministicDelay bool
+	// If set, the delay between the bounds is derived from the block ID and the seed, so that the proofs
+	// order is reproducible, while differing between the provers with different seeds.
+	DelaySeed *uint64
+	// Explicit delays of the scripted blocks, keyed by block ID.
+	BlockDelays map[uint64]time.Duration
+}
+
+// LoadDummyProofDelays loads the explicit per-block dummy proof delays from the given JSON file, which
+// maps the block IDs to the delays, e.g. `{"1": "30s", "2": "1m"}`.
+func LoadDummyProofDelays(path string) (map[uint64]time.Duration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dummy proof delays file: %w", err)
+	}
+
+	var values map[string]string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to decode dummy proof delays file %s: %w", path, err)
+	}
+
+	delays := make(map[uint64]time.Duration, len(values))
+	for key, value := range values {
+		blockID, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid block ID in dummy proof delays file: %s", key)
+		}
+		delay, err := time.ParseDuration(value)
+		if err != nil || delay < 0 {
+			return nil, fmt.Errorf("invalid dummy proof delay of block %d: %s", blockID, value)
+		}
+		delays[blockID] = delay
+	}
+
+	return delays, nil
+}
+
+// NewZeroDelayDummyProofProducer creates a new DummyProofProducer instance which returns the dummy
+// proofs right away, used by the unit tests.
+func NewZeroDelayDummyProofProducer() *DummyProofProducer {
+	return &DummyProofProducer{}
 }
 
 // RequestProof implements the ProofProducer interface.
@@ -26,7 +77,8 @@ func (d *DummyProofProducer) RequestProof(
 	header *types.Header,
 	resultCh chan *ProofWithHeader,
 ) error {
-	log.Info(
+	logger := LoggerFromContext(ctx)
+	logger.Info(
 		"Request dummy proof",
 		"blockID", blockID,
 		"beneficiary", meta.Beneficiary,
@@ -34,17 +86,35 @@ func (d *DummyProofProducer) RequestProof(
 		"hash", header.Hash(),
 	)
 
-	time.AfterFunc(d.proofDelay(), func() {
-		resultCh <- &ProofWithHeader{
-			BlockID: blockID, Meta: meta, Header: header, ZkProof: []byte{0xff}, Degree: CircuitsDegree10Txs,
+	// The proof is dropped if the request is cancelled during the delay, or before it is received.
+	delay := time.NewTimer(d.proofDelay(blockID))
+	go func() {
+		defer delay.Stop()
+
+		select {
+		case <-ctx.Done():
+			logger.Info("Dummy proof request cancelled", "blockID", blockID)
+			return
+		case <-delay.C:
+		}
+
+		select {
+		case <-ctx.Done():
+		case resultCh <- &ProofWithHeader{
+			BlockID: blockID, Meta: meta, Header: header, ZkProof: []byte{0xff}, Degree: CircuitsDegree10Txs, Logger: logger,
+		}:
 		}
-	})
+	}()
 
 	return nil
 }
 
-// proofDelay calculates a random proof delay between the bounds.
-func (d *DummyProofProducer) proofDelay() time.Duration {
+// proofDelay calculates the proof delay of the given block, see DummyProofProducer for the precedence.
+func (d *DummyProofProducer) proofDelay(blockID *big.Int) time.Duration {
+	if delay, ok := d.BlockDelays[blockID.Uint64()]; ok {
+		return delay
+	}
+
 	if d.RandomDummyProofDelayLowerBound == nil ||
 		d.RandomDummyProofDelayUpperBound == nil ||
 		*d.RandomDummyProofDelayUpperBound == time.Duration(0) {
@@ -53,6 +123,21 @@ func (d *DummyProofProducer) proofDelay() time.Duration {
 
 	lowerSeconds := int(d.RandomDummyProofDelayLowerBound.Seconds())
 	upperSeconds := int(d.RandomDummyProofDelayUpperBound.Seconds())
+	if upperSeconds <= lowerSeconds {
+		return time.Duration(lowerSeconds) * time.Second
+	}
+
+	if d.DelaySeed != nil {
+		var key [8]byte
+		binary.BigEndian.PutUint64(key[:], *d.DelaySeed^blockID.Uint64())
+		hash := binary.BigEndian.Uint64(crypto.Keccak256(key[:])[:8])
+		return time.Duration(hash%uint64(upperSeconds-lowerSeconds)+uint64(lowerSeconds)) * time.Second
+	}
+
+	if d.DeterministicDelay {
+		delaySeconds := blockID.Uint64()%uint64(upperSeconds-lowerSeconds) + uint64(lowerSeconds)
+		return time.Duration(delaySeconds) * time.Second
+	}
 
 	randomDurationSeconds := rand.Intn((upperSeconds - lowerSeconds)) + lowerSeconds
 	delay := time.Duration(randomDurationSeconds) * time.Second
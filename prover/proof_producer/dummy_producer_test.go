@@ -3,6 +3,9 @@ package producer
 import (
 	"context"
 	"crypto/rand"
+	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -14,7 +17,7 @@ import (
 )
 
 func TestRequestProof(t *testing.T) {
-	dummyProofProducer := &DummyProofProducer{}
+	dummyProofProducer := NewZeroDelayDummyProofProducer()
 
 	resCh := make(chan *ProofWithHeader, 1)
 
@@ -50,9 +53,38 @@ func TestRequestProof(t *testing.T) {
 	require.NotEmpty(t, res.ZkProof)
 }
 
+func TestRequestProofCancelled(t *testing.T) {
+	var (
+		oneSecond = 1 * time.Second
+		oneHour   = time.Hour
+	)
+	dummyProofProducer := &DummyProofProducer{
+		RandomDummyProofDelayLowerBound: &oneSecond,
+		RandomDummyProofDelayUpperBound: &oneHour,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	resCh := make(chan *ProofWithHeader, 1)
+	require.Nil(t, dummyProofProducer.RequestProof(
+		ctx,
+		&ProofRequestOptions{},
+		common.Big1,
+		&bindings.TaikoDataBlockMetadata{},
+		&types.Header{Number: common.Big1},
+		resCh,
+	))
+	cancel()
+
+	select {
+	case <-resCh:
+		t.Fatal("cancelled dummy proof request should not return a proof")
+	case <-time.After(1500 * time.Millisecond):
+	}
+}
+
 func TestProofDelay(t *testing.T) {
-	dummyProofProducer := &DummyProofProducer{}
-	require.Equal(t, time.Duration(0), dummyProofProducer.proofDelay())
+	dummyProofProducer := NewZeroDelayDummyProofProducer()
+	require.Equal(t, time.Duration(0), dummyProofProducer.proofDelay(common.Big1))
 
 	var (
 		delays    []time.Duration
@@ -65,7 +97,7 @@ func TestProofDelay(t *testing.T) {
 			RandomDummyProofDelayUpperBound: &oneDay,
 		}
 
-		delay := dummyProofProducer.proofDelay()
+		delay := dummyProofProducer.proofDelay(common.Big1)
 
 		require.LessOrEqual(t, delay, oneDay)
 		require.Greater(t, delay, oneSecond)
@@ -85,6 +117,86 @@ func TestProofDelay(t *testing.T) {
 	require.False(t, allSame(delays))
 }
 
+func TestDeterministicProofDelay(t *testing.T) {
+	var (
+		tenSeconds    = 10 * time.Second
+		twentySeconds = 20 * time.Second
+	)
+	dummyProofProducer := &DummyProofProducer{
+		RandomDummyProofDelayLowerBound: &tenSeconds,
+		RandomDummyProofDelayUpperBound: &twentySeconds,
+		DeterministicDelay:              true,
+	}
+
+	require.Equal(t, 10*time.Second, dummyProofProducer.proofDelay(common.Big0))
+	require.Equal(t, 13*time.Second, dummyProofProducer.proofDelay(big.NewInt(3)))
+	require.Equal(t, 12*time.Second, dummyProofProducer.proofDelay(big.NewInt(32)))
+	require.Equal(t, dummyProofProducer.proofDelay(big.NewInt(32)), dummyProofProducer.proofDelay(big.NewInt(32)))
+
+	// Equal bounds.
+	dummyProofProducer.RandomDummyProofDelayUpperBound = &tenSeconds
+	require.Equal(t, 10*time.Second, dummyProofProducer.proofDelay(big.NewInt(3)))
+}
+
+func TestSeededProofDelay(t *testing.T) {
+	var (
+		oneSecond = time.Second
+		oneHour   = time.Hour
+		seed      = uint64(42)
+		other     = uint64(43)
+	)
+	dummyProofProducer := &DummyProofProducer{
+		RandomDummyProofDelayLowerBound: &oneSecond,
+		RandomDummyProofDelayUpperBound: &oneHour,
+		DeterministicDelay:              true,
+		DelaySeed:                       &seed,
+	}
+	otherProducer := &DummyProofProducer{
+		RandomDummyProofDelayLowerBound: &oneSecond,
+		RandomDummyProofDelayUpperBound: &oneHour,
+		DelaySeed:                       &other,
+	}
+
+	var differs bool
+	for i := int64(0); i < 64; i++ {
+		delay := dummyProofProducer.proofDelay(big.NewInt(i))
+		require.GreaterOrEqual(t, delay, oneSecond)
+		require.Less(t, delay, oneHour)
+		require.Equal(t, delay, dummyProofProducer.proofDelay(big.NewInt(i)))
+		differs = differs || delay != otherProducer.proofDelay(big.NewInt(i))
+	}
+	require.True(t, differs)
+
+	// The explicit delays take precedence, the other blocks still use the seeded delays.
+	seeded := dummyProofProducer.proofDelay(big.NewInt(4))
+	dummyProofProducer.BlockDelays = map[uint64]time.Duration{3: 5 * time.Millisecond}
+	require.Equal(t, 5*time.Millisecond, dummyProofProducer.proofDelay(big.NewInt(3)))
+	require.Equal(t, seeded, dummyProofProducer.proofDelay(big.NewInt(4)))
+
+	// Without the bounds, only the explicit delays are used.
+	scripted := &DummyProofProducer{BlockDelays: map[uint64]time.Duration{1: time.Minute}, DelaySeed: &seed}
+	require.Equal(t, time.Minute, scripted.proofDelay(common.Big1))
+	require.Equal(t, time.Duration(0), scripted.proofDelay(common.Big2))
+}
+
+func TestLoadDummyProofDelays(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "delays.json")
+	require.Nil(t, os.WriteFile(path, []byte(`{"1": "30s", "2": "1m"}`), 0o600))
+
+	delays, err := LoadDummyProofDelays(path)
+	require.Nil(t, err)
+	require.Equal(t, map[uint64]time.Duration{1: 30 * time.Second, 2: time.Minute}, delays)
+
+	for _, invalid := range []string{`{"a": "30s"}`, `{"1": "soon"}`, `{"1": "-1s"}`, `[]`} {
+		require.Nil(t, os.WriteFile(path, []byte(invalid), 0o600))
+		_, err := LoadDummyProofDelays(path)
+		require.NotNil(t, err, invalid)
+	}
+
+	_, err = LoadDummyProofDelays(filepath.Join(t.TempDir(), "missing.json"))
+	require.ErrorContains(t, err, "failed to read dummy proof delays file")
+}
+
 func randHash() common.Hash {
 	b := make([]byte, 32)
 	if _, err := rand.Read(b); err != nil {
@@ -0,0 +1,152 @@
+package producer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/metrics"
+)
+
+// defaultProbeInterval is the default interval before a failed backend is tried again.
+var defaultProbeInterval = time.Minute
+
+// FallbackBackend is a named proof producer backend of a FallbackProducer.
+type FallbackBackend struct {
+	Name     string
+	Producer ProofProducer
+}
+
+// FallbackProducer wraps an ordered list of proof producers, the proofs are requested from the first
+// healthy backend, and the next one is tried when a request fails with a retriable error. A failed
+// backend is skipped until the probe interval elapses, then it is tried again at its original priority,
+// so that the priority order is restored once it recovers.
+type FallbackProducer struct {
+	backends      []*FallbackBackend
+	probeInterval time.Duration
+
+	mutex    sync.Mutex
+	failedAt []time.Time // Zero if the backend is healthy
+}
+
+// NewFallbackProducer creates a new FallbackProducer instance, a zero probe interval means the default one.
+func NewFallbackProducer(backends []*FallbackBackend, probeInterval time.Duration) (*FallbackProducer, error) {
+	if len(backends) == 0 {
+		return nil, errors.New("no proof producer backends")
+	}
+	if probeInterval == 0 {
+		probeInterval = defaultProbeInterval
+	}
+
+	return &FallbackProducer{
+		backends:      backends,
+		probeInterval: probeInterval,
+		failedAt:      make([]time.Time, len(backends)),
+	}, nil
+}
+
+// RequestProof implements the ProofProducer interface.
+func (p *FallbackProducer) RequestProof(
+	ctx context.Context,
+	opts *ProofRequestOptions,
+	blockID *big.Int,
+	meta *bindings.TaikoDataBlockMetadata,
+	header *types.Header,
+	resultCh chan *ProofWithHeader,
+) error {
+	logger := LoggerFromContext(ctx)
+
+	var lastErr error
+	for _, i := range p.candidates() {
+		backend := p.backends[i]
+
+		producedCh := make(chan *ProofWithHeader, 1)
+		err := backend.Producer.RequestProof(ctx, opts, blockID, meta, header, producedCh)
+		if err == nil {
+			p.markHealthy(i)
+			go p.forward(ctx, backend.Name, producedCh, resultCh)
+			return nil
+		}
+
+		if ctx.Err() != nil || errors.Is(err, ErrInvalidProofRequest) {
+			return err
+		}
+
+		lastErr = fmt.Errorf("%s: %w", backend.Name, err)
+		metrics.ProverProofProducerFallbackCounter.Inc(1)
+
+		// A backend with a full job queue is still healthy, it is just busy.
+		if errors.Is(err, ErrProducerQueueFull) {
+			logger.Info("Proof producer backend busy", "blockID", blockID, "backend", backend.Name, "error", err)
+			continue
+		}
+
+		logger.Warn("Proof producer backend failed", "blockID", blockID, "backend", backend.Name, "error", err)
+		p.markFailed(i)
+	}
+
+	return fmt.Errorf("all proof producer backends failed, last error: %w", lastErr)
+}
+
+// forward forwards the proof generated by the given backend to the result channel, with its origin recorded.
+func (p *FallbackProducer) forward(
+	ctx context.Context,
+	origin string,
+	producedCh chan *ProofWithHeader,
+	resultCh chan *ProofWithHeader,
+) {
+	select {
+	case <-ctx.Done():
+	case proofWithHeader := <-producedCh:
+		proofWithHeader.Origin = origin
+		proofWithHeader.Logger = proofWithHeader.Log().New("origin", origin)
+		select {
+		case <-ctx.Done():
+		case resultCh <- proofWithHeader:
+		}
+	}
+}
+
+// candidates returns the indexes of the backends to try in order, the healthy ones and the ones due for
+// a probe come first in priority order, followed by the other failed ones, so that a request still gets
+// a chance when all the backends have failed recently.
+func (p *FallbackProducer) candidates() []int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var (
+		available []int
+		failed    []int
+		now       = time.Now()
+	)
+	for i, failedAt := range p.failedAt {
+		if failedAt.IsZero() || now.Sub(failedAt) >= p.probeInterval {
+			available = append(available, i)
+		} else {
+			failed = append(failed, i)
+		}
+	}
+
+	return append(available, failed...)
+}
+
+// markFailed marks the given backend as failed.
+func (p *FallbackProducer) markFailed(i int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.failedAt[i] = time.Now()
+}
+
+// markHealthy marks the given backend as healthy.
+func (p *FallbackProducer) markHealthy(i int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.failedAt[i] = time.Time{}
+}
@@ -0,0 +1,119 @@
+package producer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+func TestFallbackProducerRequestProof(t *testing.T) {
+	var primaryDown = true
+	primary := &ZkevmRpcdProducer{
+		CustomProofHook: func() ([]byte, uint64, error) {
+			if primaryDown {
+				return nil, 0, errors.New("connection refused")
+			}
+			return []byte{0x01}, CircuitsDegree80Txs, nil
+		},
+	}
+
+	producer, err := NewFallbackProducer([]*FallbackBackend{
+		{Name: "primary", Producer: primary},
+		{Name: "secondary", Producer: NewZeroDelayDummyProofProducer()},
+	}, time.Hour)
+	require.Nil(t, err)
+
+	requestProof := func() *ProofWithHeader {
+		resCh := make(chan *ProofWithHeader, 1)
+		require.Nil(t, producer.RequestProof(
+			context.Background(),
+			&ProofRequestOptions{},
+			common.Big32,
+			&bindings.TaikoDataBlockMetadata{},
+			&types.Header{Number: common.Big32, Difficulty: common.Big0},
+			resCh,
+		))
+		return <-resCh
+	}
+
+	// The primary backend is down, falls back to the secondary one.
+	require.Equal(t, "secondary", requestProof().Origin)
+
+	// The failed primary backend is skipped until the probe interval elapses.
+	primaryDown = false
+	require.Equal(t, "secondary", requestProof().Origin)
+
+	producer.failedAt[0] = time.Now().Add(-time.Hour)
+	res := requestProof()
+	require.Equal(t, "primary", res.Origin)
+	require.Equal(t, []byte{0x01}, res.ZkProof)
+	require.True(t, producer.failedAt[0].IsZero())
+}
+
+func TestFallbackProducerAllFailed(t *testing.T) {
+	failing := func(err error) *ZkevmRpcdProducer {
+		return &ZkevmRpcdProducer{CustomProofHook: func() ([]byte, uint64, error) { return nil, 0, err }}
+	}
+
+	producer, err := NewFallbackProducer([]*FallbackBackend{
+		{Name: "primary", Producer: failing(errors.New("primary down"))},
+		{Name: "secondary", Producer: failing(errors.New("secondary down"))},
+	}, 0)
+	require.Nil(t, err)
+	require.Equal(t, defaultProbeInterval, producer.probeInterval)
+
+	request := func() error {
+		return producer.RequestProof(
+			context.Background(),
+			&ProofRequestOptions{},
+			common.Big32,
+			&bindings.TaikoDataBlockMetadata{},
+			&types.Header{Number: common.Big32, Difficulty: common.Big0},
+			make(chan *ProofWithHeader, 1),
+		)
+	}
+	require.ErrorContains(t, request(), "secondary: secondary down")
+
+	// The recently failed backends are still tried in priority order.
+	require.Equal(t, []int{0, 1}, producer.candidates())
+	require.ErrorContains(t, request(), "secondary: secondary down")
+
+	// An invalid proof request is not retried with the other backends.
+	producer.backends[0].Producer = failing(ErrInvalidProofRequest)
+	require.ErrorIs(t, request(), ErrInvalidProofRequest)
+
+	_, err = NewFallbackProducer(nil, 0)
+	require.NotNil(t, err)
+}
+
+func TestFallbackProducerBusyBackend(t *testing.T) {
+	producer, err := NewFallbackProducer([]*FallbackBackend{
+		{Name: "primary", Producer: &ZkevmRpcdProducer{CustomProofHook: func() ([]byte, uint64, error) {
+			return nil, 0, ErrProducerQueueFull
+		}}},
+		{Name: "secondary", Producer: &ZkevmRpcdProducer{CustomProofHook: func() ([]byte, uint64, error) {
+			return []byte{0xff}, CircuitsDegree10Txs, nil
+		}}},
+	}, 0)
+	require.Nil(t, err)
+
+	resultCh := make(chan *ProofWithHeader, 1)
+	require.Nil(t, producer.RequestProof(
+		context.Background(),
+		&ProofRequestOptions{},
+		common.Big32,
+		&bindings.TaikoDataBlockMetadata{},
+		&types.Header{Number: common.Big32, Difficulty: common.Big0},
+		resultCh,
+	))
+	require.Equal(t, "secondary", (<-resultCh).Origin)
+
+	// The busy backend is not marked as failed.
+	require.True(t, producer.failedAt[0].IsZero())
+}
@@ -0,0 +1,56 @@
+package producer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// GrpcConnOptions are the options of the connection to a gRPC proof producer service.
+type GrpcConnOptions struct {
+	TLS       bool   // if set, the connection is secured by TLS
+	CACert    string // PEM file of the CA certificates to verify the service with, the system ones if empty
+	AuthToken string // if set, sent as a bearer token with each request
+}
+
+// DialOptions returns the gRPC dial options of the connection.
+func (o *GrpcConnOptions) DialOptions() ([]grpc.DialOption, error) {
+	creds := insecure.NewCredentials()
+	if o.TLS {
+		if len(o.CACert) == 0 {
+			creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+		} else {
+			var err error
+			if creds, err = credentials.NewClientTLSFromFile(o.CACert, ""); err != nil {
+				return nil, fmt.Errorf("failed to load gRPC proof producer CA certificates: %w", err)
+			}
+		}
+	}
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if len(o.AuthToken) != 0 {
+		opts = append(opts, grpc.WithPerRPCCredentials(&bearerToken{token: o.AuthToken, secure: o.TLS}))
+	}
+
+	return opts, nil
+}
+
+// bearerToken sends a bearer token in the metadata of each gRPC request.
+type bearerToken struct {
+	token  string
+	secure bool
+}
+
+// GetRequestMetadata implements the credentials.PerRPCCredentials interface.
+func (b *bearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + b.token}, nil
+}
+
+// RequireTransportSecurity implements the credentials.PerRPCCredentials interface.
+func (b *bearerToken) RequireTransportSecurity() bool {
+	return b.secure
+}
@@ -0,0 +1,58 @@
+package producer
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	pb "github.com/taikoxyz/taiko-client/prover/proof_producer/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestGrpcConnOptionsAuthToken(t *testing.T) {
+	var authorization []string
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(grpc.StreamInterceptor(func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		md, _ := metadata.FromIncomingContext(ss.Context())
+		authorization = md.Get("authorization")
+		return handler(srv, ss)
+	}))
+	pb.RegisterProofProducerServer(server, &failingProofProducerServer{})
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	opts, err := (&GrpcConnOptions{AuthToken: "secret"}).DialOptions()
+	require.Nil(t, err)
+
+	producer, err := NewGrpcProofProducer(
+		"bufnet",
+		"",
+		"",
+		time.Second,
+		append(opts, grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return listener.Dial()
+		}))...,
+	)
+	require.Nil(t, err)
+
+	require.Nil(t, requestTestGrpcProof(producer))
+	require.Equal(t, []string{"Bearer secret"}, authorization)
+}
+
+func TestGrpcConnOptionsInvalidCACert(t *testing.T) {
+	_, err := (&GrpcConnOptions{TLS: true, CACert: "/nonexistent.pem"}).DialOptions()
+	require.ErrorContains(t, err, "failed to load gRPC proof producer CA certificates")
+
+	opts, err := (&GrpcConnOptions{TLS: true}).DialOptions()
+	require.Nil(t, err)
+	require.Len(t, opts, 1)
+}
@@ -0,0 +1,41 @@
+package producer
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcStatusError maps the status of the given error returned by a gRPC proof producer service onto the errors
+// of the ProofProducer interface: the statuses which won't change by retrying the same request are wrapped
+// with ErrInvalidProofRequest, and ResourceExhausted with ErrProducerQueueFull. Any other error is returned
+// as is, and is retriable, e.g. Unavailable or DeadlineExceeded.
+func grpcStatusError(err error) error {
+	s, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	switch s.Code() {
+	case codes.InvalidArgument,
+		codes.NotFound,
+		codes.FailedPrecondition,
+		codes.OutOfRange,
+		codes.Unimplemented,
+		codes.Unauthenticated,
+		codes.PermissionDenied:
+		return fmt.Errorf("%w, code: %s, message: %s", ErrInvalidProofRequest, s.Code(), s.Message())
+	case codes.ResourceExhausted:
+		return fmt.Errorf("%w, message: %s", ErrProducerQueueFull, s.Message())
+	default:
+		return err
+	}
+}
+
+// isPermanentGrpcError checks whether the given mapped error shouldn't be retried by the gRPC producer itself,
+// either retrying won't help, or the prover should back off before requesting again.
+func isPermanentGrpcError(err error) bool {
+	return errors.Is(err, ErrInvalidProofRequest) || errors.Is(err, ErrProducerQueueFull)
+}
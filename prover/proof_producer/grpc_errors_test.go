@@ -0,0 +1,105 @@
+package producer
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+	pb "github.com/taikoxyz/taiko-client/prover/proof_producer/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// failingProofProducerServer fails the given number of proof requests with the given status code, and then
+// returns the proof.
+type failingProofProducerServer struct {
+	pb.UnimplementedProofProducerServer
+	code     codes.Code
+	failures int32
+	requests int32
+}
+
+func (s *failingProofProducerServer) RequestProof(
+	req *pb.ProofRequest,
+	stream pb.ProofProducer_RequestProofServer,
+) error {
+	if atomic.AddInt32(&s.requests, 1) <= s.failures {
+		return status.Error(s.code, "test")
+	}
+
+	return stream.Send(&pb.ProofResponse{Result: &pb.ProofResponse_Proof{
+		Proof: &pb.Proof{ZkProof: req.BlockHash, Degree: CircuitsDegree10Txs},
+	}})
+}
+
+func TestGrpcStatusError(t *testing.T) {
+	for _, code := range []codes.Code{
+		codes.InvalidArgument,
+		codes.NotFound,
+		codes.FailedPrecondition,
+		codes.OutOfRange,
+		codes.Unimplemented,
+		codes.Unauthenticated,
+		codes.PermissionDenied,
+	} {
+		err := grpcStatusError(status.Error(code, "test"))
+		require.ErrorIs(t, err, ErrInvalidProofRequest, code.String())
+		require.True(t, isPermanentGrpcError(err), code.String())
+	}
+
+	err := grpcStatusError(status.Error(codes.ResourceExhausted, "test"))
+	require.ErrorIs(t, err, ErrProducerQueueFull)
+	require.True(t, isPermanentGrpcError(err))
+
+	for _, code := range []codes.Code{codes.Unavailable, codes.DeadlineExceeded, codes.Internal, codes.Aborted} {
+		require.False(t, isPermanentGrpcError(grpcStatusError(status.Error(code, "test"))), code.String())
+	}
+
+	require.False(t, isPermanentGrpcError(grpcStatusError(errHeartbeatTimeout)))
+	require.False(t, isPermanentGrpcError(grpcStatusError(errors.New("test"))))
+}
+
+func requestTestGrpcProof(producer *GrpcProofProducer) error {
+	header := &types.Header{Difficulty: common.Big0, Number: common.Big256}
+	return producer.RequestProof(
+		context.Background(),
+		&ProofRequestOptions{Height: header.Number},
+		common.Big32,
+		&bindings.TaikoDataBlockMetadata{},
+		header,
+		make(chan *ProofWithHeader, 1),
+	)
+}
+
+func TestGrpcProofProducerPermanentError(t *testing.T) {
+	srv := &failingProofProducerServer{code: codes.InvalidArgument, failures: 3}
+	producer := newTestGrpcProofProducer(t, srv, time.Second)
+	producer.RetryInterval = 10 * time.Millisecond
+
+	require.ErrorIs(t, requestTestGrpcProof(producer), ErrInvalidProofRequest)
+	require.Equal(t, int32(1), atomic.LoadInt32(&srv.requests))
+}
+
+func TestGrpcProofProducerQueueFull(t *testing.T) {
+	srv := &failingProofProducerServer{code: codes.ResourceExhausted, failures: 3}
+	producer := newTestGrpcProofProducer(t, srv, time.Second)
+	producer.RetryInterval = 10 * time.Millisecond
+
+	require.ErrorIs(t, requestTestGrpcProof(producer), ErrProducerQueueFull)
+	require.Equal(t, int32(1), atomic.LoadInt32(&srv.requests))
+}
+
+func TestGrpcProofProducerRetriableError(t *testing.T) {
+	srv := &failingProofProducerServer{code: codes.Unavailable, failures: 2}
+	producer := newTestGrpcProofProducer(t, srv, time.Second)
+	producer.RetryInterval = 10 * time.Millisecond
+
+	require.Nil(t, requestTestGrpcProof(producer))
+	require.Equal(t, int32(3), atomic.LoadInt32(&srv.requests))
+}
@@ -0,0 +1,200 @@
+package producer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/metrics"
+	pb "github.com/taikoxyz/taiko-client/prover/proof_producer/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var (
+	errHeartbeatTimeout = errors.New("proof producer heartbeat timeout")
+	errNoProofReturned  = errors.New("proof stream closed without proof")
+)
+
+const (
+	// defaultHeartbeatTimeout is the default maximum interval between two messages in a proof stream.
+	defaultHeartbeatTimeout = 2 * time.Minute
+)
+
+// GrpcProofProducer is responsible for requesting zk proofs from an external gRPC proof producer service,
+// the service streams progress updates as heartbeats while generating a proof.
+type GrpcProofProducer struct {
+	Endpoint         string           // a gRPC proof producer service endpoint
+	L1Endpoint       string           // a L1 node RPC endpoint
+	L2Endpoint       string           // a L2 execution engine's RPC endpoint
+	HeartbeatTimeout time.Duration    // maximum interval between two messages in a proof stream
+	RetryInterval    time.Duration    // interval between two proof request attempts
+	Validation       *ProofValidation // if set, the generated proofs are validated, an invalid one is requested again
+	client           pb.ProofProducerClient
+}
+
+// NewGrpcProofProducer creates a new `GrpcProofProducer` instance, the connection will be established lazily.
+func NewGrpcProofProducer(
+	endpoint string,
+	l1Endpoint string,
+	l2Endpoint string,
+	heartbeatTimeout time.Duration,
+	opts ...grpc.DialOption,
+) (*GrpcProofProducer, error) {
+	if heartbeatTimeout == 0 {
+		heartbeatTimeout = defaultHeartbeatTimeout
+	}
+
+	if len(opts) == 0 {
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+
+	conn, err := grpc.Dial(endpoint, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial gRPC proof producer: %w", err)
+	}
+
+	return &GrpcProofProducer{
+		Endpoint:         endpoint,
+		L1Endpoint:       l1Endpoint,
+		L2Endpoint:       l2Endpoint,
+		HeartbeatTimeout: heartbeatTimeout,
+		RetryInterval:    10 * time.Second,
+		client:           pb.NewProofProducerClient(conn),
+	}, nil
+}
+
+// RequestProof implements the ProofProducer interface, the proof is requested again until a valid one is
+// returned, unless the service rejects the request permanently, see grpcStatusError.
+func (g *GrpcProofProducer) RequestProof(
+	ctx context.Context,
+	opts *ProofRequestOptions,
+	blockID *big.Int,
+	meta *bindings.TaikoDataBlockMetadata,
+	header *types.Header,
+	resultCh chan *ProofWithHeader,
+) error {
+	logger := LoggerFromContext(ctx)
+	logger.Info(
+		"Request proof from gRPC proof producer",
+		"blockID", blockID,
+		"beneficiary", meta.Beneficiary,
+		"height", header.Number,
+		"hash", header.Hash(),
+		"endpoint", g.Endpoint,
+	)
+
+	req := &pb.ProofRequest{
+		BlockId:            blockID.Uint64(),
+		Height:             opts.Height.Uint64(),
+		BlockHash:          header.Hash().Bytes(),
+		ProverAddress:      opts.ProverAddress.Bytes(),
+		ProposeBlockTxHash: opts.ProposeBlockTxHash.Bytes(),
+		L1Rpc:              g.L1Endpoint,
+		L2Rpc:              g.L2Endpoint,
+	}
+
+	var (
+		proof *pb.Proof
+		start = time.Now()
+	)
+	if err := backoff.Retry(func() error {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		var err error
+		if proof, err = g.requestProof(ctx, req); err != nil {
+			err = grpcStatusError(err)
+			logger.Error("Failed to request proof", "blockID", blockID, "error", err, "endpoint", g.Endpoint)
+			if isPermanentGrpcError(err) {
+				return backoff.Permanent(err)
+			}
+			return err
+		}
+
+		if err := g.Validation.Validate(&ProofWithHeader{
+			BlockID: blockID,
+			Meta:    meta,
+			Header:  header,
+			ZkProof: proof.ZkProof,
+			Degree:  proof.Degree,
+		}); err != nil {
+			metrics.ProverInvalidProofCounter.Inc(1)
+			logger.Error("Invalid proof generated", "blockID", blockID, "error", err, "endpoint", g.Endpoint)
+			return err
+		}
+
+		return nil
+	}, backoff.WithContext(backoff.NewConstantBackOff(g.RetryInterval), ctx)); err != nil {
+		return err
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	logger.Info("Proof generated", "blockID", blockID, "degree", proof.Degree, "time", time.Since(start))
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case resultCh <- &ProofWithHeader{
+		BlockID: blockID,
+		Header:  header,
+		Meta:    meta,
+		ZkProof: proof.ZkProof,
+		Degree:  proof.Degree,
+		Logger:  logger,
+	}:
+	}
+
+	return nil
+}
+
+// requestProof opens a proof stream, and keeps receiving the progress updates until the proof is returned,
+// the stream will be cancelled if there is no message received within the heartbeat timeout.
+func (g *GrpcProofProducer) requestProof(ctx context.Context, req *pb.ProofRequest) (*pb.Proof, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := g.client.RequestProof(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	heartbeat := time.AfterFunc(g.HeartbeatTimeout, cancel)
+	defer heartbeat.Stop()
+
+	for {
+		res, err := stream.Recv()
+		if err != nil {
+			if !heartbeat.Stop() {
+				return nil, errHeartbeatTimeout
+			}
+			if errors.Is(err, io.EOF) {
+				return nil, errNoProofReturned
+			}
+			return nil, err
+		}
+		heartbeat.Reset(g.HeartbeatTimeout)
+
+		switch result := res.Result.(type) {
+		case *pb.ProofResponse_Progress:
+			LoggerFromContext(ctx).Debug(
+				"Proof generation progress",
+				"blockID", req.BlockId,
+				"status", result.Progress.Status,
+				"percentage", result.Progress.Percentage,
+			)
+		case *pb.ProofResponse_Proof:
+			return result.Proof, nil
+		}
+	}
+}
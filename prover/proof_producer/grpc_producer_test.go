@@ -0,0 +1,120 @@
+package producer
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+	pb "github.com/taikoxyz/taiko-client/prover/proof_producer/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testProofProducerServer streams the given number of progress updates with the given interval,
+// and then the proof.
+type testProofProducerServer struct {
+	pb.UnimplementedProofProducerServer
+	progresses int
+	interval   time.Duration
+}
+
+func (s *testProofProducerServer) RequestProof(req *pb.ProofRequest, stream pb.ProofProducer_RequestProofServer) error {
+	for i := 0; i < s.progresses; i++ {
+		time.Sleep(s.interval)
+		if err := stream.Send(&pb.ProofResponse{Result: &pb.ProofResponse_Progress{
+			Progress: &pb.Progress{Status: "generating", Percentage: uint32(i * 100 / s.progresses)},
+		}}); err != nil {
+			return err
+		}
+	}
+
+	return stream.Send(&pb.ProofResponse{Result: &pb.ProofResponse_Proof{
+		Proof: &pb.Proof{ZkProof: req.BlockHash, Degree: CircuitsDegree10Txs},
+	}})
+}
+
+func newTestGrpcProofProducer(
+	t *testing.T,
+	srv pb.ProofProducerServer,
+	heartbeatTimeout time.Duration,
+) *GrpcProofProducer {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	pb.RegisterProofProducerServer(server, srv)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	producer, err := NewGrpcProofProducer(
+		"bufnet",
+		"",
+		"",
+		heartbeatTimeout,
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.Nil(t, err)
+
+	return producer
+}
+
+func TestGrpcProofProducerRequestProof(t *testing.T) {
+	producer := newTestGrpcProofProducer(
+		t,
+		&testProofProducerServer{progresses: 3, interval: 100 * time.Millisecond},
+		time.Second,
+	)
+
+	resCh := make(chan *ProofWithHeader, 1)
+	header := &types.Header{Difficulty: common.Big0, Number: common.Big256}
+	require.Nil(t, producer.RequestProof(
+		context.Background(),
+		&ProofRequestOptions{Height: header.Number},
+		common.Big32,
+		&bindings.TaikoDataBlockMetadata{},
+		header,
+		resCh,
+	))
+
+	res := <-resCh
+	require.Equal(t, common.Big32, res.BlockID)
+	require.Equal(t, header.Hash().Bytes(), res.ZkProof)
+	require.Equal(t, uint64(CircuitsDegree10Txs), res.Degree)
+}
+
+func TestGrpcProofProducerHeartbeatTimeout(t *testing.T) {
+	producer := newTestGrpcProofProducer(
+		t,
+		&testProofProducerServer{progresses: 1, interval: time.Second},
+		100*time.Millisecond,
+	)
+
+	_, err := producer.requestProof(context.Background(), &pb.ProofRequest{})
+	require.ErrorIs(t, err, errHeartbeatTimeout)
+}
+
+func TestGrpcProofProducerCancelled(t *testing.T) {
+	producer := newTestGrpcProofProducer(
+		t,
+		&testProofProducerServer{progresses: 1, interval: time.Second},
+		time.Minute,
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	header := &types.Header{Difficulty: common.Big0, Number: common.Big256}
+	require.ErrorIs(t, producer.RequestProof(
+		ctx,
+		&ProofRequestOptions{Height: header.Number},
+		common.Big32,
+		&bindings.TaikoDataBlockMetadata{},
+		header,
+		make(chan *ProofWithHeader, 1),
+	), context.DeadlineExceeded)
+}
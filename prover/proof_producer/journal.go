@@ -0,0 +1,260 @@
+package producer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+// JournalEntry is an in-flight proverd proof job, journaled so that it can be resumed after a restart.
+type JournalEntry struct {
+	BlockID   uint64                           `json:"blockID"`
+	Endpoint  string                           `json:"endpoint"`
+	RequestID *big.Int                         `json:"requestID"`
+	Options   *ProofRequestOptions             `json:"options"`
+	Meta      *bindings.TaikoDataBlockMetadata `json:"meta"`
+	Header    *types.Header                    `json:"header"`
+	StartedAt time.Time                        `json:"startedAt"`
+}
+
+// matches returns whether the entry is a job of the given endpoint with the given request options.
+func (e *JournalEntry) matches(endpoint string, opts *ProofRequestOptions) bool {
+	return e.Endpoint == endpoint &&
+		e.Options.Height.Cmp(opts.Height) == 0 &&
+		e.Options.ProverAddress == opts.ProverAddress &&
+		e.Options.ProposeBlockTxHash == opts.ProposeBlockTxHash &&
+		e.Options.ParamsPath == opts.ParamsPath
+}
+
+// resumedJob is a journaled proof job resumed on startup, which delivers its proof by itself.
+type resumedJob struct {
+	done   chan struct{}
+	err    error // Set before done is closed
+	cancel context.CancelFunc
+}
+
+// wait waits until the resumed job is done, the job is cancelled if the given context is cancelled first.
+func (j *resumedJob) wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		j.cancel()
+		return ctx.Err()
+	case <-j.done:
+		return j.err
+	}
+}
+
+// ProofJournal is an on-disk journal of the in-flight proverd proof jobs keyed by block ID, shared by all the
+// ZkevmRpcdProducer backends. The journal is frozen once the given lifetime context is cancelled, so that
+// the jobs interrupted by a shutdown are kept and resumed after the restart. All methods of a nil
+// ProofJournal are no-ops.
+type ProofJournal struct {
+	path     string
+	lifetime context.Context
+
+	mutex   sync.Mutex
+	entries map[uint64]*JournalEntry
+	resumed map[uint64]*resumedJob
+}
+
+// OpenProofJournal opens the proof journal at the given path, the journal file is created on the first write.
+func OpenProofJournal(lifetime context.Context, path string) (*ProofJournal, error) {
+	j := &ProofJournal{
+		path:     path,
+		lifetime: lifetime,
+		entries:  make(map[uint64]*JournalEntry),
+		resumed:  make(map[uint64]*resumedJob),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return j, nil
+		}
+		return nil, fmt.Errorf("failed to read proof journal: %w", err)
+	}
+
+	var entries []*JournalEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to decode proof journal %s: %w", path, err)
+	}
+	for _, entry := range entries {
+		j.entries[entry.BlockID] = entry
+	}
+
+	return j, nil
+}
+
+// Frozen returns whether the journal has been frozen by the shutdown.
+func (j *ProofJournal) Frozen() bool {
+	return j != nil && j.lifetime.Err() != nil
+}
+
+// Get returns the journaled job of the given block, or nil if there is none.
+func (j *ProofJournal) Get(blockID uint64) *JournalEntry {
+	if j == nil {
+		return nil
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	return j.entries[blockID]
+}
+
+// Entries returns all the journaled jobs, sorted by block ID.
+func (j *ProofJournal) Entries() []*JournalEntry {
+	if j == nil {
+		return nil
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	entries := make([]*JournalEntry, 0, len(j.entries))
+	for _, entry := range j.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(a, b int) bool { return entries[a].BlockID < entries[b].BlockID })
+
+	return entries
+}
+
+// Put journals the given job, replacing the previous one of the same block.
+func (j *ProofJournal) Put(entry *JournalEntry) error {
+	if j == nil || j.Frozen() {
+		return nil
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.entries[entry.BlockID] = entry
+	return j.flush()
+}
+
+// Delete removes the journaled job of the given block.
+func (j *ProofJournal) Delete(blockID uint64) error {
+	if j == nil || j.Frozen() {
+		return nil
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if _, ok := j.entries[blockID]; !ok {
+		return nil
+	}
+	delete(j.entries, blockID)
+	return j.flush()
+}
+
+// Discard removes the journaled jobs of the blocks which have been verified, and the jobs of the endpoints
+// which are no longer configured, returns the number of the removed jobs.
+func (j *ProofJournal) Discard(latestVerifiedID uint64, endpoints []string) (int, error) {
+	if j == nil {
+		return 0, nil
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	configured := make(map[string]struct{}, len(endpoints))
+	for _, endpoint := range endpoints {
+		configured[endpoint] = struct{}{}
+	}
+
+	var discarded int
+	for blockID, entry := range j.entries {
+		if _, ok := configured[entry.Endpoint]; blockID <= latestVerifiedID || !ok {
+			delete(j.entries, blockID)
+			discarded++
+		}
+	}
+	if discarded == 0 {
+		return 0, nil
+	}
+
+	return discarded, j.flush()
+}
+
+// flush writes all the journaled jobs to the journal file atomically, the caller must hold the mutex.
+func (j *ProofJournal) flush() error {
+	entries := make([]*JournalEntry, 0, len(j.entries))
+	for _, entry := range j.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(a, b int) bool { return entries[a].BlockID < entries[b].BlockID })
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to encode proof journal: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(j.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create proof journal directory: %w", err)
+	}
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write proof journal: %w", err)
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		return fmt.Errorf("failed to write proof journal: %w", err)
+	}
+
+	return nil
+}
+
+// startResumed records a resumed job of the given block.
+func (j *ProofJournal) startResumed(blockID uint64, cancel context.CancelFunc) *resumedJob {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	job := &resumedJob{done: make(chan struct{}), cancel: cancel}
+	j.resumed[blockID] = job
+	return job
+}
+
+// finishResumed marks the given resumed job as done with the given error, a failed job is forgotten at once.
+func (j *ProofJournal) finishResumed(blockID uint64, job *resumedJob, err error) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	job.err = err
+	close(job.done)
+	if err != nil && j.resumed[blockID] == job {
+		delete(j.resumed, blockID)
+	}
+}
+
+// attachResumed returns the resumed job of the given block, or nil if there is none. A completed job is
+// attached only once, so that the later requests of the same block generate new proofs.
+func (j *ProofJournal) attachResumed(blockID uint64) *resumedJob {
+	if j == nil {
+		return nil
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	job, ok := j.resumed[blockID]
+	if !ok {
+		return nil
+	}
+	select {
+	case <-job.done:
+		delete(j.resumed, blockID)
+	default:
+	}
+
+	return job
+}
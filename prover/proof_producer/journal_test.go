@@ -0,0 +1,90 @@
+package producer
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+func newTestJournalEntry(blockID uint64, endpoint string) *JournalEntry {
+	return &JournalEntry{
+		BlockID:   blockID,
+		Endpoint:  endpoint,
+		RequestID: big.NewInt(int64(blockID) * 100),
+		Options:   &ProofRequestOptions{Height: new(big.Int).SetUint64(blockID), ProverAddress: common.Address{1}},
+		Meta:      &bindings.TaikoDataBlockMetadata{Id: blockID, GasLimit: 1024},
+		Header: &types.Header{
+			ParentHash: randHash(),
+			Difficulty: common.Big0,
+			Number:     new(big.Int).SetUint64(blockID),
+			Extra:      []byte{},
+		},
+		StartedAt: time.Now().Truncate(time.Second),
+	}
+}
+
+func TestProofJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal", "rpcd.json")
+
+	journal, err := OpenProofJournal(context.Background(), path)
+	require.Nil(t, err)
+	require.Empty(t, journal.Entries())
+
+	for _, entry := range []*JournalEntry{
+		newTestJournalEntry(3, "a"),
+		newTestJournalEntry(1, "a"),
+		newTestJournalEntry(2, "b"),
+		newTestJournalEntry(4, "removed"),
+	} {
+		require.Nil(t, journal.Put(entry))
+	}
+	require.Nil(t, journal.Delete(3))
+
+	// Reopen the journal, as if the prover has been restarted.
+	reopened, err := OpenProofJournal(context.Background(), path)
+	require.Nil(t, err)
+	entries := reopened.Entries()
+	require.Len(t, entries, 3)
+	require.Equal(t, uint64(1), entries[0].BlockID)
+	require.Equal(t, journal.Get(1).RequestID, entries[0].RequestID)
+	require.Equal(t, journal.Get(1).Header.Hash(), entries[0].Header.Hash())
+	require.Equal(t, journal.Get(1).Meta, entries[0].Meta)
+	require.True(t, entries[0].matches("a", journal.Get(1).Options))
+	require.False(t, entries[0].matches("b", journal.Get(1).Options))
+
+	discarded, err := reopened.Discard(1, []string{"a", "b"})
+	require.Nil(t, err)
+	require.Equal(t, 2, discarded)
+	require.Len(t, reopened.Entries(), 1)
+	require.NotNil(t, reopened.Get(2))
+}
+
+func TestProofJournalFrozen(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	journal, err := OpenProofJournal(ctx, filepath.Join(t.TempDir(), "rpcd.json"))
+	require.Nil(t, err)
+	require.Nil(t, journal.Put(newTestJournalEntry(1, "a")))
+
+	cancel()
+	require.True(t, journal.Frozen())
+	require.Nil(t, journal.Delete(1))
+	require.Nil(t, journal.Put(newTestJournalEntry(2, "a")))
+	require.Len(t, journal.Entries(), 1)
+}
+
+func TestNilProofJournal(t *testing.T) {
+	var journal *ProofJournal
+	require.False(t, journal.Frozen())
+	require.Nil(t, journal.Get(1))
+	require.Empty(t, journal.Entries())
+	require.Nil(t, journal.Put(newTestJournalEntry(1, "a")))
+	require.Nil(t, journal.Delete(1))
+	require.Nil(t, journal.attachResumed(1))
+}
@@ -0,0 +1,34 @@
+package producer
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// loggerKey is the context key of a proof request's logger.
+type loggerKey struct{}
+
+// WithLogger returns a copy of the given context carrying the given logger, which is tagged with
+// the context of the block to prove.
+func WithLogger(ctx context.Context, logger log.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// LoggerFromContext returns the logger carried by the given context, or the root logger if not set.
+func LoggerFromContext(ctx context.Context) log.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(log.Logger); ok {
+		return logger
+	}
+
+	return log.Root()
+}
+
+// Log returns the logger tagged with the context of the proven block, or the root logger if not set.
+func (p *ProofWithHeader) Log() log.Logger {
+	if p.Logger == nil {
+		return log.Root()
+	}
+
+	return p.Logger
+}
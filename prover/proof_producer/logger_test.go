@@ -0,0 +1,18 @@
+package producer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggerFromContext(t *testing.T) {
+	require.Equal(t, log.Root(), LoggerFromContext(context.Background()))
+	require.Equal(t, log.Root(), (&ProofWithHeader{}).Log())
+
+	logger := log.New("blockID", 1)
+	require.Equal(t, logger, LoggerFromContext(WithLogger(context.Background(), logger)))
+	require.Equal(t, logger, (&ProofWithHeader{Logger: logger}).Log())
+}
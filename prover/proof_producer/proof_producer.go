@@ -2,11 +2,14 @@ package producer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/taikoxyz/taiko-client/bindings"
 )
 
@@ -18,11 +21,29 @@ const (
 	CircuitsIdx80Txs = 1
 )
 
+// ErrInvalidProofRequest is returned when the proof producer rejects the proof request permanently,
+// retrying the same request won't help.
+var ErrInvalidProofRequest = errors.New("invalid proof request")
+
+// ErrUnsupportedBlock is returned when the block uses an opcode or a precompile which the producer's current
+// circuits don't support, it is an ErrInvalidProofRequest, retrying the request won't help until the producer
+// upgrades its circuits.
+var ErrUnsupportedBlock = fmt.Errorf("%w: unsupported block", ErrInvalidProofRequest)
+
+// ErrProofTimeout is returned when the proof generation takes longer than the configured timeout, the
+// request can be retried.
+var ErrProofTimeout = errors.New("proof generation timeout")
+
+// ErrProducerQueueFull is returned when the proof producer's job queue is full, the request can be retried
+// after backing off.
+var ErrProducerQueueFull = errors.New("proof producer queue full")
+
 // ProofRequestOptions contains all options that need to be passed to zkEVM rpcd service.
 type ProofRequestOptions struct {
 	Height             *big.Int // the block number
 	ProverAddress      common.Address
 	ProposeBlockTxHash common.Hash
+	ParamsPath         string // circuit parameters of the proof, the producer's default ones if empty
 }
 
 type ProofWithHeader struct {
@@ -31,8 +52,16 @@ type ProofWithHeader struct {
 	Header  *types.Header
 	ZkProof []byte
 	Degree  uint64
+	Logger  log.Logger // Tagged with the block's context, carried from the proof request
+	Origin  string     // Name of the backend which generated the proof, set by FallbackProducer
+	Cached  bool       // Whether the proof is found in a proof cache rather than generated, set by CachedProofProducer
+	// Elapsed time since the proof was requested, set by the prover once the proof is received
+	GenerationTime time.Duration
 }
 
+// ProofProducer generates the proofs of the given blocks, the generated proofs are sent to the given result
+// channel. The proof generation should be aborted once the given context is cancelled, then no proof will
+// be sent.
 type ProofProducer interface {
 	RequestProof(
 		ctx context.Context,
@@ -44,6 +73,18 @@ type ProofProducer interface {
 	) error
 }
 
+// BackendCapacity is the capacity reported by a proof producer's backend.
+type BackendCapacity struct {
+	QueueDepth uint64 // number of the proof requests queued in the backend
+	Saturated  bool   // whether the backend can't accept more proof requests for now
+}
+
+// CapacityProber is implemented by the proof producers whose backend can report its capacity, which
+// may be shared by multiple provers.
+type CapacityProber interface {
+	Capacity(ctx context.Context) (*BackendCapacity, error)
+}
+
 func DegreeToCircuitsIdx(degree uint64) (uint16, error) {
 	switch degree {
 	case CircuitsDegree10Txs:
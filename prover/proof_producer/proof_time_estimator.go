@@ -0,0 +1,199 @@
+package producer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// proofTimeBuckets is the number of the gas used buckets, each one is a decile of the block max gas limit.
+	proofTimeBuckets = 10
+	// proofTimeWindow is the number of the latest proof latencies kept in each bucket.
+	proofTimeWindow = 100
+	// minProofTimeSamples is the minimum number of the proof latencies an estimate is based on.
+	minProofTimeSamples = 5
+)
+
+// ProofTimeEstimate is the estimated proof generation time of a block.
+type ProofTimeEstimate struct {
+	P50     time.Duration `json:"p50"`
+	P90     time.Duration `json:"p90"`
+	Samples int           `json:"samples"` // Number of the proof latencies the estimate is based on
+}
+
+// proofTimeSample is a proof latency observed, as persisted in the history file.
+type proofTimeSample struct {
+	GasUsed uint64 `json:"gasUsed"`
+	Millis  int64  `json:"millis"`
+}
+
+// ProofTimeEstimator keeps a rolling window of the latest proof generation latencies by gas used decile, and
+// estimates the proof generation time of a block from the latencies of the blocks using a similar amount of
+// gas. The latencies are persisted to the given history file if any, so that the estimates are not cold after
+// a restart. All methods of a nil ProofTimeEstimator are no-ops.
+type ProofTimeEstimator struct {
+	path             string
+	blockMaxGasLimit uint64
+
+	mutex   sync.Mutex
+	buckets [][]proofTimeSample // Oldest first in each bucket
+}
+
+// OpenProofTimeEstimator creates a new ProofTimeEstimator with the history at the given path, the history file
+// is created on the first observation, an empty path means no persistence.
+func OpenProofTimeEstimator(path string, blockMaxGasLimit uint64) (*ProofTimeEstimator, error) {
+	e := &ProofTimeEstimator{
+		path:             path,
+		blockMaxGasLimit: blockMaxGasLimit,
+		buckets:          make([][]proofTimeSample, proofTimeBuckets),
+	}
+	if len(path) == 0 {
+		return e, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return e, nil
+		}
+		return nil, fmt.Errorf("failed to read proof time history: %w", err)
+	}
+
+	var samples []proofTimeSample
+	if err := json.Unmarshal(data, &samples); err != nil {
+		return nil, fmt.Errorf("failed to decode proof time history %s: %w", path, err)
+	}
+	// Bucketed again, in case the block max gas limit has been changed since.
+	for _, sample := range samples {
+		e.add(sample)
+	}
+
+	return e, nil
+}
+
+// Observe records the generation latency of the proof of a block using the given amount of gas.
+func (e *ProofTimeEstimator) Observe(gasUsed uint64, latency time.Duration) error {
+	if e == nil {
+		return nil
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.add(proofTimeSample{GasUsed: gasUsed, Millis: latency.Milliseconds()})
+	if len(e.path) == 0 {
+		return nil
+	}
+
+	return e.flush()
+}
+
+// EstimateProofTime estimates the proof generation time of a block using the given amount of gas, by the
+// latencies in its gas used decile, widened to the neighbouring deciles until there are enough latencies.
+// Returns nil if there are not enough latencies observed yet.
+func (e *ProofTimeEstimator) EstimateProofTime(gasUsed uint64) *ProofTimeEstimate {
+	if e == nil {
+		return nil
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	var millis []int64
+	idx := e.bucket(gasUsed)
+	for lo, hi := idx, idx; lo >= 0 || hi < proofTimeBuckets; lo, hi = lo-1, hi+1 {
+		if len(millis) >= minProofTimeSamples {
+			break
+		}
+		if lo >= 0 {
+			millis = appendMillis(millis, e.buckets[lo])
+		}
+		if hi != lo && hi < proofTimeBuckets {
+			millis = appendMillis(millis, e.buckets[hi])
+		}
+	}
+	if len(millis) < minProofTimeSamples {
+		return nil
+	}
+	sort.Slice(millis, func(i, j int) bool { return millis[i] < millis[j] })
+
+	return &ProofTimeEstimate{
+		P50:     time.Duration(percentile(millis, 50)) * time.Millisecond,
+		P90:     time.Duration(percentile(millis, 90)) * time.Millisecond,
+		Samples: len(millis),
+	}
+}
+
+// bucket returns the index of the gas used decile of the given amount of gas.
+func (e *ProofTimeEstimator) bucket(gasUsed uint64) int {
+	if e.blockMaxGasLimit == 0 {
+		return 0
+	}
+
+	idx := gasUsed * uint64(proofTimeBuckets) / e.blockMaxGasLimit
+	if idx >= uint64(proofTimeBuckets) {
+		return proofTimeBuckets - 1
+	}
+
+	return int(idx)
+}
+
+// add appends the given sample to its bucket, dropping the oldest one once the window is full, the caller
+// must hold the mutex.
+func (e *ProofTimeEstimator) add(sample proofTimeSample) {
+	idx := e.bucket(sample.GasUsed)
+	e.buckets[idx] = append(e.buckets[idx], sample)
+	if len(e.buckets[idx]) > proofTimeWindow {
+		e.buckets[idx] = e.buckets[idx][len(e.buckets[idx])-proofTimeWindow:]
+	}
+}
+
+// flush writes all the samples to the history file atomically, the caller must hold the mutex.
+func (e *ProofTimeEstimator) flush() error {
+	var samples []proofTimeSample
+	for _, bucket := range e.buckets {
+		samples = append(samples, bucket...)
+	}
+
+	data, err := json.Marshal(samples)
+	if err != nil {
+		return fmt.Errorf("failed to encode proof time history: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(e.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create proof time history directory: %w", err)
+	}
+	tmp := e.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write proof time history: %w", err)
+	}
+	if err := os.Rename(tmp, e.path); err != nil {
+		return fmt.Errorf("failed to write proof time history: %w", err)
+	}
+
+	return nil
+}
+
+// appendMillis appends the latencies of the given samples to the given slice.
+func appendMillis(millis []int64, samples []proofTimeSample) []int64 {
+	for _, sample := range samples {
+		millis = append(millis, sample.Millis)
+	}
+
+	return millis
+}
+
+// percentile returns the nearest-rank percentile of the given sorted values.
+func percentile(sorted []int64, p int) int64 {
+	rank := (len(sorted)*p + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
@@ -0,0 +1,75 @@
+package producer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProofTimeEstimator(t *testing.T) {
+	e, err := OpenProofTimeEstimator("", 1000)
+	require.Nil(t, err)
+
+	// Not enough latencies observed yet.
+	for i := 1; i < minProofTimeSamples; i++ {
+		require.Nil(t, e.Observe(950, time.Duration(i)*time.Minute))
+	}
+	require.Nil(t, e.EstimateProofTime(950))
+
+	require.Nil(t, e.Observe(1000, 10*time.Minute))
+	estimate := e.EstimateProofTime(999)
+	require.Equal(t, &ProofTimeEstimate{P50: 3 * time.Minute, P90: 10 * time.Minute, Samples: 5}, estimate)
+
+	// Widened to the neighbouring deciles, the nearest ones first.
+	for i := 0; i < minProofTimeSamples; i++ {
+		require.Nil(t, e.Observe(100, time.Second))
+	}
+	require.Equal(t, time.Second, e.EstimateProofTime(0).P90)
+	require.Equal(t, time.Second, e.EstimateProofTime(300).P90)
+	require.Equal(t, 10*time.Minute, e.EstimateProofTime(800).P90)
+}
+
+func TestProofTimeEstimatorWindow(t *testing.T) {
+	e, err := OpenProofTimeEstimator("", 1000)
+	require.Nil(t, err)
+
+	for i := 0; i < proofTimeWindow; i++ {
+		require.Nil(t, e.Observe(0, time.Hour))
+	}
+	for i := 0; i < proofTimeWindow; i++ {
+		require.Nil(t, e.Observe(0, time.Minute))
+	}
+	require.Equal(
+		t,
+		&ProofTimeEstimate{P50: time.Minute, P90: time.Minute, Samples: proofTimeWindow},
+		e.EstimateProofTime(0),
+	)
+}
+
+func TestProofTimeEstimatorPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "estimator", "history.json")
+	e, err := OpenProofTimeEstimator(path, 1000)
+	require.Nil(t, err)
+	for i := 0; i < minProofTimeSamples; i++ {
+		require.Nil(t, e.Observe(500, time.Minute))
+	}
+
+	// Bucketed again with a changed block max gas limit.
+	e, err = OpenProofTimeEstimator(path, 10000)
+	require.Nil(t, err)
+	require.Len(t, e.buckets[0], minProofTimeSamples)
+	require.Equal(t, time.Minute, e.EstimateProofTime(0).P50)
+
+	require.Nil(t, os.WriteFile(path, []byte("{"), 0o600))
+	_, err = OpenProofTimeEstimator(path, 1000)
+	require.ErrorContains(t, err, "failed to decode proof time history")
+}
+
+func TestNilProofTimeEstimator(t *testing.T) {
+	var e *ProofTimeEstimator
+	require.Nil(t, e.Observe(0, time.Second))
+	require.Nil(t, e.EstimateProofTime(0))
+}
@@ -0,0 +1,66 @@
+package producer
+
+import (
+	"errors"
+	"fmt"
+)
+
+// proofWordSize is the size of the words a zkEVM proof is made of, each one is a field element or a
+// coordinate of a curve point, read by the on-chain verifier.
+const proofWordSize = 32
+
+// ErrInvalidProof is returned when a generated proof doesn't meet the structural constraints of
+// ProofValidation, submitting it would only revert on-chain.
+var ErrInvalidProof = errors.New("invalid proof")
+
+// ProofValidation is the structural constraints checked on each generated proof, before it is handed to the
+// proof submitter. All methods of a nil ProofValidation are no-ops.
+type ProofValidation struct {
+	ProofSizes map[uint64]int // expected proof length by circuit degree, any word-aligned length if absent
+}
+
+// Validate checks whether the given generated proof is well-formed, and is the proof of its own block.
+func (v *ProofValidation) Validate(proofWithHeader *ProofWithHeader) error {
+	if v == nil {
+		return nil
+	}
+
+	blockID := proofWithHeader.BlockID
+	if proofWithHeader.Header == nil || proofWithHeader.Header.Number == nil {
+		return fmt.Errorf("%w, blockID: %d, no header", ErrInvalidProof, blockID)
+	}
+	if proofWithHeader.Header.Number.Cmp(blockID) != 0 {
+		return fmt.Errorf(
+			"%w, blockID: %d, header of another block: %d",
+			ErrInvalidProof, blockID, proofWithHeader.Header.Number,
+		)
+	}
+	if proofWithHeader.Meta != nil && proofWithHeader.Meta.Id != blockID.Uint64() {
+		return fmt.Errorf(
+			"%w, blockID: %d, metadata of another block: %d",
+			ErrInvalidProof, blockID, proofWithHeader.Meta.Id,
+		)
+	}
+
+	size := len(proofWithHeader.ZkProof)
+	if size == 0 {
+		return fmt.Errorf("%w, blockID: %d, empty proof", ErrInvalidProof, blockID)
+	}
+	if size%proofWordSize != 0 {
+		return fmt.Errorf(
+			"%w, blockID: %d, proof length %d is not a multiple of %d bytes, likely truncated",
+			ErrInvalidProof, blockID, size, proofWordSize,
+		)
+	}
+	if _, err := DegreeToCircuitsIdx(proofWithHeader.Degree); err != nil {
+		return fmt.Errorf("%w, blockID: %d: %v", ErrInvalidProof, blockID, err)
+	}
+	if expected, ok := v.ProofSizes[proofWithHeader.Degree]; ok && size != expected {
+		return fmt.Errorf(
+			"%w, blockID: %d, proof length %d, expected %d for circuit degree %d",
+			ErrInvalidProof, blockID, size, expected, proofWithHeader.Degree,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package producer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+func TestProofValidation(t *testing.T) {
+	validation := &ProofValidation{ProofSizes: map[uint64]int{CircuitsDegree80Txs: 3 * proofWordSize}}
+	newProof := func() *ProofWithHeader {
+		return &ProofWithHeader{
+			BlockID: big.NewInt(10),
+			Meta:    &bindings.TaikoDataBlockMetadata{Id: 10},
+			Header:  &types.Header{Number: big.NewInt(10)},
+			ZkProof: make([]byte, 2*proofWordSize),
+			Degree:  CircuitsDegree10Txs,
+		}
+	}
+	require.Nil(t, validation.Validate(newProof()))
+
+	for _, testCase := range []struct {
+		name     string
+		mutate   func(p *ProofWithHeader)
+		expected string
+	}{
+		{"noHeader", func(p *ProofWithHeader) { p.Header = nil }, "no header"},
+		{"otherHeader", func(p *ProofWithHeader) { p.Header.Number = big.NewInt(11) }, "header of another block: 11"},
+		{"otherMeta", func(p *ProofWithHeader) { p.Meta.Id = 11 }, "metadata of another block: 11"},
+		{"empty", func(p *ProofWithHeader) { p.ZkProof = nil }, "empty proof"},
+		{
+			"truncated",
+			func(p *ProofWithHeader) { p.ZkProof = p.ZkProof[:proofWordSize+5] },
+			"proof length 37 is not a multiple of 32 bytes",
+		},
+		{"unknownDegree", func(p *ProofWithHeader) { p.Degree = 10 }, "invalid degree: 10"},
+		{
+			"unexpectedSize",
+			func(p *ProofWithHeader) { p.Degree = CircuitsDegree80Txs },
+			"proof length 64, expected 96 for circuit degree 21",
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			proof := newProof()
+			testCase.mutate(proof)
+
+			err := validation.Validate(proof)
+			require.ErrorIs(t, err, ErrInvalidProof)
+			require.ErrorContains(t, err, testCase.expected)
+		})
+	}
+
+	// Validation skipped.
+	proof := newProof()
+	proof.ZkProof = []byte{0x01}
+	require.Nil(t, (*ProofValidation)(nil).Validate(proof))
+}
+
+func TestZkevmRpcdProducerInvalidProofTierFallback(t *testing.T) {
+	var (
+		mutex  sync.Mutex
+		proofs = map[string]string{
+			"/params/small": "0x" + strings.Repeat("01", proofWordSize-1),
+			"/params/large": "0x" + strings.Repeat("01", 2*proofWordSize),
+		}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body RequestProofBody
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+
+		mutex.Lock()
+		proof := proofs[body.Params[0].Param]
+		mutex.Unlock()
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":{"circuit":{"proof":"%s","k":19}}}`, proof)
+	}))
+	defer srv.Close()
+
+	producer, err := NewZkevmRpcdProducer(
+		srv.URL, "/params/large", "", "", false, &ParamsTier{MaxGas: 3_000_000, ParamsPath: "/params/small"},
+	)
+	require.Nil(t, err)
+	producer.Validation = &ProofValidation{}
+
+	resCh := make(chan *ProofWithHeader, 1)
+	request := func() error {
+		return producer.RequestProof(
+			context.Background(),
+			&ProofRequestOptions{Height: common.Big256},
+			common.Big256,
+			&bindings.TaikoDataBlockMetadata{Id: 256},
+			&types.Header{Number: common.Big256, GasUsed: 21000},
+			resCh,
+		)
+	}
+
+	// The truncated proof of the small circuit falls back to the large one.
+	require.Nil(t, request())
+	require.Len(t, (<-resCh).ZkProof, 2*proofWordSize)
+
+	// No valid proof at all.
+	mutex.Lock()
+	proofs["/params/large"] = "0x" + strings.Repeat("01", proofWordSize+1)
+	mutex.Unlock()
+	require.ErrorIs(t, request(), ErrInvalidProof)
+	require.Empty(t, resCh)
+}
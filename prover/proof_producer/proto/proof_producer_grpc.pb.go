@@ -0,0 +1,140 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: proof_producer.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ProofProducer_RequestProof_FullMethodName = "/proofproducer.ProofProducer/RequestProof"
+)
+
+// ProofProducerClient is the client API for ProofProducer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ProofProducerClient interface {
+	// RequestProof requests a proof of the given L2 block, the server streams progress updates
+	// as heartbeats while generating, and finally the generated proof.
+	RequestProof(ctx context.Context, in *ProofRequest, opts ...grpc.CallOption) (ProofProducer_RequestProofClient, error)
+}
+
+type proofProducerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProofProducerClient(cc grpc.ClientConnInterface) ProofProducerClient {
+	return &proofProducerClient{cc}
+}
+
+func (c *proofProducerClient) RequestProof(ctx context.Context, in *ProofRequest, opts ...grpc.CallOption) (ProofProducer_RequestProofClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ProofProducer_ServiceDesc.Streams[0], ProofProducer_RequestProof_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &proofProducerRequestProofClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ProofProducer_RequestProofClient interface {
+	Recv() (*ProofResponse, error)
+	grpc.ClientStream
+}
+
+type proofProducerRequestProofClient struct {
+	grpc.ClientStream
+}
+
+func (x *proofProducerRequestProofClient) Recv() (*ProofResponse, error) {
+	m := new(ProofResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ProofProducerServer is the server API for ProofProducer service.
+// All implementations must embed UnimplementedProofProducerServer
+// for forward compatibility
+type ProofProducerServer interface {
+	// RequestProof requests a proof of the given L2 block, the server streams progress updates
+	// as heartbeats while generating, and finally the generated proof.
+	RequestProof(*ProofRequest, ProofProducer_RequestProofServer) error
+	mustEmbedUnimplementedProofProducerServer()
+}
+
+// UnimplementedProofProducerServer must be embedded to have forward compatible implementations.
+type UnimplementedProofProducerServer struct {
+}
+
+func (UnimplementedProofProducerServer) RequestProof(*ProofRequest, ProofProducer_RequestProofServer) error {
+	return status.Errorf(codes.Unimplemented, "method RequestProof not implemented")
+}
+func (UnimplementedProofProducerServer) mustEmbedUnimplementedProofProducerServer() {}
+
+// UnsafeProofProducerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProofProducerServer will
+// result in compilation errors.
+type UnsafeProofProducerServer interface {
+	mustEmbedUnimplementedProofProducerServer()
+}
+
+func RegisterProofProducerServer(s grpc.ServiceRegistrar, srv ProofProducerServer) {
+	s.RegisterService(&ProofProducer_ServiceDesc, srv)
+}
+
+func _ProofProducer_RequestProof_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ProofRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProofProducerServer).RequestProof(m, &proofProducerRequestProofServer{stream})
+}
+
+type ProofProducer_RequestProofServer interface {
+	Send(*ProofResponse) error
+	grpc.ServerStream
+}
+
+type proofProducerRequestProofServer struct {
+	grpc.ServerStream
+}
+
+func (x *proofProducerRequestProofServer) Send(m *ProofResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// ProofProducer_ServiceDesc is the grpc.ServiceDesc for ProofProducer service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ProofProducer_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proofproducer.ProofProducer",
+	HandlerType: (*ProofProducerServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RequestProof",
+			Handler:       _ProofProducer_RequestProof_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proof_producer.proto",
+}
@@ -0,0 +1,99 @@
+package producer
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// RpcdCallbackPath is the HTTP path of the proof callbacks pushed by proverd.
+	RpcdCallbackPath = "/rpcd/callback"
+	// defaultRpcdCallbackTimeout is the default timeout of waiting for a proof callback.
+	defaultRpcdCallbackTimeout = time.Hour
+	// rpcdCallbackTokenSize is the size in bytes of the random per-request callback tokens.
+	rpcdCallbackTokenSize = 16
+)
+
+// RpcdCallback represents the JSON body of a proof callback pushed by proverd, once the proof job of the
+// given callback token is completed.
+type RpcdCallback struct {
+	Token  string      `json:"token"`
+	Result *RpcdOutput `json:"result"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// RpcdCallbacks matches the proof callbacks pushed by proverd to the pending proof requests by their
+// callback tokens, for the proverd deployments which push the completed proofs rather than answering the
+// long-polling requests. It should be served at the given callback URL, which is included in the proof
+// requests.
+type RpcdCallbacks struct {
+	URL string // External URL of the callback handler, reachable from proverd
+
+	mutex   sync.Mutex
+	pending map[string]chan *RpcdCallback
+}
+
+// NewRpcdCallbacks creates a new RpcdCallbacks instance served at the given URL.
+func NewRpcdCallbacks(url string) *RpcdCallbacks {
+	return &RpcdCallbacks{URL: url, pending: make(map[string]chan *RpcdCallback)}
+}
+
+// register registers a new pending proof request, returns its callback token and the channel which
+// receives its callback.
+func (c *RpcdCallbacks) register() (string, <-chan *RpcdCallback, error) {
+	b := make([]byte, rpcdCallbackTokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, err
+	}
+	token := hex.EncodeToString(b)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	ch := make(chan *RpcdCallback, 1)
+	c.pending[token] = ch
+	return token, ch, nil
+}
+
+// unregister removes the pending proof request of the given callback token.
+func (c *RpcdCallbacks) unregister(token string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.pending, token)
+}
+
+// ServeHTTP implements the http.Handler interface. A callback is only delivered to the first matching
+// pending request, the duplicated callbacks and the ones of the unknown tokens are responded with 404.
+func (c *RpcdCallbacks) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var callback RpcdCallback
+	if err := json.NewDecoder(r.Body).Decode(&callback); err != nil || len(callback.Token) == 0 {
+		http.Error(w, "invalid proof callback", http.StatusBadRequest)
+		return
+	}
+
+	c.mutex.Lock()
+	ch, ok := c.pending[callback.Token]
+	delete(c.pending, callback.Token)
+	c.mutex.Unlock()
+
+	if !ok {
+		log.Debug("Ignore proof callback of unknown or completed request", "remote", r.RemoteAddr)
+		http.Error(w, "unknown callback token", http.StatusNotFound)
+		return
+	}
+
+	ch <- &callback
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,183 @@
+package producer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCallbackRpcd is a proverd stub in callback mode, which acknowledges the proof requests at once, and
+// records their callback tokens by block height.
+type fakeCallbackRpcd struct {
+	mutex     sync.Mutex
+	tokens    map[uint64]string
+	cancelled int
+}
+
+func (f *fakeCallbackRpcd) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body RequestProofBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if body.Method != "proof" {
+		f.cancelled++
+	} else {
+		f.tokens[body.Params[0].Block.Uint64()] = body.Params[0].CallbackToken
+	}
+	_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":null}`))
+}
+
+// token waits until the proof request of the given height is received, and returns its callback token.
+func (f *fakeCallbackRpcd) token(t *testing.T, height uint64) string {
+	var token string
+	require.Eventually(t, func() bool {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+
+		token = f.tokens[height]
+		return len(token) != 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return token
+}
+
+// postCallback pushes a proof callback of the given token and proof, returns the response status code.
+func postCallback(t *testing.T, url string, token string, proof string) int {
+	callback := &RpcdCallback{Token: token, Result: new(RpcdOutput)}
+	callback.Result.Circuit.Proof = proof
+	callback.Result.Circuit.Degree = CircuitsDegree10Txs
+	body, err := json.Marshal(callback)
+	require.Nil(t, err)
+
+	res, err := http.Post(url, "application/json", bytes.NewReader(body))
+	require.Nil(t, err)
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	return res.StatusCode
+}
+
+func TestZkevmRpcdProducerCallbacks(t *testing.T) {
+	rpcd := &fakeCallbackRpcd{tokens: make(map[uint64]string)}
+	rpcdSrv := httptest.NewServer(rpcd)
+	defer rpcdSrv.Close()
+
+	var callbacks *RpcdCallbacks
+	callbackSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callbacks.ServeHTTP(w, r)
+	}))
+	defer callbackSrv.Close()
+	callbacks = NewRpcdCallbacks(callbackSrv.URL + RpcdCallbackPath)
+
+	producer, err := NewZkevmRpcdProducer(rpcdSrv.URL, "", "", "", false)
+	require.Nil(t, err)
+	producer.Callbacks = callbacks
+
+	type result struct {
+		proof []byte
+		err   error
+	}
+	results := make(map[uint64]chan *result)
+	for _, height := range []uint64{1, 2} {
+		resultCh := make(chan *result, 1)
+		results[height] = resultCh
+		go func(height uint64) {
+			proof, _, err := producer.callProverDaemon(
+				context.Background(), &ProofRequestOptions{Height: new(big.Int).SetUint64(height)},
+			)
+			resultCh <- &result{proof, err}
+		}(height)
+	}
+
+	// Out of order callbacks.
+	token1, token2 := rpcd.token(t, 1), rpcd.token(t, 2)
+	require.Equal(t, http.StatusOK, postCallback(t, callbackSrv.URL, token2, "0x02"))
+	res := <-results[2]
+	require.Nil(t, res.err)
+	require.Equal(t, []byte{0x02}, res.proof)
+
+	require.Equal(t, http.StatusOK, postCallback(t, callbackSrv.URL, token1, "0x01"))
+	res = <-results[1]
+	require.Nil(t, res.err)
+	require.Equal(t, []byte{0x01}, res.proof)
+
+	// Duplicated and unknown callbacks.
+	require.Equal(t, http.StatusNotFound, postCallback(t, callbackSrv.URL, token1, "0x03"))
+	require.Equal(t, http.StatusNotFound, postCallback(t, callbackSrv.URL, "unknown", "0x03"))
+	require.Empty(t, results[1])
+	require.Empty(t, callbacks.pending)
+}
+
+func TestZkevmRpcdProducerUnsupportedBlockCallback(t *testing.T) {
+	rpcd := &fakeCallbackRpcd{tokens: make(map[uint64]string)}
+	rpcdSrv := httptest.NewServer(rpcd)
+	defer rpcdSrv.Close()
+
+	callbacks := NewRpcdCallbacks("http://localhost:9001" + RpcdCallbackPath)
+	producer, err := NewZkevmRpcdProducer(rpcdSrv.URL, "", "", "", false)
+	require.Nil(t, err)
+	producer.Callbacks = callbacks
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, _, err := producer.callProverDaemon(context.Background(), &ProofRequestOptions{Height: common.Big1})
+		errCh <- err
+	}()
+
+	body, err := json.Marshal(&RpcdCallback{Token: rpcd.token(t, 1), Error: "unsupported opcode: SELFDESTRUCT"})
+	require.Nil(t, err)
+	rec := httptest.NewRecorder()
+	callbacks.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, RpcdCallbackPath, bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	require.ErrorIs(t, <-errCh, ErrUnsupportedBlock)
+	require.Zero(t, rpcd.cancelled)
+}
+
+func TestZkevmRpcdProducerCallbackTimeout(t *testing.T) {
+	rpcd := &fakeCallbackRpcd{tokens: make(map[uint64]string)}
+	rpcdSrv := httptest.NewServer(rpcd)
+	defer rpcdSrv.Close()
+
+	producer, err := NewZkevmRpcdProducer(rpcdSrv.URL, "", "", "", false)
+	require.Nil(t, err)
+	producer.Callbacks = NewRpcdCallbacks("http://localhost:9001" + RpcdCallbackPath)
+	producer.CallbackTimeout = 200 * time.Millisecond
+	producer.CancelMethod = "cancel"
+
+	_, _, err = producer.callProverDaemon(context.Background(), &ProofRequestOptions{Height: common.Big256})
+	require.ErrorIs(t, err, ErrProofTimeout)
+	require.Equal(t, 1, rpcd.cancelled)
+	require.Empty(t, producer.Callbacks.pending)
+}
+
+func TestRpcdCallbacksInvalidRequest(t *testing.T) {
+	callbacks := NewRpcdCallbacks("")
+	srv := httptest.NewServer(callbacks)
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL)
+	require.Nil(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+
+	res, err = http.Post(srv.URL, "application/json", bytes.NewReader([]byte("{")))
+	require.Nil(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
@@ -0,0 +1,116 @@
+package producer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/taikoxyz/taiko-client/metrics"
+)
+
+// Categories of the errors of the requests sent to proverd, used by the metrics, see classifyRpcdError.
+const (
+	rpcdErrorConnectionRefused = "connectionRefused" // proverd is not listening
+	rpcdErrorTimeout           = "timeout"           // A request or a whole proof job timed out
+	rpcdErrorUnsupportedBlock  = "unsupportedBlock"  // proverd reported the block unsupported by its circuits
+	rpcdErrorRpcd              = "rpcd"              // proverd responded an error, an HTTP status or JSON-RPC error
+	rpcdErrorMalformedResponse = "malformedResponse" // proverd responded something undecodable
+	rpcdErrorCanceled          = "canceled"          // The request was cancelled by the prover, e.g. on shutdown
+	rpcdErrorOther             = "other"             // Any other error, e.g. a DNS or TLS error
+)
+
+// unsupportedBlockMessage is contained in the error messages of proverd for the blocks using an opcode or a
+// precompile which its circuits don't support, e.g. "unsupported opcode: SELFDESTRUCT".
+const unsupportedBlockMessage = "unsupported"
+
+// errMalformedRpcdResponse is returned when a response of proverd is decoded, but is not a valid one.
+var errMalformedRpcdResponse = errors.New("malformed proverd response")
+
+// rpcdOutstandingJobs is the number of the proof jobs currently waited by all proverd producers.
+var rpcdOutstandingJobs int64
+
+// RpcdError is an error responded by proverd, either a non-OK HTTP status, or a JSON-RPC error object.
+type RpcdError struct {
+	StatusCode int    // HTTP status code of the response, zero if pushed through a callback
+	Code       int    // JSON-RPC error code, zero if not a JSON-RPC error
+	Message    string // Error message, empty if a HTTP status error
+}
+
+// Error implements the error interface.
+func (e *RpcdError) Error() string {
+	switch {
+	case e.Code != 0:
+		return fmt.Sprintf("proverd error, code: %d, message: %s", e.Code, e.Message)
+	case len(e.Message) != 0:
+		return fmt.Sprintf("proverd error: %s", e.Message)
+	default:
+		return fmt.Sprintf("proverd error, statusCode: %d", e.StatusCode)
+	}
+}
+
+// isUnsupportedBlock checks whether the given error of proverd reports the block unsupported by its circuits,
+// which is permanent until proverd upgrades its circuits.
+func (e *RpcdError) isUnsupportedBlock() bool {
+	return strings.Contains(strings.ToLower(e.Message), unsupportedBlockMessage)
+}
+
+// classifyRpcdError returns the category of the given error of a request sent to proverd.
+func classifyRpcdError(err error) string {
+	var (
+		rpcdErr      *RpcdError
+		netErr       net.Error
+		syntaxErr    *json.SyntaxError
+		unmarshalErr *json.UnmarshalTypeError
+	)
+	switch {
+	case errors.Is(err, context.Canceled):
+		return rpcdErrorCanceled
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return rpcdErrorConnectionRefused
+	case errors.Is(err, ErrProofTimeout),
+		errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return rpcdErrorTimeout
+	case errors.Is(err, ErrUnsupportedBlock):
+		return rpcdErrorUnsupportedBlock
+	case errors.As(err, &rpcdErr), errors.Is(err, ErrInvalidProofRequest):
+		return rpcdErrorRpcd
+	case errors.Is(err, errMalformedRpcdResponse),
+		errors.As(err, &syntaxErr),
+		errors.As(err, &unmarshalErr):
+		return rpcdErrorMalformedResponse
+	default:
+		return rpcdErrorOther
+	}
+}
+
+// recordRpcdRequest records the result of a proof request sent to proverd.
+func recordRpcdRequest(err error) {
+	metrics.ProverRpcdRequestsCounter.Inc(1)
+	if err != nil {
+		metrics.ProverRpcdRequestErrorCounter(classifyRpcdError(err)).Inc(1)
+	}
+}
+
+// trackRpcdJob marks a proof job waited from proverd as outstanding, and returns the function recording
+// its result once it is done.
+func trackRpcdJob() func(err error) {
+	metrics.ProverRpcdOutstandingJobsGauge.Update(atomic.AddInt64(&rpcdOutstandingJobs, 1))
+	start := time.Now()
+
+	return func(err error) {
+		metrics.ProverRpcdOutstandingJobsGauge.Update(atomic.AddInt64(&rpcdOutstandingJobs, -1))
+		if err != nil {
+			metrics.ProverRpcdProofFailureCounter(classifyRpcdError(err)).Inc(1)
+			return
+		}
+		metrics.ProverRpcdProofSuccessCounter.Inc(1)
+		metrics.ProverRpcdProofLatencyHistogram.Update(int64(time.Since(start).Seconds()))
+	}
+}
@@ -0,0 +1,94 @@
+package producer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyRpcdError(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		err      error
+		category string
+	}{
+		{"canceled", fmt.Errorf("poll: %w", context.Canceled), rpcdErrorCanceled},
+		{"deadline", context.DeadlineExceeded, rpcdErrorTimeout},
+		{"proofTimeout", fmt.Errorf("%w, height: 1", ErrProofTimeout), rpcdErrorTimeout},
+		{"statusCode", fmt.Errorf("failed: %w", &RpcdError{StatusCode: http.StatusBadGateway}), rpcdErrorRpcd},
+		{"errorCode", &RpcdError{StatusCode: http.StatusOK, Code: -32000, Message: "boom"}, rpcdErrorRpcd},
+		{"invalidRequest", fmt.Errorf("%w, statusCode: 400", ErrInvalidProofRequest), rpcdErrorRpcd},
+		{"unsupportedBlock", fmt.Errorf("%w, id: 1", ErrUnsupportedBlock), rpcdErrorUnsupportedBlock},
+		{"malformed", fmt.Errorf("%w, empty proof", errMalformedRpcdResponse), rpcdErrorMalformedResponse},
+		{"other", errors.New("no such host"), rpcdErrorOther},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.category, classifyRpcdError(tc.err))
+		})
+	}
+}
+
+func TestClassifyRpcdRequestErrors(t *testing.T) {
+	var (
+		response string
+		delay    time.Duration
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		_, _ = w.Write([]byte(response))
+	}))
+	defer srv.Close()
+
+	opts := &ProofRequestOptions{Height: common.Big1}
+	request := func(endpoint string, timeout time.Duration) error {
+		producer, err := NewZkevmRpcdProducer(endpoint, "", "", "", false)
+		require.Nil(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		_, err = producer.requestProof(ctx, producer.newRequestBody(common.Big1, "proof", opts), opts)
+		return err
+	}
+
+	response = `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"witness generation failed"}}`
+	err := request(srv.URL, time.Second)
+	var rpcdErr *RpcdError
+	require.ErrorAs(t, err, &rpcdErr)
+	require.Equal(t, -32000, rpcdErr.Code)
+	require.Equal(t, rpcdErrorRpcd, classifyRpcdError(err))
+
+	response = `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"unsupported precompile: 0x09"}}`
+	err = request(srv.URL, time.Second)
+	require.ErrorIs(t, err, ErrUnsupportedBlock)
+	require.Equal(t, rpcdErrorUnsupportedBlock, classifyRpcdError(err))
+
+	response = `{"jsonrpc":"2.0","id":1,"result":`
+	require.Equal(t, rpcdErrorMalformedResponse, classifyRpcdError(request(srv.URL, time.Second)))
+
+	response = `{"jsonrpc":"2.0","id":1,"result":{"circuit":{"proof":""}}}`
+	require.Equal(t, rpcdErrorMalformedResponse, classifyRpcdError(request(srv.URL, time.Second)))
+
+	response = `{"jsonrpc":"2.0","id":1,"result":null}`
+	require.Nil(t, request(srv.URL, time.Second))
+
+	delay = 100 * time.Millisecond
+	require.Equal(t, rpcdErrorTimeout, classifyRpcdError(request(srv.URL, 10*time.Millisecond)))
+
+	// A port nobody is listening on.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	require.Nil(t, l.Close())
+	require.Equal(
+		t,
+		rpcdErrorConnectionRefused,
+		classifyRpcdError(request("http://"+l.Addr().String(), time.Second)),
+	)
+}
@@ -0,0 +1,181 @@
+package producer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/taikoxyz/taiko-client/metrics"
+)
+
+// defaultRpcdProgressPeriod is the default interval between the proof job progress polls.
+const defaultRpcdProgressPeriod = 30 * time.Second
+
+// ProofProgress is the progress of a proverd proof job, reported by the proverd progress method.
+type ProofProgress struct {
+	BlockID    uint64        // ID of the proved block, which is also its height
+	Endpoint   string        // Endpoint of the proverd service generating the proof
+	Phase      string        // Name of the current proving phase
+	Percent    float64       // Overall completion percentage
+	Elapsed    time.Duration // Time spent on the job by proverd
+	ReportedAt time.Time     // Last time the phase or the percentage changed
+	Stalled    bool          // Whether no progress has been reported for the stall threshold
+	Done       bool          // Whether the job is done, successful or not, and no more progress will be reported
+}
+
+// rpcdProgressResponse represents the JSON body of the response of the progress requests.
+type rpcdProgressResponse struct {
+	Result *struct {
+		Phase   string  `json:"phase"`
+		Percent float64 `json:"percent"`
+		Elapsed float64 `json:"elapsed"` // In seconds
+	} `json:"result"`
+}
+
+// waitProverDaemonWithProgress works like waitProverDaemon, while the job's progress is polled and reported
+// in background. A job stalled for the stall threshold fails with ErrProofTimeout if StallTimeout is set.
+func (d *ZkevmRpcdProducer) waitProverDaemonWithProgress(
+	ctx context.Context,
+	requestID *big.Int,
+	opts *ProofRequestOptions,
+) ([]byte, uint64, error) {
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		stalled bool
+		done    = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+		stalled = d.watchProgress(jobCtx, requestID, opts, cancel)
+	}()
+
+	proof, degree, err := d.waitProofResult(jobCtx, requestID, opts)
+	cancel()
+	<-done
+
+	if err != nil && ctx.Err() == nil && stalled {
+		return nil, 0, fmt.Errorf(
+			"%w, proof job stalled, height: %d, endpoint: %s, threshold: %s",
+			ErrProofTimeout, opts.Height, d.RpcdEndpoint, d.StallThreshold,
+		)
+	}
+
+	return proof, degree, err
+}
+
+// watchProgress polls the progress of the given proof job until the given context is cancelled, and reports
+// each polled progress through OnProgress. Returns whether the job has been cancelled through the given
+// cancel function, since it stalled.
+func (d *ZkevmRpcdProducer) watchProgress(
+	ctx context.Context,
+	requestID *big.Int,
+	opts *ProofRequestOptions,
+	cancel context.CancelFunc,
+) bool {
+	var (
+		logger   = LoggerFromContext(ctx).New("rpcdRequestID", requestID)
+		ticker   = time.NewTicker(durationOrDefault(d.ProgressPeriod, defaultRpcdProgressPeriod))
+		progress = &ProofProgress{BlockID: opts.Height.Uint64(), Endpoint: d.RpcdEndpoint, ReportedAt: time.Now()}
+	)
+	defer func() {
+		ticker.Stop()
+		d.reportProgress(&ProofProgress{BlockID: progress.BlockID, Endpoint: d.RpcdEndpoint, Done: true})
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+
+		pollCtx, cancelPoll := context.WithTimeout(ctx, durationOrDefault(d.PollTimeout, defaultRpcdPollTimeout))
+		latest, err := d.requestProgress(pollCtx, requestID, opts)
+		cancelPoll()
+		if ctx.Err() != nil {
+			return false
+		}
+
+		// The failed polls count as no progress reported.
+		if err != nil {
+			logger.Debug("Failed to request proof progress", "height", opts.Height, "error", err)
+		} else if latest != nil && (latest.Phase != progress.Phase || latest.Percent != progress.Percent) {
+			progress = &ProofProgress{
+				BlockID:    progress.BlockID,
+				Endpoint:   d.RpcdEndpoint,
+				Phase:      latest.Phase,
+				Percent:    latest.Percent,
+				Elapsed:    latest.Elapsed,
+				ReportedAt: time.Now(),
+			}
+		} else if latest != nil {
+			progress.Elapsed = latest.Elapsed
+		}
+
+		if d.StallThreshold != 0 && time.Since(progress.ReportedAt) >= d.StallThreshold && !progress.Stalled {
+			logger.Warn(
+				"Proof job reported no progress",
+				"height", opts.Height,
+				"phase", progress.Phase,
+				"percent", progress.Percent,
+				"since", progress.ReportedAt,
+				"endpoint", d.RpcdEndpoint,
+			)
+			metrics.ProverRpcdProofStalledCounter.Inc(1)
+			progress.Stalled = true
+		}
+
+		d.reportProgress(progress)
+		if progress.Stalled && d.StallTimeout {
+			cancel()
+			return true
+		}
+	}
+}
+
+// reportProgress reports a copy of the given progress through OnProgress, if set.
+func (d *ZkevmRpcdProducer) reportProgress(progress *ProofProgress) {
+	if d.OnProgress == nil {
+		return
+	}
+
+	copied := *progress
+	d.OnProgress(&copied)
+}
+
+// requestProgress requests the progress of the given proof job from proverd, returns nil if proverd reports
+// no progress of it yet.
+func (d *ZkevmRpcdProducer) requestProgress(
+	ctx context.Context,
+	requestID *big.Int,
+	opts *ProofRequestOptions,
+) (*ProofProgress, error) {
+	res, err := d.post(ctx, d.newRequestBody(requestID, d.ProgressMethod, opts))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to request proof progress, id: %d, statusCode: %d", opts.Height, res.StatusCode)
+	}
+
+	var output rpcdProgressResponse
+	if err := json.NewDecoder(res.Body).Decode(&output); err != nil {
+		return nil, fmt.Errorf("invalid proof progress response: %w", err)
+	}
+	if output.Result == nil {
+		return nil, nil
+	}
+
+	return &ProofProgress{
+		Phase:   output.Result.Phase,
+		Percent: output.Result.Percent,
+		Elapsed: time.Duration(output.Result.Elapsed * float64(time.Second)),
+	}, nil
+}
@@ -0,0 +1,144 @@
+package producer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestProgressServer creates a proverd server whose proof is generated after the given number of polls,
+// never if negative, and which reports the progress returned by the given function.
+func newTestProgressServer(t *testing.T, polls int, progress func() string, methods chan<- string) *httptest.Server {
+	var (
+		mutex    sync.Mutex
+		proofReq int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body RequestProofBody
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		if methods != nil {
+			select {
+			case methods <- body.Method:
+			default:
+			}
+		}
+
+		switch body.Method {
+		case "progress":
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + progress() + `}`))
+		case "proof":
+			mutex.Lock()
+			proofReq++
+			generated := polls >= 0 && proofReq > polls
+			mutex.Unlock()
+			if !generated {
+				_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":null}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"circuit":{"proof":"0xff","k":10}}}`))
+		default:
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":null}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestZkevmRpcdProducerProgress(t *testing.T) {
+	var (
+		mutex      sync.Mutex
+		percent    int
+		progresses []*ProofProgress
+	)
+	srv := newTestProgressServer(t, 10, func() string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		percent += 10
+		return fmt.Sprintf(`{"phase":"witness","percent":%d,"elapsed":%d}`, percent, percent)
+	}, nil)
+
+	producer, err := NewZkevmRpcdProducer(srv.URL, "", "", "", false)
+	require.Nil(t, err)
+	producer.PollInterval = 10 * time.Millisecond
+	producer.ProgressMethod = "progress"
+	producer.ProgressPeriod = 5 * time.Millisecond
+	producer.OnProgress = func(progress *ProofProgress) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		progresses = append(progresses, progress)
+	}
+
+	proof, degree, err := producer.callProverDaemon(context.Background(), &ProofRequestOptions{Height: common.Big256})
+	require.Nil(t, err)
+	require.Equal(t, []byte{0xff}, proof)
+	require.Equal(t, uint64(10), degree)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	require.GreaterOrEqual(t, len(progresses), 2)
+	for _, progress := range progresses[:len(progresses)-1] {
+		require.Equal(t, uint64(256), progress.BlockID)
+		require.Equal(t, "witness", progress.Phase)
+		require.Greater(t, progress.Percent, float64(0))
+		require.Equal(t, time.Duration(progress.Percent)*time.Second, progress.Elapsed)
+		require.False(t, progress.Stalled)
+		require.False(t, progress.Done)
+	}
+	require.True(t, progresses[len(progresses)-1].Done)
+}
+
+func TestZkevmRpcdProducerStalled(t *testing.T) {
+	methods := make(chan string, 1024)
+	srv := newTestProgressServer(t, -1, func() string { return `{"phase":"witness","percent":10,"elapsed":1}` }, methods)
+
+	producer, err := NewZkevmRpcdProducer(srv.URL, "", "", "", false)
+	require.Nil(t, err)
+	producer.PollInterval = 10 * time.Millisecond
+	producer.CancelMethod = "cancel"
+	producer.ProgressMethod = "progress"
+	producer.ProgressPeriod = 5 * time.Millisecond
+	producer.StallThreshold = 50 * time.Millisecond
+
+	var (
+		mutex   sync.Mutex
+		stalled bool
+	)
+	producer.OnProgress = func(progress *ProofProgress) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		stalled = stalled || progress.Stalled
+	}
+
+	// Only warned without the stall timeout.
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, _, err = producer.callProverDaemon(ctx, &ProofRequestOptions{Height: common.Big256})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	mutex.Lock()
+	require.True(t, stalled)
+	mutex.Unlock()
+
+	// Failed as timed out with the stall timeout, and the job is cancelled.
+	producer.StallTimeout = true
+	start := time.Now()
+	_, _, err = producer.callProverDaemon(context.Background(), &ProofRequestOptions{Height: common.Big256})
+	require.ErrorIs(t, err, ErrProofTimeout)
+	require.Less(t, time.Since(start), 5*time.Second)
+
+	var cancelled bool
+	for len(methods) != 0 {
+		if <-methods == "cancel" {
+			cancelled = true
+		}
+	}
+	require.True(t, cancelled)
+}
@@ -0,0 +1,76 @@
+package producer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/metrics"
+)
+
+// defaultRpcdQueueDepth is the default capacity of the job queue of a ZkevmRpcdProducer.
+const defaultRpcdQueueDepth = 64
+
+// QueuedJob is a proof job queued in a ZkevmRpcdProducer, waiting for a worker to send it to proverd.
+type QueuedJob struct {
+	Opts    *ProofRequestOptions
+	BlockID *big.Int
+	Meta    *bindings.TaikoDataBlockMetadata
+	Header  *types.Header
+
+	ctx      context.Context // Context of the original request, the job is dropped once it is cancelled
+	resultCh chan *ProofWithHeader
+}
+
+// enqueueJob queues the given job, the workers are started on the first job.
+func (d *ZkevmRpcdProducer) enqueueJob(job *QueuedJob) error {
+	d.queueOnce.Do(d.startQueueWorkers)
+
+	select {
+	case d.queue <- job:
+		metrics.ProverRpcdQueueDepthGauge.Update(int64(len(d.queue)))
+		LoggerFromContext(job.ctx).Info("Proof job queued", "blockID", job.BlockID, "queued", len(d.queue))
+		return nil
+	default:
+		metrics.ProverRpcdQueueFullCounter.Inc(1)
+		return fmt.Errorf("%w, blockID: %d, depth: %d", ErrProducerQueueFull, job.BlockID, cap(d.queue))
+	}
+}
+
+// startQueueWorkers creates the job queue and starts its workers.
+func (d *ZkevmRpcdProducer) startQueueWorkers() {
+	depth := d.QueueDepth
+	if depth <= 0 {
+		depth = defaultRpcdQueueDepth
+	}
+	d.queue = make(chan *QueuedJob, depth)
+
+	for i := 0; i < d.QueueWorkers; i++ {
+		go d.runQueueWorker()
+	}
+}
+
+// runQueueWorker keeps sending the queued jobs to proverd one by one, and waiting for their proofs.
+func (d *ZkevmRpcdProducer) runQueueWorker() {
+	for job := range d.queue {
+		metrics.ProverRpcdQueueDepthGauge.Update(int64(len(d.queue)))
+
+		// The block might have been verified while its job was queued.
+		if job.ctx.Err() != nil {
+			continue
+		}
+
+		err := d.produceProof(job.ctx, job.Opts, job.BlockID, job.Meta, job.Header, job.resultCh)
+		if err == nil || job.ctx.Err() != nil {
+			continue
+		}
+
+		LoggerFromContext(job.ctx).Error("Queued proof job failed", "blockID", job.BlockID, "error", err)
+		metrics.ProverRpcdQueuedJobFailedCounter.Inc(1)
+		if d.OnJobFailed != nil {
+			d.OnJobFailed(job, err)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package producer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+func TestZkevmRpcdProducerQueue(t *testing.T) {
+	var (
+		release  = make(chan struct{})
+		started  = make(chan struct{}, 4)
+		failedCh = make(chan *QueuedJob, 1)
+		resultCh = make(chan *ProofWithHeader, 4)
+		failNext bool
+	)
+	producer, err := NewZkevmRpcdProducer("http://localhost:18545", "", "", "", false)
+	require.Nil(t, err)
+	producer.QueueWorkers = 1
+	producer.QueueDepth = 1
+	producer.CustomProofHook = func() ([]byte, uint64, error) {
+		started <- struct{}{}
+		<-release
+		if failNext {
+			return nil, 0, errors.New("proverd down")
+		}
+		return []byte{0xff}, CircuitsDegree10Txs, nil
+	}
+	producer.OnJobFailed = func(job *QueuedJob, err error) { failedCh <- job }
+	request := func(ctx context.Context, blockID int64) error {
+		return producer.RequestProof(
+			ctx,
+			&ProofRequestOptions{},
+			big.NewInt(blockID),
+			&bindings.TaikoDataBlockMetadata{},
+			&types.Header{Number: big.NewInt(blockID), Difficulty: big.NewInt(0)},
+			resultCh,
+		)
+	}
+
+	// The first job is being sent by the only worker, the second one is queued, and then the queue is full.
+	require.Nil(t, request(context.Background(), 1))
+	<-started
+	require.Nil(t, request(context.Background(), 2))
+	require.ErrorIs(t, request(context.Background(), 3), ErrProducerQueueFull)
+
+	release <- struct{}{}
+	require.Equal(t, uint64(1), (<-resultCh).BlockID.Uint64())
+	<-started
+	release <- struct{}{}
+	require.Equal(t, uint64(2), (<-resultCh).BlockID.Uint64())
+
+	// A job cancelled while queued is dropped.
+	require.Nil(t, request(context.Background(), 4))
+	<-started
+	ctx, cancel := context.WithCancel(context.Background())
+	require.Nil(t, request(ctx, 5))
+	cancel()
+	failNext = true
+	release <- struct{}{}
+	require.Equal(t, uint64(4), (<-failedCh).BlockID.Uint64())
+
+	select {
+	case <-started:
+		t.Fatal("cancelled job sent")
+	case <-time.After(50 * time.Millisecond):
+	}
+	require.Empty(t, resultCh)
+}
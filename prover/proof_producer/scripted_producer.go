@@ -0,0 +1,186 @@
+package producer
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+// ErrScriptedFailure is the default error returned by the scripted failing proof requests.
+var ErrScriptedFailure = errors.New("scripted proof request failure")
+
+// corruptedProofDegree is the circuits degree of the corrupted proofs, which is not a valid one.
+const corruptedProofDegree = 0
+
+// BlockPlan is the scripted behaviour of the proof requests of a block.
+type BlockPlan struct {
+	Failures  int           // Number of the first requests failing with Err
+	Err       error         // Error of the failing requests, ErrScriptedFailure if nil
+	Delay     time.Duration // Delay before the proof is delivered
+	Corrupted bool          // Whether to deliver a corrupted proof, rejected by the submitters' validation
+	After     []uint64      // Blocks whose proofs must be delivered before this one's
+}
+
+// FailNTimesThenSucceed returns a plan whose first n requests fail, and the next one succeeds.
+func FailNTimesThenSucceed(n int) *BlockPlan {
+	return &BlockPlan{Failures: n}
+}
+
+// AlwaysFail returns a plan whose requests always fail with the given error.
+func AlwaysFail(err error) *BlockPlan {
+	return &BlockPlan{Failures: math.MaxInt, Err: err}
+}
+
+// DelayedProof returns a plan whose proof is delivered after the given delay, e.g. to trigger the
+// timeouts and the cancellations.
+func DelayedProof(delay time.Duration) *BlockPlan {
+	return &BlockPlan{Delay: delay}
+}
+
+// CorruptedProof returns a plan which delivers a corrupted proof.
+func CorruptedProof() *BlockPlan {
+	return &BlockPlan{Corrupted: true}
+}
+
+// DeliverAfter returns a plan whose proof is delivered only after the proofs of the given blocks, so that
+// the proofs are completed out of order.
+func DeliverAfter(blockIDs ...uint64) *BlockPlan {
+	return &BlockPlan{After: blockIDs}
+}
+
+// ScriptedProducer is a proof producer for the tests, which behaves by the given per-block plans, so that
+// the error paths of the prover can be exercised deterministically. The blocks without a plan are proved
+// right away, like NewZeroDelayDummyProofProducer.
+type ScriptedProducer struct {
+	mutex     sync.Mutex
+	plans     map[uint64]*BlockPlan
+	requests  map[uint64]int
+	delivered map[uint64]chan struct{} // Closed once the block's proof is delivered
+}
+
+// NewScriptedProducer creates a new ScriptedProducer instance with the given per-block plans.
+func NewScriptedProducer(plans map[uint64]*BlockPlan) *ScriptedProducer {
+	if plans == nil {
+		plans = make(map[uint64]*BlockPlan)
+	}
+
+	return &ScriptedProducer{
+		plans:     plans,
+		requests:  make(map[uint64]int),
+		delivered: make(map[uint64]chan struct{}),
+	}
+}
+
+// SetPlan replaces the plan of the given block.
+func (p *ScriptedProducer) SetPlan(blockID uint64, plan *BlockPlan) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.plans[blockID] = plan
+}
+
+// Requests returns the number of the proof requests of the given block so far.
+func (p *ScriptedProducer) Requests(blockID uint64) int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.requests[blockID]
+}
+
+// RequestProof implements the ProofProducer interface.
+func (p *ScriptedProducer) RequestProof(
+	ctx context.Context,
+	opts *ProofRequestOptions,
+	blockID *big.Int,
+	meta *bindings.TaikoDataBlockMetadata,
+	header *types.Header,
+	resultCh chan *ProofWithHeader,
+) error {
+	logger := LoggerFromContext(ctx)
+
+	p.mutex.Lock()
+	p.requests[blockID.Uint64()]++
+	attempt := p.requests[blockID.Uint64()]
+	plan := p.plans[blockID.Uint64()]
+	if plan == nil {
+		plan = new(BlockPlan)
+	}
+	var after []chan struct{}
+	for _, id := range plan.After {
+		after = append(after, p.deliveredCh(id))
+	}
+	delivered := p.deliveredCh(blockID.Uint64())
+	p.mutex.Unlock()
+
+	if attempt <= plan.Failures {
+		logger.Info("Scripted proof request failure", "blockID", blockID, "attempt", attempt)
+		if plan.Err != nil {
+			return plan.Err
+		}
+		return ErrScriptedFailure
+	}
+
+	go func() {
+		for _, ch := range after {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ch:
+			}
+		}
+
+		delay := time.NewTimer(plan.Delay)
+		defer delay.Stop()
+		select {
+		case <-ctx.Done():
+			logger.Info("Scripted proof request cancelled", "blockID", blockID)
+			return
+		case <-delay.C:
+		}
+
+		proof := &ProofWithHeader{
+			BlockID: blockID,
+			Meta:    meta,
+			Header:  header,
+			ZkProof: []byte{0xff},
+			Degree:  CircuitsDegree10Txs,
+			Logger:  logger,
+		}
+		if plan.Corrupted {
+			proof.ZkProof, proof.Degree = []byte{0xde, 0xad}, corruptedProofDegree
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case resultCh <- proof:
+		}
+
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+		select {
+		case <-delivered:
+		default:
+			close(delivered)
+		}
+	}()
+
+	return nil
+}
+
+// deliveredCh returns the channel closed once the given block's proof is delivered, the caller must hold
+// the mutex.
+func (p *ScriptedProducer) deliveredCh(blockID uint64) chan struct{} {
+	ch, ok := p.delivered[blockID]
+	if !ok {
+		ch = make(chan struct{})
+		p.delivered[blockID] = ch
+	}
+
+	return ch
+}
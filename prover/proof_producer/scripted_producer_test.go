@@ -0,0 +1,107 @@
+package producer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+func requestScriptedProof(
+	ctx context.Context,
+	p *ScriptedProducer,
+	blockID uint64,
+	resultCh chan *ProofWithHeader,
+) error {
+	return p.RequestProof(
+		ctx,
+		&ProofRequestOptions{},
+		new(big.Int).SetUint64(blockID),
+		&bindings.TaikoDataBlockMetadata{},
+		&types.Header{Number: new(big.Int).SetUint64(blockID)},
+		resultCh,
+	)
+}
+
+func TestScriptedProducerFailNTimesThenSucceed(t *testing.T) {
+	var (
+		p        = NewScriptedProducer(map[uint64]*BlockPlan{1: FailNTimesThenSucceed(2)})
+		resultCh = make(chan *ProofWithHeader, 1)
+	)
+
+	require.ErrorIs(t, requestScriptedProof(context.Background(), p, 1, resultCh), ErrScriptedFailure)
+	require.ErrorIs(t, requestScriptedProof(context.Background(), p, 1, resultCh), ErrScriptedFailure)
+	require.Nil(t, requestScriptedProof(context.Background(), p, 1, resultCh))
+	require.Equal(t, 3, p.Requests(1))
+
+	proof := <-resultCh
+	require.Equal(t, uint64(1), proof.BlockID.Uint64())
+	require.Equal(t, uint64(CircuitsDegree10Txs), proof.Degree)
+}
+
+func TestScriptedProducerAlwaysFail(t *testing.T) {
+	errCustom := errors.New("custom")
+	p := NewScriptedProducer(map[uint64]*BlockPlan{1: AlwaysFail(errCustom)})
+	for i := 0; i < 3; i++ {
+		require.ErrorIs(t, requestScriptedProof(context.Background(), p, 1, nil), errCustom)
+	}
+
+	// The blocks without a plan are proved right away.
+	resultCh := make(chan *ProofWithHeader, 1)
+	require.Nil(t, requestScriptedProof(context.Background(), p, 2, resultCh))
+	require.Equal(t, uint64(2), (<-resultCh).BlockID.Uint64())
+}
+
+func TestScriptedProducerCorruptedProof(t *testing.T) {
+	var (
+		p        = NewScriptedProducer(map[uint64]*BlockPlan{1: CorruptedProof()})
+		resultCh = make(chan *ProofWithHeader, 1)
+	)
+	require.Nil(t, requestScriptedProof(context.Background(), p, 1, resultCh))
+
+	_, err := DegreeToCircuitsIdx((<-resultCh).Degree)
+	require.ErrorContains(t, err, "invalid degree")
+}
+
+func TestScriptedProducerDelayedProofCancelled(t *testing.T) {
+	var (
+		p           = NewScriptedProducer(nil)
+		resultCh    = make(chan *ProofWithHeader, 1)
+		ctx, cancel = context.WithCancel(context.Background())
+	)
+	p.SetPlan(1, DelayedProof(time.Hour))
+
+	require.Nil(t, requestScriptedProof(ctx, p, 1, resultCh))
+	cancel()
+
+	select {
+	case <-resultCh:
+		t.Fatal("cancelled proof request delivered")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestScriptedProducerDeliverAfter(t *testing.T) {
+	var (
+		p = NewScriptedProducer(map[uint64]*BlockPlan{
+			1: DeliverAfter(3),
+			2: DeliverAfter(1),
+		})
+		resultCh = make(chan *ProofWithHeader, 3)
+	)
+
+	for _, id := range []uint64{1, 2, 3} {
+		require.Nil(t, requestScriptedProof(context.Background(), p, id, resultCh))
+	}
+
+	var order []uint64
+	for i := 0; i < 3; i++ {
+		order = append(order, (<-resultCh).BlockID.Uint64())
+	}
+	require.Equal(t, []uint64{3, 1, 2}, order)
+}
@@ -42,7 +42,8 @@ func (d *ZkevmCmdProducer) RequestProof(
 	header *types.Header,
 	resultCh chan *ProofWithHeader,
 ) error {
-	log.Info(
+	logger := LoggerFromContext(ctx)
+	logger.Info(
 		"Request proof from ZKEVM CMD",
 		"blockID", blockID,
 		"beneficiary", meta.Beneficiary,
@@ -56,22 +57,33 @@ func (d *ZkevmCmdProducer) RequestProof(
 		err   error
 	)
 	if err := backoff.Retry(func() error {
-		if proof, err = d.ExecProverCmd(opts.Height); err != nil {
-			log.Error("Execute prover cmd error", "error", err)
+		if proof, err = d.ExecProverCmd(ctx, opts.Height); err != nil {
+			if ctx.Err() != nil {
+				return backoff.Permanent(ctx.Err())
+			}
+			logger.Error("Execute prover cmd error", "error", err)
 			return err
 		}
 
 		return nil
-	}, backoff.NewConstantBackOff(3*time.Second)); err != nil {
-		log.Error("Failed to generate proof", "error", err)
+	}, backoff.WithContext(backoff.NewConstantBackOff(3*time.Second), ctx)); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		logger.Error("Failed to generate proof", "error", err)
 	}
 
-	resultCh <- &ProofWithHeader{
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case resultCh <- &ProofWithHeader{
 		BlockID: blockID,
 		Header:  header,
 		Meta:    meta,
 		ZkProof: proof,
 		Degree:  CircuitsDegree10Txs,
+		Logger:  logger,
+	}:
 	}
 
 	return nil
@@ -82,9 +94,11 @@ type ProverCmdOutput struct {
 	Proof     []byte   `json:"proof"`
 }
 
-func (d *ZkevmCmdProducer) ExecProverCmd(height *big.Int) ([]byte, error) {
+// ExecProverCmd executes the prover command for the given block, the command will be killed once the
+// given context is cancelled.
+func (d *ZkevmCmdProducer) ExecProverCmd(ctx context.Context, height *big.Int) ([]byte, error) {
 	start := time.Now()
-	cmd := exec.Command(d.CmdPath, d.L2Endpoint, height.String())
+	cmd := exec.CommandContext(ctx, d.CmdPath, d.L2Endpoint, height.String())
 
 	var stdout, stderr bytes.Buffer
 
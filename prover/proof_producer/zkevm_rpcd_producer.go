@@ -3,12 +3,19 @@ package producer
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"math/big"
+	"net"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -16,20 +23,80 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/metrics"
 )
 
 var (
 	errProofGenerating = errors.New("proof is generating")
 )
 
+const (
+	// queueDepthHeader is the response header of the proverd health path, which carries the number of
+	// the proof requests queued in the proverd cluster.
+	queueDepthHeader = "X-Queue-Depth"
+	// cancelProofTimeout is the timeout of the best-effort proof job cancellation request.
+	cancelProofTimeout = 5 * time.Second
+	// Default timeouts and interval of the requests sent to proverd.
+	defaultRpcdConnectTimeout = 10 * time.Second
+	defaultRpcdPollTimeout    = time.Minute
+	defaultRpcdPollInterval   = 10 * time.Second
+	// rpcdKeepAlive is the TCP keep-alive period of the connections to proverd, to detect the half-open ones.
+	rpcdKeepAlive = 30 * time.Second
+	// probeMethod is the cheap JSON-RPC method of proverd used to probe it, which returns the node information.
+	probeMethod = "info"
+)
+
+var _ CapacityProber = (*ZkevmRpcdProducer)(nil)
+
 // ZkevmRpcdProducer is responsible for requesting zk proofs from the given proverd endpoint.
 type ZkevmRpcdProducer struct {
-	RpcdEndpoint    string                         // a proverd RPC endpoint
-	Param           string                         // parameter file to use
-	L1Endpoint      string                         // a L1 node RPC endpoint
-	L2Endpoint      string                         // a L2 execution engine's RPC endpoint
-	Retry           bool                           // retry proof computation if error
-	CustomProofHook func() ([]byte, uint64, error) // only for testing purposes
+	RpcdEndpoint    string                          // a proverd RPC endpoint
+	Param           string                          // parameter file to use
+	Tiers           []*ParamsTier                   // circuit parameter tiers by max gas, the last one covers all blocks
+	L1Endpoint      string                          // a L1 node RPC endpoint
+	L2Endpoint      string                          // a L2 execution engine's RPC endpoint
+	Retry           bool                            // retry proof computation if error
+	HealthPath      string                          // health path of the proverd service, to probe its capacity
+	MaxQueueDepth   uint64                          // saturated at this queue depth, 0 means no limit
+	CancelMethod    string                          // JSON-RPC method to cancel a proof job, if proverd exposes one
+	ConnectTimeout  time.Duration                   // timeout of connecting to proverd, 0 means the default one
+	PollTimeout     time.Duration                   // timeout of each proof job poll, 0 means the default one
+	PollInterval    time.Duration                   // interval between the proof job polls, 0 means the default one
+	ProofTimeout    time.Duration                   // timeout of a whole proof job, 0 means no limit
+	Journal         *ProofJournal                   // journal of the in-flight proof jobs, to resume them after restarts
+	Callbacks       *RpcdCallbacks                  // if set, the proofs are pushed by proverd instead of long-polled
+	CallbackTimeout time.Duration                   // timeout of waiting for a proof callback, 0 means the default one
+	ProgressMethod  string                          // JSON-RPC method reporting a proof job's progress, if any
+	ProgressPeriod  time.Duration                   // interval between the progress polls, 0 means the default one
+	OnProgress      func(progress *ProofProgress)   // called with each polled progress, and once a job is done
+	StallThreshold  time.Duration                   // warn if a job reports no progress for that long, 0 means never
+	StallTimeout    bool                            // whether a stalled job fails with ErrProofTimeout
+	QueueWorkers    int                             // if set, the requests are queued and sent by this many workers
+	QueueDepth      int                             // capacity of the job queue, 0 means the default one
+	OnJobFailed     func(job *QueuedJob, err error) // called when a queued job fails, unless it is cancelled
+	Validation      *ProofValidation                // if set, the generated proofs are validated before delivered
+	CustomProofHook func() ([]byte, uint64, error)  // only for testing purposes
+
+	client         *http.Client
+	clientOnce     sync.Once
+	mutex          sync.Mutex
+	circuitVersion string // Reported by the latest successful probe
+	queue          chan *QueuedJob
+	queueOnce      sync.Once
+}
+
+// ParamsTier is a circuit parameter set of proverd, which proves the blocks using no more than MaxGas gas.
+type ParamsTier struct {
+	MaxGas     uint64
+	ParamsPath string
+}
+
+// Name returns the name of the tier used in the metrics and logs.
+func (t *ParamsTier) Name() string {
+	if t.MaxGas == math.MaxUint64 {
+		return "max"
+	}
+	return strconv.FormatUint(t.MaxGas, 10)
 }
 
 // RequestProofBody represents the JSON body for requesting the proof.
@@ -53,6 +120,8 @@ type RequestProofBodyParam struct {
 	Mock               bool     `json:"mock"`
 	Aggregate          bool     `json:"aggregate"`
 	Prover             string   `json:"prover"`
+	CallbackURL        string   `json:"callback_url,omitempty"`
+	CallbackToken      string   `json:"callback_token,omitempty"`
 }
 
 // RequestProofBodyResponse represents the JSON body of the response of the proof requests.
@@ -60,6 +129,10 @@ type RequestProofBodyResponse struct {
 	JsonRPC string      `json:"jsonrpc"`
 	ID      *big.Int    `json:"id"`
 	Result  *RpcdOutput `json:"result"`
+	Error   *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
 }
 
 // RpcdOutput represents the JSON body of RequestProofBodyResponse's `result` field.
@@ -71,24 +144,59 @@ type RpcdOutput struct {
 	} `json:"circuit"`
 }
 
-// NewZkevmRpcdProducer creates a new `ZkevmRpcdProducer` instance.
+// NewZkevmRpcdProducer creates a new `ZkevmRpcdProducer` instance, the given parameter file is used by the
+// blocks which are not covered by any of the given smaller circuit parameter tiers.
 func NewZkevmRpcdProducer(
 	rpcdEndpoint string,
 	param string,
 	l1Endpoint string,
 	l2Endpoint string,
 	retry bool,
+	tiers ...*ParamsTier,
 ) (*ZkevmRpcdProducer, error) {
+	sorted := make([]*ParamsTier, 0, len(tiers)+1)
+	for _, tier := range tiers {
+		if tier.MaxGas == 0 || len(tier.ParamsPath) == 0 {
+			return nil, fmt.Errorf("invalid circuit parameter tier: %d:%s", tier.MaxGas, tier.ParamsPath)
+		}
+		sorted = append(sorted, tier)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].MaxGas < sorted[j].MaxGas })
+	sorted = append(sorted, &ParamsTier{MaxGas: math.MaxUint64, ParamsPath: param})
+
 	return &ZkevmRpcdProducer{
 		RpcdEndpoint: rpcdEndpoint,
 		Param:        param,
+		Tiers:        sorted,
 		L1Endpoint:   l1Endpoint,
 		L2Endpoint:   l2Endpoint,
 		Retry:        retry,
 	}, nil
 }
 
-// RequestProof implements the ProofProducer interface.
+// selectTier returns the smallest circuit parameter tier covering the given gas used, or the largest one.
+func (d *ZkevmRpcdProducer) selectTier(gasUsed uint64) *ParamsTier {
+	for _, tier := range d.Tiers {
+		if gasUsed <= tier.MaxGas {
+			return tier
+		}
+	}
+
+	return d.largestTier()
+}
+
+// largestTier returns the largest circuit parameter tier, which covers all blocks.
+func (d *ZkevmRpcdProducer) largestTier() *ParamsTier {
+	if len(d.Tiers) == 0 {
+		return &ParamsTier{MaxGas: math.MaxUint64, ParamsPath: d.Param}
+	}
+
+	return d.Tiers[len(d.Tiers)-1]
+}
+
+// RequestProof implements the ProofProducer interface. If QueueWorkers is set, the job is queued and this
+// returns immediately, or with ErrProducerQueueFull if the queue is full, the queued job's failure is
+// reported through OnJobFailed.
 func (d *ZkevmRpcdProducer) RequestProof(
 	ctx context.Context,
 	opts *ProofRequestOptions,
@@ -97,7 +205,31 @@ func (d *ZkevmRpcdProducer) RequestProof(
 	header *types.Header,
 	resultCh chan *ProofWithHeader,
 ) error {
-	log.Info(
+	if d.QueueWorkers > 0 {
+		return d.enqueueJob(&QueuedJob{
+			ctx:      ctx,
+			Opts:     opts,
+			BlockID:  blockID,
+			Meta:     meta,
+			Header:   header,
+			resultCh: resultCh,
+		})
+	}
+
+	return d.produceProof(ctx, opts, blockID, meta, header, resultCh)
+}
+
+// produceProof requests the proof of the given block from proverd, and waits until it is generated.
+func (d *ZkevmRpcdProducer) produceProof(
+	ctx context.Context,
+	opts *ProofRequestOptions,
+	blockID *big.Int,
+	meta *bindings.TaikoDataBlockMetadata,
+	header *types.Header,
+	resultCh chan *ProofWithHeader,
+) error {
+	logger := LoggerFromContext(ctx)
+	logger.Info(
 		"Request proof from zkevm-chain proverd service",
 		"blockID", blockID,
 		"beneficiary", meta.Beneficiary,
@@ -105,76 +237,499 @@ func (d *ZkevmRpcdProducer) RequestProof(
 		"hash", header.Hash(),
 	)
 
-	var (
-		proof  []byte
-		degree uint64
-		err    error
-	)
-	if d.CustomProofHook != nil {
-		proof, degree, err = d.CustomProofHook()
-	} else {
-		proof, degree, err = d.callProverDaemon(ctx, opts)
+	// The job resumed on startup delivers the proof by itself, a new job is only requested if it fails.
+	if job := d.Journal.attachResumed(blockID.Uint64()); job != nil {
+		err := job.wait(ctx)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+		logger.Warn("Resumed proof job failed, request a new one", "blockID", blockID, "error", err)
+	}
+
+	// The smallest circuit covering the block is tried first, and the largest one if it fails, unless the
+	// block is unsupported, which no tier of the same circuits supports.
+	tier := d.selectTier(header.GasUsed)
+	logger.Info("Select circuit parameter tier", "blockID", blockID, "gasUsed", header.GasUsed, "tier", tier.Name())
+	metrics.ProverRpcdTierRequestCounter(tier.Name()).Inc(1)
+
+	proof, degree, err := d.requestTierProof(ctx, blockID, opts, meta, header, tier)
+	largest := d.largestTier()
+	if err != nil && ctx.Err() == nil && tier != largest && !errors.Is(err, ErrUnsupportedBlock) {
+		logger.Warn(
+			"Failed to request proof with circuit parameter tier, fall back to the largest one",
+			"blockID", blockID,
+			"tier", tier.Name(),
+			"error", err,
+		)
+		metrics.ProverRpcdTierFallbackCounter.Inc(1)
+		metrics.ProverRpcdTierRequestCounter(largest.Name()).Inc(1)
+		proof, degree, err = d.requestTierProof(ctx, blockID, opts, meta, header, largest)
 	}
 	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		return err
 	}
 
-	resultCh <- &ProofWithHeader{
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case resultCh <- &ProofWithHeader{
 		BlockID: blockID,
 		Header:  header,
 		Meta:    meta,
 		ZkProof: proof,
 		Degree:  degree,
+		Logger:  logger,
+	}:
 	}
 
 	return nil
 }
 
-// callProverDaemon keeps polling the proverd service to get the requested proof.
+// requestTierProof requests the proof of the given block with the given circuit parameter tier, and
+// validates the generated proof, so that an invalid one falls back to the largest tier like a failed request.
+func (d *ZkevmRpcdProducer) requestTierProof(
+	ctx context.Context,
+	blockID *big.Int,
+	opts *ProofRequestOptions,
+	meta *bindings.TaikoDataBlockMetadata,
+	header *types.Header,
+	tier *ParamsTier,
+) ([]byte, uint64, error) {
+	proof, degree, err := d.fetchTierProof(ctx, blockID, opts, meta, header, tier)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := d.validateProof(blockID, meta, header, proof, degree); err != nil {
+		return nil, 0, err
+	}
+
+	return proof, degree, nil
+}
+
+// fetchTierProof gets the proof of the given block with the given circuit parameter tier from proverd.
+func (d *ZkevmRpcdProducer) fetchTierProof(
+	ctx context.Context,
+	blockID *big.Int,
+	opts *ProofRequestOptions,
+	meta *bindings.TaikoDataBlockMetadata,
+	header *types.Header,
+	tier *ParamsTier,
+) ([]byte, uint64, error) {
+	if d.CustomProofHook != nil {
+		return d.CustomProofHook()
+	}
+
+	tierOpts := *opts
+	tierOpts.ParamsPath = tier.ParamsPath
+	if d.Journal != nil {
+		return d.callJournaledProverDaemon(ctx, blockID, &tierOpts, meta, header)
+	}
+
+	return d.callProverDaemon(ctx, &tierOpts)
+}
+
+// validateProof checks the given proof generated by proverd against the configured proof validation.
+func (d *ZkevmRpcdProducer) validateProof(
+	blockID *big.Int,
+	meta *bindings.TaikoDataBlockMetadata,
+	header *types.Header,
+	proof []byte,
+	degree uint64,
+) error {
+	err := d.Validation.Validate(&ProofWithHeader{
+		BlockID: blockID,
+		Meta:    meta,
+		Header:  header,
+		ZkProof: proof,
+		Degree:  degree,
+	})
+	if err != nil {
+		metrics.ProverInvalidProofCounter.Inc(1)
+	}
+
+	return err
+}
+
+// ResumeJobs resumes the proof jobs of this backend journaled in the previous runs, each job keeps polling
+// proverd in background, and delivers its proof to the given result channel once completed.
+func (d *ZkevmRpcdProducer) ResumeJobs(ctx context.Context, resultCh chan *ProofWithHeader) int {
+	var resumed int
+	for _, entry := range d.Journal.Entries() {
+		if entry.Endpoint != d.RpcdEndpoint {
+			continue
+		}
+
+		jobCtx, cancel := context.WithCancel(ctx)
+		job := d.Journal.startResumed(entry.BlockID, cancel)
+		logger := log.New("blockID", entry.BlockID, "rpcdRequestID", entry.RequestID)
+		logger.Info("Resume journaled proof job", "height", entry.Options.Height, "startedAt", entry.StartedAt)
+
+		go func(entry *JournalEntry) {
+			defer cancel()
+
+			proof, degree, err := d.pollJournaledProof(jobCtx, entry)
+			if err == nil {
+				err = d.validateProof(new(big.Int).SetUint64(entry.BlockID), entry.Meta, entry.Header, proof, degree)
+			}
+			if err == nil {
+				select {
+				case <-jobCtx.Done():
+					err = jobCtx.Err()
+				case resultCh <- &ProofWithHeader{
+					BlockID: new(big.Int).SetUint64(entry.BlockID),
+					Header:  entry.Header,
+					Meta:    entry.Meta,
+					ZkProof: proof,
+					Degree:  degree,
+					Logger:  logger,
+				}:
+				}
+			}
+			if err != nil {
+				logger.Warn("Resumed proof job failed", "error", err)
+			}
+			d.Journal.finishResumed(entry.BlockID, job, err)
+		}(entry)
+		resumed++
+	}
+
+	return resumed
+}
+
+// callJournaledProverDaemon works like callProverDaemon, with the proof job journaled while it is in
+// flight, the journaled job of the same block and options is continued with its original request ID.
+func (d *ZkevmRpcdProducer) callJournaledProverDaemon(
+	ctx context.Context,
+	blockID *big.Int,
+	opts *ProofRequestOptions,
+	meta *bindings.TaikoDataBlockMetadata,
+	header *types.Header,
+) ([]byte, uint64, error) {
+	entry := d.Journal.Get(blockID.Uint64())
+	if entry == nil || !entry.matches(d.RpcdEndpoint, opts) {
+		requestID, err := newRpcdRequestID()
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to generate request ID: %w", err)
+		}
+
+		entry = &JournalEntry{
+			BlockID:   blockID.Uint64(),
+			Endpoint:  d.RpcdEndpoint,
+			RequestID: requestID,
+			Options:   opts,
+			Meta:      meta,
+			Header:    header,
+			StartedAt: time.Now(),
+		}
+		if err := d.Journal.Put(entry); err != nil {
+			LoggerFromContext(ctx).Warn("Failed to journal proof job", "blockID", blockID, "error", err)
+		}
+	}
+
+	return d.pollJournaledProof(ctx, entry)
+}
+
+// pollJournaledProof polls the given journaled proof job, and removes it from the journal once done, the
+// jobs interrupted by a shutdown are kept, since the journal has been frozen.
+func (d *ZkevmRpcdProducer) pollJournaledProof(ctx context.Context, entry *JournalEntry) ([]byte, uint64, error) {
+	proof, degree, err := d.waitProverDaemon(ctx, entry.RequestID, entry.Options)
+	if deleteErr := d.Journal.Delete(entry.BlockID); deleteErr != nil {
+		LoggerFromContext(ctx).Warn("Failed to remove journaled proof job", "blockID", entry.BlockID, "error", deleteErr)
+	}
+
+	return proof, degree, err
+}
+
+// callProverDaemon keeps polling the proverd service, or waits for its callback, to get the requested proof,
+// until the given context is cancelled, then the proof job will be cancelled too if possible.
 func (d *ZkevmRpcdProducer) callProverDaemon(ctx context.Context, opts *ProofRequestOptions) ([]byte, uint64, error) {
+	requestID, err := newRpcdRequestID()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to generate request ID: %w", err)
+	}
+
+	return d.waitProverDaemon(ctx, requestID, opts)
+}
+
+// waitProverDaemon gets the proof of the given request ID in the configured mode, and reports the job's
+// progress if proverd exposes a progress method, see callProverDaemon.
+func (d *ZkevmRpcdProducer) waitProverDaemon(
+	ctx context.Context,
+	requestID *big.Int,
+	opts *ProofRequestOptions,
+) ([]byte, uint64, error) {
+	var (
+		proof  []byte
+		degree uint64
+		err    error
+		done   = trackRpcdJob()
+	)
+	if len(d.ProgressMethod) != 0 {
+		proof, degree, err = d.waitProverDaemonWithProgress(ctx, requestID, opts)
+	} else {
+		proof, degree, err = d.waitProofResult(ctx, requestID, opts)
+	}
+	done(err)
+
+	return proof, degree, err
+}
+
+// waitProofResult waits for the proof of the given request ID, through the callback or polling.
+func (d *ZkevmRpcdProducer) waitProofResult(
+	ctx context.Context,
+	requestID *big.Int,
+	opts *ProofRequestOptions,
+) ([]byte, uint64, error) {
+	if d.Callbacks != nil {
+		return d.awaitProofCallback(ctx, requestID, opts)
+	}
+
+	return d.pollProverDaemon(ctx, requestID, opts)
+}
+
+// pollProverDaemon polls the proof job of the given request ID, see callProverDaemon.
+func (d *ZkevmRpcdProducer) pollProverDaemon(
+	ctx context.Context,
+	requestID *big.Int,
+	opts *ProofRequestOptions,
+) ([]byte, uint64, error) {
+	// Polls of the same proof share one request ID, so that they can be correlated in proverd logs.
 	var (
+		logger = LoggerFromContext(ctx).New("rpcdRequestID", requestID)
 		proof  []byte
 		degree uint64
 		start  = time.Now()
 	)
 	if err := backoff.Retry(func() error {
-		if ctx.Err() != nil {
-			return nil
+		if d.ProofTimeout != 0 && time.Since(start) > d.ProofTimeout {
+			return backoff.Permanent(fmt.Errorf(
+				"%w, height: %d, endpoint: %s, elapsed: %s",
+				ErrProofTimeout, opts.Height, d.RpcdEndpoint, time.Since(start).Round(time.Second),
+			))
 		}
-		output, err := d.requestProof(opts)
+
+		// Each poll has its own timeout, so that a half-open connection only fails one poll.
+		pollCtx, cancel := context.WithTimeout(ctx, durationOrDefault(d.PollTimeout, defaultRpcdPollTimeout))
+		defer cancel()
+
+		output, err := d.requestProof(pollCtx, d.newRequestBody(requestID, "proof", opts), opts)
 		if err != nil {
-			log.Error("Failed to request proof", "height", opts.Height, "err", err, "endpoint", d.RpcdEndpoint)
+			if ctx.Err() != nil {
+				return backoff.Permanent(ctx.Err())
+			}
+			logger.Error("Failed to request proof", "height", opts.Height, "err", err, "endpoint", d.RpcdEndpoint)
+			if errors.Is(err, ErrInvalidProofRequest) {
+				return backoff.Permanent(err)
+			}
 			return err
 		}
 
-		log.Info("Request proof", "height", opts.Height, "output", output)
+		logger.Info("Request proof", "height", opts.Height, "output", output)
 
 		if output == nil {
 			return errProofGenerating
 		}
 		proof = common.Hex2Bytes(output.Circuit.Proof[2:])
 		degree = output.Circuit.Degree
-		log.Info("Proof generated", "height", opts.Height, "degree", degree, "time", time.Since(start))
+		logger.Info("Proof generated", "height", opts.Height, "degree", degree, "time", time.Since(start))
 		return nil
-	}, backoff.NewConstantBackOff(10*time.Second)); err != nil {
+	}, backoff.WithContext(
+		backoff.NewConstantBackOff(durationOrDefault(d.PollInterval, defaultRpcdPollInterval)),
+		ctx,
+	)); err != nil {
+		if ctx.Err() != nil {
+			// The journaled jobs interrupted by a shutdown keep running, to be resumed after the restart.
+			if !d.Journal.Frozen() {
+				d.cancelProof(requestID, opts, logger)
+			}
+			return nil, 0, ctx.Err()
+		}
+		if errors.Is(err, ErrProofTimeout) {
+			d.cancelProof(requestID, opts, logger)
+		}
 		return nil, 0, err
 	}
 	return proof, degree, nil
 }
 
-// requestProof sends a RPC request to proverd to try to get the requested proof.
-func (d *ZkevmRpcdProducer) requestProof(opts *ProofRequestOptions) (*RpcdOutput, error) {
-	reqBody := RequestProofBody{
+// awaitProofCallback sends the proof request of the given request ID to proverd with a new callback token,
+// and waits for the proof pushed to the callback URL, see callProverDaemon. The request is only resent on
+// errors, and the proof job is cancelled if its callback doesn't arrive before the callback timeout.
+func (d *ZkevmRpcdProducer) awaitProofCallback(
+	ctx context.Context,
+	requestID *big.Int,
+	opts *ProofRequestOptions,
+) ([]byte, uint64, error) {
+	token, callbackCh, err := d.Callbacks.register()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to generate callback token: %w", err)
+	}
+	defer d.Callbacks.unregister(token)
+
+	var (
+		logger  = LoggerFromContext(ctx).New("rpcdRequestID", requestID)
+		timeout = durationOrDefault(d.CallbackTimeout, defaultRpcdCallbackTimeout)
+		start   = time.Now()
+		output  *RpcdOutput
+	)
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	body := d.newRequestBody(requestID, "proof", opts)
+	body.Params[0].CallbackURL = d.Callbacks.URL
+	body.Params[0].CallbackToken = token
+
+	err = backoff.Retry(func() error {
+		reqCtx, cancel := context.WithTimeout(waitCtx, durationOrDefault(d.PollTimeout, defaultRpcdPollTimeout))
+		defer cancel()
+
+		if output, err = d.requestProof(reqCtx, body, opts); err != nil {
+			if waitCtx.Err() != nil {
+				return backoff.Permanent(waitCtx.Err())
+			}
+			logger.Error("Failed to request proof", "height", opts.Height, "err", err, "endpoint", d.RpcdEndpoint)
+			if errors.Is(err, ErrInvalidProofRequest) {
+				return backoff.Permanent(err)
+			}
+			return err
+		}
+		return nil
+	}, backoff.WithContext(
+		backoff.NewConstantBackOff(durationOrDefault(d.PollInterval, defaultRpcdPollInterval)),
+		waitCtx,
+	))
+
+	// A proof which has been generated already might be responded at once.
+	if err == nil && output == nil {
+		logger.Info("Proof requested, wait for callback", "height", opts.Height, "timeout", timeout)
+		select {
+		case <-waitCtx.Done():
+			err = waitCtx.Err()
+		case callback := <-callbackCh:
+			if rpcdErr := (&RpcdError{Message: callback.Error}); rpcdErr.isUnsupportedBlock() {
+				err = fmt.Errorf("%w, height: %d, %s", ErrUnsupportedBlock, opts.Height, rpcdErr)
+			} else if len(callback.Error) != 0 {
+				err = fmt.Errorf("%w, height: %d", rpcdErr, opts.Height)
+			} else if callback.Result == nil || len(callback.Result.Circuit.Proof) < 2 {
+				err = fmt.Errorf("%w, empty proof callback, height: %d", errMalformedRpcdResponse, opts.Height)
+			}
+			output = callback.Result
+		}
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			// The journaled jobs interrupted by a shutdown keep running, to be resumed after the restart.
+			if !d.Journal.Frozen() {
+				d.cancelProof(requestID, opts, logger)
+			}
+			return nil, 0, ctx.Err()
+		}
+		if waitCtx.Err() != nil {
+			d.cancelProof(requestID, opts, logger)
+			return nil, 0, fmt.Errorf(
+				"%w, no callback received, height: %d, endpoint: %s, elapsed: %s",
+				ErrProofTimeout, opts.Height, d.RpcdEndpoint, time.Since(start).Round(time.Second),
+			)
+		}
+		return nil, 0, err
+	}
+
+	degree := output.Circuit.Degree
+	logger.Info("Proof generated", "height", opts.Height, "degree", degree, "time", time.Since(start))
+	return common.Hex2Bytes(output.Circuit.Proof[2:]), degree, nil
+}
+
+// requestProof sends the given RPC request to proverd to try to get the requested proof, returns nil if
+// the proof is still being generated.
+func (d *ZkevmRpcdProducer) requestProof(
+	ctx context.Context,
+	body *RequestProofBody,
+	opts *ProofRequestOptions,
+) (output *RpcdOutput, err error) {
+	defer func() { recordRpcdRequest(err) }()
+
+	res, err := d.post(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		// The 4xx errors except 429 are caused by the request itself, which will never succeed.
+		if res.StatusCode >= 400 && res.StatusCode < 500 && res.StatusCode != http.StatusTooManyRequests {
+			return nil, fmt.Errorf("%w, id: %d, statusCode: %d", ErrInvalidProofRequest, opts.Height, res.StatusCode)
+		}
+		return nil, fmt.Errorf("failed to request proof, id: %d: %w", opts.Height, &RpcdError{StatusCode: res.StatusCode})
+	}
+
+	resBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var response RequestProofBodyResponse
+	if err := json.Unmarshal(resBytes, &response); err != nil {
+		return nil, err
+	}
+	if response.Error != nil {
+		rpcdErr := &RpcdError{StatusCode: res.StatusCode, Code: response.Error.Code, Message: response.Error.Message}
+		if rpcdErr.isUnsupportedBlock() {
+			return nil, fmt.Errorf("%w, id: %d, %s", ErrUnsupportedBlock, opts.Height, rpcdErr)
+		}
+		return nil, fmt.Errorf("failed to request proof, id: %d: %w", opts.Height, rpcdErr)
+	}
+	if response.Result != nil && len(response.Result.Circuit.Proof) < 2 {
+		return nil, fmt.Errorf("%w, empty proof, id: %d", errMalformedRpcdResponse, opts.Height)
+	}
+
+	return response.Result, nil
+}
+
+// cancelProof sends a best-effort request to proverd to cancel the given proof job, so that it won't keep
+// consuming the circuit workers, does nothing if proverd exposes no cancel method.
+func (d *ZkevmRpcdProducer) cancelProof(requestID *big.Int, opts *ProofRequestOptions, logger log.Logger) {
+	if len(d.CancelMethod) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cancelProofTimeout)
+	defer cancel()
+
+	res, err := d.post(ctx, d.newRequestBody(requestID, d.CancelMethod, opts))
+	if err != nil {
+		logger.Warn("Failed to cancel proof job", "height", opts.Height, "error", err)
+		return
+	}
+	defer res.Body.Close()
+
+	logger.Info("Proof job cancelled", "height", opts.Height, "statusCode", res.StatusCode)
+}
+
+// newRequestBody creates a new JSON-RPC request body of the given method for the given proof job.
+func (d *ZkevmRpcdProducer) newRequestBody(
+	requestID *big.Int,
+	method string,
+	opts *ProofRequestOptions,
+) *RequestProofBody {
+	param := d.Param
+	if len(opts.ParamsPath) != 0 {
+		param = opts.ParamsPath
+	}
+
+	return &RequestProofBody{
 		JsonRPC: "2.0",
-		ID:      common.Big1,
-		Method:  "proof",
+		ID:      requestID,
+		Method:  method,
 		Params: []*RequestProofBodyParam{{
 			Circuit:            "pi",
 			Block:              opts.Height,
 			L1RPC:              d.L1Endpoint,
 			L2RPC:              d.L2Endpoint,
 			Retry:              true,
-			Param:              d.Param,
+			Param:              param,
 			VerifyProof:        true,
 			Mock:               false,
 			Aggregate:          false,
@@ -182,31 +737,139 @@ func (d *ZkevmRpcdProducer) requestProof(opts *ProofRequestOptions) (*RpcdOutput
 			ProposeBlockTxHash: opts.ProposeBlockTxHash.Hex()[2:],
 		}},
 	}
+}
 
-	jsonValue, err := json.Marshal(reqBody)
+// post sends the given JSON-RPC request body to proverd with the given context.
+func (d *ZkevmRpcdProducer) post(ctx context.Context, body *RequestProofBody) (*http.Response, error) {
+	jsonValue, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := http.Post(d.RpcdEndpoint, "application/json", bytes.NewBuffer(jsonValue))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.RpcdEndpoint, bytes.NewBuffer(jsonValue))
 	if err != nil {
 		return nil, err
 	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return d.httpClient().Do(req)
+}
+
+// httpClient returns the HTTP client of the requests sent to proverd, which has a connect timeout and
+// TCP keep-alive enabled, the read timeouts are set by the requests' contexts.
+func (d *ZkevmRpcdProducer) httpClient() *http.Client {
+	d.clientOnce.Do(func() {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.DialContext = (&net.Dialer{
+			Timeout:   durationOrDefault(d.ConnectTimeout, defaultRpcdConnectTimeout),
+			KeepAlive: rpcdKeepAlive,
+		}).DialContext
+		d.client = &http.Client{Transport: transport}
+	})
+
+	return d.client
+}
+
+// durationOrDefault returns the given duration, or the default one if it is zero.
+func durationOrDefault(d time.Duration, defaultValue time.Duration) time.Duration {
+	if d == 0 {
+		return defaultValue
+	}
+	return d
+}
+
+// newRpcdRequestID generates a random JSON-RPC request ID for a proof request.
+func newRpcdRequestID() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).SetUint64(math.MaxUint32))
+}
 
+// Probe checks whether the proverd service is reachable and serving the JSON-RPC requests, through a cheap
+// `info` request, since the proof requests are only sent when the first block needs proving.
+func (d *ZkevmRpcdProducer) Probe(ctx context.Context) error {
+	requestID, err := newRpcdRequestID()
+	if err != nil {
+		return fmt.Errorf("failed to generate request ID: %w", err)
+	}
+
+	res, err := d.post(ctx, &RequestProofBody{
+		JsonRPC: "2.0",
+		ID:      requestID,
+		Method:  probeMethod,
+		Params:  []*RequestProofBodyParam{},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to probe proverd: %w", err)
+	}
 	defer res.Body.Close()
+
 	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to request proof, id: %d, statusCode: %d", opts.Height, res.StatusCode)
+		return fmt.Errorf("failed to probe proverd, statusCode: %d", res.StatusCode)
 	}
 
-	resBytes, err := io.ReadAll(res.Body)
+	var output struct {
+		Result *struct {
+			CircuitVersion string `json:"circuit_version"`
+		} `json:"result"`
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&output); err != nil {
+		return fmt.Errorf("invalid proverd probe response: %w", err)
+	}
+	if output.Error != nil {
+		return fmt.Errorf("proverd probe error, code: %d, message: %s", output.Error.Code, output.Error.Message)
+	}
+	if output.Result != nil {
+		d.mutex.Lock()
+		d.circuitVersion = output.Result.CircuitVersion
+		d.mutex.Unlock()
+	}
+
+	return nil
+}
+
+// CircuitVersion returns the circuit version of proverd reported by the latest successful probe, empty if
+// never probed, or proverd doesn't report one.
+func (d *ZkevmRpcdProducer) CircuitVersion() string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.circuitVersion
+}
+
+// Capacity implements the CapacityProber interface, it sends a HEAD request to the proverd service's
+// health path, the service is saturated if it responds 429 / 503, or its queue depth reported by the
+// `X-Queue-Depth` header reaches the maximum queue depth.
+func (d *ZkevmRpcdProducer) Capacity(ctx context.Context) (*BackendCapacity, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, strings.TrimSuffix(d.RpcdEndpoint, "/")+d.HealthPath, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	var output RequestProofBodyResponse
-	if err := json.Unmarshal(resBytes, &output); err != nil {
-		return nil, err
+	res, err := d.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to probe proverd capacity: %w", err)
+	}
+	defer res.Body.Close()
+
+	capacity := &BackendCapacity{
+		Saturated: res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusServiceUnavailable,
+	}
+	if !capacity.Saturated && res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to probe proverd capacity, statusCode: %d", res.StatusCode)
+	}
+
+	if value := res.Header.Get(queueDepthHeader); len(value) != 0 {
+		if capacity.QueueDepth, err = strconv.ParseUint(value, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid %s header %q: %w", queueDepthHeader, value, err)
+		}
+	}
+
+	if d.MaxQueueDepth != 0 && capacity.QueueDepth >= d.MaxQueueDepth {
+		capacity.Saturated = true
 	}
 
-	return output.Result, nil
+	return capacity, nil
 }
@@ -2,6 +2,14 @@ package producer
 
 import (
 	"context"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -52,3 +60,332 @@ func TestNewZkevmRpcdProducer(t *testing.T) {
 	require.Equal(t, res.Header, header)
 	require.NotEmpty(t, res.ZkProof)
 }
+
+func TestZkevmRpcdProducerInvalidProofRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	producer, err := NewZkevmRpcdProducer(srv.URL, "", "", "", false)
+	require.Nil(t, err)
+
+	_, _, err = producer.callProverDaemon(context.Background(), &ProofRequestOptions{Height: common.Big256})
+	require.ErrorIs(t, err, ErrInvalidProofRequest)
+}
+
+func TestZkevmRpcdProducerUnsupportedBlock(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		_, _ = w.Write([]byte(
+			`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"Unsupported opcode: SELFDESTRUCT"}}`,
+		))
+	}))
+	defer srv.Close()
+
+	producer, err := NewZkevmRpcdProducer(
+		srv.URL, "/params/large", "", "", false, &ParamsTier{MaxGas: 3_000_000, ParamsPath: "/params/small"},
+	)
+	require.Nil(t, err)
+	producer.PollInterval = time.Millisecond
+
+	// Neither polled again, nor fell back to the largest tier.
+	err = producer.RequestProof(
+		context.Background(),
+		&ProofRequestOptions{Height: common.Big256},
+		common.Big32,
+		&bindings.TaikoDataBlockMetadata{},
+		&types.Header{Number: common.Big256, GasUsed: 21000},
+		make(chan *ProofWithHeader, 1),
+	)
+	require.ErrorIs(t, err, ErrUnsupportedBlock)
+	require.ErrorIs(t, err, ErrInvalidProofRequest)
+	require.ErrorContains(t, err, "SELFDESTRUCT")
+	require.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestZkevmRpcdProducerParamsTiers(t *testing.T) {
+	_, err := NewZkevmRpcdProducer("", "/params/large", "", "", false, &ParamsTier{MaxGas: 0, ParamsPath: "/params/small"})
+	require.NotNil(t, err)
+
+	producer, err := NewZkevmRpcdProducer(
+		"",
+		"/params/large",
+		"",
+		"",
+		false,
+		&ParamsTier{MaxGas: 8_000_000, ParamsPath: "/params/medium"},
+		&ParamsTier{MaxGas: 3_000_000, ParamsPath: "/params/small"},
+	)
+	require.Nil(t, err)
+
+	require.Equal(t, "/params/small", producer.selectTier(0).ParamsPath)
+	require.Equal(t, "/params/small", producer.selectTier(3_000_000).ParamsPath)
+	require.Equal(t, "/params/medium", producer.selectTier(3_000_001).ParamsPath)
+	require.Equal(t, "/params/large", producer.selectTier(8_000_001).ParamsPath)
+	require.Equal(t, "max", producer.largestTier().Name())
+	require.Equal(t, "3000000", producer.selectTier(0).Name())
+}
+
+func TestZkevmRpcdProducerParamsTierFallback(t *testing.T) {
+	var (
+		mutex  sync.Mutex
+		params []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body RequestProofBody
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+
+		mutex.Lock()
+		params = append(params, body.Params[0].Param)
+		mutex.Unlock()
+
+		// The small circuit can't prove the block.
+		if body.Params[0].Param == "/params/small" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"circuit":{"proof":"0x01","k":19}}}`))
+	}))
+	defer srv.Close()
+
+	producer, err := NewZkevmRpcdProducer(
+		srv.URL, "/params/large", "", "", false, &ParamsTier{MaxGas: 3_000_000, ParamsPath: "/params/small"},
+	)
+	require.Nil(t, err)
+
+	resCh := make(chan *ProofWithHeader, 1)
+	require.Nil(t, producer.RequestProof(
+		context.Background(),
+		&ProofRequestOptions{Height: common.Big256},
+		common.Big32,
+		&bindings.TaikoDataBlockMetadata{},
+		&types.Header{Number: common.Big256, GasUsed: 21000},
+		resCh,
+	))
+	require.Equal(t, []byte{0x01}, (<-resCh).ZkProof)
+	require.Equal(t, []string{"/params/small", "/params/large"}, params)
+}
+
+func TestZkevmRpcdProducerCapacity(t *testing.T) {
+	var (
+		statusCode = http.StatusOK
+		queueDepth = "3"
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodHead, r.Method)
+		require.Equal(t, "/health", r.URL.Path)
+		w.Header().Set(queueDepthHeader, queueDepth)
+		w.WriteHeader(statusCode)
+	}))
+	defer srv.Close()
+
+	producer, err := NewZkevmRpcdProducer(srv.URL, "", "", "", false)
+	require.Nil(t, err)
+	producer.HealthPath = "/health"
+
+	// No maximum queue depth.
+	capacity, err := producer.Capacity(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, uint64(3), capacity.QueueDepth)
+	require.False(t, capacity.Saturated)
+
+	// Saturated by the queue depth.
+	producer.MaxQueueDepth = 3
+	capacity, err = producer.Capacity(context.Background())
+	require.Nil(t, err)
+	require.True(t, capacity.Saturated)
+
+	// Saturated by the status code.
+	producer.MaxQueueDepth = 0
+	statusCode = http.StatusServiceUnavailable
+	capacity, err = producer.Capacity(context.Background())
+	require.Nil(t, err)
+	require.True(t, capacity.Saturated)
+
+	// Unexpected responses.
+	statusCode = http.StatusInternalServerError
+	_, err = producer.Capacity(context.Background())
+	require.NotNil(t, err)
+
+	statusCode = http.StatusOK
+	queueDepth = "invalid"
+	_, err = producer.Capacity(context.Background())
+	require.ErrorContains(t, err, "invalid X-Queue-Depth header")
+}
+
+func TestZkevmRpcdProducerCancelled(t *testing.T) {
+	methods := make(chan string, 16)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body RequestProofBody
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		methods <- body.Method
+
+		// The proof is always generating.
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":null}`))
+	}))
+	defer srv.Close()
+
+	producer, err := NewZkevmRpcdProducer(srv.URL, "", "", "", false)
+	require.Nil(t, err)
+	producer.CancelMethod = "cancel"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		require.Equal(t, "proof", <-methods)
+		cancel()
+	}()
+
+	start := time.Now()
+	_, _, err = producer.callProverDaemon(ctx, &ProofRequestOptions{Height: common.Big256})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Less(t, time.Since(start), 5*time.Second)
+	require.Equal(t, "cancel", <-methods)
+}
+
+func TestZkevmRpcdProducerPollTimeout(t *testing.T) {
+	var polls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		_, _ = io.Copy(io.Discard, r.Body)
+		// The first poll hangs like a half-open connection.
+		if polls == 1 {
+			<-r.Context().Done()
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"circuit":{"instance":[],"proof":"0x00"}}}`))
+	}))
+	defer srv.Close()
+
+	producer, err := NewZkevmRpcdProducer(srv.URL, "", "", "", false)
+	require.Nil(t, err)
+	producer.PollTimeout = 100 * time.Millisecond
+	producer.PollInterval = 10 * time.Millisecond
+
+	_, _, err = producer.callProverDaemon(context.Background(), &ProofRequestOptions{Height: common.Big256})
+	require.Nil(t, err)
+	require.Equal(t, 2, polls)
+}
+
+func TestZkevmRpcdProducerProofTimeout(t *testing.T) {
+	methods := make(chan string, 1024)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body RequestProofBody
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		methods <- body.Method
+
+		// The proof is always generating.
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":null}`))
+	}))
+	defer srv.Close()
+
+	producer, err := NewZkevmRpcdProducer(srv.URL, "", "", "", false)
+	require.Nil(t, err)
+	producer.CancelMethod = "cancel"
+	producer.PollInterval = 10 * time.Millisecond
+	producer.ProofTimeout = 100 * time.Millisecond
+
+	_, _, err = producer.callProverDaemon(context.Background(), &ProofRequestOptions{Height: common.Big256})
+	require.ErrorIs(t, err, ErrProofTimeout)
+	require.NotErrorIs(t, err, ErrInvalidProofRequest)
+
+	close(methods)
+	var last string
+	for method := range methods {
+		last = method
+	}
+	require.Equal(t, "cancel", last)
+}
+
+func TestZkevmRpcdProducerResumeJobs(t *testing.T) {
+	var (
+		requestIDs = make(chan string, 16)
+		completed  = make(chan struct{})
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body RequestProofBody
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		requestIDs <- body.ID.String()
+
+		select {
+		case <-completed:
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"circuit":{"instance":[],"proof":"0x01","k":19}}}`))
+		default:
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":null}`))
+		}
+	}))
+	defer srv.Close()
+
+	journal, err := OpenProofJournal(context.Background(), filepath.Join(t.TempDir(), "rpcd.json"))
+	require.Nil(t, err)
+	entry := newTestJournalEntry(8, srv.URL)
+	require.Nil(t, journal.Put(entry))
+	require.Nil(t, journal.Put(newTestJournalEntry(9, "http://other.endpoint")))
+
+	producer, err := NewZkevmRpcdProducer(srv.URL, "", "", "", false)
+	require.Nil(t, err)
+	producer.PollInterval = 10 * time.Millisecond
+	producer.Journal = journal
+
+	resultCh := make(chan *ProofWithHeader, 1)
+	require.Equal(t, 1, producer.ResumeJobs(context.Background(), resultCh))
+
+	// The job is continued with its journaled request ID.
+	require.Equal(t, entry.RequestID.String(), <-requestIDs)
+
+	// The later request of the same block attaches to the resumed job, instead of requesting a new one.
+	requestErrCh := make(chan error, 1)
+	go func() {
+		requestErrCh <- producer.RequestProof(
+			context.Background(), entry.Options, new(big.Int).SetUint64(entry.BlockID), entry.Meta, entry.Header, resultCh,
+		)
+	}()
+	close(completed)
+
+	proofWithHeader := <-resultCh
+	require.Equal(t, entry.BlockID, proofWithHeader.BlockID.Uint64())
+	require.Equal(t, entry.Header.Hash(), proofWithHeader.Header.Hash())
+	require.Equal(t, []byte{0x01}, proofWithHeader.ZkProof)
+	require.Equal(t, uint64(CircuitsDegree10Txs), proofWithHeader.Degree)
+	require.Nil(t, <-requestErrCh)
+	require.Empty(t, resultCh)
+
+	// Only the job of the other endpoint is left.
+	require.Nil(t, journal.Get(entry.BlockID))
+	require.Len(t, journal.Entries(), 1)
+}
+
+func TestZkevmRpcdProducerProbe(t *testing.T) {
+	var (
+		statusCode = http.StatusOK
+		response   = `{"jsonrpc":"2.0","id":1,"result":{"id":"proverd","tasks":[],"circuit_version":"v0.5.0"}}`
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body RequestProofBody
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, probeMethod, body.Method)
+
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(response))
+	}))
+	defer srv.Close()
+
+	producer, err := NewZkevmRpcdProducer(srv.URL, "", "", "", false)
+	require.Nil(t, err)
+	require.Equal(t, "", producer.CircuitVersion())
+	require.Nil(t, producer.Probe(context.Background()))
+	require.Equal(t, "v0.5.0", producer.CircuitVersion())
+
+	// A failed probe keeps the latest circuit version.
+	response = `{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`
+	require.ErrorContains(t, producer.Probe(context.Background()), "method not found")
+	require.Equal(t, "v0.5.0", producer.CircuitVersion())
+
+	statusCode = http.StatusBadGateway
+	require.ErrorContains(t, producer.Probe(context.Background()), "statusCode: 502")
+
+	unreachable, err := NewZkevmRpcdProducer("http://localhost:1", "", "", "", false)
+	require.Nil(t, err)
+	require.ErrorContains(t, unreachable.Probe(context.Background()), "failed to probe proverd")
+}
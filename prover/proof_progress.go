@@ -0,0 +1,67 @@
+package prover
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/metrics"
+	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+)
+
+var proofProgressLogInterval = time.Minute
+
+// onProofProgress records the given proof generation progress reported by a ZKEVM RPCD producer, and
+// exports it as the block's progress gauge, which is removed once the proof job is done.
+func (p *Prover) onProofProgress(progress *proofProducer.ProofProgress) {
+	if progress.Done {
+		p.proofProgress.Delete(progress.BlockID)
+		metrics.UnregisterProverProofProgressGauge(progress.BlockID)
+		return
+	}
+
+	p.proofProgress.Store(progress.BlockID, progress)
+	metrics.ProverProofProgressGauge(progress.BlockID).Update(progress.Percent)
+}
+
+// proofProgresses returns the latest progresses of all in-flight proof jobs, sorted by block ID.
+func (p *Prover) proofProgresses() []*proofProducer.ProofProgress {
+	var all []*proofProducer.ProofProgress
+	p.proofProgress.Range(func(_, v interface{}) bool {
+		all = append(all, v.(*proofProducer.ProofProgress))
+		return true
+	})
+	sort.Slice(all, func(i, j int) bool { return all[i].BlockID < all[j].BlockID })
+
+	return all
+}
+
+// monitorProofProgress logs the progresses of the in-flight proof jobs periodically, so that a long proof
+// can be told apart from a hung one.
+func (p *Prover) monitorProofProgress() {
+	ticker := time.NewTicker(proofProgressLogInterval)
+	defer func() {
+		ticker.Stop()
+		p.wg.Done()
+	}()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			for _, progress := range p.proofProgresses() {
+				log.Info(
+					"Proof generation progress",
+					"blockID", progress.BlockID,
+					"phase", progress.Phase,
+					"percent", progress.Percent,
+					"elapsed", progress.Elapsed.Round(time.Second),
+					"lastProgressAt", progress.ReportedAt,
+					"stalled", progress.Stalled,
+					"endpoint", progress.Endpoint,
+				)
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package prover
+
+import (
+	"context"
+	"time"
+
+	"github.com/taikoxyz/taiko-client/metrics"
+	"github.com/taikoxyz/taiko-client/prover/lifecycle"
+	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+)
+
+// collectProofs keeps moving the proofs generated by the proof producers into the submission queue, so that
+// the producers are never blocked by the pending submissions.
+func (p *Prover) collectProofs() {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case proofWithHeader := <-p.proveValidProofCh:
+			p.enqueueProof(proofWithHeader, proofClassValid)
+		case proofWithHeader := <-p.proveInvalidProofCh:
+			p.enqueueProof(proofWithHeader, proofClassInvalid)
+		}
+	}
+}
+
+// enqueueProof records the given generated proof as received, and queues it for submission.
+func (p *Prover) enqueueProof(proofWithHeader *proofProducer.ProofWithHeader, class proofClass) {
+	blockID := proofWithHeader.BlockID
+	p.updateProofGenerationTimer(proofWithHeader, class == proofClassValid)
+	p.recordProofReceived(blockID.Uint64())
+	p.releaseProofRequest(blockID.Uint64())
+	if class == proofClassValid {
+		p.lifecycleNotifier.Notify(lifecycle.EventProofGenerated, blockID, nil, nil)
+	}
+
+	_, deadline := proofWindow(proofWithHeader.Meta, p.cfg.ProofWindow)
+	p.submissionQueue.Push(proofWithHeader, class, deadline)
+}
+
+// dispatchProofSubmissions keeps submitting the queued proofs, the most urgent ones first, the number of
+// concurrent submissions is limited by the submitProofConcurrencyGuard.
+func (p *Prover) dispatchProofSubmissions() {
+	defer p.wg.Done()
+
+	for {
+		// Acquire a slot before popping, so that the most urgent proof at that moment will be picked.
+		if err := p.submitProofConcurrencyGuard.Acquire(p.ctx); err != nil {
+			return
+		}
+
+		queued, err := p.submissionQueue.Pop(p.ctx)
+		if err != nil {
+			p.submitProofConcurrencyGuard.Release()
+			return
+		}
+
+		go func() {
+			defer p.submitProofConcurrencyGuard.Release()
+			p.submitProof(p.ctx, queued.proofWithHeader, queued.isValid())
+		}()
+	}
+}
+
+// submitProof performs a (valid block / invalid block) proof submission operation.
+func (p *Prover) submitProof(ctx context.Context, proofWithHeader *proofProducer.ProofWithHeader, isValidProof bool) {
+	startedAt := time.Now()
+	if err := p.validProofSubmitter.SubmitProof(ctx, proofWithHeader); err != nil {
+		proofWithHeader.Log().Error("Submit proof error", "isValidProof", isValidProof, "error", err)
+		return
+	}
+	p.recordProofSubmitted(proofWithHeader.BlockID.Uint64())
+
+	if isValidProof {
+		metrics.ProverValidProofSubmissionTimer.UpdateSince(startedAt)
+	} else {
+		metrics.ProverInvalidProofSubmissionTimer.UpdateSince(startedAt)
+	}
+}
@@ -0,0 +1,246 @@
+package submitter
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-client/metrics"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+const (
+	// competingProofPollInterval is the interval to poll the receipt of a competing proof transaction.
+	competingProofPollInterval = time.Second
+	// maxPendingTxsPerCheck is the maximum number of pending transactions fetched from the pending
+	// transaction filter in each check.
+	maxPendingTxsPerCheck = 256
+	// maxPendingProofTxs is the maximum number of collected pending proof transactions.
+	maxPendingProofTxs = 1024
+)
+
+// competingProofDetector inspects the pending transactions in L1 mempool, to find the TaikoL1.proveBlock
+// transactions sent by other provers for the same block, all checks are best-effort.
+type competingProofDetector struct {
+	rpc            *rpc.Client
+	taikoL1Address common.Address
+	ownAddresses   func() []common.Address
+	window         time.Duration
+
+	mutex             sync.Mutex
+	txPoolUnsupported bool
+	filterID          string
+	pendingProofTxs   map[uint64]common.Hash // blockID -> txHash, collected through the pending transaction filter
+}
+
+// newCompetingProofDetector creates a new competingProofDetector instance, returns nil if the given
+// window is zero, which disables the check.
+func newCompetingProofDetector(
+	rpc *rpc.Client,
+	taikoL1Address common.Address,
+	ownAddresses func() []common.Address,
+	window time.Duration,
+) *competingProofDetector {
+	if window == 0 {
+		return nil
+	}
+
+	return &competingProofDetector{
+		rpc:             rpc,
+		taikoL1Address:  taikoL1Address,
+		ownAddresses:    ownAddresses,
+		window:          window,
+		pendingProofTxs: make(map[uint64]common.Hash),
+	}
+}
+
+// waitCompetingProof checks whether there is a competing proof transaction for the given block pending in
+// the L1 mempool, if so, waits at most the configured window for it to be mined. The first result is true only
+// if the competing proof transaction has been mined successfully, which means our submission can be skipped,
+// the second one is true if a competing proof transaction has been found at all.
+func (d *competingProofDetector) waitCompetingProof(ctx context.Context, blockID *big.Int) (bool, bool) {
+	if d == nil {
+		return false, false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.window)
+	defer cancel()
+
+	txHash, found, err := d.findCompetingProofTx(ctx, blockID)
+	if err != nil {
+		log.Debug("Failed to inspect pending competing proof transactions", "blockID", blockID, "error", err)
+		return false, false
+	}
+	if !found {
+		return false, false
+	}
+
+	log.Info(
+		"Competing proof transaction found in L1 mempool, wait for it",
+		"blockID", blockID,
+		"txHash", txHash,
+		"window", d.window,
+	)
+	metrics.ProverCompetingProofDetectedCounter.Inc(1)
+
+	ticker := time.NewTicker(competingProofPollInterval)
+	defer ticker.Stop()
+
+	for {
+		receipt, err := d.rpc.L1.TransactionReceipt(ctx, txHash)
+		if err == nil {
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				log.Info("Competing proof transaction reverted, submit our proof", "blockID", blockID, "txHash", txHash)
+				return false, true
+			}
+
+			log.Info("Competing proof transaction mined, skip our submission", "blockID", blockID, "txHash", txHash)
+			metrics.ProverCompetingProofGasSavedCounter.Inc(int64(receipt.GasUsed))
+			return true, true
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info(
+				"Competing proof transaction not mined within the window, submit our proof",
+				"blockID", blockID,
+				"txHash", txHash,
+			)
+			return false, true
+		case <-ticker.C:
+		}
+	}
+}
+
+// findCompetingProofTx tries to find a pending TaikoL1.proveBlock transaction for the given block sent by
+// other provers, through `txpool_content` if available, otherwise through a pending transaction filter.
+func (d *competingProofDetector) findCompetingProofTx(
+	ctx context.Context,
+	blockID *big.Int,
+) (common.Hash, bool, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if !d.txPoolUnsupported {
+		txHash, found, err := d.findInTxPool(ctx, blockID)
+		if err == nil || !rpc.IsMethodNotFoundError(err) {
+			return txHash, found, err
+		}
+
+		log.Info("L1 node doesn't support txpool_content, use pending transaction filter instead")
+		d.txPoolUnsupported = true
+	}
+
+	return d.findInPendingTxFilter(ctx, blockID)
+}
+
+// findInTxPool looks up the competing proof transaction in the L1 node's pending transaction pool.
+func (d *competingProofDetector) findInTxPool(ctx context.Context, blockID *big.Int) (common.Hash, bool, error) {
+	var content struct {
+		Pending map[common.Address]map[string]*types.Transaction `json:"pending"`
+	}
+	if err := d.rpc.L1RawRPC.CallContext(ctx, &content, "txpool_content"); err != nil {
+		return common.Hash{}, false, err
+	}
+
+	for from, txs := range content.Pending {
+		if d.isOwnAddress(from) {
+			continue
+		}
+
+		for _, tx := range txs {
+			if id, ok := d.proveBlockID(tx); ok && id.Cmp(blockID) == 0 {
+				return tx.Hash(), true, nil
+			}
+		}
+	}
+
+	return common.Hash{}, false, nil
+}
+
+// findInPendingTxFilter collects the new pending proof transactions since the last check through a
+// pending transaction filter, and then looks up the competing proof transaction among them.
+func (d *competingProofDetector) findInPendingTxFilter(
+	ctx context.Context,
+	blockID *big.Int,
+) (common.Hash, bool, error) {
+	if d.filterID == "" {
+		if err := d.rpc.L1RawRPC.CallContext(ctx, &d.filterID, "eth_newPendingTransactionFilter"); err != nil {
+			return common.Hash{}, false, err
+		}
+	}
+
+	var hashes []common.Hash
+	if err := d.rpc.L1RawRPC.CallContext(ctx, &hashes, "eth_getFilterChanges", d.filterID); err != nil {
+		// The filter might have been expired, recreate it in the next check.
+		if strings.Contains(err.Error(), "filter not found") {
+			d.filterID = ""
+		}
+		return common.Hash{}, false, err
+	}
+
+	if len(hashes) > maxPendingTxsPerCheck {
+		hashes = hashes[len(hashes)-maxPendingTxsPerCheck:]
+	}
+
+	for _, hash := range hashes {
+		tx, isPending, err := d.rpc.L1.TransactionByHash(ctx, hash)
+		if err != nil || !isPending {
+			continue
+		}
+
+		id, ok := d.proveBlockID(tx)
+		if !ok {
+			continue
+		}
+
+		from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+		if err != nil || d.isOwnAddress(from) {
+			continue
+		}
+
+		if len(d.pendingProofTxs) >= maxPendingProofTxs {
+			d.pendingProofTxs = make(map[uint64]common.Hash)
+		}
+		d.pendingProofTxs[id.Uint64()] = hash
+	}
+
+	txHash, found := d.pendingProofTxs[blockID.Uint64()]
+	delete(d.pendingProofTxs, blockID.Uint64())
+
+	return txHash, found, nil
+}
+
+// proveBlockID returns the block ID of the given transaction if it's a TaikoL1.proveBlock transaction.
+func (d *competingProofDetector) proveBlockID(tx *types.Transaction) (*big.Int, bool) {
+	method := encoding.TaikoL1ABI.Methods["proveBlock"]
+	if tx.To() == nil || *tx.To() != d.taikoL1Address || !bytes.HasPrefix(tx.Data(), method.ID) {
+		return nil, false
+	}
+
+	args, err := method.Inputs.Unpack(tx.Data()[len(method.ID):])
+	if err != nil || len(args) == 0 {
+		return nil, false
+	}
+
+	id, ok := args[0].(*big.Int)
+	return id, ok
+}
+
+// isOwnAddress checks whether the given address is one of current prover's submission addresses.
+func (d *competingProofDetector) isOwnAddress(address common.Address) bool {
+	for _, own := range d.ownAddresses() {
+		if own == address {
+			return true
+		}
+	}
+
+	return false
+}
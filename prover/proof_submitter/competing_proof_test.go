@@ -0,0 +1,61 @@
+package submitter
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+)
+
+func TestCompetingProofDetectorDisabled(t *testing.T) {
+	detector := newCompetingProofDetector(nil, common.Address{}, nil, 0)
+	require.Nil(t, detector)
+	landed, contested := detector.waitCompetingProof(context.Background(), common.Big1)
+	require.False(t, landed)
+	require.False(t, contested)
+}
+
+func TestCompetingProofDetectorProveBlockID(t *testing.T) {
+	taikoL1Address := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	detector := newCompetingProofDetector(nil, taikoL1Address, func() []common.Address { return nil }, time.Second)
+
+	calldata, err := encoding.TaikoL1ABI.Pack("proveBlock", big.NewInt(10), []byte{0x01})
+	require.Nil(t, err)
+
+	id, ok := detector.proveBlockID(types.NewTx(&types.DynamicFeeTx{To: &taikoL1Address, Data: calldata}))
+	require.True(t, ok)
+	require.Equal(t, big.NewInt(10), id)
+
+	// Not sent to TaikoL1.
+	otherAddress := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	_, ok = detector.proveBlockID(types.NewTx(&types.DynamicFeeTx{To: &otherAddress, Data: calldata}))
+	require.False(t, ok)
+
+	// Contract creation.
+	_, ok = detector.proveBlockID(types.NewTx(&types.DynamicFeeTx{Data: calldata}))
+	require.False(t, ok)
+
+	// Not a TaikoL1.proveBlock call.
+	calldata, err = encoding.TaikoL1ABI.Pack("verifyBlocks", big.NewInt(10))
+	require.Nil(t, err)
+	_, ok = detector.proveBlockID(types.NewTx(&types.DynamicFeeTx{To: &taikoL1Address, Data: calldata}))
+	require.False(t, ok)
+}
+
+func TestCompetingProofDetectorIsOwnAddress(t *testing.T) {
+	own := common.HexToAddress("0x0000000000000000000000000000000000000003")
+	detector := newCompetingProofDetector(
+		nil,
+		common.Address{},
+		func() []common.Address { return []common.Address{own} },
+		time.Second,
+	)
+
+	require.True(t, detector.isOwnAddress(own))
+	require.False(t, detector.isOwnAddress(common.Address{}))
+}
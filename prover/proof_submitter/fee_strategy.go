@@ -0,0 +1,191 @@
+package submitter
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/taikoxyz/taiko-client/metrics"
+)
+
+const (
+	// FeeStrategyFlat uses the L1 node's suggested priority fee, within the configured bounds.
+	FeeStrategyFlat = "flat"
+	// FeeStrategyCompetition raises the priority fee towards the configured maximum as the proving
+	// competition of the block intensifies.
+	FeeStrategyCompetition = "competition"
+
+	// winRateWindow is the number of the latest submission outcomes the win rate is calculated from.
+	winRateWindow = 64
+
+	// Weights of the competition inputs, which sum up to 1.
+	contestedWeight = 0.5
+	lossRateWeight  = 0.3
+	deadlineWeight  = 0.2
+)
+
+// FeeInputs are the inputs of a fee strategy decision for a proof submission transaction.
+type FeeInputs struct {
+	WinRate   float64       // Win rate of the latest submissions, 1 if there is no outcome yet
+	Contested bool          // Whether a competing proof transaction is pending in L1 mempool
+	BlockAge  time.Duration // Time since the block was proposed
+}
+
+// FeeStrategy decides the priority fee of each proof submission transaction.
+type FeeStrategy interface {
+	Name() string
+	PriorityFee(suggested *big.Int, inputs *FeeInputs) *big.Int
+}
+
+// FeeBounds are the bounds of the priority fees, a nil bound means unbounded.
+type FeeBounds struct {
+	Min *big.Int
+	Max *big.Int
+}
+
+// clamp returns the given fee within the bounds.
+func (b *FeeBounds) clamp(fee *big.Int) *big.Int {
+	if b.Min != nil && fee.Cmp(b.Min) < 0 {
+		return new(big.Int).Set(b.Min)
+	}
+	if b.Max != nil && fee.Cmp(b.Max) > 0 {
+		return new(big.Int).Set(b.Max)
+	}
+
+	return fee
+}
+
+// FlatFeeStrategy uses the suggested priority fee within the bounds, regardless of the competition.
+type FlatFeeStrategy struct {
+	Bounds FeeBounds
+}
+
+// Name implements the FeeStrategy interface.
+func (s *FlatFeeStrategy) Name() string { return FeeStrategyFlat }
+
+// PriorityFee implements the FeeStrategy interface.
+func (s *FlatFeeStrategy) PriorityFee(suggested *big.Int, _ *FeeInputs) *big.Int {
+	return s.Bounds.clamp(suggested)
+}
+
+// CompetitionFeeStrategy interpolates the priority fee between the flat one and the maximum bound, by the
+// competition intensity of the block: whether it is contested in L1 mempool, the recent loss rate, and how
+// much of the proof window has elapsed. An uncontested block of a prover winning all its recent submissions
+// is submitted with the flat fee.
+type CompetitionFeeStrategy struct {
+	Bounds      FeeBounds // Max must be set
+	ProofWindow time.Duration
+}
+
+// Name implements the FeeStrategy interface.
+func (s *CompetitionFeeStrategy) Name() string { return FeeStrategyCompetition }
+
+// PriorityFee implements the FeeStrategy interface.
+func (s *CompetitionFeeStrategy) PriorityFee(suggested *big.Int, inputs *FeeInputs) *big.Int {
+	flat := s.Bounds.clamp(suggested)
+	if flat.Cmp(s.Bounds.Max) >= 0 {
+		return flat
+	}
+
+	// Scaled in basis points to interpolate the big integers.
+	intensity := big.NewInt(int64(s.intensity(inputs) * 10000))
+	delta := new(big.Int).Sub(s.Bounds.Max, flat)
+	delta.Mul(delta, intensity).Div(delta, big.NewInt(10000))
+
+	return delta.Add(delta, flat)
+}
+
+// intensity returns the competition intensity of the given inputs, in [0, 1].
+func (s *CompetitionFeeStrategy) intensity(inputs *FeeInputs) float64 {
+	var intensity float64
+	if inputs.Contested {
+		intensity += contestedWeight
+	}
+	intensity += lossRateWeight * clampRatio(1-inputs.WinRate)
+	if s.ProofWindow > 0 {
+		intensity += deadlineWeight * clampRatio(float64(inputs.BlockAge)/float64(s.ProofWindow))
+	}
+
+	return clampRatio(intensity)
+}
+
+// clampRatio returns the given ratio within [0, 1].
+func clampRatio(ratio float64) float64 {
+	if ratio < 0 {
+		return 0
+	}
+	if ratio > 1 {
+		return 1
+	}
+
+	return ratio
+}
+
+// NewFeeStrategy creates the fee strategy of the given name.
+func NewFeeStrategy(name string, bounds FeeBounds, proofWindow time.Duration) (FeeStrategy, error) {
+	if bounds.Min != nil && bounds.Max != nil && bounds.Min.Cmp(bounds.Max) > 0 {
+		return nil, fmt.Errorf("minimum priority fee %s is above the maximum %s", bounds.Min, bounds.Max)
+	}
+
+	switch name {
+	case "", FeeStrategyFlat:
+		return &FlatFeeStrategy{Bounds: bounds}, nil
+	case FeeStrategyCompetition:
+		if bounds.Max == nil {
+			return nil, fmt.Errorf("fee strategy %s requires a maximum priority fee", name)
+		}
+		return &CompetitionFeeStrategy{Bounds: bounds, ProofWindow: proofWindow}, nil
+	default:
+		return nil, fmt.Errorf("unknown fee strategy: %s", name)
+	}
+}
+
+// winRateStats keeps the outcomes of the latest proof submissions, a submission is won if its transaction
+// is mined successfully, and lost if another prover's proof lands first.
+type winRateStats struct {
+	mutex    sync.Mutex
+	outcomes []bool // Ring buffer of the latest outcomes
+	next     int
+}
+
+// record records the outcome of a submission sent with the given priority fee.
+func (w *winRateStats) record(won bool, fee *big.Int) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if len(w.outcomes) < winRateWindow {
+		w.outcomes = append(w.outcomes, won)
+	} else {
+		w.outcomes[w.next] = won
+	}
+	w.next = (w.next + 1) % winRateWindow
+
+	if won {
+		metrics.ProverSubmissionWonCounter.Inc(1)
+		if fee != nil {
+			metrics.ProverSubmissionWonPriorityFeeGauge.Update(fee.Int64())
+		}
+	} else {
+		metrics.ProverSubmissionLostCounter.Inc(1)
+	}
+}
+
+// winRate returns the win rate of the latest submissions, 1 if there is no outcome yet.
+func (w *winRateStats) winRate() float64 {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if len(w.outcomes) == 0 {
+		return 1
+	}
+
+	var won int
+	for _, outcome := range w.outcomes {
+		if outcome {
+			won++
+		}
+	}
+
+	return float64(won) / float64(len(w.outcomes))
+}
@@ -0,0 +1,92 @@
+package submitter
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlatFeeStrategy(t *testing.T) {
+	strategy := &FlatFeeStrategy{Bounds: FeeBounds{Min: big.NewInt(10), Max: big.NewInt(100)}}
+	contested := &FeeInputs{WinRate: 0, Contested: true, BlockAge: time.Hour}
+
+	require.Equal(t, FeeStrategyFlat, strategy.Name())
+	require.Equal(t, big.NewInt(50), strategy.PriorityFee(big.NewInt(50), contested))
+	require.Equal(t, big.NewInt(10), strategy.PriorityFee(big.NewInt(1), contested))
+	require.Equal(t, big.NewInt(100), strategy.PriorityFee(big.NewInt(1000), contested))
+
+	// Unbounded.
+	require.Equal(t, big.NewInt(1000), new(FlatFeeStrategy).PriorityFee(big.NewInt(1000), contested))
+}
+
+func TestCompetitionFeeStrategy(t *testing.T) {
+	strategy := &CompetitionFeeStrategy{
+		Bounds:      FeeBounds{Min: big.NewInt(100), Max: big.NewInt(1100)},
+		ProofWindow: time.Hour,
+	}
+	require.Equal(t, FeeStrategyCompetition, strategy.Name())
+
+	testCases := []struct {
+		name      string
+		suggested int64
+		inputs    *FeeInputs
+		expected  int64
+	}{
+		{"uncontested", 100, &FeeInputs{WinRate: 1}, 100},
+		{"uncontestedBelowMin", 1, &FeeInputs{WinRate: 1}, 100},
+		{"contested", 100, &FeeInputs{WinRate: 1, Contested: true}, 600},
+		{"allLost", 100, &FeeInputs{WinRate: 0}, 400},
+		{"halfWindowElapsed", 100, &FeeInputs{WinRate: 1, BlockAge: 30 * time.Minute}, 200},
+		{"windowElapsed", 100, &FeeInputs{WinRate: 1, BlockAge: 2 * time.Hour}, 300},
+		{"fullIntensity", 100, &FeeInputs{WinRate: 0, Contested: true, BlockAge: time.Hour}, 1100},
+		{"suggestedAboveMax", 2000, &FeeInputs{WinRate: 1}, 1100},
+		{"suggestedInterpolated", 600, &FeeInputs{WinRate: 1, Contested: true}, 850},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, big.NewInt(tc.expected), strategy.PriorityFee(big.NewInt(tc.suggested), tc.inputs))
+		})
+	}
+
+	// Without a proof window, the deadline proximity is ignored.
+	strategy.ProofWindow = 0
+	require.Equal(t, big.NewInt(100), strategy.PriorityFee(big.NewInt(100), &FeeInputs{WinRate: 1, BlockAge: time.Hour}))
+}
+
+func TestNewFeeStrategy(t *testing.T) {
+	strategy, err := NewFeeStrategy("", FeeBounds{}, time.Hour)
+	require.Nil(t, err)
+	require.Equal(t, FeeStrategyFlat, strategy.Name())
+
+	strategy, err = NewFeeStrategy(FeeStrategyCompetition, FeeBounds{Max: big.NewInt(1)}, time.Hour)
+	require.Nil(t, err)
+	require.Equal(t, FeeStrategyCompetition, strategy.Name())
+
+	_, err = NewFeeStrategy(FeeStrategyCompetition, FeeBounds{}, time.Hour)
+	require.ErrorContains(t, err, "requires a maximum priority fee")
+
+	_, err = NewFeeStrategy(FeeStrategyFlat, FeeBounds{Min: big.NewInt(2), Max: big.NewInt(1)}, time.Hour)
+	require.ErrorContains(t, err, "is above the maximum")
+
+	_, err = NewFeeStrategy("auction", FeeBounds{}, time.Hour)
+	require.ErrorContains(t, err, "unknown fee strategy: auction")
+}
+
+func TestWinRateStats(t *testing.T) {
+	stats := new(winRateStats)
+	require.Equal(t, float64(1), stats.winRate())
+
+	stats.record(true, big.NewInt(1))
+	stats.record(false, nil)
+	require.Equal(t, 0.5, stats.winRate())
+
+	// Only the latest outcomes are kept.
+	for i := 0; i < winRateWindow; i++ {
+		stats.record(i%4 != 0, big.NewInt(1))
+	}
+	require.Equal(t, winRateWindow, len(stats.outcomes))
+	require.Equal(t, 0.75, stats.winRate())
+}
@@ -3,6 +3,7 @@ package submitter
 import (
 	"context"
 
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/taikoxyz/taiko-client/bindings"
 	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
 )
@@ -11,3 +12,18 @@ type ProofSubmitter interface {
 	RequestProof(ctx context.Context, event *bindings.TaikoL1ClientBlockProposed) error
 	SubmitProof(ctx context.Context, proofWithHeader *proofProducer.ProofWithHeader) error
 }
+
+// StagedProofSubmitter is a ProofSubmitter whose proof requests can be split into the witness preparation
+// and the proof computation stages, so that the stages can be bounded separately.
+type StagedProofSubmitter interface {
+	ProofSubmitter
+	PrepareProof(ctx context.Context, event *bindings.TaikoL1ClientBlockProposed) (*PreparedProof, error)
+	RequestPreparedProof(ctx context.Context, prepared *PreparedProof) error
+}
+
+// PreparedProof is a proof request whose witness has been prepared, waiting for the proof computation.
+type PreparedProof struct {
+	Event  *bindings.TaikoL1ClientBlockProposed
+	Header *types.Header
+	Opts   *proofProducer.ProofRequestOptions
+}
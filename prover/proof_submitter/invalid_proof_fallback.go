@@ -0,0 +1,166 @@
+package submitter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/metrics"
+	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+)
+
+const (
+	// invalidProofError is the TaikoL1 custom error of the proof submissions rejected by the verifier.
+	invalidProofError = "L1_INVALID_PROOF"
+	// maxInvalidProofRetries is the maximum number of the fallback proofs requested for a block.
+	maxInvalidProofRetries = 3
+)
+
+// invalidProofFallback tracks the blocks whose proofs have been rejected by the verifier, and proved again
+// by a fallback proof producer, so that a circuit bug making a block's proofs always invalid doesn't lose
+// the block's proof forever. All methods of a nil invalidProofFallback are no-ops.
+type invalidProofFallback struct {
+	producer proofProducer.ProofProducer
+	mutex    sync.Mutex
+	retries  map[uint64]int // Number of the fallback proofs requested by block ID
+}
+
+// RetryWithFallbackProducer makes the submitter request the proof of a block again from the given fallback
+// proof producer, once its submission reverts with L1_INVALID_PROOF, at most maxInvalidProofRetries times.
+func (s *ValidProofSubmitter) RetryWithFallbackProducer(producer proofProducer.ProofProducer) {
+	s.invalidProofFallback = &invalidProofFallback{producer: producer, retries: make(map[uint64]int)}
+}
+
+// next counts a new fallback proof of the given block, returns its attempt number, and whether the
+// retries are not exhausted yet.
+func (f *invalidProofFallback) next(blockID uint64) (int, bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.retries[blockID] >= maxInvalidProofRetries {
+		delete(f.retries, blockID)
+		return maxInvalidProofRetries, false
+	}
+	f.retries[blockID]++
+
+	return f.retries[blockID], true
+}
+
+// done forgets the given block once its proof submission is finished.
+func (f *invalidProofFallback) done(blockID uint64) {
+	if f == nil {
+		return
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	delete(f.retries, blockID)
+}
+
+// isInvalidProofError checks whether the given proof submission error is caused by a proof rejected by
+// the verifier.
+func isInvalidProofError(err error) bool {
+	var unretryable *unretryableError
+	return errors.As(err, &unretryable) && strings.HasPrefix(unretryable.reason.Error(), invalidProofError)
+}
+
+// retryInvalidProof requests the proof of the given block from the fallback proof producer, if its
+// submission failed with the given error because the proof is invalid. The new proof is delivered to the
+// result channel like the other generated proofs. Reports whether a new proof is requested.
+func (s *ValidProofSubmitter) retryInvalidProof(
+	ctx context.Context,
+	proofWithHeader *proofProducer.ProofWithHeader,
+	err error,
+) bool {
+	f := s.invalidProofFallback
+	if f == nil {
+		return false
+	}
+
+	blockID := proofWithHeader.BlockID
+	if !isInvalidProofError(err) {
+		f.done(blockID.Uint64())
+		return false
+	}
+
+	attempt, ok := f.next(blockID.Uint64())
+	if !ok {
+		metrics.ProverInvalidProofExhaustedCounter.Inc(1)
+		proofWithHeader.Log().Error(
+			"Proof still rejected after fallback retries, give up",
+			"blockID", blockID,
+			"retries", attempt,
+			"origin", proofWithHeader.Origin,
+		)
+		return false
+	}
+
+	event, err := s.getBlockProposedEvent(ctx, blockID)
+	if err != nil {
+		f.done(blockID.Uint64())
+		proofWithHeader.Log().Error("Failed to request fallback proof", "blockID", blockID, "error", err)
+		return false
+	}
+
+	metrics.ProverInvalidProofFallbackCounter.Inc(1)
+	proofWithHeader.Log().Warn(
+		"Proof rejected by verifier, request fallback proof",
+		"blockID", blockID,
+		"attempt", attempt,
+		"origin", proofWithHeader.Origin,
+	)
+
+	opts := &proofProducer.ProofRequestOptions{
+		Height:             proofWithHeader.Header.Number,
+		ProverAddress:      s.proverAddress,
+		ProposeBlockTxHash: event.Raw.TxHash,
+	}
+	// The proof producer might block until the proof is generated, the submission slot shouldn't be held.
+	go func() {
+		if err := f.producer.RequestProof(
+			ctx, opts, blockID, &event.Meta, proofWithHeader.Header, s.reusltCh,
+		); err != nil {
+			f.done(blockID.Uint64())
+			proofWithHeader.Log().Error("Failed to request fallback proof", "blockID", blockID, "error", err)
+		}
+	}()
+
+	return true
+}
+
+// getBlockProposedEvent fetches the BlockProposed event of the given block, at the L1 height of its
+// L1 origin.
+func (s *ValidProofSubmitter) getBlockProposedEvent(
+	ctx context.Context,
+	blockID *big.Int,
+) (*bindings.TaikoL1ClientBlockProposed, error) {
+	l1Origin, err := s.rpc.WaitL1Origin(ctx, blockID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch l1Origin, blockID: %d, err: %w", blockID, err)
+	}
+
+	height := l1Origin.L1BlockHeight.Uint64()
+	iter, err := s.rpc.TaikoL1.FilterBlockProposed(
+		&bind.FilterOpts{Start: height, End: &height, Context: ctx},
+		[]*big.Int{blockID},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter BlockProposed events: %w", err)
+	}
+	defer iter.Close()
+
+	if iter.Next() {
+		return iter.Event, nil
+	}
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("failed to iterate BlockProposed events: %w", err)
+	}
+
+	return nil, fmt.Errorf("BlockProposed event not found, blockID: %d, l1Height: %d", blockID, height)
+}
@@ -0,0 +1,51 @@
+package submitter
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+)
+
+func TestIsInvalidProofError(t *testing.T) {
+	require.True(t, isInvalidProofError(&unretryableError{reason: errors.New(invalidProofError)}))
+	require.True(t, isInvalidProofError(
+		fmt.Errorf("failed to submit proof: %w", &unretryableError{reason: errors.New(invalidProofError)}),
+	))
+	require.True(t, errors.Is(&unretryableError{reason: errors.New(invalidProofError)}, errUnretryable))
+
+	require.False(t, isInvalidProofError(&unretryableError{reason: errors.New("L1_ALREADY_PROVEN")}))
+	require.False(t, isInvalidProofError(errors.New(invalidProofError)))
+	require.False(t, isInvalidProofError(errUnretryable))
+}
+
+func TestInvalidProofFallbackRetries(t *testing.T) {
+	s := &ValidProofSubmitter{}
+	s.RetryWithFallbackProducer(&proofProducer.DummyProofProducer{})
+	f := s.invalidProofFallback
+
+	for i := 1; i <= maxInvalidProofRetries; i++ {
+		attempt, ok := f.next(1)
+		require.True(t, ok)
+		require.Equal(t, i, attempt)
+	}
+	attempt, ok := f.next(1)
+	require.False(t, ok)
+	require.Equal(t, maxInvalidProofRetries, attempt)
+
+	// The exhausted block is forgotten, and the other blocks are counted separately.
+	attempt, ok = f.next(1)
+	require.True(t, ok)
+	require.Equal(t, 1, attempt)
+	attempt, _ = f.next(2)
+	require.Equal(t, 1, attempt)
+
+	f.done(1)
+	attempt, _ = f.next(1)
+	require.Equal(t, 1, attempt)
+
+	var disabled *invalidProofFallback
+	require.NotPanics(t, func() { disabled.done(1) })
+}
@@ -0,0 +1,57 @@
+package submitter
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/testutils"
+)
+
+// TestSubmitProofForInvalidTxList checks the proving path of a block proposed with an invalid txList, the
+// driver inserts an empty L2 block with only the anchor transaction for it, which is then proven with a
+// valid proof, as the protocol has no separate invalid block proofs.
+func (s *ProofSubmitterTestSuite) TestSubmitProofForInvalidTxList() {
+	sink := make(chan *bindings.TaikoL1ClientBlockProposed)
+
+	sub, err := s.RpcClient.TaikoL1.WatchBlockProposed(nil, sink, nil)
+	s.Nil(err)
+	defer func() {
+		sub.Unsubscribe()
+		close(sink)
+	}()
+
+	testutils.ProposeInvalidTxListBytes(&s.ClientTestSuite, s.proposer)
+	event := <-sink
+
+	l1Head, err := s.RpcClient.L1.HeaderByNumber(context.Background(), nil)
+	s.Nil(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	s.Nil(s.calldataSyncer.ProcessL1Blocks(ctx, l1Head))
+
+	// Only the anchor transaction is in the inserted L2 block.
+	l1Origin, err := s.RpcClient.WaitL1Origin(ctx, event.Id)
+	s.Nil(err)
+	block, err := s.RpcClient.L2.BlockByHash(ctx, l1Origin.L2BlockHash)
+	s.Nil(err)
+	s.Equal(1, block.Transactions().Len())
+
+	s.Nil(s.validProofSubmitter.RequestProof(ctx, event))
+	proofWithHeader := <-s.validProofCh
+	s.Equal(event.Id, proofWithHeader.BlockID)
+	s.Equal(block.Hash(), proofWithHeader.Header.Hash())
+
+	s.Nil(s.validProofSubmitter.SubmitProof(ctx, proofWithHeader))
+
+	parent, err := s.RpcClient.L2.HeaderByHash(ctx, block.ParentHash())
+	s.Nil(err)
+
+	fc, err := s.RpcClient.TaikoL1.GetForkChoice(nil, event.Id, parent.Hash(), uint32(parent.GasUsed))
+	s.Nil(err)
+	s.Equal(block.Hash(), common.BytesToHash(fc.BlockHash[:]))
+	s.Equal(s.validProofSubmitter.proverAddress, fc.Prover)
+}
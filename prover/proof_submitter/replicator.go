@@ -0,0 +1,93 @@
+package submitter
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/metrics"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	"golang.org/x/sync/errgroup"
+)
+
+// replica is an additional L1 chain which the proofs are replicated to.
+type replica struct {
+	rpc   *rpc.Client
+	mutex sync.Mutex // Serializes the transactions sent to this chain, to keep the sender's nonces in order
+}
+
+// ProofReplicator replicates the TaikoL1.proveBlock transactions submitted to the primary L1 chain to
+// the additional L1 chains, for the multi-chain deployments whose TaikoL1 contracts share the same L2 chain.
+type ProofReplicator struct {
+	replicas      []*replica
+	proverPrivKey *ecdsa.PrivateKey
+}
+
+// NewProofReplicator creates a new ProofReplicator instance, the replicated transactions will be sent by
+// the given prover private key. All methods of a nil ProofReplicator are no-ops.
+func NewProofReplicator(clients []*rpc.Client, proverPrivKey *ecdsa.PrivateKey) *ProofReplicator {
+	if len(clients) == 0 {
+		return nil
+	}
+
+	replicas := make([]*replica, len(clients))
+	for i, client := range clients {
+		replicas[i] = &replica{rpc: client}
+	}
+
+	return &ProofReplicator{replicas: replicas, proverPrivKey: proverPrivKey}
+}
+
+// Replicate sends the TaikoL1.proveBlock transaction with the given input to all the additional L1 chains in
+// parallel, and waits until all of them are done. A failed chain never stops the others, and the first error
+// is returned after all the chains are done.
+func (r *ProofReplicator) Replicate(ctx context.Context, blockID *big.Int, input []byte) error {
+	if r == nil {
+		return nil
+	}
+
+	var g errgroup.Group
+	for _, replica := range r.replicas {
+		replica := replica
+		g.Go(func() error {
+			if err := r.replicate(ctx, replica, blockID, input); err != nil {
+				log.Warn("Failed to replicate proof", "blockID", blockID, "l1ChainID", replica.rpc.L1ChainID, "error", err)
+				metrics.ProverReplicatedProofFailedCounter.Inc(1)
+				return fmt.Errorf("failed to replicate proof to L1 %s: %w", replica.rpc.L1ChainID, err)
+			}
+
+			metrics.ProverReplicatedProofCounter.Inc(1)
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
+
+// replicate sends the TaikoL1.proveBlock transaction with the given input to the given additional L1 chain.
+func (r *ProofReplicator) replicate(ctx context.Context, replica *replica, blockID *big.Int, input []byte) error {
+	txOpts, err := getProveBlocksTxOpts(ctx, replica.rpc.L1, replica.rpc.L1ChainID, r.proverPrivKey)
+	if err != nil {
+		return err
+	}
+
+	sendTx := func() (*types.Transaction, error) {
+		replica.mutex.Lock()
+		defer replica.mutex.Unlock()
+
+		return replica.rpc.TaikoL1.ProveBlock(txOpts, blockID, input)
+	}
+
+	txHash, err := sendTxWithBackoff(ctx, replica.rpc, blockID, nil, sendTx)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Proof replicated", "blockID", blockID, "l1ChainID", replica.rpc.L1ChainID, "txHash", txHash)
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package submitter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+func TestNilProofReplicator(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	replicator := NewProofReplicator(nil, key)
+	require.Nil(t, replicator)
+	require.Nil(t, replicator.Replicate(context.Background(), common.Big1, []byte{}))
+
+	replicator = NewProofReplicator([]*rpc.Client{{}, {}}, key)
+	require.Len(t, replicator.replicas, 2)
+}
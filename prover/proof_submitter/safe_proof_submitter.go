@@ -0,0 +1,261 @@
+package submitter
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-client/metrics"
+	"github.com/taikoxyz/taiko-client/pkg/safe"
+	"github.com/taikoxyz/taiko-client/prover/lifecycle"
+	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+)
+
+var _ ProofSubmitter = (*SafeProofSubmitter)(nil)
+
+const (
+	// defaultSafeConfirmationsPollInterval is the default interval to poll the Safe transaction confirmations.
+	defaultSafeConfirmationsPollInterval = 12 * time.Second
+)
+
+// SafeProofSubmitter is responsible for submitting the generated valid block proofs through a Gnosis Safe
+// multi-sig wallet, the TaikoL1.proveBlock transactions are proposed to the Safe Transaction Service, and
+// then executed once enough Safe owners have confirmed them.
+type SafeProofSubmitter struct {
+	*ValidProofSubmitter
+	safeAddress   common.Address
+	safeService   *safe.ServiceClient
+	safeThreshold uint64
+	ownerPrivKey  *ecdsa.PrivateKey
+	pollInterval  time.Duration
+	mutex         sync.Mutex // Ensures the Safe transactions are proposed and executed one by one.
+}
+
+// NewSafeProofSubmitter creates a new SafeProofSubmitter instance, the given owner private key is used
+// to propose and sign the Safe transactions.
+func NewSafeProofSubmitter(
+	validProofSubmitter *ValidProofSubmitter,
+	safeAddress common.Address,
+	safeServiceURL string,
+	safeThreshold uint64,
+	ownerPrivKey *ecdsa.PrivateKey,
+) (*SafeProofSubmitter, error) {
+	if safeThreshold == 0 {
+		return nil, errors.New("invalid safe threshold: 0")
+	}
+
+	return &SafeProofSubmitter{
+		ValidProofSubmitter: validProofSubmitter,
+		safeAddress:         safeAddress,
+		safeService:         safe.NewServiceClient(safeServiceURL, 0),
+		safeThreshold:       safeThreshold,
+		ownerPrivKey:        ownerPrivKey,
+		pollInterval:        defaultSafeConfirmationsPollInterval,
+	}, nil
+}
+
+// SubmitProof implements the ProofSubmitter interface.
+func (s *SafeProofSubmitter) SubmitProof(
+	ctx context.Context,
+	proofWithHeader *proofProducer.ProofWithHeader,
+) error {
+	// Dry-run mode never sends any transactions, there is nothing to be confirmed by the Safe owners.
+	if s.dryRun {
+		return s.ValidProofSubmitter.SubmitProof(ctx, proofWithHeader)
+	}
+
+	log.Info(
+		"New valid block proof",
+		"blockID", proofWithHeader.BlockID,
+		"beneficiary", proofWithHeader.Meta.Beneficiary,
+		"hash", proofWithHeader.Header.Hash(),
+		"proof", common.Bytes2Hex(proofWithHeader.ZkProof),
+		"safe", s.safeAddress,
+	)
+
+	metrics.ProverReceivedProofCounter.Inc(1)
+	metrics.ProverReceivedValidProofCounter.Inc(1)
+
+	block, evidence, input, err := s.prepareProveBlockInput(ctx, proofWithHeader)
+	if err != nil {
+		return err
+	}
+
+	calldata, err := encoding.TaikoL1ABI.Pack("proveBlock", proofWithHeader.BlockID, input)
+	if err != nil {
+		return fmt.Errorf("failed to pack TaikoL1.proveBlock calldata: %w", err)
+	}
+
+	// Skip the submission if a competing proof transaction for the same block lands first.
+	landed, contested := s.competingProofs.waitCompetingProof(ctx, proofWithHeader.BlockID)
+	if landed {
+		s.winRates.record(false, nil)
+		return nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	safeTx, safeTxHash, err := s.proposeSafeTx(ctx, calldata)
+	if err != nil {
+		return err
+	}
+
+	log.Info(
+		"Safe transaction proposed, waiting for confirmations",
+		"blockID", proofWithHeader.BlockID,
+		"safeTxHash", safeTxHash,
+		"nonce", safeTx.Nonce,
+		"threshold", s.safeThreshold,
+	)
+	metrics.ProverSafeTxProposedCounter.Inc(1)
+
+	multisigTx, err := s.waitConfirmations(ctx, safeTxHash)
+	if err != nil {
+		return err
+	}
+
+	// Executed by other Safe owners.
+	if multisigTx.IsExecuted {
+		log.Info(
+			"Safe transaction has been executed",
+			"blockID", proofWithHeader.BlockID,
+			"safeTxHash", safeTxHash,
+			"txHash", multisigTx.TransactionHash,
+		)
+		s.notifier.Notify(lifecycle.EventProofSubmitted, proofWithHeader.BlockID, multisigTx.TransactionHash, nil)
+		if multisigTx.TransactionHash != nil {
+			var executor common.Address
+			if multisigTx.Executor != nil {
+				executor = *multisigTx.Executor
+			}
+			s.recordSubmission(proofWithHeader, evidence, block, *multisigTx.TransactionHash, executor)
+		}
+		return nil
+	}
+
+	execCalldata, err := safe.PackExecTransaction(safeTx, multisigTx.ConfirmationList())
+	if err != nil {
+		return fmt.Errorf("failed to pack Safe.execTransaction calldata: %w", err)
+	}
+
+	submissionKey, senderMutex := s.submissionKeys.Next()
+	txOpts, err := getProveBlocksTxOpts(ctx, s.rpc.L1, s.rpc.L1ChainID, submissionKey)
+	if err != nil {
+		return err
+	}
+	s.setPriorityFee(proofWithHeader, txOpts, contested)
+
+	safeContract := bind.NewBoundContract(s.safeAddress, safe.SafeABI, s.rpc.L1, s.rpc.L1, s.rpc.L1)
+	sendTx := func() (*types.Transaction, error) {
+		senderMutex.Lock()
+		defer senderMutex.Unlock()
+
+		return safeContract.RawTransact(txOpts, execCalldata)
+	}
+
+	txHash, err := sendTxWithBackoff(ctx, s.rpc, proofWithHeader.BlockID, s.notifier, sendTx)
+	if err != nil {
+		if errors.Is(err, errUnretryable) {
+			s.winRates.record(false, txOpts.GasTipCap)
+			return nil
+		}
+
+		return err
+	}
+	s.winRates.record(true, txOpts.GasTipCap)
+	s.recordSubmission(proofWithHeader, evidence, block, txHash, txOpts.From)
+
+	log.Info(
+		"✅ Valid block proved through Safe",
+		"blockID", proofWithHeader.BlockID,
+		"hash", block.Hash(), "height", block.Number(),
+		"transactions", block.Transactions().Len(),
+		"safeTxHash", safeTxHash,
+	)
+
+	metrics.ProverSentProofCounter.Inc(1)
+	metrics.ProverSentValidProofCounter.Inc(1)
+	metrics.ProverLatestProvenBlockIDGauge.Update(proofWithHeader.BlockID.Int64())
+
+	return nil
+}
+
+// proposeSafeTx proposes a new Safe transaction which calls TaikoL1 with the given calldata, signed by
+// the owner private key.
+func (s *SafeProofSubmitter) proposeSafeTx(
+	ctx context.Context,
+	calldata []byte,
+) (*safe.Transaction, common.Hash, error) {
+	nonce, err := safe.Nonce(ctx, s.rpc.L1, s.safeAddress)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+
+	safeTx := &safe.Transaction{
+		To:        s.taikoL1Address,
+		Data:      calldata,
+		Operation: safe.OperationCall,
+		Nonce:     nonce,
+	}
+	safeTxHash := safeTx.Hash(s.rpc.L1ChainID, s.safeAddress)
+
+	signature, err := safe.Sign(safeTxHash, s.ownerPrivKey)
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("failed to sign safe transaction: %w", err)
+	}
+
+	if err := s.safeService.ProposeTransaction(
+		ctx,
+		s.safeAddress,
+		safeTx,
+		safeTxHash,
+		crypto.PubkeyToAddress(s.ownerPrivKey.PublicKey),
+		signature,
+	); err != nil {
+		return nil, common.Hash{}, err
+	}
+
+	return safeTx, safeTxHash, nil
+}
+
+// waitConfirmations keeps polling the given Safe transaction, until it has been confirmed by enough
+// Safe owners, or executed.
+func (s *SafeProofSubmitter) waitConfirmations(
+	ctx context.Context,
+	safeTxHash common.Hash,
+) (*safe.MultisigTransaction, error) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		multisigTx, err := s.safeService.GetTransaction(ctx, safeTxHash)
+		if err != nil {
+			log.Warn("Failed to fetch safe transaction", "safeTxHash", safeTxHash, "error", err)
+		} else if multisigTx.IsExecuted || uint64(len(multisigTx.Confirmations)) >= s.safeThreshold {
+			return multisigTx, nil
+		} else {
+			log.Debug(
+				"Waiting for safe transaction confirmations",
+				"safeTxHash", safeTxHash,
+				"confirmations", len(multisigTx.Confirmations),
+				"threshold", s.safeThreshold,
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package submitter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSafeProofSubmitterWaitConfirmations(t *testing.T) {
+	var polls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		confirmations := `{"owner": "0x0000000000000000000000000000000000000001", "signature": "0x01"}`
+		if atomic.AddInt32(&polls, 1) > 2 {
+			confirmations += `, {"owner": "0x0000000000000000000000000000000000000002", "signature": "0x02"}`
+		}
+		_, _ = fmt.Fprintf(w, `{"isExecuted": false, "confirmations": [%s]}`, confirmations)
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	_, err = NewSafeProofSubmitter(nil, common.Address{}, srv.URL, 0, key)
+	require.NotNil(t, err)
+
+	submitter, err := NewSafeProofSubmitter(nil, common.Address{}, srv.URL, 2, key)
+	require.Nil(t, err)
+	submitter.pollInterval = 10 * time.Millisecond
+
+	multisigTx, err := submitter.waitConfirmations(context.Background(), common.Hash{})
+	require.Nil(t, err)
+	require.Len(t, multisigTx.Confirmations, 2)
+	require.Equal(t, int32(3), atomic.LoadInt32(&polls))
+
+	// Not enough confirmations before the context is done.
+	submitter.safeThreshold = 3
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = submitter.waitConfirmations(ctx, common.Hash{})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,143 @@
+package submitter
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	errNoSubmissionKeys = errors.New("no proof submission keys")
+)
+
+// SubmissionKeys holds the L1 private keys used to send the proof submission transactions, the keys are
+// rotated in a round-robin manner, and the transactions sent by the same key are serialized, so that
+// each address keeps its own nonces in order.
+type SubmissionKeys struct {
+	mutex   sync.Mutex
+	keys    []*ecdsa.PrivateKey
+	next    int
+	senders map[common.Address]*sync.Mutex
+}
+
+// NewSubmissionKeys creates a new SubmissionKeys instance with the given private keys.
+func NewSubmissionKeys(keys []*ecdsa.PrivateKey) (*SubmissionKeys, error) {
+	k := &SubmissionKeys{senders: make(map[common.Address]*sync.Mutex)}
+	if err := k.Reload(keys); err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+// Next returns the next private key to send a proof submission transaction, along with the mutex which
+// must be held while sending the transaction.
+func (k *SubmissionKeys) Next() (*ecdsa.PrivateKey, *sync.Mutex) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	key := k.keys[k.next%len(k.keys)]
+	k.next = (k.next + 1) % len(k.keys)
+
+	return key, k.senders[crypto.PubkeyToAddress(key.PublicKey)]
+}
+
+// Reload replaces the current private keys with the given ones, the pending transactions of the
+// addresses which are kept won't be affected.
+func (k *SubmissionKeys) Reload(keys []*ecdsa.PrivateKey) error {
+	if len(keys) == 0 {
+		return errNoSubmissionKeys
+	}
+
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	for _, key := range keys {
+		address := crypto.PubkeyToAddress(key.PublicKey)
+		if _, ok := k.senders[address]; !ok {
+			k.senders[address] = &sync.Mutex{}
+		}
+	}
+
+	k.keys = keys
+	k.next = 0
+
+	return nil
+}
+
+// Addresses returns the addresses of the current private keys.
+func (k *SubmissionKeys) Addresses() []common.Address {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	addresses := make([]common.Address, len(k.keys))
+	for i, key := range k.keys {
+		addresses[i] = crypto.PubkeyToAddress(key.PublicKey)
+	}
+
+	return addresses
+}
+
+// LoadSubmissionKeys reads the hex encoded private keys from the given file, one key per line, empty lines
+// and lines starting with `#` will be ignored.
+func LoadSubmissionKeys(path string) ([]*ecdsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read submission keys file: %w", err)
+	}
+
+	var (
+		keys    []*ecdsa.PrivateKey
+		scanner = bufio.NewScanner(bytes.NewReader(data))
+	)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if len(text) == 0 || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		key, err := crypto.ToECDSA(common.FromHex(text))
+		if err != nil {
+			return nil, fmt.Errorf("invalid private key at line %d: %w", line, err)
+		}
+
+		keys = append(keys, key)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read submission keys file: %w", err)
+	}
+
+	if len(keys) == 0 {
+		return nil, errNoSubmissionKeys
+	}
+
+	return keys, nil
+}
+
+// Roles of the accounts paying for the proof submission transactions.
+const (
+	senderRoleProver    = "prover"    // The prover's own key, --l1.proverPrivKey
+	senderRoleSubmitter = "submitter" // A separate submission key, or a Safe owner executing the transaction
+	senderRoleUnknown   = "unknown"   // A Safe transaction executed by an owner not reported by the service
+)
+
+// submissionSenderRole returns the role of the given account which paid for a proof submission transaction
+// of the given prover.
+func submissionSenderRole(sender, prover common.Address) string {
+	switch sender {
+	case common.Address{}:
+		return senderRoleUnknown
+	case prover:
+		return senderRoleProver
+	default:
+		return senderRoleSubmitter
+	}
+}
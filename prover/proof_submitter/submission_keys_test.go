@@ -0,0 +1,83 @@
+package submitter
+
+import (
+	"crypto/ecdsa"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmissionKeysRoundRobin(t *testing.T) {
+	_, err := NewSubmissionKeys(nil)
+	require.ErrorIs(t, err, errNoSubmissionKeys)
+
+	var keys []*ecdsa.PrivateKey
+	for i := 0; i < 3; i++ {
+		key, err := crypto.GenerateKey()
+		require.Nil(t, err)
+		keys = append(keys, key)
+	}
+
+	submissionKeys, err := NewSubmissionKeys(keys[:2])
+	require.Nil(t, err)
+
+	key0, mutex0 := submissionKeys.Next()
+	key1, mutex1 := submissionKeys.Next()
+	key2, mutex2 := submissionKeys.Next()
+	require.Equal(t, keys[0], key0)
+	require.Equal(t, keys[1], key1)
+	require.Equal(t, keys[0], key2)
+	require.NotSame(t, mutex0, mutex1)
+	require.Same(t, mutex0, mutex2)
+
+	// The mutexes of the kept addresses won't be changed after reloading.
+	require.ErrorIs(t, submissionKeys.Reload(nil), errNoSubmissionKeys)
+	require.Nil(t, submissionKeys.Reload(keys[1:]))
+	require.Equal(t, []common.Address{
+		crypto.PubkeyToAddress(keys[1].PublicKey),
+		crypto.PubkeyToAddress(keys[2].PublicKey),
+	}, submissionKeys.Addresses())
+
+	key1, reloadedMutex1 := submissionKeys.Next()
+	require.Equal(t, keys[1], key1)
+	require.Same(t, mutex1, reloadedMutex1)
+}
+
+func TestLoadSubmissionKeys(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	path := filepath.Join(t.TempDir(), "keys")
+	require.Nil(t, os.WriteFile(path, []byte(
+		"# submission keys\n\n"+common.Bytes2Hex(crypto.FromECDSA(key))+"\n 0x"+common.Bytes2Hex(crypto.FromECDSA(key))+" \n",
+	), 0600))
+
+	keys, err := LoadSubmissionKeys(path)
+	require.Nil(t, err)
+	require.Len(t, keys, 2)
+	require.Equal(t, key.D, keys[0].D)
+	require.Equal(t, key.D, keys[1].D)
+
+	require.Nil(t, os.WriteFile(path, []byte("# empty\n"), 0600))
+	_, err = LoadSubmissionKeys(path)
+	require.ErrorIs(t, err, errNoSubmissionKeys)
+
+	require.Nil(t, os.WriteFile(path, []byte("invalid"), 0600))
+	_, err = LoadSubmissionKeys(path)
+	require.ErrorContains(t, err, "line 1")
+
+	_, err = LoadSubmissionKeys(filepath.Join(t.TempDir(), "notExist"))
+	require.NotNil(t, err)
+}
+
+func TestSubmissionSenderRole(t *testing.T) {
+	prover := common.HexToAddress("0x01")
+
+	require.Equal(t, senderRoleProver, submissionSenderRole(prover, prover))
+	require.Equal(t, senderRoleSubmitter, submissionSenderRole(common.HexToAddress("0x02"), prover))
+	require.Equal(t, senderRoleUnknown, submissionSenderRole(common.Address{}, prover))
+}
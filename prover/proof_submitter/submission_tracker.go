@@ -0,0 +1,145 @@
+package submitter
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/taikoxyz/taiko-client/metrics"
+)
+
+// SubmissionOutcome is the outcome of a submitted proof.
+type SubmissionOutcome string
+
+const (
+	SubmissionPending  SubmissionOutcome = "pending"  // The proved block hasn't been verified yet
+	SubmissionVerified SubmissionOutcome = "verified" // The proved block hash has been verified
+	SubmissionWasted   SubmissionOutcome = "wasted"   // Another block hash has been verified instead
+)
+
+// SubmittedProof is a proof submitted by current prover, and its outcome.
+type SubmittedProof struct {
+	BlockID     uint64            `json:"blockID"`
+	ParentHash  common.Hash       `json:"parentHash"`
+	BlockHash   common.Hash       `json:"blockHash"`
+	TxHash      common.Hash       `json:"txHash"`
+	Sender      common.Address    `json:"sender"` // Account which paid for the transaction, zero if unknown
+	SubmittedAt time.Time         `json:"submittedAt"`
+	Outcome     SubmissionOutcome `json:"outcome"`
+}
+
+// SubmissionTracker tracks all proofs submitted by current prover in memory, and checks whether they
+// finally got verified, so that the wasted proofs can be measured. The entries older than the retention
+// will be trimmed. All methods of a nil SubmissionTracker are no-ops.
+type SubmissionTracker struct {
+	mutex     sync.Mutex
+	proofs    []*SubmittedProof
+	retention time.Duration
+}
+
+// NewSubmissionTracker creates a new SubmissionTracker instance keeping the entries for the given retention.
+func NewSubmissionTracker(retention time.Duration) *SubmissionTracker {
+	return &SubmissionTracker{retention: retention}
+}
+
+// Record adds a new pending entry of a proof submitted by the given transaction, sent by the given account.
+func (t *SubmissionTracker) Record(blockID uint64, parentHash, blockHash, txHash common.Hash, sender common.Address) {
+	if t == nil {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.trim(time.Now())
+	t.proofs = append(t.proofs, &SubmittedProof{
+		BlockID:     blockID,
+		ParentHash:  parentHash,
+		BlockHash:   blockHash,
+		TxHash:      txHash,
+		Sender:      sender,
+		SubmittedAt: time.Now(),
+		Outcome:     SubmissionPending,
+	})
+}
+
+// OnBlockVerified resolves the pending entries of the given verified block, by comparing the verified block
+// hash with the proved ones.
+func (t *SubmissionTracker) OnBlockVerified(blockID uint64, blockHash common.Hash) {
+	if t == nil {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for _, proof := range t.proofs {
+		if proof.BlockID != blockID || proof.Outcome != SubmissionPending {
+			continue
+		}
+
+		if proof.BlockHash == blockHash {
+			proof.Outcome = SubmissionVerified
+			metrics.ProverProofsVerifiedCounter.Inc(1)
+		} else {
+			proof.Outcome = SubmissionWasted
+			metrics.ProverProofsWastedCounter.Inc(1)
+		}
+	}
+
+	t.trim(time.Now())
+}
+
+// Restore adds the given entries restored from a snapshot, e.g. after a migration to another machine.
+func (t *SubmissionTracker) Restore(entries []SubmittedProof) {
+	if t == nil {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for i := range entries {
+		entry := entries[i]
+		t.proofs = append(t.proofs, &entry)
+	}
+	t.trim(time.Now())
+}
+
+// Entries returns copies of all the tracked entries, sorted by block ID.
+func (t *SubmissionTracker) Entries() []SubmittedProof {
+	if t == nil {
+		return nil
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.trim(time.Now())
+	entries := make([]SubmittedProof, 0, len(t.proofs))
+	for _, proof := range t.proofs {
+		entries = append(entries, *proof)
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].BlockID < entries[j].BlockID })
+
+	return entries
+}
+
+// trim removes the entries submitted before the retention, the caller must hold the mutex.
+func (t *SubmissionTracker) trim(now time.Time) {
+	if t.retention == 0 {
+		return
+	}
+
+	kept := t.proofs[:0]
+	for _, proof := range t.proofs {
+		if now.Sub(proof.SubmittedAt) <= t.retention {
+			kept = append(kept, proof)
+		}
+	}
+	for i := len(kept); i < len(t.proofs); i++ {
+		t.proofs[i] = nil
+	}
+	t.proofs = kept
+}
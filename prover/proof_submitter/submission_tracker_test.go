@@ -0,0 +1,82 @@
+package submitter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmissionTrackerOnBlockVerified(t *testing.T) {
+	var (
+		tracker = NewSubmissionTracker(time.Hour)
+		sender  = common.HexToAddress("0xb1")
+	)
+
+	tracker.Record(2, common.HexToHash("0x01"), common.HexToHash("0x02"), common.HexToHash("0xa2"), sender)
+	tracker.Record(1, common.HexToHash("0x00"), common.HexToHash("0x01"), common.HexToHash("0xa1"), sender)
+	tracker.Record(3, common.HexToHash("0x02"), common.HexToHash("0x03"), common.HexToHash("0xa3"), common.Address{})
+
+	tracker.OnBlockVerified(1, common.HexToHash("0x01"))
+	tracker.OnBlockVerified(2, common.HexToHash("0x04"))
+
+	entries := tracker.Entries()
+	require.Len(t, entries, 3)
+	require.Equal(t, uint64(1), entries[0].BlockID)
+	require.Equal(t, SubmissionVerified, entries[0].Outcome)
+	require.Equal(t, common.HexToHash("0xa1"), entries[0].TxHash)
+	require.Equal(t, sender, entries[0].Sender)
+	require.Equal(t, common.Address{}, entries[2].Sender)
+	require.Equal(t, SubmissionWasted, entries[1].Outcome)
+	require.Equal(t, SubmissionPending, entries[2].Outcome)
+
+	// The resolved outcomes never change.
+	tracker.OnBlockVerified(2, common.HexToHash("0x02"))
+	require.Equal(t, SubmissionWasted, tracker.Entries()[1].Outcome)
+}
+
+func TestSubmissionTrackerTrim(t *testing.T) {
+	tracker := NewSubmissionTracker(time.Hour)
+
+	tracker.Record(1, common.Hash{}, common.HexToHash("0x01"), common.HexToHash("0xa1"), common.Address{})
+	tracker.Record(2, common.Hash{}, common.HexToHash("0x02"), common.HexToHash("0xa2"), common.Address{})
+	tracker.proofs[0].SubmittedAt = time.Now().Add(-2 * time.Hour)
+
+	entries := tracker.Entries()
+	require.Len(t, entries, 1)
+	require.Equal(t, uint64(2), entries[0].BlockID)
+
+	// Zero retention keeps all the entries.
+	tracker = NewSubmissionTracker(0)
+	tracker.Record(1, common.Hash{}, common.HexToHash("0x01"), common.HexToHash("0xa1"), common.Address{})
+	tracker.proofs[0].SubmittedAt = time.Now().Add(-24 * time.Hour)
+	require.Len(t, tracker.Entries(), 1)
+}
+
+func TestSubmissionTrackerRestore(t *testing.T) {
+	tracker := NewSubmissionTracker(time.Hour)
+	tracker.Record(2, common.HexToHash("0x01"), common.HexToHash("0x02"), common.HexToHash("0xa2"), common.Address{})
+
+	tracker.Restore([]SubmittedProof{
+		{BlockID: 1, BlockHash: common.HexToHash("0x01"), SubmittedAt: time.Now(), Outcome: SubmissionPending},
+		{BlockID: 3, BlockHash: common.HexToHash("0x03"), SubmittedAt: time.Now().Add(-2 * time.Hour)},
+	})
+
+	// The expired restored entries are trimmed, and the pending ones are still resolved.
+	entries := tracker.Entries()
+	require.Len(t, entries, 2)
+	require.Equal(t, uint64(1), entries[0].BlockID)
+	tracker.OnBlockVerified(1, common.HexToHash("0x01"))
+	require.Equal(t, SubmissionVerified, tracker.Entries()[0].Outcome)
+}
+
+func TestNilSubmissionTracker(t *testing.T) {
+	var tracker *SubmissionTracker
+	require.NotPanics(t, func() {
+		tracker.Record(1, common.Hash{}, common.Hash{}, common.Hash{}, common.Address{})
+		tracker.OnBlockVerified(1, common.Hash{})
+		tracker.Restore([]SubmittedProof{{BlockID: 1}})
+		require.Nil(t, tracker.Entries())
+	})
+}
@@ -9,18 +9,42 @@ import (
 	"strings"
 
 	"github.com/cenkalti/backoff/v4"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/taikoxyz/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-client/metrics"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	"github.com/taikoxyz/taiko-client/prover/lifecycle"
 )
 
 var (
 	errUnretryable = errors.New("unretryable")
 )
 
+// unretryableError is the errUnretryable carrying the reason why the proof submission can't be retried.
+type unretryableError struct {
+	reason error
+}
+
+// Error implements the error interface.
+func (e *unretryableError) Error() string {
+	return fmt.Sprintf("%s: %v", errUnretryable, e.reason)
+}
+
+// Is makes the unretryableError match errUnretryable.
+func (e *unretryableError) Is(target error) bool {
+	return target == errUnretryable
+}
+
+// Unwrap returns the reason.
+func (e *unretryableError) Unwrap() error {
+	return e.reason
+}
+
 // isSubmitProofTxErrorRetryable checks whether the error returned by a proof submission transaction
 // is retryable.
 func isSubmitProofTxErrorRetryable(err error, blockID *big.Int) bool {
@@ -58,14 +82,43 @@ func getProveBlocksTxOpts(
 	return opts, nil
 }
 
-// sendTxWithBackoff tries to send the given proof submission transaction with a backoff policy.
+// getRevertReason re-simulates the given reverted transaction at its inclusion block, and tries to
+// decode the revert reason.
+func getRevertReason(ctx context.Context, cli *rpc.Client, tx *types.Transaction, blockNumber *big.Int) error {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return fmt.Errorf("%w: failed to recover sender: %v", rpc.ErrTxReverted, err)
+	}
+
+	if _, err = cli.L1.CallContract(ctx, ethereum.CallMsg{
+		From:      from,
+		To:        tx.To(),
+		Gas:       tx.Gas(),
+		GasFeeCap: tx.GasFeeCap(),
+		GasTipCap: tx.GasTipCap(),
+		Value:     tx.Value(),
+		Data:      tx.Data(),
+	}, blockNumber); err != nil {
+		return encoding.TryParsingCustomError(err)
+	}
+
+	return fmt.Errorf("%w: unknown reason", rpc.ErrTxReverted)
+}
+
+// sendTxWithBackoff tries to send the given proof submission transaction with a backoff policy, the results
+// of the mined transactions will be posted to the given lifecycle notifier. Returns the hash of the
+// successfully mined transaction.
 func sendTxWithBackoff(
 	ctx context.Context,
 	cli *rpc.Client,
 	blockID *big.Int,
+	notifier *lifecycle.Notifier,
 	sendTxFunc func() (*types.Transaction, error),
-) error {
-	var isUnretryableError bool
+) (common.Hash, error) {
+	var (
+		unretryableReason error
+		minedTxHash       common.Hash
+	)
 	if err := backoff.Retry(func() error {
 		if ctx.Err() != nil {
 			return nil
@@ -79,23 +132,52 @@ func sendTxWithBackoff(
 				return err
 			}
 
-			isUnretryableError = true
+			unretryableReason = err
 			return nil
 		}
 
-		if _, err := rpc.WaitReceipt(ctx, cli.L1, tx); err != nil {
-			log.Warn("Failed to wait till transaction executed", "blockID", blockID, "txHash", tx.Hash(), "error", err)
-			return err
+		receipt, err := rpc.WaitReceipt(ctx, cli.L1, tx)
+		if err != nil {
+			if !errors.Is(err, rpc.ErrTxReverted) {
+				log.Warn("Failed to wait till transaction executed", "blockID", blockID, "txHash", tx.Hash(), "error", err)
+				return err
+			}
+
+			// The transaction has been mined but reverted, decode the revert reason and then apply the
+			// same retry policy as a failed broadcast.
+			metrics.ProverRevertedProofTxCounter.Inc(1)
+			metrics.ProverRevertedProofTxGasUsedCounter.Inc(int64(receipt.GasUsed))
+
+			reason := getRevertReason(ctx, cli, tx, receipt.BlockNumber)
+			txHash := tx.Hash()
+			notifier.Notify(lifecycle.EventSubmissionReverted, blockID, &txHash, reason)
+			log.Warn(
+				"TaikoL1.proveBlock transaction reverted",
+				"blockID", blockID,
+				"txHash", tx.Hash(),
+				"gasUsed", receipt.GasUsed,
+				"reason", reason,
+			)
+			if isSubmitProofTxErrorRetryable(reason, blockID) {
+				return reason
+			}
+
+			unretryableReason = reason
+			return nil
 		}
 
+		metrics.ProverSuccessfulProofTxCounter.Inc(1)
+		minedTxHash = tx.Hash()
+		notifier.Notify(lifecycle.EventProofSubmitted, blockID, &minedTxHash, nil)
+
 		return nil
 	}, backoff.NewExponentialBackOff()); err != nil {
-		return fmt.Errorf("failed to send TaikoL1.proveBlock transaction: %w", err)
+		return common.Hash{}, fmt.Errorf("failed to send TaikoL1.proveBlock transaction: %w", err)
 	}
 
-	if isUnretryableError {
-		return errUnretryable
+	if unretryableReason != nil {
+		return common.Hash{}, &unretryableError{reason: unretryableReason}
 	}
 
-	return nil
+	return minedTxHash, nil
 }
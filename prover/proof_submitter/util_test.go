@@ -4,9 +4,13 @@ import (
 	"context"
 	"errors"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
 )
 
 func (s *ProofSubmitterTestSuite) TestIsSubmitProofTxErrorRetryable() {
@@ -27,13 +31,13 @@ func (s *ProofSubmitterTestSuite) TestGetProveBlocksTxOpts() {
 }
 
 func (s *ProofSubmitterTestSuite) TestSendTxWithBackoff() {
-	err := sendTxWithBackoff(context.Background(), s.RpcClient, common.Big1, func() (*types.Transaction, error) {
+	_, err := sendTxWithBackoff(context.Background(), s.RpcClient, common.Big1, nil, func() (*types.Transaction, error) {
 		return nil, errors.New("L1_TEST")
 	})
 
 	s.NotNil(err)
 
-	err = sendTxWithBackoff(context.Background(), s.RpcClient, common.Big1, func() (*types.Transaction, error) {
+	sendTx := func() (*types.Transaction, error) {
 		height, err := s.RpcClient.L1.BlockNumber(context.Background())
 		s.Nil(err)
 
@@ -48,7 +52,42 @@ func (s *ProofSubmitterTestSuite) TestSendTxWithBackoff() {
 		}
 
 		return block.Transactions()[0], nil
-	})
+	}
+
+	txHash, err := sendTxWithBackoff(context.Background(), s.RpcClient, common.Big1, nil, sendTx)
+	s.Nil(err)
+	s.NotEqual(common.Hash{}, txHash)
+}
 
+func (s *ProofSubmitterTestSuite) TestSendTxWithBackoffReverted() {
+	input, err := encoding.EncodeProveBlockInput(
+		&encoding.TaikoL1Evidence{Meta: bindings.TaikoDataBlockMetadata{}, Prover: testAddr},
+		types.NewTransaction(0, testAddr, common.Big0, 0, common.Big0, []byte{}),
+		types.NewReceipt([]byte{}, false, 0),
+	)
 	s.Nil(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// Block 0 can never be proven, set a fixed gas limit to skip the gas estimation, so that a reverted
+	// transaction will be mined.
+	_, err = sendTxWithBackoff(ctx, s.RpcClient, common.Big0, nil, func() (*types.Transaction, error) {
+		opts, err := getProveBlocksTxOpts(ctx, s.RpcClient.L1, s.RpcClient.L1ChainID, s.TestAddrPrivKey)
+		s.Nil(err)
+		opts.GasLimit = 1_000_000
+
+		return s.RpcClient.TaikoL1.ProveBlock(opts, common.Big0, input)
+	})
+	s.ErrorIs(err, errUnretryable)
+}
+
+func (s *ProofSubmitterTestSuite) TestGetRevertReason() {
+	// Unsigned transaction.
+	s.ErrorIs(getRevertReason(
+		context.Background(),
+		s.RpcClient,
+		types.NewTx(&types.DynamicFeeTx{ChainID: s.RpcClient.L1ChainID, Data: []byte{}}),
+		nil,
+	), rpc.ErrTxReverted)
 }
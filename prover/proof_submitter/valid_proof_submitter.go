@@ -5,17 +5,21 @@ import (
 	"crypto/ecdsa"
 	"errors"
 	"fmt"
-	"sync"
+	"time"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/log"
 	"github.com/taikoxyz/taiko-client/bindings"
 	"github.com/taikoxyz/taiko-client/bindings/encoding"
 	"github.com/taikoxyz/taiko-client/metrics"
+	"github.com/taikoxyz/taiko-client/pkg/blockfeed"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
 	anchorTxValidator "github.com/taikoxyz/taiko-client/prover/anchor_tx_validator"
+	"github.com/taikoxyz/taiko-client/prover/lifecycle"
+	proofArchive "github.com/taikoxyz/taiko-client/prover/proof_archive"
 	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
 )
 
@@ -28,57 +32,132 @@ type ValidProofSubmitter struct {
 	proofProducer     proofProducer.ProofProducer
 	reusltCh          chan *proofProducer.ProofWithHeader
 	anchorTxValidator *anchorTxValidator.AnchorTxValidator
-	proverPrivKey     *ecdsa.PrivateKey
 	proverAddress     common.Address
-	mutex             *sync.Mutex
+	taikoL1Address    common.Address
+	submissionKeys    *SubmissionKeys
+	notifier          *lifecycle.Notifier
+	competingProofs   *competingProofDetector
+	blockFeed         *blockfeed.Client
+	submissions       *SubmissionTracker
+	replicator        *ProofReplicator
+	feeStrategy       FeeStrategy
+	winRates          *winRateStats
+	dryRun            bool
+	archive           *proofArchive.Archive // If set, the artifacts of the submitted proofs are archived
+	// If set, the blocks whose proofs are rejected by the verifier are proved again, see RetryWithFallbackProducer.
+	invalidProofFallback *invalidProofFallback
 }
 
-// NewValidProofSubmitter creates a new ValidProofSubmitter instance.
+// ValidProofSubmitterOptions are the optional dependencies and settings of a ValidProofSubmitter, the zero
+// value of each field disables the feature or selects its default.
+type ValidProofSubmitterOptions struct {
+	// Notified of the proofs' lifecycle events.
+	Notifier *lifecycle.Notifier
+	// The submission is delayed at most this window, while a competing proof transaction for the same block is
+	// pending in L1 mempool, 0 means no delay.
+	CompetingProofWindow time.Duration
+	// The driver's block feed, which delivers the inserted L2 blocks before the L2 node is polled for them.
+	BlockFeed *blockfeed.Client
+	// Records the submitted proofs.
+	Submissions *SubmissionTracker
+	// Replicates the submitted proofs to the additional L1 chains.
+	Replicator *ProofReplicator
+	// Decides the priority fees, the unbounded flat one if nil.
+	FeeStrategy FeeStrategy
+	// Only simulates the proof transactions rather than sending them.
+	DryRun bool
+}
+
+// NewValidProofSubmitter creates a new ValidProofSubmitter instance, the proofs will be generated for the
+// given prover, and submitted by the given submission keys, nil options mean all the defaults.
 func NewValidProofSubmitter(
 	rpc *rpc.Client,
 	proofProducer proofProducer.ProofProducer,
 	reusltCh chan *proofProducer.ProofWithHeader,
+	taikoL1Address common.Address,
 	taikoL2Address common.Address,
 	proverPrivKey *ecdsa.PrivateKey,
-	mutex *sync.Mutex,
+	submissionKeys *SubmissionKeys,
+	opts *ValidProofSubmitterOptions,
 ) (*ValidProofSubmitter, error) {
 	anchorValidator, err := anchorTxValidator.New(taikoL2Address, rpc.L2ChainID, rpc)
 	if err != nil {
 		return nil, err
 	}
+	if opts == nil {
+		opts = new(ValidProofSubmitterOptions)
+	}
+	feeStrategy := opts.FeeStrategy
+	if feeStrategy == nil {
+		feeStrategy = &FlatFeeStrategy{}
+	}
 
 	return &ValidProofSubmitter{
 		rpc:               rpc,
 		proofProducer:     proofProducer,
 		reusltCh:          reusltCh,
 		anchorTxValidator: anchorValidator,
-		proverPrivKey:     proverPrivKey,
 		proverAddress:     crypto.PubkeyToAddress(proverPrivKey.PublicKey),
-		mutex:             mutex,
+		taikoL1Address:    taikoL1Address,
+		submissionKeys:    submissionKeys,
+		notifier:          opts.Notifier,
+		competingProofs: newCompetingProofDetector(
+			rpc,
+			taikoL1Address,
+			submissionKeys.Addresses,
+			opts.CompetingProofWindow,
+		),
+		blockFeed:   opts.BlockFeed,
+		submissions: opts.Submissions,
+		replicator:  opts.Replicator,
+		feeStrategy: feeStrategy,
+		winRates:    new(winRateStats),
+		dryRun:      opts.DryRun,
 	}, nil
 }
 
+// ArchiveProofs makes the submitter write the artifacts of the submitted proofs to the given archive.
+func (s *ValidProofSubmitter) ArchiveProofs(archive *proofArchive.Archive) {
+	s.archive = archive
+}
+
 // RequestProof implements the ProofSubmitter interface.
 func (s *ValidProofSubmitter) RequestProof(ctx context.Context, event *bindings.TaikoL1ClientBlockProposed) error {
-	l1Origin, err := s.rpc.WaitL1Origin(ctx, event.Id)
+	prepared, err := s.PrepareProof(ctx, event)
 	if err != nil {
-		return fmt.Errorf("failed to fetch l1Origin, blockID: %d, err: %w", event.Id, err)
+		return err
 	}
 
-	// Get the header of the block to prove from L2 execution engine.
-	header, err := s.rpc.L2.HeaderByHash(ctx, l1Origin.L2BlockHash)
+	return s.RequestPreparedProof(ctx, prepared)
+}
+
+// PrepareProof implements the StagedProofSubmitter interface.
+func (s *ValidProofSubmitter) PrepareProof(
+	ctx context.Context,
+	event *bindings.TaikoL1ClientBlockProposed,
+) (*PreparedProof, error) {
+	header, err := s.getBlockHeader(ctx, event)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Request proof.
-	opts := &proofProducer.ProofRequestOptions{
-		Height:             header.Number,
-		ProverAddress:      s.proverAddress,
-		ProposeBlockTxHash: event.Raw.TxHash,
-	}
+	return &PreparedProof{
+		Event:  event,
+		Header: header,
+		Opts: &proofProducer.ProofRequestOptions{
+			Height:             header.Number,
+			ProverAddress:      s.proverAddress,
+			ProposeBlockTxHash: event.Raw.TxHash,
+		},
+	}, nil
+}
 
-	if err := s.proofProducer.RequestProof(ctx, opts, event.Id, &event.Meta, header, s.reusltCh); err != nil {
+// RequestPreparedProof implements the StagedProofSubmitter interface.
+func (s *ValidProofSubmitter) RequestPreparedProof(ctx context.Context, prepared *PreparedProof) error {
+	event := prepared.Event
+	if err := s.proofProducer.RequestProof(
+		ctx, prepared.Opts, event.Id, &event.Meta, prepared.Header, s.reusltCh,
+	); err != nil {
 		return err
 	}
 
@@ -88,34 +167,203 @@ func (s *ValidProofSubmitter) RequestProof(ctx context.Context, event *bindings.
 	return nil
 }
 
+// getBlockHeader fetches the header of the block to prove, from the driver's block feed if the block
+// has arrived there and matches the given event, otherwise from the L2 execution engine.
+func (s *ValidProofSubmitter) getBlockHeader(
+	ctx context.Context,
+	event *bindings.TaikoL1ClientBlockProposed,
+) (*types.Header, error) {
+	block, err := s.blockFeed.Wait(ctx, event)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		proofProducer.LoggerFromContext(ctx).Warn(
+			"Untrusted block from block feed, fall back to L2 execution engine",
+			"blockID", event.Id,
+			"error", err,
+		)
+		metrics.ProverBlockFeedMismatchCounter.Inc(1)
+	} else if block != nil {
+		metrics.ProverBlockFeedHitCounter.Inc(1)
+		return block.Header, nil
+	}
+
+	l1Origin, err := s.rpc.WaitL1Origin(ctx, event.Id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch l1Origin, blockID: %d, err: %w", event.Id, err)
+	}
+
+	return s.rpc.L2.HeaderByHash(ctx, l1Origin.L2BlockHash)
+}
+
 // SubmitProof implements the ProofSubmitter interface.
 func (s *ValidProofSubmitter) SubmitProof(
 	ctx context.Context,
 	proofWithHeader *proofProducer.ProofWithHeader,
 ) (err error) {
-	log.Info(
+	proofWithHeader.Log().Info(
 		"New valid block proof",
 		"blockID", proofWithHeader.BlockID,
 		"beneficiary", proofWithHeader.Meta.Beneficiary,
 		"hash", proofWithHeader.Header.Hash(),
 		"proof", common.Bytes2Hex(proofWithHeader.ZkProof),
 	)
+	blockID := proofWithHeader.BlockID
+
+	metrics.ProverReceivedProofCounter.Inc(1)
+	metrics.ProverReceivedValidProofCounter.Inc(1)
+
+	block, evidence, input, err := s.prepareProveBlockInput(ctx, proofWithHeader)
+	if err != nil {
+		return err
+	}
+
+	// In dry-run mode, all checks have been done, simulate the TaikoL1.proveBlock transaction through
+	// `eth_call` instead of sending it.
+	if s.dryRun {
+		return s.simulateProof(ctx, proofWithHeader, input)
+	}
+
+	// Skip the submission if a competing proof transaction for the same block lands first.
+	landed, contested := s.competingProofs.waitCompetingProof(ctx, blockID)
+	if landed {
+		s.winRates.record(false, nil)
+		return nil
+	}
+
+	// Send the TaikoL1.proveBlock transaction, transactions sent by the same key are serialized to keep
+	// its nonces in order.
+	submissionKey, senderMutex := s.submissionKeys.Next()
+	txOpts, err := getProveBlocksTxOpts(ctx, s.rpc.L1, s.rpc.L1ChainID, submissionKey)
+	if err != nil {
+		return err
+	}
+	s.setPriorityFee(proofWithHeader, txOpts, contested)
+
+	sendTx := func() (*types.Transaction, error) {
+		senderMutex.Lock()
+		defer senderMutex.Unlock()
+
+		return s.rpc.TaikoL1.ProveBlock(txOpts, blockID, input)
+	}
+
+	txHash, err := sendTxWithBackoff(ctx, s.rpc, blockID, s.notifier, sendTx)
+	if err != nil {
+		if errors.Is(err, errUnretryable) {
+			s.winRates.record(false, txOpts.GasTipCap)
+			s.retryInvalidProof(ctx, proofWithHeader, err)
+			return nil
+		}
+
+		return err
+	}
+	s.winRates.record(true, txOpts.GasTipCap)
+	s.recordSubmission(proofWithHeader, evidence, block, txHash, txOpts.From)
+	s.invalidProofFallback.done(blockID.Uint64())
+
+	proofWithHeader.Log().Info(
+		"✅ Valid block proved",
+		"blockID", proofWithHeader.BlockID,
+		"hash", block.Hash(), "height", block.Number(),
+		"transactions", block.Transactions().Len(),
+		"submitter", txOpts.From,
+	)
+
+	metrics.ProverSentProofCounter.Inc(1)
+	metrics.ProverSentValidProofCounter.Inc(1)
+	metrics.ProverLatestProvenBlockIDGauge.Update(proofWithHeader.BlockID.Int64())
+	metrics.ProverProofSizeHistogram.Update(int64(len(proofWithHeader.ZkProof)))
+
+	// The proof has landed on the primary L1 chain, the replication failures are only logged.
+	if err := s.replicator.Replicate(ctx, blockID, input); err != nil {
+		proofWithHeader.Log().Warn("Proof replication incomplete", "blockID", blockID, "error", err)
+	}
+
+	return nil
+}
+
+// setPriorityFee sets the priority fee of the given proof submission transaction options by the fee
+// strategy, the suggested one is replaced.
+func (s *ValidProofSubmitter) setPriorityFee(
+	proofWithHeader *proofProducer.ProofWithHeader,
+	txOpts *bind.TransactOpts,
+	contested bool,
+) {
+	var (
+		suggested = txOpts.GasTipCap
+		inputs    = &FeeInputs{
+			WinRate:   s.winRates.winRate(),
+			Contested: contested,
+			BlockAge:  time.Since(time.Unix(int64(proofWithHeader.Meta.Timestamp), 0)),
+		}
+	)
+	txOpts.GasTipCap = s.feeStrategy.PriorityFee(suggested, inputs)
+
+	proofWithHeader.Log().Info(
+		"Proof submission priority fee",
+		"blockID", proofWithHeader.BlockID,
+		"strategy", s.feeStrategy.Name(),
+		"suggested", suggested,
+		"priorityFee", txOpts.GasTipCap,
+		"winRate", inputs.WinRate,
+		"contested", inputs.Contested,
+		"blockAge", inputs.BlockAge,
+	)
+}
+
+// recordSubmission tracks the given submitted proof of the given block, counts it by the role of the
+// account which paid for the submission transaction, and archives its artifact.
+func (s *ValidProofSubmitter) recordSubmission(
+	proofWithHeader *proofProducer.ProofWithHeader,
+	evidence *encoding.TaikoL1Evidence,
+	block *types.Block,
+	txHash common.Hash,
+	sender common.Address,
+) {
+	s.submissions.Record(proofWithHeader.BlockID.Uint64(), block.ParentHash(), block.Hash(), txHash, sender)
+	metrics.ProverSubmissionSenderCounter(submissionSenderRole(sender, s.proverAddress)).Inc(1)
+
+	s.archive.Put(&proofArchive.Artifact{
+		BlockID: proofWithHeader.BlockID,
+		Meta:    proofWithHeader.Meta,
+		Header:  proofWithHeader.Header,
+		PublicInputs: &proofArchive.PublicInputs{
+			ParentHash: evidence.ParentHash,
+			BlockHash:  evidence.BlockHash,
+			SignalRoot: evidence.SignalRoot,
+			Graffiti:   evidence.Graffiti,
+			Prover:     evidence.Prover,
+			VerifierID: evidence.Zkproof.VerifierId,
+		},
+		ZkProof:     proofWithHeader.ZkProof,
+		Job:         &proofArchive.Job{Degree: proofWithHeader.Degree, Origin: proofWithHeader.Origin},
+		TxHash:      txHash,
+		Submitter:   sender,
+		SubmittedAt: time.Now().UTC(),
+	})
+}
+
+// prepareProveBlockInput validates the L2 block of the given proof, and then assembles the evidence and
+// encodes the TaikoL1.proveBlock transaction input.
+func (s *ValidProofSubmitter) prepareProveBlockInput(
+	ctx context.Context,
+	proofWithHeader *proofProducer.ProofWithHeader,
+) (*types.Block, *encoding.TaikoL1Evidence, []byte, error) {
 	var (
 		blockID = proofWithHeader.BlockID
 		header  = proofWithHeader.Header
 		zkProof = proofWithHeader.ZkProof
 	)
 
-	metrics.ProverReceivedProofCounter.Inc(1)
-	metrics.ProverReceivedValidProofCounter.Inc(1)
-
 	// Get the corresponding L2 block.
 	block, err := s.rpc.L2.BlockByHash(ctx, header.Hash())
 	if err != nil {
-		return fmt.Errorf("failed to get L2 block with given hash %s: %w", header.Hash(), err)
+		return nil, nil, nil, fmt.Errorf("failed to get L2 block with given hash %s: %w", header.Hash(), err)
 	}
 
-	log.Debug(
+	proofWithHeader.Log().Debug(
 		"Get the L2 block to prove",
 		"blockID", blockID,
 		"hash", block.Hash(),
@@ -124,29 +372,29 @@ func (s *ValidProofSubmitter) SubmitProof(
 	)
 
 	if block.Transactions().Len() == 0 {
-		return fmt.Errorf("invalid block without anchor transaction, blockID %s", blockID)
+		return nil, nil, nil, fmt.Errorf("invalid block without anchor transaction, blockID %s", blockID)
 	}
 
 	// Validate TaikoL2.anchor transaction inside the L2 block.
 	anchorTx := block.Transactions()[0]
 	if err := s.anchorTxValidator.ValidateAnchorTx(ctx, anchorTx); err != nil {
-		return fmt.Errorf("invalid anchor transaction: %w", err)
+		return nil, nil, nil, fmt.Errorf("invalid anchor transaction: %w", err)
 	}
 
 	// Get and validate this anchor transaction's receipt.
 	anchorTxReceipt, err := s.anchorTxValidator.GetAndValidateAnchorTxReceipt(ctx, anchorTx)
 	if err != nil {
-		return fmt.Errorf("failed to fetch anchor transaction receipt: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to fetch anchor transaction receipt: %w", err)
 	}
 
 	circuitsIdx, err := proofProducer.DegreeToCircuitsIdx(proofWithHeader.Degree)
 	if err != nil {
-		return err
+		return nil, nil, nil, err
 	}
 
 	signalRoot, err := s.anchorTxValidator.GetAnchoredSignalRoot(ctx, anchorTx)
 	if err != nil {
-		return err
+		return nil, nil, nil, err
 	}
 
 	evidence := &encoding.TaikoL1Evidence{
@@ -161,40 +409,69 @@ func (s *ValidProofSubmitter) SubmitProof(
 
 	input, err := encoding.EncodeProveBlockInput(evidence, anchorTx, anchorTxReceipt)
 	if err != nil {
-		return fmt.Errorf("failed to encode TaikoL1.proveBlock inputs: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to encode TaikoL1.proveBlock inputs: %w", err)
 	}
 
-	// Send the TaikoL1.proveBlock transaction.
-	txOpts, err := getProveBlocksTxOpts(ctx, s.rpc.L1, s.rpc.L1ChainID, s.proverPrivKey)
+	return block, evidence, input, nil
+}
+
+// simulateProof calls TaikoL1.proveBlock with the calldata which would have been sent in dry-run mode through
+// `eth_call`, and logs the simulation result, a reverted simulation won't be treated as an error.
+func (s *ValidProofSubmitter) simulateProof(
+	ctx context.Context,
+	proofWithHeader *proofProducer.ProofWithHeader,
+	input []byte,
+) error {
+	taikoL1ABI, err := bindings.TaikoL1ClientMetaData.GetAbi()
 	if err != nil {
 		return err
 	}
 
-	sendTx := func() (*types.Transaction, error) {
-		s.mutex.Lock()
-		defer s.mutex.Unlock()
-
-		return s.rpc.TaikoL1.ProveBlock(txOpts, blockID, input)
+	calldata, err := taikoL1ABI.Pack("proveBlock", proofWithHeader.BlockID, input)
+	if err != nil {
+		return fmt.Errorf("failed to pack TaikoL1.proveBlock calldata: %w", err)
 	}
 
-	if err := sendTxWithBackoff(ctx, s.rpc, blockID, sendTx); err != nil {
-		if errors.Is(err, errUnretryable) {
-			return nil
+	// Hold the proof submission transaction mutex as usual, so that the dry-run pipeline behaves identically.
+	_, senderMutex := s.submissionKeys.Next()
+	senderMutex.Lock()
+	defer senderMutex.Unlock()
+
+	metrics.ProverDryRunProofsCounter.Inc(1)
+
+	if _, err := s.rpc.L1.CallContract(ctx, ethereum.CallMsg{
+		From: s.proverAddress,
+		To:   &s.taikoL1Address,
+		Data: calldata,
+	}, nil); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
 		}
 
-		return err
+		proofWithHeader.Log().Warn(
+			"🧪 Dry run, TaikoL1.proveBlock simulation reverted",
+			"blockID", proofWithHeader.BlockID,
+			"hash", proofWithHeader.Header.Hash(),
+			"proofSize", len(proofWithHeader.ZkProof),
+			"calldataSize", len(calldata),
+			"calldata", common.Bytes2Hex(calldata),
+			"generationTime", proofWithHeader.GenerationTime,
+			"reason", encoding.TryParsingCustomError(err),
+		)
+		metrics.ProverDryRunRevertedProofsCounter.Inc(1)
+
+		return nil
 	}
 
-	log.Info(
-		"✅ Valid block proved",
+	proofWithHeader.Log().Info(
+		"🧪 Dry run, TaikoL1.proveBlock simulation succeeded",
 		"blockID", proofWithHeader.BlockID,
-		"hash", block.Hash(), "height", block.Number(),
-		"transactions", block.Transactions().Len(),
+		"hash", proofWithHeader.Header.Hash(),
+		"proofSize", len(proofWithHeader.ZkProof),
+		"calldataSize", len(calldata),
+		"calldata", common.Bytes2Hex(calldata),
+		"generationTime", proofWithHeader.GenerationTime,
 	)
 
-	metrics.ProverSentProofCounter.Inc(1)
-	metrics.ProverSentValidProofCounter.Inc(1)
-	metrics.ProverLatestProvenBlockIDGauge.Update(proofWithHeader.BlockID.Int64())
-
 	return nil
 }
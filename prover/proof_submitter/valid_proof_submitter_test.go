@@ -2,8 +2,8 @@ package submitter
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"os"
-	"sync"
 	"testing"
 	"time"
 
@@ -15,6 +15,7 @@ import (
 	"github.com/taikoxyz/taiko-client/driver/chain_syncer/beaconsync"
 	"github.com/taikoxyz/taiko-client/driver/chain_syncer/calldata"
 	"github.com/taikoxyz/taiko-client/driver/state"
+	"github.com/taikoxyz/taiko-client/pkg/protocol"
 	"github.com/taikoxyz/taiko-client/proposer"
 	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
 	"github.com/taikoxyz/taiko-client/testutils"
@@ -35,16 +36,21 @@ func (s *ProofSubmitterTestSuite) SetupTest() {
 	l1ProverPrivKey, err := crypto.ToECDSA(common.Hex2Bytes(os.Getenv("L1_PROVER_PRIVATE_KEY")))
 	s.Nil(err)
 
+	submissionKeys, err := NewSubmissionKeys([]*ecdsa.PrivateKey{l1ProverPrivKey})
+	s.Nil(err)
+
 	s.validProofCh = make(chan *proofProducer.ProofWithHeader, 1024)
 	s.invalidProofCh = make(chan *proofProducer.ProofWithHeader, 1024)
 
 	s.validProofSubmitter, err = NewValidProofSubmitter(
 		s.RpcClient,
-		&proofProducer.DummyProofProducer{},
+		proofProducer.NewZeroDelayDummyProofProducer(),
 		s.validProofCh,
+		common.HexToAddress(os.Getenv("TAIKO_L1_ADDRESS")),
 		common.HexToAddress(os.Getenv("TAIKO_L2_ADDRESS")),
 		l1ProverPrivKey,
-		&sync.Mutex{},
+		submissionKeys,
+		&ValidProofSubmitterOptions{Submissions: NewSubmissionTracker(time.Hour)},
 	)
 	s.Nil(err)
 
@@ -54,12 +60,16 @@ func (s *ProofSubmitterTestSuite) SetupTest() {
 
 	tracker := beaconsync.NewSyncProgressTracker(s.RpcClient.L2, 30*time.Second)
 
+	protocolConfigs, err := protocol.New(context.Background(), s.RpcClient.TaikoL1)
+	s.Nil(err)
+
 	s.calldataSyncer, err = calldata.NewSyncer(
 		context.Background(),
 		s.RpcClient,
 		testState,
 		tracker,
 		common.HexToAddress(os.Getenv("L1_SIGNAL_SERVICE_CONTRACT_ADDRESS")),
+		protocolConfigs,
 	)
 	s.Nil(err)
 
@@ -112,6 +122,68 @@ func (s *ProofSubmitterTestSuite) TestValidSubmitProofs() {
 		proofWithHeader := <-s.validProofCh
 		s.Nil(s.validProofSubmitter.SubmitProof(context.Background(), proofWithHeader))
 	}
+
+	submitted := s.validProofSubmitter.submissions.Entries()
+	s.Equal(len(events), len(submitted))
+	for _, proof := range submitted {
+		s.Equal(SubmissionPending, proof.Outcome)
+		s.NotEqual(common.Hash{}, proof.TxHash)
+	}
+}
+
+func (s *ProofSubmitterTestSuite) TestValidSubmitProofsWithSubmissionKeys() {
+	l1ProverPrivKey, err := crypto.ToECDSA(common.Hex2Bytes(os.Getenv("L1_PROVER_PRIVATE_KEY")))
+	s.Nil(err)
+	l1ProposerPrivKey, err := crypto.ToECDSA(common.Hex2Bytes(os.Getenv("L1_PROPOSER_PRIVATE_KEY")))
+	s.Nil(err)
+
+	submissionKeys, err := NewSubmissionKeys([]*ecdsa.PrivateKey{l1ProposerPrivKey, l1ProverPrivKey})
+	s.Nil(err)
+
+	submitter, err := NewValidProofSubmitter(
+		s.RpcClient,
+		proofProducer.NewZeroDelayDummyProofProducer(),
+		s.validProofCh,
+		common.HexToAddress(os.Getenv("TAIKO_L1_ADDRESS")),
+		common.HexToAddress(os.Getenv("TAIKO_L2_ADDRESS")),
+		l1ProverPrivKey,
+		submissionKeys,
+		nil,
+	)
+	s.Nil(err)
+
+	events := testutils.ProposeAndInsertEmptyBlocks(&s.ClientTestSuite, s.proposer, s.calldataSyncer)
+
+	for _, e := range events {
+		s.Nil(submitter.RequestProof(context.Background(), e))
+		proofWithHeader := <-s.validProofCh
+		s.Nil(submitter.SubmitProof(context.Background(), proofWithHeader))
+	}
+}
+
+func (s *ProofSubmitterTestSuite) TestValidSubmitProofsDryRun() {
+	l1ProverPrivKey, err := crypto.ToECDSA(common.Hex2Bytes(os.Getenv("L1_PROVER_PRIVATE_KEY")))
+	s.Nil(err)
+
+	dryRunSubmitter, err := NewValidProofSubmitter(
+		s.RpcClient,
+		proofProducer.NewZeroDelayDummyProofProducer(),
+		s.validProofCh,
+		common.HexToAddress(os.Getenv("TAIKO_L1_ADDRESS")),
+		common.HexToAddress(os.Getenv("TAIKO_L2_ADDRESS")),
+		l1ProverPrivKey,
+		s.validProofSubmitter.submissionKeys,
+		&ValidProofSubmitterOptions{DryRun: true},
+	)
+	s.Nil(err)
+
+	events := testutils.ProposeAndInsertEmptyBlocks(&s.ClientTestSuite, s.proposer, s.calldataSyncer)
+
+	for _, e := range events {
+		s.Nil(dryRunSubmitter.RequestProof(context.Background(), e))
+		proofWithHeader := <-s.validProofCh
+		s.Nil(dryRunSubmitter.SubmitProof(context.Background(), proofWithHeader))
+	}
 }
 
 func TestProofSubmitterTestSuite(t *testing.T) {
@@ -0,0 +1,145 @@
+package prover
+
+import (
+	"sort"
+	"time"
+
+	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+)
+
+var (
+	proofTimesRetention     = 1 * time.Hour
+	proofTimesFlushInterval = 5 * time.Minute
+)
+
+// ProofTimes contains the timings of each proving stage of a block, which is exposed by the
+// `/debug/proof-times` endpoint: BlockProposed event observed -> proof requested, proof requested ->
+// proof received, proof received -> proof submitted.
+type ProofTimes struct {
+	BlockID          uint64     `json:"blockID"`
+	ObservedAt       time.Time  `json:"observedAt"`
+	RequestedAt      time.Time  `json:"requestedAt"`
+	ReceivedAt       *time.Time `json:"receivedAt,omitempty"`
+	SubmittedAt      *time.Time `json:"submittedAt,omitempty"`
+	DispatchMillis   int64      `json:"dispatchMillis"`
+	GenerationMillis int64      `json:"generationMillis,omitempty"`
+	SubmissionMillis int64      `json:"submissionMillis,omitempty"`
+
+	// Estimated proof generation time when the proof is requested, if there is any estimate
+	Estimate *proofProducer.ProofTimeEstimate `json:"estimate,omitempty"`
+}
+
+// InFlightProof is a proof requested but not received yet, with its estimated time of arrival, which is
+// exposed by the `/status` endpoint.
+type InFlightProof struct {
+	BlockID     uint64     `json:"blockID"`
+	RequestedAt time.Time  `json:"requestedAt"`
+	ETA         *time.Time `json:"eta,omitempty"`    // By the p50 of the estimated proof generation time
+	ETAP90      *time.Time `json:"etaP90,omitempty"` // By the p90 of the estimated proof generation time
+}
+
+// recordProofRequested starts tracking the timings of the given block, when its proof is requested, the
+// given estimate is optional.
+func (p *Prover) recordProofRequested(
+	blockID uint64,
+	observedAt time.Time,
+	estimate *proofProducer.ProofTimeEstimate,
+) {
+	now := time.Now()
+	p.proofTimes.Store(blockID, ProofTimes{
+		BlockID:        blockID,
+		ObservedAt:     observedAt,
+		RequestedAt:    now,
+		DispatchMillis: now.Sub(observedAt).Milliseconds(),
+		Estimate:       estimate,
+	})
+}
+
+// recordProofReceived records the time when the given block's proof is received from the proof producer.
+func (p *Prover) recordProofReceived(blockID uint64) {
+	v, ok := p.proofTimes.Load(blockID)
+	if !ok {
+		return
+	}
+
+	// The entries are updated by copy, so that the readers never see a partially updated one.
+	times, now := v.(ProofTimes), time.Now()
+	times.ReceivedAt = &now
+	times.GenerationMillis = now.Sub(times.RequestedAt).Milliseconds()
+	p.proofTimes.Store(blockID, times)
+}
+
+// recordProofSubmitted records the time when the given block's proof submission returns.
+func (p *Prover) recordProofSubmitted(blockID uint64) {
+	v, ok := p.proofTimes.Load(blockID)
+	if !ok {
+		return
+	}
+
+	times, now := v.(ProofTimes), time.Now()
+	if times.ReceivedAt == nil {
+		return
+	}
+	times.SubmittedAt = &now
+	times.SubmissionMillis = now.Sub(*times.ReceivedAt).Milliseconds()
+	p.proofTimes.Store(blockID, times)
+}
+
+// ProofTimes returns the proving timings of all tracked blocks, sorted by block ID.
+func (p *Prover) ProofTimes() []ProofTimes {
+	all := make([]ProofTimes, 0)
+	p.proofTimes.Range(func(_, v interface{}) bool {
+		all = append(all, v.(ProofTimes))
+		return true
+	})
+	sort.Slice(all, func(i, j int) bool { return all[i].BlockID < all[j].BlockID })
+
+	return all
+}
+
+// inFlightProofs returns the tracked blocks whose proofs have not been received yet, sorted by block ID.
+func (p *Prover) inFlightProofs() []InFlightProof {
+	all := make([]InFlightProof, 0)
+	for _, times := range p.ProofTimes() {
+		if times.ReceivedAt != nil {
+			continue
+		}
+
+		proof := InFlightProof{BlockID: times.BlockID, RequestedAt: times.RequestedAt}
+		if times.Estimate != nil {
+			eta, etaP90 := times.RequestedAt.Add(times.Estimate.P50), times.RequestedAt.Add(times.Estimate.P90)
+			proof.ETA, proof.ETAP90 = &eta, &etaP90
+		}
+		all = append(all, proof)
+	}
+
+	return all
+}
+
+// flushProofTimes removes the timings of the blocks observed before the given time.
+func (p *Prover) flushProofTimes(before time.Time) {
+	p.proofTimes.Range(func(k, v interface{}) bool {
+		if v.(ProofTimes).ObservedAt.Before(before) {
+			p.proofTimes.Delete(k)
+		}
+		return true
+	})
+}
+
+// monitorProofTimes flushes the proving timings older than the retention periodically.
+func (p *Prover) monitorProofTimes() {
+	ticker := time.NewTicker(proofTimesFlushInterval)
+	defer func() {
+		ticker.Stop()
+		p.wg.Done()
+	}()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			p.flushProofTimes(time.Now().Add(-proofTimesRetention))
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package prover
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+)
+
+func TestProofTimes(t *testing.T) {
+	p := new(Prover)
+	now := time.Now()
+
+	// Not requested yet.
+	p.recordProofReceived(1)
+	p.recordProofSubmitted(1)
+	require.Empty(t, p.ProofTimes())
+
+	estimate := &proofProducer.ProofTimeEstimate{P50: time.Minute, P90: time.Hour, Samples: 5}
+	p.recordProofRequested(2, now.Add(-2*time.Second), estimate)
+	p.recordProofRequested(1, now.Add(-time.Second), nil)
+
+	// Not received yet.
+	p.recordProofSubmitted(1)
+	p.recordProofReceived(1)
+	p.recordProofSubmitted(1)
+
+	times := p.ProofTimes()
+	require.Len(t, times, 2)
+	require.Equal(t, uint64(1), times[0].BlockID)
+	require.GreaterOrEqual(t, times[0].DispatchMillis, int64(1000))
+	require.NotNil(t, times[0].ReceivedAt)
+	require.NotNil(t, times[0].SubmittedAt)
+	require.False(t, times[0].SubmittedAt.Before(*times[0].ReceivedAt))
+
+	require.Equal(t, uint64(2), times[1].BlockID)
+	require.GreaterOrEqual(t, times[1].DispatchMillis, int64(2000))
+	require.Nil(t, times[1].ReceivedAt)
+	require.Nil(t, times[1].SubmittedAt)
+	require.Equal(t, estimate, times[1].Estimate)
+
+	// Only the proof not received yet is in flight, with its ETA.
+	inFlight := p.inFlightProofs()
+	require.Len(t, inFlight, 1)
+	require.Equal(t, uint64(2), inFlight[0].BlockID)
+	require.Equal(t, times[1].RequestedAt.Add(time.Minute), *inFlight[0].ETA)
+	require.Equal(t, times[1].RequestedAt.Add(time.Hour), *inFlight[0].ETAP90)
+
+	p.flushProofTimes(now.Add(-1500 * time.Millisecond))
+	times = p.ProofTimes()
+	require.Len(t, times, 1)
+	require.Equal(t, uint64(1), times[0].BlockID)
+	require.Empty(t, p.inFlightProofs())
+}
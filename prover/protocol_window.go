@@ -0,0 +1,36 @@
+package prover
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// inProtocolWindow checks whether the given block ID is in the protocol window
+// (lastVerifiedID, lastVerifiedID + maxNumBlocks], the blocks outside it will be rejected by the protocol.
+func inProtocolWindow(id uint64, lastVerifiedID uint64, maxNumBlocks uint64) bool {
+	return id > lastVerifiedID && id-lastVerifiedID <= maxNumBlocks
+}
+
+// isInProtocolWindow checks whether the given block ID is in the current protocol window, the cached latest
+// verified block ID will be refreshed once if the block seems to be ahead of the window, since the cache
+// might lag behind the protocol.
+func (p *Prover) isInProtocolWindow(ctx context.Context, id uint64) (bool, error) {
+	maxNumBlocks := p.protocolConfigs.Config().MaxNumProposedBlocks
+	if inProtocolWindow(id, p.latestVerifiedID, maxNumBlocks) {
+		return true, nil
+	}
+
+	if id <= p.latestVerifiedID {
+		return false, nil
+	}
+
+	stateVars, err := p.rpc.GetProtocolStateVariables(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return false, fmt.Errorf("failed to get protocol state variables: %w", err)
+	}
+	p.latestVerifiedID = stateVars.LastVerifiedBlockId
+
+	return inProtocolWindow(id, p.latestVerifiedID, maxNumBlocks), nil
+}
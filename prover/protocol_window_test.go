@@ -0,0 +1,29 @@
+package prover
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInProtocolWindow(t *testing.T) {
+	// The latest verified block itself and the blocks before it.
+	require.False(t, inProtocolWindow(10, 10, 5))
+	require.False(t, inProtocolWindow(9, 10, 5))
+	require.False(t, inProtocolWindow(0, 10, 5))
+
+	// The lower and upper bounds.
+	require.True(t, inProtocolWindow(11, 10, 5))
+	require.True(t, inProtocolWindow(15, 10, 5))
+	require.False(t, inProtocolWindow(16, 10, 5))
+
+	// Nothing verified yet.
+	require.False(t, inProtocolWindow(0, 0, 5))
+	require.True(t, inProtocolWindow(1, 0, 5))
+	require.True(t, inProtocolWindow(5, 0, 5))
+	require.False(t, inProtocolWindow(6, 0, 5))
+
+	// No overflow near the uint64 upper bound.
+	require.True(t, inProtocolWindow(^uint64(0), ^uint64(0)-1, 5))
+	require.False(t, inProtocolWindow(^uint64(0), 0, 5))
+}
@@ -0,0 +1,144 @@
+package prover
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/metrics"
+	eventIterator "github.com/taikoxyz/taiko-client/pkg/chain_iterator/event_iterator"
+)
+
+// forkChoiceKey identifies a fork choice of a block.
+type forkChoiceKey struct {
+	BlockID    uint64
+	ParentHash common.Hash
+}
+
+// provenBlock is a fork choice proved by current prover in the previous runs.
+type provenBlock struct {
+	forkChoiceKey
+	BlockHash   common.Hash
+	TxHash      common.Hash
+	Overwritten bool // whether the fork choice has been proved by another prover afterwards
+}
+
+// reconcileProvenBlocks rebuilds the set of the fork choices proved by current prover, from the BlockProven
+// events emitted in the latest reconcile window, and seeds the submission tracker with the not verified ones,
+// so that their outcomes are still tracked after a restart.
+func (p *Prover) reconcileProvenBlocks(ctx context.Context, window uint64) error {
+	if window == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	l1Head, err := p.rpc.L1.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L1 head: %w", err)
+	}
+	var from uint64
+	if l1Head > window {
+		from = l1Head - window
+	}
+
+	var events []*bindings.TaikoL1ClientBlockProven
+	iter, err := eventIterator.NewBlockProvenIterator(ctx, &eventIterator.BlockProvenIteratorConfig{
+		Client:      p.rpc.L1,
+		TaikoL1:     p.rpc.TaikoL1,
+		StartHeight: new(big.Int).SetUint64(from),
+		EndHeight:   new(big.Int).SetUint64(l1Head),
+		OnBlockProvenEvent: func(
+			_ context.Context,
+			event *bindings.TaikoL1ClientBlockProven,
+			_ eventIterator.EndBlockProvenEventIterFunc,
+		) error {
+			events = append(events, event)
+			return nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if err := iter.Iter(); err != nil {
+		return fmt.Errorf("failed to iterate BlockProven events: %w", err)
+	}
+
+	var (
+		proven      = collectProvenBlocks(events, p.proverAddress)
+		overwritten int
+		seeded      int
+	)
+	for _, block := range proven {
+		if block.Overwritten {
+			overwritten++
+			log.Warn(
+				"Fork choice proved by current prover has been overwritten by another prover",
+				"blockID", block.BlockID,
+				"parentHash", block.ParentHash,
+				"blockHash", block.BlockHash,
+				"txHash", block.TxHash,
+			)
+			continue
+		}
+
+		if block.BlockID > p.latestVerifiedID {
+			p.submissions.Record(block.BlockID, block.ParentHash, block.BlockHash, block.TxHash, common.Address{})
+			seeded++
+		}
+	}
+
+	metrics.ProverReconciledProvenBlocksGauge.Update(int64(len(proven)))
+	metrics.ProverReconciledOverwrittenGauge.Update(int64(overwritten))
+
+	log.Info(
+		"Reconciled the blocks proved in the previous runs",
+		"fromL1", from,
+		"toL1", l1Head,
+		"events", len(events),
+		"provedByUs", len(proven),
+		"overwritten", overwritten,
+		"seeded", seeded,
+		"elapsed", time.Since(start),
+	)
+
+	return nil
+}
+
+// collectProvenBlocks collects the fork choices proved by the given prover from the given BlockProven events
+// in emission order, sorted by block ID. A fork choice is marked as overwritten if its latest event was
+// emitted for another prover.
+func collectProvenBlocks(events []*bindings.TaikoL1ClientBlockProven, prover common.Address) []*provenBlock {
+	provedByUs := make(map[forkChoiceKey]*provenBlock)
+	for _, event := range events {
+		key := forkChoiceKey{BlockID: event.Id.Uint64(), ParentHash: event.ParentHash}
+
+		if event.Prover == prover {
+			provedByUs[key] = &provenBlock{
+				forkChoiceKey: key,
+				BlockHash:     event.BlockHash,
+				TxHash:        event.Raw.TxHash,
+			}
+		} else if block, ok := provedByUs[key]; ok {
+			block.Overwritten = true
+		}
+	}
+
+	blocks := make([]*provenBlock, 0, len(provedByUs))
+	for _, block := range provedByUs {
+		blocks = append(blocks, block)
+	}
+	sort.Slice(blocks, func(i, j int) bool {
+		if blocks[i].BlockID != blocks[j].BlockID {
+			return blocks[i].BlockID < blocks[j].BlockID
+		}
+		return bytes.Compare(blocks[i].ParentHash[:], blocks[j].ParentHash[:]) < 0
+	})
+
+	return blocks
+}
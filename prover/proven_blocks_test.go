@@ -0,0 +1,56 @@
+package prover
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+func TestCollectProvenBlocks(t *testing.T) {
+	var (
+		us    = common.HexToAddress("0x01")
+		other = common.HexToAddress("0x02")
+	)
+	newEvent := func(
+		id int64,
+		parentHash string,
+		prover common.Address,
+		txHash string,
+	) *bindings.TaikoL1ClientBlockProven {
+		return &bindings.TaikoL1ClientBlockProven{
+			Id:         big.NewInt(id),
+			ParentHash: common.HexToHash(parentHash),
+			BlockHash:  common.HexToHash(txHash),
+			Prover:     prover,
+			Raw:        types.Log{TxHash: common.HexToHash(txHash)},
+		}
+	}
+
+	blocks := collectProvenBlocks([]*bindings.TaikoL1ClientBlockProven{
+		newEvent(3, "0x02", us, "0xa3"),
+		newEvent(1, "0x00", us, "0xa1"),
+		newEvent(2, "0x01", other, "0xb2"),
+		// Overwritten by another prover afterwards.
+		newEvent(4, "0x03", us, "0xa4"),
+		newEvent(4, "0x03", other, "0xb4"),
+		// Proved by us after another prover.
+		newEvent(5, "0x04", other, "0xb5"),
+		newEvent(5, "0x04", us, "0xa5"),
+	}, us)
+
+	require.Len(t, blocks, 4)
+	for i, id := range []uint64{1, 3, 4, 5} {
+		require.Equal(t, id, blocks[i].BlockID)
+	}
+	require.Equal(t, common.HexToHash("0xa1"), blocks[0].TxHash)
+	require.False(t, blocks[1].Overwritten)
+	require.True(t, blocks[2].Overwritten)
+	require.False(t, blocks[3].Overwritten)
+	require.Equal(t, common.HexToHash("0xa5"), blocks[3].TxHash)
+
+	require.Empty(t, collectProvenBlocks(nil, us))
+}
@@ -2,64 +2,159 @@ package prover
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"net/http"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/taikoxyz/taiko-client/bindings"
 	"github.com/taikoxyz/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-client/cmd/flags"
 	"github.com/taikoxyz/taiko-client/metrics"
+	"github.com/taikoxyz/taiko-client/pkg/blockfeed"
 	eventIterator "github.com/taikoxyz/taiko-client/pkg/chain_iterator/event_iterator"
+	"github.com/taikoxyz/taiko-client/pkg/logsampler"
+	phaseTracker "github.com/taikoxyz/taiko-client/pkg/phase_tracker"
+	"github.com/taikoxyz/taiko-client/pkg/protocol"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	"github.com/taikoxyz/taiko-client/pkg/server"
 	txListValidator "github.com/taikoxyz/taiko-client/pkg/tx_list_validator"
+	"github.com/taikoxyz/taiko-client/prover/cache"
+	"github.com/taikoxyz/taiko-client/prover/divergence"
+	"github.com/taikoxyz/taiko-client/prover/lease"
+	"github.com/taikoxyz/taiko-client/prover/lifecycle"
+	proofArchive "github.com/taikoxyz/taiko-client/prover/proof_archive"
+	proofCache "github.com/taikoxyz/taiko-client/prover/proof_cache"
 	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
 	proofSubmitter "github.com/taikoxyz/taiko-client/prover/proof_submitter"
+	"github.com/taikoxyz/taiko-client/prover/snapshot"
 	"github.com/urfave/cli/v2"
+	"golang.org/x/sync/singleflight"
 )
 
+var (
+	defaultPollInterval              = 12 * time.Second
+	defaultBlockDedupCacheSize       = uint(1024)
+	defaultPriorityQueueSize         = uint(1024)
+	defaultProofClassWeight          = uint(1)
+	defaultEventChBufferSize         = uint(1024)
+	defaultProofChBufferSize         = uint(1024)
+	defaultCapacityProbeInterval     = 10 * time.Second
+	defaultRequestProofMaxAttempts   = uint64(5)
+	defaultRequestProofRetryInterval = 10 * time.Second
+)
+
+// Prover's startup phases, the startup finishes when the first proving operation, which catches up
+// with all the blocks proposed before the prover starts, is done.
+const (
+	StartupPhaseRPCDialing    = "rpcDialing"
+	StartupPhaseInitL1Current = "initL1Current"
+	StartupPhaseCatchUp       = "catchUp"
+)
+
+// handledBlockKey is the key of a block which proof has been requested, used to deduplicate the
+// re-delivered BlockProposed events.
+type handledBlockKey struct {
+	blockID    uint64
+	parentHash common.Hash
+}
+
 // Prover keep trying to prove new proposed blocks valid/invalid.
 type Prover struct {
 	// Configurations
 	cfg           *Config
 	proverAddress common.Address
+	reloadable    atomic.Value // *ReloadableConfig
+	reloadMutex   sync.Mutex
 
 	// Clients
 	rpc *rpc.Client
 
 	// Contract configurations
 	txListValidator *txListValidator.TxListValidator
-	protocolConfigs *bindings.TaikoDataConfig
+	protocolConfigs *protocol.Configs
 
 	// States
 	latestVerifiedL1Height uint64
+	latestVerifiedID       uint64
 	lastHandledBlockID     uint64
 	l1Current              uint64
 
 	// Proof submitters
 	validProofSubmitter proofSubmitter.ProofSubmitter
-
-	// Subscriptions
-	blockProposedCh  chan *bindings.TaikoL1ClientBlockProposed
-	blockProposedSub event.Subscription
-	blockVerifiedCh  chan *bindings.TaikoL1ClientBlockVerified
-	blockVerifiedSub event.Subscription
-	proveNotify      chan struct{}
+	submissionKeys      *proofSubmitter.SubmissionKeys
+
+	// Subscriptions, the BlockProposed / BlockVerified events are received by the sink channels, and then
+	// forwarded to the buffered ones, dropping the oldest events on overflow.
+	blockProposedSinkCh chan *bindings.TaikoL1ClientBlockProposed
+	blockProposedCh     chan *bindings.TaikoL1ClientBlockProposed
+	blockProposedSub    event.Subscription
+	blockVerifiedSinkCh chan *bindings.TaikoL1ClientBlockVerified
+	blockVerifiedCh     chan *bindings.TaikoL1ClientBlockVerified
+	blockVerifiedSub    event.Subscription
+	blockProvenCh       chan *bindings.TaikoL1ClientBlockProven
+	blockProvenSub      event.Subscription
+	proveNotify         chan struct{}
 
 	// Proof related
 	proveValidProofCh   chan *proofProducer.ProofWithHeader
 	proveInvalidProofCh chan *proofProducer.ProofWithHeader
+	proofRequestedAt    sync.Map // blockID -> time.Time, used by the proof generation latency metrics
+	proofTimes          sync.Map // blockID -> ProofTimes, exposed by the `/debug/proof-times` endpoint
+	proofProgress       sync.Map // blockID -> *proofProducer.ProofProgress, of the in-flight proverd proof jobs
+	proofCancels        sync.Map // blockID -> context.CancelFunc of the in-flight proof request
+	proofRequestEvents  sync.Map // blockID -> *bindings.TaikoL1ClientBlockProposed of the in-flight proof request
+	handledBlocks       *cache.LRU[handledBlockKey, struct{}]
+	proofQueue          *PriorityQueue   // Pending proof requests, the blocks closest to expiry first
+	proofQueueFull      int32            // Set to 1 when a block is rejected by the full proof queue
+	provingPaused       int32            // Set to 1 when the proving of new blocks is paused by the admin API
+	submissionQueue     *SubmissionQueue // Generated proofs waiting for submission, valid proofs first
+	capacityProber      proofProducer.CapacityProber
+	rpcdProducers       []*proofProducer.ZkevmRpcdProducer
+	rpcdHealth          *rpcdHealth                 // Latest probe results of the rpcdProducers
+	proofJournal        *proofProducer.ProofJournal // In-flight proverd proof jobs, resumed after restarts
+	rpcdCallbackServer  *server.Server              // Listener of the proofs pushed by proverd, in callback mode
+	proofDirCache       *proofCache.DirCache        // Generated proofs, reused after restarts or retries
+	proofArchive        *proofArchive.Archive       // Artifacts of the submitted proofs, for auditing
+	proofTimeEstimator  *proofProducer.ProofTimeEstimator
+	parentHeaderLookups singleflight.Group
+	prefetchedL1Origins sync.Map // blockID -> *rawdb.L1Origin, prefetched by the proving operations
+
+	// Liveness of the blocks current prover is responsible for
+	unprovenCandidates    *unprovenCandidates
+	unprovableBlocks      *unprovableBlocks // Blocks unsupported by the current circuits of the proof producer
+	oldestUnproven        atomic.Value      // *OldestUnprovenBlock
+	overdueAlertedBlockID uint64
+	submissions           *proofSubmitter.SubmissionTracker
+
+	// Coordination of the prover replicas sharing one prover key
+	blockLeases *blockLeases
+
+	// Optional L2 execution divergence detection
+	divergenceChecker *divergence.Checker
 
 	// Concurrency guards
-	proposeConcurrencyGuard     chan struct{}
-	submitProofConcurrencyGuard chan struct{}
-	submitProofTxMutex          *sync.Mutex
+	proposeConcurrencyGuard     *resizableSemaphore
+	submitProofConcurrencyGuard *resizableSemaphore
+	witnessConcurrencyGuard     *resizableSemaphore // Nil if the witness preparation isn't bounded separately
+	preparedWitnessGuard        *resizableSemaphore // Prepared proof requests waiting for a proving slot
+
+	startupTracker    *phaseTracker.Tracker
+	lifecycleNotifier *lifecycle.Notifier
+	logSampler        *logsampler.Sampler // Bounds the repetitive per-block log messages
+	blockFeed         *blockfeed.Client
+	httpServer        *server.Server
 
 	ctx context.Context
 	wg  sync.WaitGroup
@@ -79,88 +174,495 @@ func (p *Prover) InitFromCli(ctx context.Context, c *cli.Context) error {
 func InitFromConfig(ctx context.Context, p *Prover, cfg *Config) (err error) {
 	p.cfg = cfg
 	p.ctx = ctx
+	p.startupTracker = phaseTracker.New("prover")
 
 	// Clients
+	p.startupTracker.Enter(StartupPhaseRPCDialing)
 	if p.rpc, err = rpc.NewClient(p.ctx, &rpc.ClientConfig{
-		L1Endpoint:     cfg.L1WsEndpoint,
-		L2Endpoint:     cfg.L2WsEndpoint,
-		TaikoL1Address: cfg.TaikoL1Address,
-		TaikoL2Address: cfg.TaikoL2Address,
+		L1Endpoint:        cfg.L1WsEndpoint,
+		L2Endpoint:        cfg.L2WsEndpoint,
+		TaikoL1Address:    cfg.TaikoL1Address,
+		TaikoL2Address:    cfg.TaikoL2Address,
+		TaikoL1ABIPath:    cfg.TaikoL1ABIPath,
+		TaikoL2ABIPath:    cfg.TaikoL2ABIPath,
+		L1ArchiveEndpoint: cfg.L1ArchiveEndpoint,
 	}); err != nil {
 		return err
 	}
 
 	// Configs
-	protocolConfigs, err := p.rpc.TaikoL1.GetConfig(nil)
-	if err != nil {
-		return fmt.Errorf("failed to get protocol configs: %w", err)
+	if p.protocolConfigs, err = protocol.New(p.ctx, p.rpc.TaikoL1); err != nil {
+		return err
 	}
-	p.protocolConfigs = &protocolConfigs
 
-	log.Info("Protocol configs", "configs", p.protocolConfigs)
+	log.Info("Protocol configs", "configs", p.protocolConfigs.Config())
 
-	p.submitProofTxMutex = &sync.Mutex{}
-	p.txListValidator = txListValidator.NewTxListValidator(
-		p.protocolConfigs.BlockMaxGasLimit.Uint64(),
-		p.protocolConfigs.MaxTransactionsPerBlock.Uint64(),
-		p.protocolConfigs.MaxBytesPerTxList.Uint64(),
-		p.protocolConfigs.MinTxGasLimit.Uint64(),
-		p.rpc.L2ChainID,
-	)
+	p.txListValidator = p.protocolConfigs.Config().NewTxListValidator(p.rpc.L2ChainID)
+	if p.proofTimeEstimator, err = proofProducer.OpenProofTimeEstimator(
+		cfg.ProofTimeHistoryPath,
+		p.protocolConfigs.Config().BlockMaxGasLimit,
+	); err != nil {
+		return err
+	}
 	p.proverAddress = crypto.PubkeyToAddress(p.cfg.L1ProverPrivKey.PublicKey)
+	p.lifecycleNotifier = lifecycle.New(cfg.WebhookURL, p.proverAddress)
+	p.logSampler = logsampler.New(cfg.LogSampling, time.Minute)
+	p.blockFeed = blockfeed.NewClient(cfg.BlockFeedSocket, 0)
+	if p.cfg.DryRun {
+		log.Warn("Dry run mode enabled, generated proofs will never be submitted", "proverAddress", p.proverAddress)
+	}
 
-	chBufferSize := p.protocolConfigs.MaxNumProposedBlocks.Uint64()
-	p.blockProposedCh = make(chan *bindings.TaikoL1ClientBlockProposed, chBufferSize)
-	p.blockVerifiedCh = make(chan *bindings.TaikoL1ClientBlockVerified, chBufferSize)
-	p.proveValidProofCh = make(chan *proofProducer.ProofWithHeader, chBufferSize)
-	p.proveInvalidProofCh = make(chan *proofProducer.ProofWithHeader, chBufferSize)
+	eventChBufferSize := cfg.EventChBufferSize
+	if eventChBufferSize == 0 {
+		eventChBufferSize = defaultEventChBufferSize
+	}
+	proofChBufferSize := cfg.ProofChBufferSize
+	if proofChBufferSize == 0 {
+		proofChBufferSize = defaultProofChBufferSize
+	}
+	p.blockProposedSinkCh = make(chan *bindings.TaikoL1ClientBlockProposed)
+	p.blockProposedCh = make(chan *bindings.TaikoL1ClientBlockProposed, eventChBufferSize)
+	p.blockVerifiedSinkCh = make(chan *bindings.TaikoL1ClientBlockVerified)
+	p.blockVerifiedCh = make(chan *bindings.TaikoL1ClientBlockVerified, eventChBufferSize)
+	p.blockProvenCh = make(chan *bindings.TaikoL1ClientBlockProven, eventChBufferSize)
+	p.proveValidProofCh = make(chan *proofProducer.ProofWithHeader, proofChBufferSize)
+	p.proveInvalidProofCh = make(chan *proofProducer.ProofWithHeader, proofChBufferSize)
 	p.proveNotify = make(chan struct{}, 1)
-	if err := p.initL1Current(cfg.StartingBlockID); err != nil {
-		return fmt.Errorf("initialize L1 current cursor error: %w", err)
+	dedupCacheSize := cfg.BlockDedupCacheSize
+	if dedupCacheSize == 0 {
+		dedupCacheSize = defaultBlockDedupCacheSize
 	}
+	p.handledBlocks = cache.NewLRU[handledBlockKey, struct{}](int(dedupCacheSize))
+	priorityQueueSize := cfg.PriorityQueueSize
+	if priorityQueueSize == 0 {
+		priorityQueueSize = defaultPriorityQueueSize
+	}
+	p.proofQueue = NewPriorityQueue(int(priorityQueueSize))
+	validProofWeight, invalidProofWeight := cfg.ValidProofWeight, cfg.InvalidProofWeight
+	if validProofWeight == 0 {
+		validProofWeight = defaultProofClassWeight
+	}
+	if invalidProofWeight == 0 {
+		invalidProofWeight = defaultProofClassWeight
+	}
+	p.proofQueue.SetWeights(int(validProofWeight), int(invalidProofWeight))
+	p.submissionQueue = NewSubmissionQueue(defaultSubmissionEscalationMargin)
+	p.unprovenCandidates = newUnprovenCandidates()
+	p.unprovableBlocks = newUnprovableBlocks()
+	p.submissions = proofSubmitter.NewSubmissionTracker(cfg.SubmissionRetention)
 
 	// Concurrency guards
-	p.proposeConcurrencyGuard = make(chan struct{}, cfg.MaxConcurrentProvingJobs)
-	p.submitProofConcurrencyGuard = make(chan struct{}, cfg.MaxConcurrentProvingJobs)
+	p.proposeConcurrencyGuard = newResizableSemaphore(cfg.MaxConcurrentProvingJobs)
+	p.submitProofConcurrencyGuard = newResizableSemaphore(cfg.MaxConcurrentProvingJobs)
+	if cfg.MaxConcurrentWitnessJobs != 0 {
+		maxPreparedWitnesses := cfg.MaxPreparedWitnesses
+		if maxPreparedWitnesses == 0 {
+			maxPreparedWitnesses = cfg.MaxConcurrentProvingJobs
+		}
+		log.Info(
+			"Witness preparation bounded separately",
+			"maxConcurrentWitnessJobs", cfg.MaxConcurrentWitnessJobs,
+			"maxPreparedWitnesses", maxPreparedWitnesses,
+		)
+		p.witnessConcurrencyGuard = newResizableSemaphore(cfg.MaxConcurrentWitnessJobs)
+		p.preparedWitnessGuard = newResizableSemaphore(maxPreparedWitnesses)
+	}
+
+	// Reloadable configurations
+	p.applyReloadableConfig(&ReloadableConfig{
+		MaxProvingLag:            cfg.MaxProvingLag,
+		MinProofRewardWei:        cfg.MinProofRewardWei,
+		MaxConcurrentProvingJobs: cfg.MaxConcurrentProvingJobs,
+	})
+	if len(cfg.ConfigFile) != 0 {
+		if err := p.reloadConfigFile(); err != nil {
+			return err
+		}
+	}
+
+	startingBlockID := cfg.StartingBlockID
+	var restored *snapshot.Snapshot
+	if len(cfg.RestoreSnapshotPath) != 0 {
+		if restored, err = p.loadSnapshot(cfg.RestoreSnapshotPath); err != nil {
+			return fmt.Errorf("failed to load snapshot: %w", err)
+		}
+		if startingBlockID == nil {
+			startingBlockID = snapshotStartingBlockID(restored)
+		}
+	}
+
+	p.startupTracker.Enter(StartupPhaseInitL1Current)
+	if err := p.initL1Current(startingBlockID); err != nil {
+		return fmt.Errorf("initialize L1 current cursor error: %w", err)
+	}
+
+	// The reconciliation is best-effort, it should never block the prover from starting.
+	if err := p.reconcileProvenBlocks(p.ctx, cfg.ReconcileWindow); err != nil {
+		log.Warn("Failed to reconcile the blocks proved in the previous runs", "error", err)
+	}
 
 	var producer proofProducer.ProofProducer
 	if cfg.Dummy {
-		producer = &proofProducer.DummyProofProducer{
+		dummyProducer := &proofProducer.DummyProofProducer{
 			RandomDummyProofDelayLowerBound: p.cfg.RandomDummyProofDelayLowerBound,
 			RandomDummyProofDelayUpperBound: p.cfg.RandomDummyProofDelayUpperBound,
+			DelaySeed:                       p.cfg.DummyProofDelaySeed,
 		}
-	} else {
-		if producer, err = proofProducer.NewZkevmRpcdProducer(
-			cfg.ZKEvmRpcdEndpoint,
-			cfg.ZkEvmRpcdParamsPath,
+		if len(p.cfg.DummyProofDelaysPath) != 0 {
+			if dummyProducer.BlockDelays, err = proofProducer.LoadDummyProofDelays(p.cfg.DummyProofDelaysPath); err != nil {
+				return err
+			}
+		}
+		producer = dummyProducer
+	} else if cfg.ProofProducerType == ProofProducerTypeGrpc {
+		dialOpts, err := (&proofProducer.GrpcConnOptions{
+			TLS:       cfg.GrpcProofProducerTLS,
+			CACert:    cfg.GrpcProofProducerCACert,
+			AuthToken: cfg.GrpcProofProducerAuthToken,
+		}).DialOptions()
+		if err != nil {
+			return err
+		}
+		grpcProducer, err := proofProducer.NewGrpcProofProducer(
+			cfg.GrpcProofProducerEndpoint,
 			cfg.L1HttpEndpoint,
 			cfg.L2HttpEndpoint,
-			true,
-		); err != nil {
+			0,
+			dialOpts...,
+		)
+		if err != nil {
 			return err
 		}
+		grpcProducer.Validation = proofValidation(cfg)
+		producer = grpcProducer
+	} else if producer, err = p.initZkevmRpcdProducer(cfg); err != nil {
+		return err
+	}
+	log.Info("Proof backend initialized", "backend", cfg.ProofBackend())
+
+	if len(cfg.LeaseEndpoint) != 0 {
+		holder := cfg.LeaseHolderID
+		if len(holder) == 0 {
+			if holder, err = defaultLeaseHolderID(); err != nil {
+				return fmt.Errorf("failed to get hostname: %w", err)
+			}
+		}
+
+		log.Info("Block leases enabled", "endpoint", cfg.LeaseEndpoint, "holder", holder, "ttl", cfg.LeaseTTL)
+		p.blockLeases = newBlockLeases(
+			lease.New(cfg.LeaseEndpoint, cfg.LeaseToken, 0),
+			holder,
+			p.proverAddress,
+			cfg.LeaseTTL,
+		)
+	}
+
+	if len(cfg.PeerL2Endpoints) != 0 {
+		log.Info("L2 divergence checker enabled", "peers", cfg.PeerL2Endpoints)
+		if err := p.initDivergenceChecker(p.ctx, cfg.PeerL2Endpoints); err != nil {
+			return err
+		}
+	}
+
+	if cfg.ProofCacheDir != "" {
+		log.Info("Local proof cache enabled", "dir", cfg.ProofCacheDir, "maxAge", cfg.ProofCacheMaxAge)
+		if p.proofDirCache, err = proofCache.NewDirCache(cfg.ProofCacheDir, cfg.ProofCacheMaxAge); err != nil {
+			return err
+		}
+	}
+	if cfg.ProofCacheEndpoint != "" || p.proofDirCache != nil {
+		var cacheClient *proofCache.Client
+		if cfg.ProofCacheEndpoint != "" {
+			log.Info("Proof cache service enabled", "endpoint", cfg.ProofCacheEndpoint)
+			cacheClient = proofCache.New(cfg.ProofCacheEndpoint, cfg.ProofCacheToken, 0)
+		}
+		producer = proofProducer.NewCachedProofProducer(producer, cacheClient, p.proofDirCache)
+	}
+
+	if restored != nil {
+		if err := p.restoreSnapshot(restored); err != nil {
+			return fmt.Errorf("failed to restore snapshot: %w", err)
+		}
 	}
 
 	// Proof submitter
-	if p.validProofSubmitter, err = proofSubmitter.NewValidProofSubmitter(
+	if err := p.initSubmissionKeys(); err != nil {
+		return err
+	}
+	replicator, err := p.initProofReplicator(cfg)
+	if err != nil {
+		return err
+	}
+	feeStrategy, err := proofSubmitter.NewFeeStrategy(
+		cfg.FeeStrategy,
+		proofSubmitter.FeeBounds{Min: cfg.MinPriorityFeeWei, Max: cfg.MaxPriorityFeeWei},
+		cfg.ProofWindow,
+	)
+	if err != nil {
+		return err
+	}
+	validProofSubmitter, err := proofSubmitter.NewValidProofSubmitter(
 		p.rpc,
 		producer,
 		p.proveValidProofCh,
+		p.cfg.TaikoL1Address,
 		p.cfg.TaikoL2Address,
 		p.cfg.L1ProverPrivKey,
-		p.submitProofTxMutex,
-	); err != nil {
+		p.submissionKeys,
+		&proofSubmitter.ValidProofSubmitterOptions{
+			Notifier:             p.lifecycleNotifier,
+			CompetingProofWindow: p.cfg.CompetingProofWindow,
+			BlockFeed:            p.blockFeed,
+			Submissions:          p.submissions,
+			Replicator:           replicator,
+			FeeStrategy:          feeStrategy,
+			DryRun:               p.cfg.DryRun,
+		},
+	)
+	if err != nil {
 		return err
 	}
+	p.validProofSubmitter = validProofSubmitter
+	if cfg.ProofArchiveDir != "" {
+		log.Info("Proof archive enabled", "dir", cfg.ProofArchiveDir, "maxSize", cfg.ProofArchiveMaxSize)
+		if p.proofArchive, err = proofArchive.New(cfg.ProofArchiveDir, cfg.ProofArchiveMaxSize, 0); err != nil {
+			return err
+		}
+		validProofSubmitter.ArchiveProofs(p.proofArchive)
+	}
+	if p.cfg.DummyProofFallback {
+		log.Warn("Dummy proof fallback enabled, the proofs rejected by the verifier are replaced by dummy proofs")
+		validProofSubmitter.RetryWithFallbackProducer(&proofProducer.DummyProofProducer{})
+	}
+
+	if p.cfg.SafeAddress != (common.Address{}) {
+		log.Info("Safe proof submitter enabled", "safe", p.cfg.SafeAddress, "threshold", p.cfg.SafeThreshold)
+		if p.validProofSubmitter, err = proofSubmitter.NewSafeProofSubmitter(
+			validProofSubmitter,
+			p.cfg.SafeAddress,
+			p.cfg.SafeServiceURL,
+			p.cfg.SafeThreshold,
+			p.cfg.L1ProverPrivKey,
+		); err != nil {
+			return err
+		}
+	}
+
+	if len(cfg.HTTPAddr) != 0 {
+		p.httpServer = server.New(cfg.HTTPAddr)
+		p.httpServer.HandleJSON("/status", func(r *http.Request) (interface{}, error) {
+			return p.Status(), nil
+		})
+		p.httpServer.HandleFunc("/healthz", p.handleHealthz)
+		p.httpServer.HandleJSON("/debug/proof-times", func(r *http.Request) (interface{}, error) {
+			return p.ProofTimes(), nil
+		})
+		p.httpServer.HandleJSON("/unprovenBlocks", func(r *http.Request) (interface{}, error) {
+			return p.UnprovenBlocks(r.Context())
+		})
+		p.httpServer.HandleJSON("/config", func(r *http.Request) (interface{}, error) {
+			return p.ConfigStatus(), nil
+		})
+		// The state-changing APIs are only served with an admin token.
+		if len(cfg.AdminToken) != 0 {
+			p.registerAdminHandlers(cfg.AdminToken)
+		}
+	}
 
 	return nil
 }
 
+// proofValidation returns the structural constraints of the generated proofs, or nil if
+// --prover.skipProofValidation is set.
+func proofValidation(cfg *Config) *proofProducer.ProofValidation {
+	if cfg.SkipProofValidation {
+		log.Warn("Proof validation skipped, the generated proofs are submitted as they are")
+		return nil
+	}
+
+	return &proofProducer.ProofValidation{ProofSizes: cfg.ProofSizes}
+}
+
+// initZkevmRpcdProducer initializes a ZkevmRpcdProducer for each of the configured endpoints, and wraps
+// them with a FallbackProducer if there are more than one.
+func (p *Prover) initZkevmRpcdProducer(cfg *Config) (proofProducer.ProofProducer, error) {
+	if len(cfg.ZkEvmRpcdJournalPath) != 0 {
+		journal, err := proofProducer.OpenProofJournal(p.ctx, cfg.ZkEvmRpcdJournalPath)
+		if err != nil {
+			return nil, err
+		}
+		p.proofJournal = journal
+	}
+
+	var callbacks *proofProducer.RpcdCallbacks
+	if len(cfg.RpcdCallbackAddr) != 0 {
+		baseURL := cfg.RpcdCallbackURL
+		if len(baseURL) == 0 {
+			baseURL = "http://" + cfg.RpcdCallbackAddr
+		}
+		callbacks = proofProducer.NewRpcdCallbacks(strings.TrimSuffix(baseURL, "/") + proofProducer.RpcdCallbackPath)
+		p.rpcdCallbackServer = server.New(cfg.RpcdCallbackAddr)
+		p.rpcdCallbackServer.HandleFunc(proofProducer.RpcdCallbackPath, callbacks.ServeHTTP)
+		log.Info("ZKEVM RPCD callback mode enabled", "addr", cfg.RpcdCallbackAddr, "url", callbacks.URL)
+	}
+
+	var (
+		backends   []*proofProducer.FallbackBackend
+		validation = proofValidation(cfg)
+	)
+	for i, endpoint := range cfg.ZKEvmRpcdEndpoints {
+		rpcdProducer, err := proofProducer.NewZkevmRpcdProducer(
+			endpoint,
+			cfg.ZkEvmRpcdParamsPath,
+			cfg.L1HttpEndpoint,
+			cfg.L2HttpEndpoint,
+			true,
+			cfg.ZkEvmRpcdParamsTiers...,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		rpcdProducer.CancelMethod = cfg.ZkEvmRpcdCancelMethod
+		rpcdProducer.ConnectTimeout = cfg.ZkEvmRpcdConnectTimeout
+		rpcdProducer.PollTimeout = cfg.ZkEvmRpcdPollTimeout
+		rpcdProducer.ProofTimeout = cfg.ZkEvmRpcdProofTimeout
+		rpcdProducer.Journal = p.proofJournal
+		rpcdProducer.Callbacks = callbacks
+		rpcdProducer.CallbackTimeout = cfg.RpcdCallbackTimeout
+		rpcdProducer.Validation = validation
+		if cfg.ZkEvmRpcdQueueWorkers != 0 {
+			rpcdProducer.QueueWorkers = int(cfg.ZkEvmRpcdQueueWorkers)
+			rpcdProducer.QueueDepth = int(cfg.ZkEvmRpcdQueueDepth)
+			rpcdProducer.OnJobFailed = p.onQueuedProofFailed
+		}
+		if len(cfg.ZkEvmRpcdProgressMethod) != 0 {
+			rpcdProducer.ProgressMethod = cfg.ZkEvmRpcdProgressMethod
+			rpcdProducer.ProgressPeriod = cfg.ZkEvmRpcdProgressInterval
+			rpcdProducer.StallThreshold = cfg.ZkEvmRpcdStallThreshold
+			rpcdProducer.StallTimeout = cfg.ZkEvmRpcdStallTimeout
+			rpcdProducer.OnProgress = p.onProofProgress
+		}
+		p.rpcdProducers = append(p.rpcdProducers, rpcdProducer)
+
+		// The proverd cluster might be shared by several provers, probe its real capacity if possible,
+		// only the primary one is probed, since the fallbacks are only used during its outages.
+		if len(cfg.ZkEvmRpcdHealthPath) != 0 {
+			rpcdProducer.HealthPath = cfg.ZkEvmRpcdHealthPath
+			rpcdProducer.MaxQueueDepth = cfg.ZkEvmRpcdMaxQueueDepth
+			if i == 0 {
+				p.capacityProber = rpcdProducer
+			}
+		}
+
+		backends = append(backends, &proofProducer.FallbackBackend{Name: endpoint, Producer: rpcdProducer})
+	}
+
+	p.rpcdHealth = newRpcdHealth(cfg.ZKEvmRpcdEndpoints)
+	if cfg.SkipRpcdProbe {
+		log.Warn("ZKEVM RPCD startup probe skipped")
+	} else if err := p.probeRpcdEndpoints(p.ctx); err != nil {
+		return nil, fmt.Errorf("%w, use --%s to start anyway", err, flags.SkipRpcdProbe.Name)
+	}
+
+	if len(backends) == 1 {
+		return backends[0].Producer, nil
+	}
+
+	log.Info("ZKEVM RPCD fallback endpoints enabled", "endpoints", cfg.ZKEvmRpcdEndpoints)
+	return proofProducer.NewFallbackProducer(backends, cfg.ZkEvmRpcdFallbackProbeInterval)
+}
+
+// initProofReplicator initializes the RPC clients of the additional L1 chains, and a ProofReplicator
+// replicating the proof submissions to them. The clients are initialized with the same protocol contracts,
+// so that an additional L1 chain whose TaikoL1 doesn't share the L2 genesis is rejected.
+func (p *Prover) initProofReplicator(cfg *Config) (*proofSubmitter.ProofReplicator, error) {
+	var clients []*rpc.Client
+	for _, endpoint := range cfg.AdditionalL1Endpoints {
+		client, err := rpc.NewClient(p.ctx, &rpc.ClientConfig{
+			L1Endpoint:     endpoint,
+			L2Endpoint:     cfg.L2WsEndpoint,
+			TaikoL1Address: cfg.TaikoL1Address,
+			TaikoL2Address: cfg.TaikoL2Address,
+			TaikoL1ABIPath: cfg.TaikoL1ABIPath,
+			TaikoL2ABIPath: cfg.TaikoL2ABIPath,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize additional L1 client %s: %w", endpoint, err)
+		}
+
+		log.Info("Proof replication to additional L1 enabled", "endpoint", endpoint, "l1ChainID", client.L1ChainID)
+		clients = append(clients, client)
+	}
+
+	return proofSubmitter.NewProofReplicator(clients, cfg.L1ProverPrivKey), nil
+}
+
 // Start starts the main loop of the L2 block prover.
 func (p *Prover) Start() error {
-	p.wg.Add(1)
+	if p.httpServer != nil {
+		if err := p.httpServer.Start(); err != nil {
+			return err
+		}
+	}
+	// Started before resuming the journaled proof jobs, which might wait for their callbacks.
+	if p.rpcdCallbackServer != nil {
+		if err := p.rpcdCallbackServer.Start(); err != nil {
+			return err
+		}
+	}
+
+	p.blockFeed.Start(p.ctx)
+	p.protocolConfigs.Start(p.ctx)
+	p.resumeProofJobs()
+	p.pruneProofCache()
+
+	p.wg.Add(9)
 	p.initSubscription()
+	go func() {
+		defer p.wg.Done()
+		forwardDropOldest(
+			p.ctx, "BlockProposed", p.blockProposedSinkCh, p.blockProposedCh, metrics.ProverBlockProposedDroppedCounter,
+		)
+	}()
+	go func() {
+		defer p.wg.Done()
+		forwardDropOldest(
+			p.ctx, "BlockVerified", p.blockVerifiedSinkCh, p.blockVerifiedCh, metrics.ProverBlockVerifiedDroppedCounter,
+		)
+	}()
 	go p.eventLoop()
+	go p.monitorChannels()
+	go p.dispatchProofRequests()
+	go p.collectProofs()
+	go p.dispatchProofSubmissions()
+	go p.monitorUnprovenBlocks()
+	go p.monitorProofTimes()
+
+	if len(p.cfg.ConfigFile) != 0 || len(p.cfg.SubmissionKeysFile) != 0 {
+		p.wg.Add(1)
+		go p.watchReloadSignal()
+	}
+
+	if p.blockLeases != nil {
+		p.wg.Add(1)
+		go p.maintainBlockLeases()
+	}
+
+	if p.divergenceChecker != nil {
+		p.wg.Add(1)
+		go p.monitorL2Divergence()
+	}
+
+	if len(p.rpcdProducers) != 0 {
+		p.wg.Add(1)
+		go p.monitorRpcdEndpoints()
+	}
+
+	if len(p.cfg.ZkEvmRpcdProgressMethod) != 0 {
+		p.wg.Add(1)
+		go p.monitorProofProgress()
+	}
 
 	return nil
 }
@@ -189,20 +691,25 @@ func (p *Prover) eventLoop() {
 	// Call reqProving() right away to catch up with the latest state.
 	reqProving()
 
+	// The generated proofs are handled by collectProofs and dispatchProofSubmissions.
 	for {
 		select {
 		case <-p.ctx.Done():
 			return
-		case proofWithHeader := <-p.proveValidProofCh:
-			p.submitProofOp(p.ctx, proofWithHeader, true)
-		case proofWithHeader := <-p.proveInvalidProofCh:
-			p.submitProofOp(p.ctx, proofWithHeader, false)
 		case <-p.proveNotify:
+			p.startupTracker.Enter(StartupPhaseCatchUp)
 			if err := p.proveOp(); err != nil {
 				log.Error("Prove new blocks error", "error", err)
+				continue
 			}
+			p.startupTracker.Finish()
 		case <-p.blockProposedCh:
 			reqProving()
+		case e := <-p.blockProvenCh:
+			p.unprovenCandidates.Remove(e.Id.Uint64())
+			if p.blockLeases != nil {
+				p.blockLeases.release(p.ctx, e.Id.Uint64())
+			}
 		case e := <-p.blockVerifiedCh:
 			if err := p.onBlockVerified(p.ctx, e); err != nil {
 				log.Error("Handle BlockVerified event error", "error", err)
@@ -215,16 +722,37 @@ func (p *Prover) eventLoop() {
 
 // Close closes the prover instance.
 func (p *Prover) Close() {
+	if p.httpServer != nil {
+		if err := p.httpServer.Shutdown(context.Background()); err != nil {
+			log.Error("Failed to shutdown HTTP server", "error", err)
+		}
+	}
 	p.closeSubscription()
 	p.wg.Wait()
+	if p.rpcdCallbackServer != nil {
+		if err := p.rpcdCallbackServer.Shutdown(context.Background()); err != nil {
+			log.Error("Failed to shutdown ZKEVM RPCD callback server", "error", err)
+		}
+	}
+	p.blockFeed.Close()
+	p.lifecycleNotifier.Close()
+	p.logSampler.Close()
+	p.proofArchive.Close()
 }
 
 // proveOp performs a proving operation, find current unproven blocks, then
 // request generating proofs for them.
 func (p *Prover) proveOp() error {
+	// Fetch the parent L1Origins of all pending blocks in batches, rather than one lookup per block.
+	if err := p.prefetchL1Origins(p.ctx); err != nil {
+		log.Warn("Failed to prefetch L1Origins", "error", err)
+	}
+
+	// The live BlockProposed events are still received by the L1 endpoint's subscription.
+	l1Client, taikoL1 := p.rpc.L1Backfill()
 	iter, err := eventIterator.NewBlockProposedIterator(p.ctx, &eventIterator.BlockProposedIteratorConfig{
-		Client:               p.rpc.L1,
-		TaikoL1:              p.rpc.TaikoL1,
+		Client:               l1Client,
+		TaikoL1:              taikoL1,
 		StartHeight:          new(big.Int).SetUint64(p.l1Current),
 		OnBlockProposedEvent: p.onBlockProposed,
 	})
@@ -249,66 +777,342 @@ func (p *Prover) onBlockProposed(
 	if event.Id.Uint64() <= p.lastHandledBlockID {
 		return nil
 	}
-	log.Info("Proposed block", "blockID", event.Id)
+	// Stop iterating while paused, the block will be handled again once resumed.
+	if p.ProvingPaused() {
+		end()
+		return nil
+	}
+	logger := p.blockLogger(event)
+	p.logSampler.Info(logger, "Proposed block")
 	metrics.ProverReceivedProposedBlockGauge.Update(event.Id.Int64())
 
-	handleBlockProposedEvent := func() error {
-		defer func() { <-p.proposeConcurrencyGuard }()
+	// Skip the blocks outside the protocol window, e.g. replayed old events, their proofs will be rejected.
+	inWindow, err := p.isInProtocolWindow(ctx, event.Id.Uint64())
+	if err != nil {
+		return err
+	}
+	if !inWindow {
+		logger.Warn(
+			"Skip the block outside the protocol window",
+			"latestVerifiedID", p.latestVerifiedID,
+			"maxNumBlocks", p.protocolConfigs.Config().MaxNumProposedBlocks,
+		)
+		metrics.ProverOutOfWindowBlockSkippedCounter.Inc(1)
+		p.l1Current = event.Raw.BlockNumber
+		p.lastHandledBlockID = event.Id.Uint64()
+		return nil
+	}
+
+	// Only handle the block if current replica holds its lease, otherwise wait for the lease to expire,
+	// the lock service errors are only logged, so that a broken lock service never stalls proving.
+	if p.blockLeases != nil {
+		acquired, err := p.blockLeases.acquire(ctx, event)
+		if err != nil {
+			logger.Warn("Failed to acquire block lease, handle the block anyway", "error", err)
+			metrics.ProverLeaseErrorCounter.Inc(1)
+		} else if !acquired {
+			logger.Info("Block leased by another prover replica, defer it")
+			metrics.ProverLeasedBlockDeferredCounter.Inc(1)
+			p.l1Current = event.Raw.BlockNumber
+			p.lastHandledBlockID = event.Id.Uint64()
+			return nil
+		}
+	}
+
+	// Queue the block, the queued blocks are handled by their remaining proof windows, if the queue is
+	// full, stop iterating and retry the block in the next proving operation.
+	if err := p.proofQueue.Push(p.classifiedProofRequest(ctx, event, logger)); err != nil {
+		logger.Warn("Proof priority queue is full, retry the block later", "error", err)
+		metrics.ProverPriorityQueueFullCounter.Inc(1)
+		atomic.StoreInt32(&p.proofQueueFull, 1)
+		end()
+		return nil
+	}
+	metrics.ProverPriorityQueueDepthGauge.Update(int64(p.proofQueue.Len()))
+	p.unprovenCandidates.Add(event.Id.Uint64(), time.Unix(int64(event.Meta.Timestamp), 0))
 
-		// Check whether the block has been verified.
-		isVerified, err := p.isBlockVerified(event.Id)
+	p.l1Current = event.Raw.BlockNumber
+	p.lastHandledBlockID = event.Id.Uint64()
+
+	return nil
+}
+
+// dispatchProofRequests keeps handling the queued proof requests, the most urgent ones first, the number
+// of concurrent handlings is limited by the proofRequestSlots.
+func (p *Prover) dispatchProofRequests() {
+	defer p.wg.Done()
+
+	for {
+		// Acquire a slot before popping, so that the most urgent request at that moment will be picked.
+		slot, err := p.acquireProofRequestSlot(p.ctx)
 		if err != nil {
-			return err
+			return
+		}
+
+		// Defer the queued requests, until the proof producer's backend has capacity for them.
+		if err := p.proofQueue.Wait(p.ctx); err != nil {
+			slot.release()
+			return
+		}
+		if err := p.waitBackendCapacity(p.ctx); err != nil {
+			slot.release()
+			return
+		}
+
+		req, err := p.proofQueue.Pop(p.ctx)
+		if err != nil {
+			slot.release()
+			return
+		}
+		metrics.ProverPriorityQueueDepthGauge.Update(int64(p.proofQueue.Len()))
+
+		// Some blocks have been rejected by the full queue, retry them now.
+		if atomic.CompareAndSwapInt32(&p.proofQueueFull, 1, 0) {
+			select {
+			case p.proveNotify <- struct{}{}:
+			default:
+			}
+		}
+
+		if req.logger == nil {
+			req.logger = p.blockLogger(req.event)
 		}
+		req.logger.Debug("Dispatch proof request", "remaining", time.Until(req.deadline))
+
+		go func() {
+			defer slot.release()
+
+			if err := p.handleBlockProposed(p.ctx, req.event, req.observedAt, req.logger, slot); err != nil {
+				req.logger.Error("Handle new BlockProposed event error", "error", err)
+			}
+		}()
+	}
+}
+
+// waitBackendCapacity blocks until the proof producer's backend reports that it has capacity for new
+// proof requests, the probe errors are only logged, so that a broken probe never stalls proving.
+func (p *Prover) waitBackendCapacity(ctx context.Context) error {
+	if p.capacityProber == nil {
+		return nil
+	}
+
+	for {
+		capacity, err := p.capacityProber.Capacity(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
 
-		if isVerified {
-			log.Info("📋 Block has been verified", "blockID", event.Id)
+			log.Warn("Failed to probe proof producer capacity", "error", err)
 			return nil
 		}
 
-		needNewProof, err := p.NeedNewProof(event.Id)
+		metrics.ProverBackendQueueDepthGauge.Update(int64(capacity.QueueDepth))
+		if !capacity.Saturated {
+			return nil
+		}
+
+		log.Info(
+			"Proof producer backend saturated, defer new proof requests",
+			"queueDepth", capacity.QueueDepth,
+			"pendingBlocks", p.proofQueue.Len(),
+		)
+		metrics.ProverBackendSaturatedCounter.Inc(1)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(defaultCapacityProbeInterval):
+		}
+	}
+}
+
+// handleBlockProposed checks whether the given proposed block needs a new proof, if so, requests
+// generating the proof.
+func (p *Prover) handleBlockProposed(
+	ctx context.Context,
+	event *bindings.TaikoL1ClientBlockProposed,
+	observedAt time.Time,
+	logger log.Logger,
+	slot *proofRequestSlot,
+) error {
+	// Hold the block while the local L2 node diverges from the peers at or before its height, since a
+	// block's height is never greater than its ID, comparing the ID is conservative.
+	if p.divergenceChecker != nil && p.divergenceChecker.IsPaused(event.Id.Uint64()) {
+		logger.Warn("L2 divergence detected, block proving paused")
+		if err := p.divergenceChecker.Wait(ctx, event.Id.Uint64()); err != nil {
+			return err
+		}
+	}
+
+	// Check whether the block has been verified.
+	isVerified, err := p.isBlockVerified(event.Id)
+	if err != nil {
+		return err
+	}
+
+	if isVerified {
+		p.logSampler.Info(logger, "📋 Block has been verified")
+		p.unprovenCandidates.Remove(event.Id.Uint64())
+		return nil
+	}
+
+	// Reloaded configurations take effect for the next block.
+	reloadableCfg := p.reloadableConfig()
+
+	// Check whether the block is out of the proving lag window, or will be before its proof is generated.
+	var proofTime time.Duration
+	estimate := p.estimateProofTime(event)
+	if p.cfg.SkipNearlyStaleBlocks && estimate != nil {
+		proofTime = estimate.P90
+	}
+	isStale, err := p.isBlockStale(event.Id, reloadableCfg.MaxProvingLag, proofTime)
+	if err != nil {
+		return err
+	}
+
+	if isStale {
+		logger.Info("Skip the stale block", "maxProvingLag", reloadableCfg.MaxProvingLag, "estimatedProofTime", proofTime)
+		metrics.ProverStaleBlockSkippedCounter.Inc(1)
+		p.unprovenCandidates.Remove(event.Id.Uint64())
+		return nil
+	}
+
+	// Check whether the current proof reward is worth proving the block.
+	if reloadableCfg.MinProofRewardWei != nil {
+		reward, err := p.getProofReward(ctx, event.Id)
 		if err != nil {
-			return fmt.Errorf("failed to check whether the L2 block needs a new proof: %w", err)
+			return fmt.Errorf("failed to get the block's proof reward: %w", err)
 		}
 
-		if !needNewProof {
+		if reward.Cmp(reloadableCfg.MinProofRewardWei) < 0 {
+			logger.Info(
+				"Skip the block with a low proof reward",
+				"reward", reward,
+				"minReward", reloadableCfg.MinProofRewardWei,
+			)
+			metrics.ProverLowRewardBlockSkippedCounter.Inc(1)
+			p.unprovenCandidates.Remove(event.Id.Uint64())
 			return nil
 		}
+	}
 
-		return p.validProofSubmitter.RequestProof(ctx, event)
+	parent, err := p.getParentHeader(ctx, event.Id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the L2 block's parent header: %w", err)
 	}
 
-	p.proposeConcurrencyGuard <- struct{}{}
+	// Skip the re-delivered events of the blocks which have already been handled.
+	handledKey := handledBlockKey{blockID: event.Id.Uint64(), parentHash: parent.Hash()}
+	if p.handledBlocks.Contains(handledKey) {
+		p.logSampler.Info(logger, "Skip the already handled block", "parentHash", parent.Hash())
+		metrics.ProverDuplicateBlockSkippedCounter.Inc(1)
+		return nil
+	}
 
-	p.l1Current = event.Raw.BlockNumber
-	p.lastHandledBlockID = event.Id.Uint64()
+	// Skip the blocks unsupported by the current circuits, they are retried once the circuits change.
+	if p.unprovableBlocks.Contains(event.Id.Uint64()) {
+		p.logSampler.Info(logger, "Skip the unprovable block")
+		return nil
+	}
 
-	go func() {
-		if err := handleBlockProposedEvent(); err != nil {
-			log.Error("Handle new BlockProposed event error", "error", err)
+	needNewProof, err := p.needNewProof(event.Id, parent)
+	if err != nil {
+		return fmt.Errorf("failed to check whether the L2 block needs a new proof: %w", err)
+	}
+
+	if !needNewProof {
+		p.unprovenCandidates.Remove(event.Id.Uint64())
+		return nil
+	}
+
+	metrics.ProverValidProofDispatchTimer.UpdateSince(observedAt)
+	p.proofRequestedAt.Store(event.Id.Uint64(), time.Now())
+	p.recordProofRequested(event.Id.Uint64(), observedAt, estimate)
+
+	// The proof generation might outlive this call, e.g. the producers delivering the proofs asynchronously,
+	// so the request has its own context, which is released once the proof is received or the block is verified.
+	requestCtx := p.proofRequestContext(ctx, event.Id.Uint64())
+	p.proofRequestEvents.Store(event.Id.Uint64(), event)
+	if err := p.requestProofWithRetry(proofProducer.WithLogger(requestCtx, logger), event, slot); err != nil {
+		p.proofRequestedAt.Delete(event.Id.Uint64())
+		p.proofTimes.Delete(event.Id.Uint64())
+		p.releaseProofRequest(event.Id.Uint64())
+		if errors.Is(err, proofProducer.ErrUnsupportedBlock) {
+			p.markUnprovable(event, err, logger)
+			return nil
 		}
-	}()
+		return err
+	}
+	p.lifecycleNotifier.Notify(lifecycle.EventProofRequested, event.Id, nil, nil)
+
+	p.handledBlocks.Add(handledKey, struct{}{})
 
 	return nil
 }
 
-// submitProofOp performs a (valid block / invalid block) proof submission operation.
-func (p *Prover) submitProofOp(ctx context.Context, proofWithHeader *proofProducer.ProofWithHeader, isValidProof bool) {
-	p.submitProofConcurrencyGuard <- struct{}{}
-	go func() {
-		defer func() { <-p.submitProofConcurrencyGuard }()
+// updateProofGenerationTimer updates the proof generation latency metrics, when a new generated
+// proof is received from the proof producer.
+func (p *Prover) updateProofGenerationTimer(proofWithHeader *proofProducer.ProofWithHeader, isValidProof bool) {
+	requestedAt, ok := p.proofRequestedAt.LoadAndDelete(proofWithHeader.BlockID.Uint64())
+	if !ok {
+		return
+	}
 
-		if err := p.validProofSubmitter.SubmitProof(p.ctx, proofWithHeader); err != nil {
-			log.Error("Submit proof error", "isValidProof", isValidProof, "error", err)
-		}
-	}()
+	proofWithHeader.GenerationTime = time.Since(requestedAt.(time.Time))
+
+	if !isValidProof {
+		metrics.ProverInvalidProofGenerationTimer.UpdateSince(requestedAt.(time.Time))
+		return
+	}
+	metrics.ProverValidProofGenerationTimer.UpdateSince(requestedAt.(time.Time))
+
+	// The cached proofs tell nothing about the proof generation time.
+	if proofWithHeader.Cached {
+		return
+	}
+	if err := p.proofTimeEstimator.Observe(
+		proofWithHeader.Header.GasUsed,
+		time.Since(requestedAt.(time.Time)),
+	); err != nil {
+		proofWithHeader.Log().Warn("Failed to record the proof generation latency", "error", err)
+	}
 }
 
-// onBlockVerified update the latestVerified block in current state.
-// TODO: cancel the corresponding block's proof generation, if requested before.
+// blockLogger returns a logger tagged with the context of the given proposed block, so that all logs
+// about proving the block can be correlated.
+func (p *Prover) blockLogger(event *bindings.TaikoL1ClientBlockProposed) log.Logger {
+	proofType := p.cfg.ProofProducerType
+	if p.cfg.Dummy {
+		proofType = "dummy"
+	}
+
+	return log.New("blockID", event.Id, "l1Height", event.Raw.BlockNumber, "proofType", proofType)
+}
+
+// onBlockVerified update the latestVerified block in current state, and cancels the in-flight proof
+// requests of the verified blocks.
 func (p *Prover) onBlockVerified(ctx context.Context, event *bindings.TaikoL1ClientBlockVerified) error {
 	metrics.ProverLatestVerifiedIDGauge.Update(event.Id.Int64())
 	p.latestVerifiedL1Height = event.Raw.BlockNumber
+	if event.Id.Uint64() > p.latestVerifiedID {
+		p.latestVerifiedID = event.Id.Uint64()
+	}
+	p.unprovenCandidates.RemoveUpTo(event.Id.Uint64())
+	p.unprovableBlocks.RemoveUpTo(event.Id.Uint64())
+	p.cancelProofRequestsUpTo(event.Id.Uint64())
+	if p.blockLeases != nil {
+		p.blockLeases.releaseUpTo(ctx, event.Id.Uint64())
+	}
+	p.prefetchedL1Origins.Range(func(key, _ interface{}) bool {
+		if key.(uint64) < event.Id.Uint64() {
+			p.prefetchedL1Origins.Delete(key)
+		}
+		return true
+	})
+
+	p.submissions.OnBlockVerified(event.Id.Uint64(), common.BytesToHash(event.BlockHash[:]))
+	p.pruneProofCache()
 
 	if event.BlockHash == (common.Hash{}) {
 		log.Info("New verified invalid block", "blockID", event.Id)
@@ -324,18 +1128,26 @@ func (p *Prover) Name() string {
 	return "prover"
 }
 
-// initL1Current initializes prover's L1Current cursor.
+// initL1Current initializes prover's L1Current cursor, if no starting block ID is given, the block
+// resolved from the starting block hash or timestamp will be used.
 func (p *Prover) initL1Current(startingBlockID *big.Int) error {
 	if err := p.rpc.WaitTillL2Synced(p.ctx); err != nil {
 		return err
 	}
 
+	stateVars, err := p.rpc.GetProtocolStateVariables(nil)
+	if err != nil {
+		return err
+	}
+	p.latestVerifiedID = stateVars.LastVerifiedBlockId
+
 	if startingBlockID == nil {
-		stateVars, err := p.rpc.GetProtocolStateVariables(nil)
-		if err != nil {
-			return err
+		if startingBlockID, err = p.resolveStartingBlockID(p.ctx, stateVars.GenesisHeight); err != nil {
+			return fmt.Errorf("failed to resolve the starting block: %w", err)
 		}
+	}
 
+	if startingBlockID == nil {
 		if stateVars.LastVerifiedBlockId == 0 {
 			p.l1Current = stateVars.GenesisHeight
 			return nil
@@ -344,6 +1156,20 @@ func (p *Prover) initL1Current(startingBlockID *big.Int) error {
 		startingBlockID = new(big.Int).SetUint64(stateVars.LastVerifiedBlockId)
 	}
 
+	// Start no further back than the latest verified block minus the maximum proving lag.
+	maxProvingLag := p.reloadableConfig().MaxProvingLag
+	if maxProvingLag != 0 && stateVars.LastVerifiedBlockId > maxProvingLag {
+		lowerBound := new(big.Int).SetUint64(stateVars.LastVerifiedBlockId - maxProvingLag)
+		if startingBlockID.Cmp(lowerBound) < 0 {
+			log.Info(
+				"Starting block ID is out of the proving lag window",
+				"startingBlockID", startingBlockID,
+				"newStartingBlockID", lowerBound,
+			)
+			startingBlockID = lowerBound
+		}
+	}
+
 	latestVerifiedHeaderL1Origin, err := p.rpc.L2.L1OriginByID(p.ctx, startingBlockID)
 	if err != nil {
 		return err
@@ -363,48 +1189,196 @@ func (p *Prover) isBlockVerified(id *big.Int) (bool, error) {
 	return id.Uint64() <= stateVars.LastVerifiedBlockId, nil
 }
 
-// NeedNewProof checks whether the L2 block still needs a new proof.
-func (p *Prover) NeedNewProof(id *big.Int) (bool, error) {
-	var parent *types.Header
-	if id.Cmp(common.Big1) == 0 {
-		header, err := p.rpc.L2.HeaderByNumber(p.ctx, common.Big0)
-		if err != nil {
-			return false, err
-		}
+// isBlockStale checks whether the given block lags behind the latest proposed block by more than
+// the given maximum proving lag, or is expected to once its proof taking the given time is generated,
+// a zero proof time means only the current lag is checked.
+func (p *Prover) isBlockStale(id *big.Int, maxProvingLag uint64, proofTime time.Duration) (bool, error) {
+	if maxProvingLag == 0 {
+		return false, nil
+	}
 
-		parent = header
-	} else {
-		parentL1Origin, err := p.rpc.WaitL1Origin(p.ctx, new(big.Int).Sub(id, common.Big1))
-		if err != nil {
-			return false, err
-		}
+	stateVars, err := p.rpc.GetProtocolStateVariables(nil)
+	if err != nil {
+		return false, err
+	}
 
-		if parent, err = p.rpc.L2.HeaderByHash(p.ctx, parentL1Origin.L2BlockHash); err != nil {
-			return false, err
-		}
+	return exceedsProvingLag(id.Uint64(), stateVars, maxProvingLag, proofTime), nil
+}
+
+// exceedsProvingLag checks whether the given block lags behind the latest proposed block by more than the given
+// maximum proving lag, or will once the given proof time passes, at the protocol's average block time.
+func exceedsProvingLag(
+	id uint64,
+	stateVars *bindings.TaikoDataStateVariables,
+	maxProvingLag uint64,
+	proofTime time.Duration,
+) bool {
+	latestID := stateVars.NumBlocks - 1
+	if id+maxProvingLag < latestID {
+		return true
+	}
+	if proofTime == 0 || stateVars.AvgBlockTime == 0 {
+		return false
+	}
+
+	// The average block time is in milliseconds.
+	blocksLeft := id + maxProvingLag - latestID
+	return time.Duration(blocksLeft*stateVars.AvgBlockTime)*time.Millisecond < proofTime
+}
+
+// estimateProofTime estimates the proof generation time of the given block, by its gas used if the block
+// has arrived in the driver's block feed, otherwise by its gas limit, which is an upper bound.
+func (p *Prover) estimateProofTime(event *bindings.TaikoL1ClientBlockProposed) *proofProducer.ProofTimeEstimate {
+	gasUsed := uint64(event.Meta.GasLimit)
+	if header := p.blockFeed.VerifiedHeader(event.Id); header != nil {
+		gasUsed = header.GasUsed
+	}
+
+	return p.proofTimeEstimator.EstimateProofTime(gasUsed)
+}
+
+// getProofReward fetches the current proof reward of the given block from the protocol contract.
+func (p *Prover) getProofReward(ctx context.Context, id *big.Int) (*big.Int, error) {
+	block, err := p.rpc.TaikoL1.GetBlock(&bind.CallOpts{Context: ctx}, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.rpc.TaikoL1.GetProofReward(&bind.CallOpts{Context: ctx}, uint64(time.Now().Unix()), block.ProposedAt)
+}
+
+// NeedNewProof checks whether the L2 block still needs a new proof.
+func (p *Prover) NeedNewProof(id *big.Int) (bool, error) {
+	parent, err := p.getParentHeader(p.ctx, id)
+	if err != nil {
+		return false, err
 	}
 
+	return p.needNewProof(id, parent)
+}
+
+// needNewProof checks whether the L2 block with the given parent still needs a new proof.
+func (p *Prover) needNewProof(id *big.Int, parent *types.Header) (bool, error) {
 	fc, err := p.rpc.TaikoL1.GetForkChoice(nil, id, parent.Hash(), uint32(parent.GasUsed))
 	if err != nil && !strings.Contains(encoding.TryParsingCustomError(err).Error(), "L1_FORK_CHOICE_NOT_FOUND") {
 		return false, encoding.TryParsingCustomError(err)
 	}
 
 	if p.proverAddress == fc.Prover {
-		log.Info("📬 Block's proof has already been submitted by current prover", "blockID", id)
+		p.logSampler.Info(log.Root(), "📬 Block's proof has already been submitted by current prover", "blockID", id)
 		return false, nil
 	}
 
 	return true, nil
 }
 
-// initSubscription initializes all subscriptions in current prover instance.
+// getParentHeader fetches the parent header of the L2 block with the given ID, the concurrent lookups of
+// the same parent share one L1Origin wait.
+func (p *Prover) getParentHeader(ctx context.Context, id *big.Int) (*types.Header, error) {
+	parentID := new(big.Int).Sub(id, common.Big1)
+
+	// The parent block from the driver's block feed is trusted once it has been verified against its event.
+	if header := p.blockFeed.VerifiedHeader(parentID); header != nil {
+		return header, nil
+	}
+
+	resultCh := p.parentHeaderLookups.DoChan(parentID.String(), func() (interface{}, error) {
+		if parentID.Sign() == 0 {
+			return p.rpc.L2.HeaderByNumber(p.ctx, common.Big0)
+		}
+
+		if parentL1Origin, ok := p.prefetchedL1Origins.LoadAndDelete(parentID.Uint64()); ok {
+			return p.rpc.L2.HeaderByHash(p.ctx, parentL1Origin.(*rawdb.L1Origin).L2BlockHash)
+		}
+
+		parentL1Origin, err := p.rpc.WaitL1Origin(p.ctx, parentID)
+		if err != nil {
+			return nil, err
+		}
+
+		return p.rpc.L2.HeaderByHash(p.ctx, parentL1Origin.L2BlockHash)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-resultCh:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*types.Header), nil
+	}
+}
+
+// prefetchL1Origins fetches the L1Origins of the parents of all pending blocks in JSON-RPC batch requests,
+// which will be used by the parent header lookups later.
+func (p *Prover) prefetchL1Origins(ctx context.Context) error {
+	stateVars, err := p.rpc.GetProtocolStateVariables(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return err
+	}
+
+	var ids []*big.Int
+	for id := stateVars.LastVerifiedBlockId; id+1 < stateVars.NumBlocks; id++ {
+		if id == 0 {
+			continue
+		}
+		if _, ok := p.prefetchedL1Origins.Load(id); ok {
+			continue
+		}
+		ids = append(ids, new(big.Int).SetUint64(id))
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	l1Origins, err := p.rpc.BatchGetL1OriginByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for i, l1Origin := range l1Origins {
+		// Not derived by the L2 execution engine yet, will be waited for by the lookup.
+		if l1Origin == nil {
+			continue
+		}
+		p.prefetchedL1Origins.Store(ids[i].Uint64(), l1Origin)
+	}
+
+	return nil
+}
+
+// initSubscription initializes all subscriptions in current prover instance, if the L1 endpoint doesn't
+// support subscriptions, the protocol events will be polled instead.
 func (p *Prover) initSubscription() {
-	p.blockProposedSub = rpc.SubscribeBlockProposed(p.rpc.TaikoL1, p.blockProposedCh)
-	p.blockVerifiedSub = rpc.SubscribeBlockVerified(p.rpc.TaikoL1, p.blockVerifiedCh)
+	if !rpc.IsSubscriptionSupported(p.cfg.L1WsEndpoint) {
+		pollInterval := p.cfg.PollInterval
+		if pollInterval == 0 {
+			pollInterval = defaultPollInterval
+		}
+
+		log.Info("L1 endpoint doesn't support subscriptions, poll protocol events instead", "interval", pollInterval)
+
+		p.blockProposedSub = rpc.PollBlockProposed(p.rpc.L1, p.rpc.TaikoL1, p.blockProposedSinkCh, pollInterval)
+		p.blockVerifiedSub = rpc.PollBlockVerified(p.rpc.L1, p.rpc.TaikoL1, p.blockVerifiedSinkCh, pollInterval)
+		p.blockProvenSub = rpc.PollBlockProven(p.rpc.L1, p.rpc.TaikoL1, p.blockProvenCh, pollInterval)
+		return
+	}
+
+	p.blockProposedSub = rpc.SubscribeBlockProposed(p.rpc.TaikoL1, p.blockProposedSinkCh, p.onSubscriptionErr)
+	p.blockVerifiedSub = rpc.SubscribeBlockVerified(p.rpc.TaikoL1, p.blockVerifiedSinkCh, p.onSubscriptionErr)
+	p.blockProvenSub = rpc.SubscribeBlockProven(p.rpc.TaikoL1, p.blockProvenCh, p.onSubscriptionErr)
+}
+
+// onSubscriptionErr notifies the lifecycle webhook when a protocol event subscription is lost.
+func (p *Prover) onSubscriptionErr(eventName string, err error) {
+	p.lifecycleNotifier.Notify(lifecycle.EventSubscriptionLost, nil, nil, fmt.Errorf("%s: %w", eventName, err))
 }
 
 // closeSubscription closes all subscriptions.
 func (p *Prover) closeSubscription() {
 	p.blockVerifiedSub.Unsubscribe()
 	p.blockProposedSub.Unsubscribe()
+	p.blockProvenSub.Unsubscribe()
 }
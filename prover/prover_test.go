@@ -2,19 +2,27 @@ package prover
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/taikoxyz/taiko-client/bindings"
 	"github.com/taikoxyz/taiko-client/driver"
 	"github.com/taikoxyz/taiko-client/pkg/jwt"
 	"github.com/taikoxyz/taiko-client/proposer"
 	producer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+	proofSubmitter "github.com/taikoxyz/taiko-client/prover/proof_submitter"
+	"github.com/taikoxyz/taiko-client/prover/snapshot"
 	"github.com/taikoxyz/taiko-client/testutils"
 )
 
@@ -49,6 +57,10 @@ func (s *ProverTestSuite) SetupTest() {
 	s.p = p
 	s.cancel = cancel
 
+	// Handle the queued proof requests, since the prover is not started in unit tests.
+	p.wg.Add(1)
+	go p.dispatchProofRequests()
+
 	// Init driver
 	jwtSecret, err := jwt.ParseSecretFromFile(os.Getenv("JWT_SECRET"))
 	s.Nil(err)
@@ -106,6 +118,256 @@ func (s *ProverTestSuite) TestOnBlockProposed() {
 	}
 }
 
+func (s *ProverTestSuite) TestOnBlockProposedIteratorFinished() {
+	e := testutils.ProposeAndInsertValidBlock(&s.ClientTestSuite, s.proposer, s.d.ChainSyncer().CalldataSyncer())
+
+	// The iterator's context is cancelled right after the event is handled, the proof request goes on.
+	iterCtx, cancel := context.WithCancel(context.Background())
+	s.Nil(s.p.onBlockProposed(iterCtx, e, func() {}))
+	cancel()
+
+	select {
+	case proofWithHeader := <-s.p.proveValidProofCh:
+		s.Equal(e.Id, proofWithHeader.BlockID)
+		s.Nil(s.p.validProofSubmitter.SubmitProof(context.Background(), proofWithHeader))
+	case <-time.After(time.Minute):
+		s.Fail("proof request cancelled with the iterator")
+	}
+}
+
+func (s *ProverTestSuite) TestOnBlockProposedDuplicated() {
+	e := testutils.ProposeAndInsertValidBlock(&s.ClientTestSuite, s.proposer, s.d.ChainSyncer().CalldataSyncer())
+	s.Nil(s.p.onBlockProposed(context.Background(), e, func() {}))
+	proofWithHeader := <-s.p.proveValidProofCh
+
+	// Re-deliver the same event.
+	s.p.lastHandledBlockID = 0
+	s.Nil(s.p.onBlockProposed(context.Background(), e, func() {}))
+
+	select {
+	case <-s.p.proveValidProofCh:
+		s.Fail("duplicated block should not be proved again")
+	case <-time.After(3 * time.Second):
+	}
+
+	s.Nil(s.p.validProofSubmitter.SubmitProof(context.Background(), proofWithHeader))
+}
+
+func (s *ProverTestSuite) TestUnprovenBlocks() {
+	e := testutils.ProposeAndInsertValidBlock(&s.ClientTestSuite, s.proposer, s.d.ChainSyncer().CalldataSyncer())
+
+	unprovenBlocks, err := s.p.UnprovenBlocks(context.Background())
+	s.Nil(err)
+	s.NotEmpty(unprovenBlocks)
+
+	last := unprovenBlocks[len(unprovenBlocks)-1]
+	s.Equal(e.Id.Uint64(), last.BlockID)
+
+	parent, err := s.p.getParentHeader(context.Background(), e.Id)
+	s.Nil(err)
+	s.Equal(parent.Hash(), last.ParentHash)
+
+	// The block should not be listed any more after current prover submits its proof.
+	s.Nil(s.p.onBlockProposed(context.Background(), e, func() {}))
+	s.Nil(s.p.validProofSubmitter.SubmitProof(context.Background(), <-s.p.proveValidProofCh))
+
+	unprovenBlocks, err = s.p.UnprovenBlocks(context.Background())
+	s.Nil(err)
+	for _, block := range unprovenBlocks {
+		s.NotEqual(e.Id.Uint64(), block.BlockID)
+	}
+}
+
+func (s *ProverTestSuite) TestIsBlockStale() {
+	testutils.ProposeAndInsertEmptyBlocks(&s.ClientTestSuite, s.proposer, s.d.ChainSyncer().CalldataSyncer())
+
+	stateVars, err := s.p.rpc.GetProtocolStateVariables(nil)
+	s.Nil(err)
+	latestID := new(big.Int).SetUint64(stateVars.NumBlocks - 1)
+
+	// No limit.
+	isStale, err := s.p.isBlockStale(common.Big1, 0, 0)
+	s.Nil(err)
+	s.False(isStale)
+
+	isStale, err = s.p.isBlockStale(latestID, 1, 0)
+	s.Nil(err)
+	s.False(isStale)
+
+	isStale, err = s.p.isBlockStale(new(big.Int).Sub(latestID, common.Big1), 1, 0)
+	s.Nil(err)
+	s.False(isStale)
+
+	isStale, err = s.p.isBlockStale(new(big.Int).Sub(latestID, common.Big2), 1, 0)
+	s.Nil(err)
+	s.True(isStale)
+}
+
+func (s *ProverTestSuite) TestOnBlockProposedLowReward() {
+	e := testutils.ProposeAndInsertValidBlock(&s.ClientTestSuite, s.proposer, s.d.ChainSyncer().CalldataSyncer())
+
+	reward, err := s.p.getProofReward(context.Background(), e.Id)
+	s.Nil(err)
+
+	reloadableCfg := *s.p.reloadableConfig()
+	defer s.p.applyReloadableConfig(s.p.reloadableConfig())
+
+	reloadableCfg.MinProofRewardWei = new(big.Int).Add(new(big.Int).Mul(reward, common.Big2), common.Big1)
+	s.p.applyReloadableConfig(&reloadableCfg)
+
+	s.Nil(s.p.onBlockProposed(context.Background(), e, func() {}))
+
+	select {
+	case <-s.p.proveValidProofCh:
+		s.Fail("block with a low proof reward should not be proved")
+	case <-time.After(3 * time.Second):
+	}
+}
+
+// testProofSubmitter fails the first given number of proof requests with the given error.
+type testProofSubmitter struct {
+	failures int
+	err      error
+	requests int
+}
+
+func (s *testProofSubmitter) RequestProof(ctx context.Context, event *bindings.TaikoL1ClientBlockProposed) error {
+	s.requests++
+	if s.requests <= s.failures {
+		return s.err
+	}
+	return nil
+}
+
+func (s *testProofSubmitter) SubmitProof(ctx context.Context, proofWithHeader *producer.ProofWithHeader) error {
+	return nil
+}
+
+func (s *ProverTestSuite) TestRequestProofWithRetry() {
+	submitter := s.p.validProofSubmitter
+	maxAttempts, retryInterval := s.p.cfg.RequestProofMaxAttempts, s.p.cfg.RequestProofRetryInterval
+	defer func() {
+		s.p.validProofSubmitter = submitter
+		s.p.cfg.RequestProofMaxAttempts, s.p.cfg.RequestProofRetryInterval = maxAttempts, retryInterval
+	}()
+
+	s.p.cfg.RequestProofMaxAttempts = 3
+	s.p.cfg.RequestProofRetryInterval = 10 * time.Millisecond
+
+	event := &bindings.TaikoL1ClientBlockProposed{Id: new(big.Int).SetUint64(math.MaxUint64)}
+
+	// Transient errors are retried.
+	fake := &testProofSubmitter{failures: 2, err: errors.New("transient")}
+	s.p.validProofSubmitter = fake
+	s.Nil(s.p.requestProofWithRetry(context.Background(), event, nil))
+	s.Equal(3, fake.requests)
+
+	// Retries exhausted.
+	fake = &testProofSubmitter{failures: 3, err: errors.New("transient")}
+	s.p.validProofSubmitter = fake
+	s.ErrorContains(s.p.requestProofWithRetry(context.Background(), event, nil), "transient")
+	s.Equal(3, fake.requests)
+
+	// Permanent errors are not retried.
+	fake = &testProofSubmitter{failures: 1, err: fmt.Errorf("%w: bad request", producer.ErrInvalidProofRequest)}
+	s.p.validProofSubmitter = fake
+	s.ErrorIs(s.p.requestProofWithRetry(context.Background(), event, nil), producer.ErrInvalidProofRequest)
+	s.Equal(1, fake.requests)
+
+	// Stop retrying once the block has been verified.
+	fake = &testProofSubmitter{failures: 1, err: errors.New("transient")}
+	s.p.validProofSubmitter = fake
+	s.Nil(s.p.requestProofWithRetry(context.Background(), &bindings.TaikoL1ClientBlockProposed{Id: common.Big0}, nil))
+	s.Equal(1, fake.requests)
+}
+
+func (s *ProverTestSuite) TestRequestProofWithScriptedProducer() {
+	submitter := s.p.validProofSubmitter
+	maxAttempts, retryInterval := s.p.cfg.RequestProofMaxAttempts, s.p.cfg.RequestProofRetryInterval
+	defer func() {
+		s.p.validProofSubmitter = submitter
+		s.p.cfg.RequestProofMaxAttempts, s.p.cfg.RequestProofRetryInterval = maxAttempts, retryInterval
+	}()
+
+	s.p.cfg.RequestProofMaxAttempts = 3
+	s.p.cfg.RequestProofRetryInterval = 10 * time.Millisecond
+
+	scripted := producer.NewScriptedProducer(nil)
+	scriptedSubmitter, err := proofSubmitter.NewValidProofSubmitter(
+		s.p.rpc,
+		scripted,
+		s.p.proveValidProofCh,
+		s.p.cfg.TaikoL1Address,
+		s.p.cfg.TaikoL2Address,
+		s.p.cfg.L1ProverPrivKey,
+		s.p.submissionKeys,
+		nil,
+	)
+	s.Nil(err)
+	s.p.validProofSubmitter = scriptedSubmitter
+
+	// The transient proof producer failures are retried.
+	e := testutils.ProposeAndInsertValidBlock(&s.ClientTestSuite, s.proposer, s.d.ChainSyncer().CalldataSyncer())
+	scripted.SetPlan(e.Id.Uint64(), producer.FailNTimesThenSucceed(2))
+	s.Nil(s.p.requestProofWithRetry(context.Background(), e, nil))
+	s.Equal(3, scripted.Requests(e.Id.Uint64()))
+	s.Nil(s.p.validProofSubmitter.SubmitProof(context.Background(), <-s.p.proveValidProofCh))
+
+	// A corrupted proof is rejected by the submitter before any transaction is sent.
+	e = testutils.ProposeAndInsertValidBlock(&s.ClientTestSuite, s.proposer, s.d.ChainSyncer().CalldataSyncer())
+	scripted.SetPlan(e.Id.Uint64(), producer.CorruptedProof())
+	s.Nil(s.p.requestProofWithRetry(context.Background(), e, nil))
+	s.ErrorContains(s.p.validProofSubmitter.SubmitProof(context.Background(), <-s.p.proveValidProofCh), "invalid degree")
+}
+
+func (s *ProverTestSuite) TestSnapshotRestoreResume() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := *s.p.cfg
+	cfg.ProofCacheDir = s.T().TempDir()
+	p := new(Prover)
+	s.Nil(InitFromConfig(ctx, p, &cfg))
+	p.wg.Add(1)
+	go p.dispatchProofRequests()
+
+	// The proof is generated and cached, but the prover is migrated before submitting it.
+	e := testutils.ProposeAndInsertValidBlock(&s.ClientTestSuite, s.proposer, s.d.ChainSyncer().CalldataSyncer())
+	s.Nil(p.onBlockProposed(context.Background(), e, func() {}))
+	generated := <-p.proveValidProofCh
+	s.Equal(e.Id, generated.BlockID)
+
+	path := filepath.Join(s.T().TempDir(), "snap.tar")
+	result, err := p.WriteSnapshot(path)
+	s.Nil(err)
+	s.Equal(1, result.Proofs)
+
+	// Restored on a fresh machine, the cached proof is reused and then submitted.
+	restoredCfg := cfg
+	restoredCfg.ProofCacheDir = s.T().TempDir()
+	restoredCfg.RestoreSnapshotPath = path
+	restored := new(Prover)
+	s.Nil(InitFromConfig(ctx, restored, &restoredCfg))
+	restored.wg.Add(1)
+	go restored.dispatchProofRequests()
+
+	proofs, err := snapshot.ListProofs(restoredCfg.ProofCacheDir)
+	s.Nil(err)
+	s.Len(proofs, 1)
+
+	s.Nil(restored.onBlockProposed(context.Background(), e, func() {}))
+	resumed := <-restored.proveValidProofCh
+	s.Equal(generated.ZkProof, resumed.ZkProof)
+	s.Nil(restored.validProofSubmitter.SubmitProof(context.Background(), resumed))
+
+	// Once the block is verified, the snapshot has nothing left to restore.
+	snap, err := snapshot.ReadFile(path)
+	s.Nil(err)
+	snap.Reconcile(e.Id.Uint64())
+	s.Empty(snap.Proofs)
+	s.Zero(snap.Cursor())
+}
+
 func (s *ProverTestSuite) TestOnBlockVerifiedEmptyBlockHash() {
 	s.Nil(s.p.onBlockVerified(context.Background(), &bindings.TaikoL1ClientBlockVerified{
 		Id:        common.Big1,
@@ -113,9 +375,9 @@ func (s *ProverTestSuite) TestOnBlockVerifiedEmptyBlockHash() {
 	))
 }
 
-func (s *ProverTestSuite) TestSubmitProofOp() {
+func (s *ProverTestSuite) TestSubmitProof() {
 	s.NotPanics(func() {
-		s.p.submitProofOp(context.Background(), &producer.ProofWithHeader{
+		s.p.submitProof(context.Background(), &producer.ProofWithHeader{
 			BlockID: common.Big1,
 			Meta:    &bindings.TaikoDataBlockMetadata{},
 			Header:  &types.Header{},
@@ -123,7 +385,7 @@ func (s *ProverTestSuite) TestSubmitProofOp() {
 		}, true)
 	})
 	s.NotPanics(func() {
-		s.p.submitProofOp(context.Background(), &producer.ProofWithHeader{
+		s.p.submitProof(context.Background(), &producer.ProofWithHeader{
 			BlockID: common.Big1,
 			Meta:    &bindings.TaikoDataBlockMetadata{},
 			Header:  &types.Header{},
@@ -132,17 +394,55 @@ func (s *ProverTestSuite) TestSubmitProofOp() {
 	})
 }
 
+func (s *ProverTestSuite) TestUpdateProofGenerationTimer() {
+	// No proof has been requested.
+	s.NotPanics(func() { s.p.updateProofGenerationTimer(&producer.ProofWithHeader{BlockID: common.Big256}, true) })
+
+	s.p.proofRequestedAt.Store(common.Big256.Uint64(), time.Now().Add(-time.Second))
+	proofWithHeader := &producer.ProofWithHeader{BlockID: common.Big256}
+	s.p.updateProofGenerationTimer(proofWithHeader, true)
+	s.GreaterOrEqual(proofWithHeader.GenerationTime, time.Second)
+
+	_, ok := s.p.proofRequestedAt.Load(common.Big256.Uint64())
+	s.False(ok)
+}
+
 func (s *ProverTestSuite) TestStartSubscription() {
 	s.NotPanics(s.p.initSubscription)
 	s.NotPanics(s.p.closeSubscription)
 }
 
+func (s *ProverTestSuite) TestStartPollingSubscription() {
+	l1WsEndpoint := s.p.cfg.L1WsEndpoint
+	defer func() { s.p.cfg.L1WsEndpoint = l1WsEndpoint }()
+
+	s.p.cfg.L1WsEndpoint = os.Getenv("L1_NODE_HTTP_ENDPOINT")
+	s.NotPanics(s.p.initSubscription)
+	s.NotPanics(s.p.closeSubscription)
+}
+
 func (s *ProverTestSuite) TestStartClose() {
 	s.Nil(s.p.Start())
 	s.cancel()
 	s.NotPanics(s.p.Close)
 }
 
+func TestExceedsProvingLag(t *testing.T) {
+	stateVars := &bindings.TaikoDataStateVariables{NumBlocks: 11, AvgBlockTime: 12000}
+
+	require.False(t, exceedsProvingLag(8, stateVars, 2, 0))
+	require.True(t, exceedsProvingLag(7, stateVars, 2, 0))
+
+	// Two more blocks can be proposed in 24 seconds before the block is stale.
+	require.False(t, exceedsProvingLag(10, stateVars, 2, 24*time.Second))
+	require.True(t, exceedsProvingLag(10, stateVars, 2, 25*time.Second))
+	require.True(t, exceedsProvingLag(8, stateVars, 2, time.Second))
+
+	// No average block time yet.
+	stateVars.AvgBlockTime = 0
+	require.False(t, exceedsProvingLag(8, stateVars, 2, time.Hour))
+}
+
 func TestProverTestSuite(t *testing.T) {
 	suite.Run(t, new(ProverTestSuite))
 }
@@ -0,0 +1,52 @@
+package prover
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/metrics"
+)
+
+// PauseProving pauses the proving of the newly proposed blocks without stopping the prover, e.g. during
+// L1 upgrades, the already queued and in-flight proof requests are still completed.
+func (p *Prover) PauseProving() {
+	if atomic.CompareAndSwapInt32(&p.provingPaused, 0, 1) {
+		log.Info("Proving paused")
+	}
+	metrics.ProverPausedGauge.Update(1)
+}
+
+// ResumeProving resumes the proving paused by PauseProving, the blocks proposed in the meantime are
+// proved right away.
+func (p *Prover) ResumeProving() {
+	if atomic.CompareAndSwapInt32(&p.provingPaused, 1, 0) {
+		log.Info("Proving resumed")
+	}
+	metrics.ProverPausedGauge.Update(0)
+
+	select {
+	case p.proveNotify <- struct{}{}:
+	default:
+	}
+}
+
+// ProvingPaused returns whether the proving is paused.
+func (p *Prover) ProvingPaused() bool {
+	return atomic.LoadInt32(&p.provingPaused) == 1
+}
+
+// registerAdminHandlers registers the admin APIs guarded by the given token on the HTTP server, which are
+// all the APIs changing the prover's state.
+func (p *Prover) registerAdminHandlers(token string) {
+	p.httpServer.HandleAdminJSONPost("/prover_reloadConfig", token, p.handleReloadConfig)
+	p.httpServer.HandleAdminJSONPost("/admin/pause", token, func(r *http.Request) (interface{}, error) {
+		p.PauseProving()
+		return p.Status(), nil
+	})
+	p.httpServer.HandleAdminJSONPost("/admin/resume", token, func(r *http.Request) (interface{}, error) {
+		p.ResumeProving()
+		return p.Status(), nil
+	})
+	p.httpServer.HandleAdminJSONPost("/admin/snapshot", token, p.handleSnapshot)
+}
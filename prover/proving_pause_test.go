@@ -0,0 +1,46 @@
+package prover
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/pkg/server"
+)
+
+func TestAdminHandlers(t *testing.T) {
+	p := &Prover{proveNotify: make(chan struct{}, 1), httpServer: server.New("127.0.0.1:0")}
+	p.registerAdminHandlers("secret")
+	require.Nil(t, p.httpServer.Start())
+	defer p.httpServer.Shutdown(context.Background())
+
+	// All the state-changing APIs require the admin token.
+	for _, path := range []string{"/admin/pause", "/admin/resume", "/admin/snapshot", "/prover_reloadConfig"} {
+		res, err := http.Post("http://"+p.httpServer.Addr()+path, "application/json", bytes.NewBufferString(`{}`))
+		require.Nil(t, err)
+		res.Body.Close()
+		require.Equal(t, http.StatusUnauthorized, res.StatusCode, path)
+	}
+	require.False(t, p.ProvingPaused())
+}
+
+func TestPauseResumeProving(t *testing.T) {
+	p := &Prover{proveNotify: make(chan struct{}, 1)}
+	require.False(t, p.ProvingPaused())
+
+	p.PauseProving()
+	p.PauseProving()
+	require.True(t, p.ProvingPaused())
+	require.Len(t, p.proveNotify, 0)
+
+	// Resuming requests a proving operation right away.
+	p.ResumeProving()
+	require.False(t, p.ProvingPaused())
+	require.Len(t, p.proveNotify, 1)
+
+	// Never blocks if a proving operation has been requested already.
+	p.ResumeProving()
+	require.Len(t, p.proveNotify, 1)
+}
@@ -0,0 +1,270 @@
+package prover
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"os/signal"
+	"reflect"
+	"sort"
+	"syscall"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/taikoxyz/taiko-client/cmd/flags"
+	"github.com/taikoxyz/taiko-client/pkg/server"
+	proofSubmitter "github.com/taikoxyz/taiko-client/prover/proof_submitter"
+)
+
+var (
+	errNotReloadable = errors.New("not reloadable")
+	errUnknownField  = errors.New("unknown field")
+)
+
+// ReloadableConfig contains the prover configurations which can be reloaded at runtime, filters and
+// economics take effect for the next block, concurrency changes are applied to the new proving jobs.
+type ReloadableConfig struct {
+	MaxProvingLag            uint64   `json:"maxProvingLag"`
+	MinProofRewardWei        *big.Int `json:"minProofRewardWei"`
+	MaxConcurrentProvingJobs uint     `json:"maxConcurrentProvingJobs"`
+}
+
+// reloadableFields maps the names of the whitelisted reloadable flags to the functions which decode
+// their new values into the given ReloadableConfig.
+var reloadableFields = map[string]func(cfg *ReloadableConfig, value json.RawMessage) error{
+	flags.MaxProvingLag.Name: func(cfg *ReloadableConfig, value json.RawMessage) error {
+		return json.Unmarshal(value, &cfg.MaxProvingLag)
+	},
+	flags.MinProofRewardGwei.Name: func(cfg *ReloadableConfig, value json.RawMessage) error {
+		var gwei *uint64
+		if err := json.Unmarshal(value, &gwei); err != nil {
+			return err
+		}
+
+		if gwei == nil {
+			cfg.MinProofRewardWei = nil
+			return nil
+		}
+
+		cfg.MinProofRewardWei = new(big.Int).Mul(new(big.Int).SetUint64(*gwei), big.NewInt(params.GWei))
+		return nil
+	},
+	flags.MaxConcurrentProvingJobs.Name: func(cfg *ReloadableConfig, value json.RawMessage) error {
+		var jobs uint
+		if err := json.Unmarshal(value, &jobs); err != nil {
+			return err
+		}
+
+		if jobs == 0 {
+			return errors.New("must be greater than 0")
+		}
+
+		cfg.MaxConcurrentProvingJobs = jobs
+		return nil
+	},
+}
+
+// ConfigStatus contains the prover's current effective configurations, which is exposed by the
+// `/config` endpoint.
+type ConfigStatus struct {
+	ProverAddress     common.Address `json:"proverAddress"`
+	TaikoL1Address    common.Address `json:"taikoL1Address"`
+	TaikoL2Address    common.Address `json:"taikoL2Address"`
+	ProofProducerType string         `json:"proofProducerType"`
+	DryRun            bool           `json:"dryRun"`
+	*ReloadableConfig
+}
+
+// ConfigStatus returns the prover's current effective configurations.
+func (p *Prover) ConfigStatus() *ConfigStatus {
+	return &ConfigStatus{
+		ProverAddress:     p.proverAddress,
+		TaikoL1Address:    p.cfg.TaikoL1Address,
+		TaikoL2Address:    p.cfg.TaikoL2Address,
+		ProofProducerType: p.cfg.ProofProducerType,
+		DryRun:            p.cfg.DryRun,
+		ReloadableConfig:  p.reloadableConfig(),
+	}
+}
+
+// reloadableConfig returns the current reloadable configurations.
+func (p *Prover) reloadableConfig() *ReloadableConfig {
+	return p.reloadable.Load().(*ReloadableConfig)
+}
+
+// ReloadConfig atomically applies the given configuration values keyed by the flag names, only the
+// whitelisted fields can be reloaded, otherwise no changes will be applied.
+func (p *Prover) ReloadConfig(values map[string]json.RawMessage) error {
+	p.reloadMutex.Lock()
+	defer p.reloadMutex.Unlock()
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	newCfg := *p.reloadableConfig()
+	for _, name := range names {
+		decode, ok := reloadableFields[name]
+		if !ok {
+			if isProverFlag(name) {
+				return fmt.Errorf("field %s is %w", name, errNotReloadable)
+			}
+			return fmt.Errorf("%w: %s", errUnknownField, name)
+		}
+
+		if err := decode(&newCfg, values[name]); err != nil {
+			return fmt.Errorf("invalid value of field %s: %w", name, err)
+		}
+	}
+
+	p.applyReloadableConfig(&newCfg)
+
+	return nil
+}
+
+// handleReloadConfig handles the `/prover_reloadConfig` requests, whose body is like {"maxProvingLag": 64}.
+func (p *Prover) handleReloadConfig(r *http.Request) (interface{}, error) {
+	var values map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&values); err != nil {
+		return nil, server.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	if err := p.ReloadConfig(values); err != nil {
+		return nil, server.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	return p.ConfigStatus(), nil
+}
+
+// applyReloadableConfig stores the given reloadable configurations, logs all the changed values, and
+// resizes the concurrency guards.
+func (p *Prover) applyReloadableConfig(newCfg *ReloadableConfig) {
+	if oldCfg, ok := p.reloadable.Load().(*ReloadableConfig); ok {
+		var (
+			oldValue = reflect.ValueOf(oldCfg).Elem()
+			newValue = reflect.ValueOf(newCfg).Elem()
+		)
+		for i := 0; i < oldValue.NumField(); i++ {
+			if reflect.DeepEqual(oldValue.Field(i).Interface(), newValue.Field(i).Interface()) {
+				continue
+			}
+
+			log.Info(
+				"Prover config reloaded",
+				"field", oldValue.Type().Field(i).Name,
+				"old", oldValue.Field(i).Interface(),
+				"new", newValue.Field(i).Interface(),
+			)
+		}
+	}
+
+	p.reloadable.Store(newCfg)
+
+	if p.proposeConcurrencyGuard != nil {
+		p.proposeConcurrencyGuard.SetLimit(newCfg.MaxConcurrentProvingJobs)
+	}
+	if p.submitProofConcurrencyGuard != nil {
+		p.submitProofConcurrencyGuard.SetLimit(newCfg.MaxConcurrentProvingJobs)
+	}
+}
+
+// reloadConfigFile re-reads the configured config file, and then applies its values.
+func (p *Prover) reloadConfigFile() error {
+	data, err := os.ReadFile(p.cfg.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("failed to decode config file: %w", err)
+	}
+
+	return p.ReloadConfig(values)
+}
+
+// initSubmissionKeys initializes the proof submission keys, the prover's private key will be used if there is
+// no submission keys file configured.
+func (p *Prover) initSubmissionKeys() (err error) {
+	keys := []*ecdsa.PrivateKey{p.cfg.L1ProverPrivKey}
+	if len(p.cfg.SubmissionKeysFile) != 0 {
+		if keys, err = proofSubmitter.LoadSubmissionKeys(p.cfg.SubmissionKeysFile); err != nil {
+			return err
+		}
+	}
+
+	if p.submissionKeys, err = proofSubmitter.NewSubmissionKeys(keys); err != nil {
+		return err
+	}
+
+	log.Info("Proof submission keys", "addresses", p.submissionKeys.Addresses())
+
+	return nil
+}
+
+// reloadSubmissionKeys re-reads the configured submission keys file, and then replaces the current
+// submission keys.
+func (p *Prover) reloadSubmissionKeys() error {
+	keys, err := proofSubmitter.LoadSubmissionKeys(p.cfg.SubmissionKeysFile)
+	if err != nil {
+		return err
+	}
+
+	if err := p.submissionKeys.Reload(keys); err != nil {
+		return err
+	}
+
+	log.Info("Proof submission keys reloaded", "addresses", p.submissionKeys.Addresses())
+
+	return nil
+}
+
+// watchReloadSignal keeps reloading the config file and the submission keys file when receiving SIGHUP signals.
+func (p *Prover) watchReloadSignal() {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+	defer func() {
+		signal.Stop(sigCh)
+		p.wg.Done()
+	}()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-sigCh:
+			if len(p.cfg.ConfigFile) != 0 {
+				log.Info("Received SIGHUP, reload the config file", "path", p.cfg.ConfigFile)
+				if err := p.reloadConfigFile(); err != nil {
+					log.Error("Failed to reload the config file", "path", p.cfg.ConfigFile, "error", err)
+				}
+			}
+			if len(p.cfg.SubmissionKeysFile) != 0 {
+				log.Info("Received SIGHUP, reload the submission keys file", "path", p.cfg.SubmissionKeysFile)
+				if err := p.reloadSubmissionKeys(); err != nil {
+					log.Error("Failed to reload the submission keys file", "path", p.cfg.SubmissionKeysFile, "error", err)
+				}
+			}
+		}
+	}
+}
+
+// isProverFlag checks whether the given name is one of the prover's flag names.
+func isProverFlag(name string) bool {
+	for _, flag := range flags.ProverFlags {
+		for _, flagName := range flag.Names() {
+			if flagName == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,82 @@
+package prover
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/taikoxyz/taiko-client/cmd/flags"
+)
+
+func (s *ProverTestSuite) TestReloadConfig() {
+	defer s.p.applyReloadableConfig(s.p.reloadableConfig())
+
+	s.Nil(s.p.ReloadConfig(map[string]json.RawMessage{
+		flags.MaxProvingLag.Name:            json.RawMessage("64"),
+		flags.MinProofRewardGwei.Name:       json.RawMessage("5"),
+		flags.MaxConcurrentProvingJobs.Name: json.RawMessage("4"),
+	}))
+
+	status := s.p.ConfigStatus()
+	s.Equal(uint64(64), status.MaxProvingLag)
+	s.Equal(big.NewInt(5*params.GWei), status.MinProofRewardWei)
+	s.Equal(uint(4), status.MaxConcurrentProvingJobs)
+	s.Equal(uint(4), s.p.proposeConcurrencyGuard.Limit())
+	s.Equal(uint(4), s.p.submitProofConcurrencyGuard.Limit())
+
+	// Disable the minimum proof reward filter.
+	s.Nil(s.p.ReloadConfig(map[string]json.RawMessage{flags.MinProofRewardGwei.Name: json.RawMessage("null")}))
+	s.Nil(s.p.ConfigStatus().MinProofRewardWei)
+
+	// Non-reloadable, unknown and invalid fields, no changes should be applied.
+	s.ErrorIs(s.p.ReloadConfig(map[string]json.RawMessage{
+		flags.MaxProvingLag.Name: json.RawMessage("1"),
+		flags.L1WSEndpoint.Name:  json.RawMessage(`"ws://localhost:8546"`),
+	}), errNotReloadable)
+	s.ErrorIs(s.p.ReloadConfig(map[string]json.RawMessage{"unknown": json.RawMessage("1")}), errUnknownField)
+	s.NotNil(s.p.ReloadConfig(map[string]json.RawMessage{flags.MaxConcurrentProvingJobs.Name: json.RawMessage("0")}))
+	s.Equal(uint64(64), s.p.ConfigStatus().MaxProvingLag)
+	s.Equal(uint(4), s.p.ConfigStatus().MaxConcurrentProvingJobs)
+}
+
+func (s *ProverTestSuite) TestReloadConfigFile() {
+	defer s.p.applyReloadableConfig(s.p.reloadableConfig())
+
+	configFile := filepath.Join(s.T().TempDir(), "config.json")
+	s.Nil(os.WriteFile(configFile, []byte(`{"prover.maxProvingLag": 32}`), 0600))
+
+	s.p.cfg.ConfigFile = configFile
+	defer func() { s.p.cfg.ConfigFile = "" }()
+
+	s.Nil(s.p.reloadConfigFile())
+	s.Equal(uint64(32), s.p.ConfigStatus().MaxProvingLag)
+
+	s.Nil(os.WriteFile(configFile, []byte(`{"l1.proverPrivKey": "0x"}`), 0600))
+	s.ErrorIs(s.p.reloadConfigFile(), errNotReloadable)
+}
+
+func (s *ProverTestSuite) TestReloadSubmissionKeys() {
+	defer func() { s.Nil(s.p.submissionKeys.Reload([]*ecdsa.PrivateKey{s.p.cfg.L1ProverPrivKey})) }()
+
+	keysFile := filepath.Join(s.T().TempDir(), "keys")
+	s.Nil(os.WriteFile(keysFile, []byte(os.Getenv("L1_PROPOSER_PRIVATE_KEY")), 0600))
+
+	s.p.cfg.SubmissionKeysFile = keysFile
+	defer func() { s.p.cfg.SubmissionKeysFile = "" }()
+
+	l1ProposerPrivKey, err := crypto.ToECDSA(common.Hex2Bytes(os.Getenv("L1_PROPOSER_PRIVATE_KEY")))
+	s.Nil(err)
+
+	s.Nil(s.p.reloadSubmissionKeys())
+	s.Equal([]common.Address{crypto.PubkeyToAddress(l1ProposerPrivKey.PublicKey)}, s.p.submissionKeys.Addresses())
+
+	// Invalid keys file, the current keys should be kept.
+	s.Nil(os.WriteFile(keysFile, []byte("invalid"), 0600))
+	s.NotNil(s.p.reloadSubmissionKeys())
+	s.Equal([]common.Address{crypto.PubkeyToAddress(l1ProposerPrivKey.PublicKey)}, s.p.submissionKeys.Addresses())
+}
@@ -0,0 +1,169 @@
+package prover
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/metrics"
+	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+	proofSubmitter "github.com/taikoxyz/taiko-client/prover/proof_submitter"
+)
+
+var (
+	errBlockVerified = errors.New("block has been verified")
+)
+
+// requestProofWithRetry requests a proof for the given block, the transient errors will be retried with
+// the configured interval until the maximum attempts are reached, while the permanent errors won't. The
+// given slot of the request is moved to the proof computation stage once the witness is prepared.
+func (p *Prover) requestProofWithRetry(
+	ctx context.Context,
+	event *bindings.TaikoL1ClientBlockProposed,
+	slot *proofRequestSlot,
+) error {
+	maxAttempts := p.cfg.RequestProofMaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = defaultRequestProofMaxAttempts
+	}
+	retryInterval := p.cfg.RequestProofRetryInterval
+	if retryInterval == 0 {
+		retryInterval = defaultRequestProofRetryInterval
+	}
+
+	var (
+		logger   = proofProducer.LoggerFromContext(ctx)
+		attempts uint64
+	)
+	err := backoff.Retry(func() error {
+		attempts++
+
+		// The block might have been verified by other provers while retrying.
+		if attempts > 1 {
+			if err := slot.toWitness(ctx); err != nil {
+				return backoff.Permanent(err)
+			}
+
+			isVerified, err := p.isBlockVerified(event.Id)
+			if err != nil {
+				return err
+			}
+			if isVerified {
+				return backoff.Permanent(errBlockVerified)
+			}
+		}
+
+		err := p.requestStagedProof(ctx, event, slot, retryInterval)
+		if err == nil {
+			return nil
+		}
+
+		if isPermanentRequestProofError(err) {
+			metrics.ProverRequestProofPermanentErrCounter.Inc(1)
+			return backoff.Permanent(err)
+		}
+
+		metrics.ProverRequestProofTransientErrCounter.Inc(1)
+		logger.Warn("Failed to request proof", "attempts", attempts, "error", err)
+
+		return err
+	}, backoff.WithContext(
+		backoff.WithMaxRetries(backoff.NewConstantBackOff(retryInterval), maxAttempts-1),
+		ctx,
+	))
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, errBlockVerified) {
+		logger.Info("📋 Block has been verified, stop requesting proof")
+		return nil
+	}
+
+	if !isPermanentRequestProofError(err) {
+		logger.Error(
+			"Failed to request proof, retries exhausted",
+			"attempts", attempts,
+			"error", err,
+		)
+		metrics.ProverRequestProofExhaustedCounter.Inc(1)
+	}
+
+	return err
+}
+
+// isPermanentRequestProofError checks whether the given proof request error is permanent, which means
+// retrying the same request won't help.
+func isPermanentRequestProofError(err error) bool {
+	return errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, errBlockVerified) ||
+		errors.Is(err, proofProducer.ErrInvalidProofRequest)
+}
+
+// requestStagedProof requests a proof for the given block, the given slot is moved to the proof computation
+// stage once the witness is prepared, or right away if the submitter's requests can't be split into stages.
+func (p *Prover) requestStagedProof(
+	ctx context.Context,
+	event *bindings.TaikoL1ClientBlockProposed,
+	slot *proofRequestSlot,
+	retryInterval time.Duration,
+) error {
+	staged, ok := p.validProofSubmitter.(proofSubmitter.StagedProofSubmitter)
+	if !ok {
+		if err := slot.toProving(ctx); err != nil {
+			return err
+		}
+		return waitProducerQueue(ctx, retryInterval, func() error {
+			return p.validProofSubmitter.RequestProof(ctx, event)
+		})
+	}
+
+	prepared, err := staged.PrepareProof(ctx, event)
+	if err != nil {
+		return err
+	}
+	if err := slot.toProving(ctx); err != nil {
+		return err
+	}
+
+	return waitProducerQueue(ctx, retryInterval, func() error {
+		return staged.RequestPreparedProof(ctx, prepared)
+	})
+}
+
+// waitProducerQueue keeps calling the given proof request while the proof producer's job queue is full,
+// backing off for the given interval in between, so that a busy producer never exhausts the request's
+// retries, and the proving slot is held meanwhile, which keeps the following blocks in the priority queue.
+func waitProducerQueue(ctx context.Context, interval time.Duration, request func() error) error {
+	for {
+		err := request()
+		if !errors.Is(err, proofProducer.ErrProducerQueueFull) {
+			return err
+		}
+
+		proofProducer.LoggerFromContext(ctx).Info("Proof producer queue full, back off", "error", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// onQueuedProofFailed handles the failure of a proof job queued by the ZKEVM RPCD producer, which fails
+// after its request has returned, the block is released like a failed request, so that it can be
+// handled again, or marked unprovable if it is unsupported by the circuits.
+func (p *Prover) onQueuedProofFailed(job *proofProducer.QueuedJob, err error) {
+	blockID := job.BlockID.Uint64()
+	if event, ok := p.proofRequestEvents.Load(blockID); ok && errors.Is(err, proofProducer.ErrUnsupportedBlock) {
+		event := event.(*bindings.TaikoL1ClientBlockProposed)
+		p.markUnprovable(event, err, p.blockLogger(event))
+	}
+	p.proofRequestedAt.Delete(blockID)
+	p.proofTimes.Delete(blockID)
+	p.releaseProofRequest(blockID)
+	p.handledBlocks.Remove(handledBlockKey{blockID: blockID, parentHash: job.Header.ParentHash})
+}
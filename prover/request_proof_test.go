@@ -0,0 +1,86 @@
+package prover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/prover/cache"
+	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+)
+
+func TestWaitProducerQueue(t *testing.T) {
+	var calls int
+	require.Nil(t, waitProducerQueue(context.Background(), time.Millisecond, func() error {
+		if calls++; calls < 3 {
+			return proofProducer.ErrProducerQueueFull
+		}
+		return nil
+	}))
+	require.Equal(t, 3, calls)
+
+	// Other errors are returned at once.
+	calls = 0
+	require.ErrorContains(t, waitProducerQueue(context.Background(), time.Millisecond, func() error {
+		calls++
+		return errors.New("transient")
+	}), "transient")
+	require.Equal(t, 1, calls)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, waitProducerQueue(ctx, time.Hour, func() error {
+		return proofProducer.ErrProducerQueueFull
+	}), context.Canceled)
+}
+
+func TestOnQueuedProofFailed(t *testing.T) {
+	p := &Prover{handledBlocks: cache.NewLRU[handledBlockKey, struct{}](16)}
+
+	parentHash := common.HexToHash("0x01")
+	key := handledBlockKey{blockID: 2, parentHash: parentHash}
+	p.handledBlocks.Add(key, struct{}{})
+	p.proofRequestedAt.Store(uint64(2), time.Now())
+	requestCtx := p.proofRequestContext(context.Background(), 2)
+
+	p.onQueuedProofFailed(&proofProducer.QueuedJob{
+		BlockID: big.NewInt(2),
+		Header:  &types.Header{ParentHash: parentHash},
+	}, errors.New("proverd down"))
+
+	require.False(t, p.handledBlocks.Contains(key))
+	_, ok := p.proofRequestedAt.Load(uint64(2))
+	require.False(t, ok)
+	require.ErrorIs(t, requestCtx.Err(), context.Canceled)
+}
+
+func TestOnQueuedProofFailedUnsupportedBlock(t *testing.T) {
+	p := &Prover{
+		cfg:              &Config{},
+		handledBlocks:    cache.NewLRU[handledBlockKey, struct{}](16),
+		unprovableBlocks: newUnprovableBlocks(),
+	}
+	event := &bindings.TaikoL1ClientBlockProposed{Id: big.NewInt(2)}
+	job := &proofProducer.QueuedJob{BlockID: big.NewInt(2), Header: &types.Header{}}
+
+	_ = p.proofRequestContext(context.Background(), 2)
+	p.proofRequestEvents.Store(uint64(2), event)
+	p.onQueuedProofFailed(job, fmt.Errorf("%w, height: 2", proofProducer.ErrUnsupportedBlock))
+	require.True(t, p.unprovableBlocks.Contains(2))
+	_, ok := p.proofRequestEvents.Load(uint64(2))
+	require.False(t, ok)
+
+	// Only the unsupported blocks are unprovable.
+	_ = p.proofRequestContext(context.Background(), 3)
+	p.proofRequestEvents.Store(uint64(3), &bindings.TaikoL1ClientBlockProposed{Id: big.NewInt(3)})
+	job.BlockID = big.NewInt(3)
+	p.onQueuedProofFailed(job, errors.New("proverd down"))
+	require.False(t, p.unprovableBlocks.Contains(3))
+}
@@ -0,0 +1,172 @@
+package prover
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/metrics"
+	"github.com/taikoxyz/taiko-client/pkg/server"
+)
+
+const (
+	defaultRpcdProbeInterval = time.Minute
+	rpcdProbeTimeout         = 10 * time.Second
+)
+
+// RpcdEndpointHealth is the latest probe result of a ZKEVM RPCD endpoint.
+type RpcdEndpointHealth struct {
+	Endpoint       string     `json:"endpoint"`
+	Up             bool       `json:"up"`
+	ProbedAt       *time.Time `json:"probedAt,omitempty"`       // Nil if never probed
+	CircuitVersion string     `json:"circuitVersion,omitempty"` // Reported by the latest successful probe
+	Error          string     `json:"error,omitempty"`
+}
+
+// Health is the response of the `/healthz` endpoint, the prover is healthy if at least one of its ZKEVM
+// RPCD endpoints is up, since the others are the fallbacks.
+type Health struct {
+	Healthy       bool                  `json:"healthy"`
+	RpcdEndpoints []*RpcdEndpointHealth `json:"rpcdEndpoints,omitempty"`
+}
+
+// rpcdHealth holds the latest probe results of the ZKEVM RPCD endpoints.
+type rpcdHealth struct {
+	mutex     sync.Mutex
+	endpoints []*RpcdEndpointHealth
+}
+
+// newRpcdHealth creates a new rpcdHealth instance of the given endpoints, which are never probed yet.
+func newRpcdHealth(endpoints []string) *rpcdHealth {
+	h := &rpcdHealth{endpoints: make([]*RpcdEndpointHealth, len(endpoints))}
+	for i, endpoint := range endpoints {
+		h.endpoints[i] = &RpcdEndpointHealth{Endpoint: endpoint}
+	}
+	return h
+}
+
+// record records the probe result of the i-th endpoint, along with its circuit version.
+func (h *rpcdHealth) record(i int, probedAt time.Time, circuitVersion string, err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	// The entries are updated by copy, so that the readers never see a partially updated one.
+	entry := &RpcdEndpointHealth{
+		Endpoint:       h.endpoints[i].Endpoint,
+		Up:             err == nil,
+		ProbedAt:       &probedAt,
+		CircuitVersion: circuitVersion,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	h.endpoints[i] = entry
+}
+
+// circuitVersions returns the latest circuit versions of all the endpoints joined, which changes once any
+// endpoint upgrades its circuits, empty if none of them reports a version.
+func (h *rpcdHealth) circuitVersions() string {
+	if h == nil {
+		return ""
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	var (
+		versions = make([]string, len(h.endpoints))
+		reported bool
+	)
+	for i, endpoint := range h.endpoints {
+		versions[i] = endpoint.CircuitVersion
+		reported = reported || len(endpoint.CircuitVersion) != 0
+	}
+	if !reported {
+		return ""
+	}
+
+	return strings.Join(versions, ",")
+}
+
+// snapshot returns the latest probe results.
+func (h *rpcdHealth) snapshot() []*RpcdEndpointHealth {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return append([]*RpcdEndpointHealth{}, h.endpoints...)
+}
+
+// probeRpcdEndpoints probes all the ZKEVM RPCD endpoints, and returns the first error if any of them is down.
+func (p *Prover) probeRpcdEndpoints(ctx context.Context) error {
+	var firstErr error
+	for i, producer := range p.rpcdProducers {
+		probeCtx, cancel := context.WithTimeout(ctx, rpcdProbeTimeout)
+		err := producer.Probe(probeCtx)
+		cancel()
+
+		p.rpcdHealth.record(i, time.Now(), producer.CircuitVersion(), err)
+		if err != nil {
+			log.Warn("ZKEVM RPCD endpoint is down", "endpoint", producer.RpcdEndpoint, "error", err)
+			metrics.ProverRpcdEndpointUpGauge(i).Update(0)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		metrics.ProverRpcdEndpointUpGauge(i).Update(1)
+	}
+
+	return firstErr
+}
+
+// monitorRpcdEndpoints keeps probing the ZKEVM RPCD endpoints periodically.
+func (p *Prover) monitorRpcdEndpoints() {
+	ticker := time.NewTicker(defaultRpcdProbeInterval)
+	defer func() {
+		ticker.Stop()
+		p.wg.Done()
+	}()
+
+	for {
+		// The errors are logged and exported by the endpoints' health.
+		_ = p.probeRpcdEndpoints(p.ctx)
+		p.retryUnprovableBlocks(p.ctx)
+
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Health returns the health of the prover.
+func (p *Prover) Health() *Health {
+	if p.rpcdHealth == nil {
+		return &Health{Healthy: true}
+	}
+
+	health := &Health{RpcdEndpoints: p.rpcdHealth.snapshot()}
+	for _, endpoint := range health.RpcdEndpoints {
+		if endpoint.Up {
+			health.Healthy = true
+			break
+		}
+	}
+
+	return health
+}
+
+// handleHealthz handles the `/healthz` requests, responds 503 if the prover is unhealthy.
+func (p *Prover) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	health := p.Health()
+
+	statusCode := http.StatusOK
+	if !health.Healthy {
+		statusCode = http.StatusServiceUnavailable
+	}
+	server.WriteJSON(w, statusCode, health)
+}
@@ -0,0 +1,69 @@
+package prover
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+)
+
+func TestProbeRpcdEndpoints(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"circuit_version":"v0.5.0"}}`))
+	}))
+	defer srv.Close()
+
+	endpoints := []string{"http://localhost:1", srv.URL}
+	p := &Prover{rpcdHealth: newRpcdHealth(endpoints)}
+	for _, endpoint := range endpoints {
+		producer, err := proofProducer.NewZkevmRpcdProducer(endpoint, "", "", "", false)
+		require.Nil(t, err)
+		p.rpcdProducers = append(p.rpcdProducers, producer)
+	}
+
+	// Never probed yet.
+	require.False(t, p.Health().Healthy)
+	require.Empty(t, p.rpcdHealth.circuitVersions())
+
+	require.ErrorContains(t, p.probeRpcdEndpoints(context.Background()), "failed to probe proverd")
+
+	// The fallback endpoint is up.
+	health := p.Health()
+	require.True(t, health.Healthy)
+	require.False(t, health.RpcdEndpoints[0].Up)
+	require.NotEmpty(t, health.RpcdEndpoints[0].Error)
+	require.NotNil(t, health.RpcdEndpoints[0].ProbedAt)
+	require.True(t, health.RpcdEndpoints[1].Up)
+	require.Equal(t, "v0.5.0", health.RpcdEndpoints[1].CircuitVersion)
+	require.Equal(t, ",v0.5.0", p.rpcdHealth.circuitVersions())
+
+	rec := httptest.NewRecorder()
+	p.handleHealthz(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	// All endpoints are down.
+	srv.Close()
+	require.NotNil(t, p.probeRpcdEndpoints(context.Background()))
+
+	rec = httptest.NewRecorder()
+	p.handleHealthz(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	var res Health
+	require.Nil(t, json.NewDecoder(rec.Body).Decode(&res))
+	require.False(t, res.Healthy)
+	require.Len(t, res.RpcdEndpoints, 2)
+
+	// The circuit versions are kept while the endpoints are down.
+	require.Equal(t, "v0.5.0", res.RpcdEndpoints[1].CircuitVersion)
+}
+
+func TestHealthWithoutRpcdEndpoints(t *testing.T) {
+	p := &Prover{}
+	require.True(t, p.Health().Healthy)
+	require.Empty(t, p.rpcdHealth.circuitVersions())
+}
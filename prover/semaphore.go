@@ -0,0 +1,88 @@
+package prover
+
+import (
+	"context"
+	"sync"
+)
+
+// resizableSemaphore is a counting semaphore whose limit can be changed at runtime, shrinking the limit
+// never interrupts the holders, new acquisitions will just wait until enough holders are released.
+type resizableSemaphore struct {
+	mu    sync.Mutex
+	cond  *sync.Cond
+	limit uint
+	inUse uint
+}
+
+// newResizableSemaphore creates a new resizableSemaphore instance with the given limit.
+func newResizableSemaphore(limit uint) *resizableSemaphore {
+	s := &resizableSemaphore{limit: limit}
+	s.cond = sync.NewCond(&s.mu)
+	return s
+}
+
+// Acquire blocks until the semaphore is acquired, or the given context is done.
+func (s *resizableSemaphore) Acquire(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.inUse >= s.limit {
+		// Wake up the waiters once the context is done, the lock is held until s.cond.Wait, so the broadcast
+		// can't be missed.
+		acquired := make(chan struct{})
+		defer close(acquired)
+		go func() {
+			select {
+			case <-ctx.Done():
+				s.mu.Lock()
+				s.cond.Broadcast()
+				s.mu.Unlock()
+			case <-acquired:
+			}
+		}()
+	}
+
+	for s.inUse >= s.limit {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		s.cond.Wait()
+	}
+	s.inUse++
+
+	return nil
+}
+
+// Release releases the semaphore.
+func (s *resizableSemaphore) Release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.inUse--
+	s.cond.Broadcast()
+}
+
+// SetLimit changes the limit of the semaphore.
+func (s *resizableSemaphore) SetLimit(limit uint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.limit = limit
+	s.cond.Broadcast()
+}
+
+// Limit returns the current limit of the semaphore.
+func (s *resizableSemaphore) Limit() uint {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.limit
+}
+
+// InUse returns the number of current holders.
+func (s *resizableSemaphore) InUse() uint {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.inUse
+}
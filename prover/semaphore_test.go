@@ -0,0 +1,61 @@
+package prover
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResizableSemaphore(t *testing.T) {
+	s := newResizableSemaphore(1)
+	require.Nil(t, s.Acquire(context.Background()))
+	require.Equal(t, uint(1), s.InUse())
+
+	acquired := make(chan struct{})
+	go func() {
+		require.Nil(t, s.Acquire(context.Background()))
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("should not acquire when the limit is reached")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Growing the limit unblocks the waiting acquisition.
+	s.SetLimit(2)
+	<-acquired
+	require.Equal(t, uint(2), s.InUse())
+
+	// Shrinking the limit keeps the current holders.
+	s.SetLimit(1)
+	require.Equal(t, uint(1), s.Limit())
+	require.Equal(t, uint(2), s.InUse())
+
+	s.Release()
+	s.Release()
+	require.Zero(t, s.InUse())
+}
+
+func TestResizableSemaphoreCancelled(t *testing.T) {
+	s := newResizableSemaphore(1)
+	require.Nil(t, s.Acquire(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error)
+	go func() { errCh <- s.Acquire(ctx) }()
+
+	select {
+	case <-errCh:
+		t.Fatal("should not acquire when the limit is reached")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Cancelling the context unblocks the waiting acquisition.
+	cancel()
+	require.ErrorIs(t, <-errCh, context.Canceled)
+	require.Equal(t, uint(1), s.InUse())
+}
@@ -0,0 +1,336 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	producer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+	submitter "github.com/taikoxyz/taiko-client/prover/proof_submitter"
+)
+
+const (
+	// Version is the version of the snapshot format written by current client, bumped on every
+	// incompatible change.
+	Version = 1
+
+	// manifestName is the name of the manifest entry of the snapshot archives.
+	manifestName = "manifest.json"
+	// proofsDir is the directory of the cached proof entries of the snapshot archives.
+	proofsDir = "proofs/"
+	// proofExt is the file extension of the local proof cache entries.
+	proofExt = ".json"
+)
+
+// ErrUnsupportedVersion is returned when reading a snapshot of an unknown format version.
+var ErrUnsupportedVersion = errors.New("unsupported snapshot version")
+
+// Snapshot is the proof pipeline state of a prover, which can be moved to another machine during a planned
+// maintenance migration, so that the in-flight proof jobs and the generated but not yet submitted proofs
+// are not lost.
+type Snapshot struct {
+	Version            int                        `json:"version"`
+	CreatedAt          time.Time                  `json:"createdAt"`
+	ClientVersion      string                     `json:"clientVersion"`
+	TaikoL1Address     common.Address             `json:"taikoL1Address"`
+	ProverAddress      common.Address             `json:"proverAddress"`      // Zero if taken offline
+	Jobs               []*producer.JournalEntry   `json:"jobs"`               // In-flight proverd proof jobs
+	QueuedBlocks       []uint64                   `json:"queuedBlocks"`       // Pending proof requests
+	PendingSubmissions []uint64                   `json:"pendingSubmissions"` // Proofs waiting for submission
+	UnprovenBlocks     []uint64                   `json:"unprovenBlocks"`     // Blocks still lacking a fork choice
+	Submissions        []submitter.SubmittedProof `json:"submissions"`        // Tracked submitted proofs
+	Proofs             []string                   `json:"proofs"`             // Entries of the local proof cache
+
+	proofs map[string][]byte // Contents of the cached proofs, set by Read
+}
+
+// Cursor returns the lowest block ID still to be proved by the snapshot, from which the proving should
+// be resumed, or zero if there is none.
+func (s *Snapshot) Cursor() uint64 {
+	var cursor uint64
+	lower := func(id uint64) {
+		if cursor == 0 || id < cursor {
+			cursor = id
+		}
+	}
+
+	for _, job := range s.Jobs {
+		lower(job.BlockID)
+	}
+	for _, ids := range [][]uint64{s.QueuedBlocks, s.PendingSubmissions, s.UnprovenBlocks} {
+		for _, id := range ids {
+			lower(id)
+		}
+	}
+
+	return cursor
+}
+
+// Reconcile drops all the entries of the blocks which have been verified, returns the number of the
+// dropped entries.
+func (s *Snapshot) Reconcile(latestVerifiedID uint64) int {
+	var dropped int
+
+	jobs := s.Jobs[:0]
+	for _, job := range s.Jobs {
+		if job.BlockID > latestVerifiedID {
+			jobs = append(jobs, job)
+		} else {
+			dropped++
+		}
+	}
+	s.Jobs = jobs
+
+	keep := func(ids []uint64) []uint64 {
+		kept := ids[:0]
+		for _, id := range ids {
+			if id > latestVerifiedID {
+				kept = append(kept, id)
+			} else {
+				dropped++
+			}
+		}
+		return kept
+	}
+	s.QueuedBlocks = keep(s.QueuedBlocks)
+	s.PendingSubmissions = keep(s.PendingSubmissions)
+	s.UnprovenBlocks = keep(s.UnprovenBlocks)
+
+	submissions := s.Submissions[:0]
+	for _, submission := range s.Submissions {
+		if submission.BlockID > latestVerifiedID {
+			submissions = append(submissions, submission)
+		} else {
+			dropped++
+		}
+	}
+	s.Submissions = submissions
+
+	proofs := s.Proofs[:0]
+	for _, name := range s.Proofs {
+		if blockID, ok := proofBlockID(name); ok && blockID > latestVerifiedID {
+			proofs = append(proofs, name)
+		} else {
+			delete(s.proofs, name)
+			dropped++
+		}
+	}
+	s.Proofs = proofs
+
+	return dropped
+}
+
+// ListProofs returns the names of the local proof cache entries in the given directory, sorted by name.
+func ListProofs(dir string) ([]string, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read proof cache directory: %w", err)
+	}
+
+	var names []string
+	for _, file := range files {
+		if _, ok := proofBlockID(file.Name()); ok && !file.IsDir() {
+			names = append(names, file.Name())
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// Write writes the given snapshot as a tar archive to the given writer, with the listed proofs read from
+// the given local proof cache directory.
+func Write(w io.Writer, snap *Snapshot, proofDir string) error {
+	snap.Version = Version
+
+	manifest, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot manifest: %w", err)
+	}
+
+	tw := tar.NewWriter(w)
+	if err := writeEntry(tw, manifestName, manifest, snap.CreatedAt); err != nil {
+		return err
+	}
+	for _, name := range snap.Proofs {
+		data, err := os.ReadFile(filepath.Join(proofDir, name))
+		if err != nil {
+			return fmt.Errorf("failed to read cached proof: %w", err)
+		}
+		if err := writeEntry(tw, proofsDir+name, data, snap.CreatedAt); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// WriteFile writes the given snapshot to the given path atomically, see Write.
+func WriteFile(path string, snap *Snapshot, proofDir string) error {
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot: %w", err)
+	}
+	if err := Write(f, snap, proofDir); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// ReadFile reads the snapshot at the given path, see Read.
+func ReadFile(path string) (*Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer f.Close()
+
+	return Read(f)
+}
+
+// writeEntry writes a file entry of the given content to the given tar archive.
+func writeEntry(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o600,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	}); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// Read reads a snapshot tar archive written by Write from the given reader, the proofs are kept in memory
+// until they are extracted by ExtractProofs.
+func Read(r io.Reader) (*Snapshot, error) {
+	var (
+		tr     = tar.NewReader(r)
+		snap   *Snapshot
+		proofs = make(map[string][]byte)
+	)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot: %w", err)
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot: %w", err)
+		}
+
+		switch {
+		case header.Name == manifestName:
+			if snap, err = decodeManifest(data); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(header.Name, proofsDir):
+			name := strings.TrimPrefix(header.Name, proofsDir)
+			if _, ok := proofBlockID(name); !ok || strings.ContainsAny(name, `/\`) {
+				return nil, fmt.Errorf("invalid snapshot proof entry: %s", header.Name)
+			}
+			proofs[name] = data
+		default:
+			return nil, fmt.Errorf("unknown snapshot entry: %s", header.Name)
+		}
+	}
+
+	if snap == nil {
+		return nil, fmt.Errorf("snapshot manifest not found")
+	}
+	for _, name := range snap.Proofs {
+		if _, ok := proofs[name]; !ok {
+			return nil, fmt.Errorf("snapshot proof entry not found: %s", name)
+		}
+	}
+	snap.proofs = proofs
+
+	return snap, nil
+}
+
+// decodeManifest decodes the given snapshot manifest, rejecting the unknown format versions.
+func decodeManifest(data []byte) (*Snapshot, error) {
+	var versioned struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &versioned); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot manifest: %w", err)
+	}
+	if versioned.Version != Version {
+		return nil, fmt.Errorf("%w: %d, expected: %d", ErrUnsupportedVersion, versioned.Version, Version)
+	}
+
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot manifest: %w", err)
+	}
+
+	return &snap, nil
+}
+
+// ExtractProofs writes the proofs of the snapshot read by Read to the given local proof cache directory,
+// the directory is created if it does not exist.
+func (s *Snapshot) ExtractProofs(dir string) error {
+	if len(s.Proofs) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create proof cache directory: %w", err)
+	}
+
+	for _, name := range s.Proofs {
+		if err := os.WriteFile(filepath.Join(dir, name), s.proofs[name], 0o600); err != nil {
+			return fmt.Errorf("failed to write cached proof: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// proofBlockID parses the block ID of the given local proof cache entry name.
+func proofBlockID(name string) (uint64, bool) {
+	if !strings.HasSuffix(name, proofExt) {
+		return 0, false
+	}
+	blockID, err := strconv.ParseUint(strings.SplitN(name, "-", 2)[0], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return blockID, true
+}
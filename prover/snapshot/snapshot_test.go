@@ -0,0 +1,143 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	producer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+	submitter "github.com/taikoxyz/taiko-client/prover/proof_submitter"
+)
+
+func newTestSnapshot(t *testing.T, proofDir string) *Snapshot {
+	for _, name := range []string{"1-0x01-0x00.json", "3-0x03-0x02.json"} {
+		require.Nil(t, os.WriteFile(filepath.Join(proofDir, name), []byte(name), 0o600))
+	}
+	// Not cache entries.
+	require.Nil(t, os.WriteFile(filepath.Join(proofDir, "3-0x03-0x02.json.tmp"), []byte{}, 0o600))
+	require.Nil(t, os.WriteFile(filepath.Join(proofDir, "README"), []byte{}, 0o600))
+
+	proofs, err := ListProofs(proofDir)
+	require.Nil(t, err)
+
+	return &Snapshot{
+		CreatedAt:      time.Unix(1700000000, 0).UTC(),
+		TaikoL1Address: common.HexToAddress("0x01"),
+		Jobs: []*producer.JournalEntry{
+			{BlockID: 2, Endpoint: "http://localhost:9000", RequestID: big.NewInt(2)},
+			{BlockID: 4, Endpoint: "http://localhost:9000", RequestID: big.NewInt(4)},
+		},
+		QueuedBlocks:       []uint64{5, 6},
+		PendingSubmissions: []uint64{3},
+		UnprovenBlocks:     []uint64{1, 2, 3, 4, 5, 6},
+		Submissions:        []submitter.SubmittedProof{{BlockID: 1}, {BlockID: 3}},
+		Proofs:             proofs,
+	}
+}
+
+func TestWriteReadRoundTrip(t *testing.T) {
+	proofDir := t.TempDir()
+	snap := newTestSnapshot(t, proofDir)
+	require.Equal(t, []string{"1-0x01-0x00.json", "3-0x03-0x02.json"}, snap.Proofs)
+	require.Equal(t, uint64(1), snap.Cursor())
+
+	path := filepath.Join(t.TempDir(), "snap.tar")
+	require.Nil(t, WriteFile(path, snap, proofDir))
+
+	restored, err := ReadFile(path)
+	require.Nil(t, err)
+	require.Equal(t, Version, restored.Version)
+	require.Equal(t, snap.CreatedAt, restored.CreatedAt)
+	require.Equal(t, snap.TaikoL1Address, restored.TaikoL1Address)
+	require.Equal(t, snap.QueuedBlocks, restored.QueuedBlocks)
+	require.Equal(t, snap.PendingSubmissions, restored.PendingSubmissions)
+	require.Equal(t, snap.Submissions, restored.Submissions)
+	require.Len(t, restored.Jobs, 2)
+	require.Equal(t, int64(4), restored.Jobs[1].RequestID.Int64())
+
+	targetDir := filepath.Join(t.TempDir(), "proofs")
+	require.Nil(t, restored.ExtractProofs(targetDir))
+	proofs, err := ListProofs(targetDir)
+	require.Nil(t, err)
+	require.Equal(t, snap.Proofs, proofs)
+	data, err := os.ReadFile(filepath.Join(targetDir, "3-0x03-0x02.json"))
+	require.Nil(t, err)
+	require.Equal(t, []byte("3-0x03-0x02.json"), data)
+}
+
+func TestReconcile(t *testing.T) {
+	proofDir := t.TempDir()
+
+	var buf bytes.Buffer
+	require.Nil(t, Write(&buf, newTestSnapshot(t, proofDir), proofDir))
+	snap, err := Read(&buf)
+	require.Nil(t, err)
+
+	// Job 2, pending submission 3, unproven blocks 1, 2, 3, submissions 1, 3, and proofs 1, 3.
+	require.Equal(t, 9, snap.Reconcile(3))
+	require.Len(t, snap.Jobs, 1)
+	require.Equal(t, uint64(4), snap.Jobs[0].BlockID)
+	require.Equal(t, []uint64{5, 6}, snap.QueuedBlocks)
+	require.Empty(t, snap.PendingSubmissions)
+	require.Equal(t, []uint64{4, 5, 6}, snap.UnprovenBlocks)
+	require.Empty(t, snap.Submissions)
+	require.Empty(t, snap.Proofs)
+	require.Equal(t, uint64(4), snap.Cursor())
+
+	// Nothing left to be proved.
+	require.Equal(t, 6, snap.Reconcile(6))
+	require.Zero(t, snap.Cursor())
+
+	targetDir := filepath.Join(t.TempDir(), "proofs")
+	require.Nil(t, snap.ExtractProofs(targetDir))
+	_, err = os.Stat(targetDir)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestReadInvalid(t *testing.T) {
+	archive := func(entries map[string][]byte) *bytes.Buffer {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for name, data := range entries {
+			require.Nil(t, writeEntry(tw, name, data, time.Now()))
+		}
+		require.Nil(t, tw.Close())
+		return &buf
+	}
+	manifest := func(snap map[string]interface{}) []byte {
+		data, err := json.Marshal(snap)
+		require.Nil(t, err)
+		return data
+	}
+
+	_, err := Read(archive(map[string][]byte{manifestName: manifest(map[string]interface{}{"version": Version + 1})}))
+	require.True(t, errors.Is(err, ErrUnsupportedVersion))
+
+	_, err = Read(archive(map[string][]byte{}))
+	require.ErrorContains(t, err, "manifest not found")
+
+	_, err = Read(archive(map[string][]byte{
+		manifestName: manifest(map[string]interface{}{"version": Version, "proofs": []string{"1-0x01-0x00.json"}}),
+	}))
+	require.ErrorContains(t, err, "proof entry not found")
+
+	_, err = Read(archive(map[string][]byte{
+		manifestName:                      manifest(map[string]interface{}{"version": Version}),
+		proofsDir + "../1-0x01-0x00.json": {},
+	}))
+	require.ErrorContains(t, err, "invalid snapshot proof entry")
+
+	_, err = Read(archive(map[string][]byte{
+		manifestName: manifest(map[string]interface{}{"version": Version}),
+		"unknown":    {},
+	}))
+	require.ErrorContains(t, err, "unknown snapshot entry")
+}
@@ -0,0 +1,119 @@
+package prover
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/bindings"
+	eventIterator "github.com/taikoxyz/taiko-client/pkg/chain_iterator/event_iterator"
+)
+
+// resolveStartingBlockID resolves the `--prover.startingBlockHash` or `--prover.startingTimestamp` starting
+// option into a block ID, returns nil if neither of them is set.
+func (p *Prover) resolveStartingBlockID(ctx context.Context, genesisHeight uint64) (*big.Int, error) {
+	if p.cfg.StartingBlockHash != nil {
+		return p.blockIDByHash(ctx)
+	}
+
+	if p.cfg.StartingTimestamp != 0 {
+		return p.firstBlockIDProposedAfter(ctx, genesisHeight, p.cfg.StartingTimestamp)
+	}
+
+	return nil, nil
+}
+
+// blockIDByHash looks up the ID of the starting L2 block by its hash, the block must be a canonical one
+// derived from L1.
+func (p *Prover) blockIDByHash(ctx context.Context) (*big.Int, error) {
+	header, err := p.rpc.L2.HeaderByHash(ctx, *p.cfg.StartingBlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the starting L2 block %s: %w", p.cfg.StartingBlockHash, err)
+	}
+
+	l1Origin, err := p.rpc.L2.L1OriginByID(ctx, header.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the starting L2 block's L1 origin: %w", err)
+	}
+
+	if l1Origin.L2BlockHash != header.Hash() {
+		return nil, fmt.Errorf(
+			"starting L2 block %s is not canonical, L1 origin's block hash: %s",
+			header.Hash(),
+			l1Origin.L2BlockHash,
+		)
+	}
+
+	return header.Number, nil
+}
+
+// firstBlockIDProposedAfter finds the ID of the first block proposed at or after the given timestamp, it
+// binary searches the first L1 block at or after that time, and then iterates the BlockProposed events
+// from there. Returns nil if no block has been proposed after that time yet.
+func (p *Prover) firstBlockIDProposedAfter(
+	ctx context.Context,
+	genesisHeight uint64,
+	timestamp uint64,
+) (*big.Int, error) {
+	head, err := p.rpc.L1.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch L1 head: %w", err)
+	}
+
+	if head.Time < timestamp {
+		log.Warn("No block proposed after the starting timestamp", "timestamp", timestamp, "l1Head", head.Number)
+		return nil, nil
+	}
+
+	// Find the first L1 block with a timestamp not earlier than the given one.
+	lo, hi := genesisHeight, head.Number.Uint64()
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+
+		header, err := p.rpc.L1.HeaderByNumber(ctx, new(big.Int).SetUint64(mid))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch L1 header %d: %w", mid, err)
+		}
+
+		if header.Time < timestamp {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	var startingBlockID *big.Int
+	l1Client, taikoL1 := p.rpc.L1Backfill()
+	iter, err := eventIterator.NewBlockProposedIterator(ctx, &eventIterator.BlockProposedIteratorConfig{
+		Client:      l1Client,
+		TaikoL1:     taikoL1,
+		StartHeight: new(big.Int).SetUint64(lo),
+		EndHeight:   head.Number,
+		OnBlockProposedEvent: func(
+			_ context.Context,
+			event *bindings.TaikoL1ClientBlockProposed,
+			end eventIterator.EndBlockProposedEventIterFunc,
+		) error {
+			startingBlockID = event.Id
+			end()
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := iter.Iter(); err != nil {
+		return nil, fmt.Errorf("failed to iterate BlockProposed events: %w", err)
+	}
+
+	if startingBlockID == nil {
+		log.Warn("No block proposed after the starting timestamp", "timestamp", timestamp, "l1Height", lo)
+		return nil, nil
+	}
+
+	log.Info("Resolved the starting block by timestamp", "timestamp", timestamp, "blockID", startingBlockID)
+
+	return startingBlockID, nil
+}
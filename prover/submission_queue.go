@@ -0,0 +1,159 @@
+package prover
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/taikoxyz/taiko-client/metrics"
+	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+)
+
+// defaultSubmissionEscalationMargin is the remaining proof window, below which a generated proof is
+// submitted before all the others regardless of its class.
+const defaultSubmissionEscalationMargin = 5 * time.Minute
+
+// queuedProof is a generated proof waiting for submission.
+type queuedProof struct {
+	proofWithHeader *proofProducer.ProofWithHeader
+	class           proofClass
+	deadline        time.Time // End of the block's proof window
+	enqueuedAt      time.Time
+	seq             uint64 // FIFO order within the class
+}
+
+// isValid returns whether the queued proof is a valid block proof.
+func (p *queuedProof) isValid() bool { return p.class == proofClassValid }
+
+// SubmissionQueue is an unbounded queue of the generated proofs waiting for submission. The valid proofs are
+// dequeued before the invalid ones, FIFO within the class, except that the proofs whose blocks are within
+// the escalation margin of their proof window's end are dequeued first, the earliest deadline first.
+type SubmissionQueue struct {
+	mutex            sync.Mutex
+	items            []*queuedProof // Small in practice, so it is scanned linearly
+	seq              uint64
+	escalationMargin time.Duration
+	notify           chan struct{}
+}
+
+// NewSubmissionQueue creates a new SubmissionQueue instance with the given escalation margin.
+func NewSubmissionQueue(escalationMargin time.Duration) *SubmissionQueue {
+	return &SubmissionQueue{escalationMargin: escalationMargin, notify: make(chan struct{}, 1)}
+}
+
+// Push adds the given generated proof to the queue.
+func (q *SubmissionQueue) Push(proofWithHeader *proofProducer.ProofWithHeader, class proofClass, deadline time.Time) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.seq++
+	q.items = append(q.items, &queuedProof{
+		proofWithHeader: proofWithHeader,
+		class:           class,
+		deadline:        deadline,
+		enqueuedAt:      time.Now(),
+		seq:             q.seq,
+	})
+	q.updateDepthGauges()
+	q.signal()
+}
+
+// Pop blocks until there is a proof in the queue, and then removes and returns the most urgent one.
+func (q *SubmissionQueue) Pop(ctx context.Context) (*queuedProof, error) {
+	for {
+		q.mutex.Lock()
+		if len(q.items) != 0 {
+			i := q.next(time.Now())
+			item := q.items[i]
+			q.items = append(q.items[:i], q.items[i+1:]...)
+			q.updateDepthGauges()
+			// Wake up the other waiters, if there are still some proofs left.
+			if len(q.items) != 0 {
+				q.signal()
+			}
+			q.mutex.Unlock()
+
+			metrics.ProverSubmissionQueueWaitTimer.UpdateSince(item.enqueuedAt)
+			return item, nil
+		}
+		q.mutex.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-q.notify:
+		}
+	}
+}
+
+// next returns the index of the most urgent proof at the given time, the caller must hold the mutex and
+// make sure the queue is not empty.
+func (q *SubmissionQueue) next(now time.Time) int {
+	var (
+		escalated = -1
+		best      = 0
+	)
+	for i, item := range q.items {
+		if item.deadline.Sub(now) < q.escalationMargin {
+			if escalated == -1 || item.deadline.Before(q.items[escalated].deadline) {
+				escalated = i
+			}
+			continue
+		}
+
+		if item.class < q.items[best].class || (item.class == q.items[best].class && item.seq < q.items[best].seq) {
+			best = i
+		}
+	}
+	if escalated != -1 {
+		metrics.ProverSubmissionQueueEscalatedCounter.Inc(1)
+		return escalated
+	}
+
+	return best
+}
+
+// Len returns the number of proofs in the queue.
+func (q *SubmissionQueue) Len() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return len(q.items)
+}
+
+// BlockIDs returns the IDs of the blocks whose proofs are in the queue, sorted by block ID.
+func (q *SubmissionQueue) BlockIDs() []uint64 {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	ids := make([]uint64, 0, len(q.items))
+	for _, item := range q.items {
+		ids = append(ids, item.proofWithHeader.BlockID.Uint64())
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
+
+// updateDepthGauges updates the queue depth metrics of each class, the caller must hold the mutex.
+func (q *SubmissionQueue) updateDepthGauges() {
+	var valid, invalid int64
+	for _, item := range q.items {
+		if item.isValid() {
+			valid++
+		} else {
+			invalid++
+		}
+	}
+	metrics.ProverSubmissionQueueValidDepthGauge.Update(valid)
+	metrics.ProverSubmissionQueueInvalidDepthGauge.Update(invalid)
+}
+
+// signal notifies the waiters without blocking, the caller must hold the mutex.
+func (q *SubmissionQueue) signal() {
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
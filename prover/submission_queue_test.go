@@ -0,0 +1,124 @@
+package prover
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+)
+
+func newTestProofWithHeader(id int64, proposedAt time.Time) *proofProducer.ProofWithHeader {
+	return &proofProducer.ProofWithHeader{
+		BlockID: big.NewInt(id),
+		Meta:    &bindings.TaikoDataBlockMetadata{Id: uint64(id), Timestamp: uint64(proposedAt.Unix())},
+		Header:  &types.Header{Number: big.NewInt(id)},
+	}
+}
+
+func TestSubmissionQueueOrdering(t *testing.T) {
+	var (
+		q      = NewSubmissionQueue(5 * time.Minute)
+		future = time.Now().Add(time.Hour)
+	)
+
+	q.Push(newTestProofWithHeader(1, time.Now()), proofClassInvalid, future)
+	q.Push(newTestProofWithHeader(2, time.Now()), proofClassValid, future)
+	q.Push(newTestProofWithHeader(3, time.Now()), proofClassInvalid, time.Now().Add(2*time.Minute))
+	q.Push(newTestProofWithHeader(4, time.Now()), proofClassValid, future)
+	q.Push(newTestProofWithHeader(5, time.Now()), proofClassInvalid, time.Now().Add(time.Minute))
+	require.Equal(t, 5, q.Len())
+
+	// Escalated ones first by deadline, then valid ones, then invalid ones, FIFO within the class.
+	for _, id := range []int64{5, 3, 2, 4, 1} {
+		queued, err := q.Pop(context.Background())
+		require.Nil(t, err)
+		require.Equal(t, id, queued.proofWithHeader.BlockID.Int64())
+	}
+	require.Zero(t, q.Len())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := q.Pop(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+// orderedProofSubmitter records the order of the submitted proofs, the first submission blocks until
+// released.
+type orderedProofSubmitter struct {
+	testProofSubmitter
+	mutex    sync.Mutex
+	started  chan struct{}
+	release  chan struct{}
+	order    []int64
+	inFlight int
+	maxInUse int
+}
+
+func (s *orderedProofSubmitter) SubmitProof(_ context.Context, proofWithHeader *proofProducer.ProofWithHeader) error {
+	s.mutex.Lock()
+	s.order = append(s.order, proofWithHeader.BlockID.Int64())
+	s.inFlight++
+	if s.inFlight > s.maxInUse {
+		s.maxInUse = s.inFlight
+	}
+	first := len(s.order) == 1
+	s.mutex.Unlock()
+
+	if first {
+		close(s.started)
+		<-s.release
+	}
+
+	s.mutex.Lock()
+	s.inFlight--
+	s.mutex.Unlock()
+	return nil
+}
+
+func TestDispatchProofSubmissions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	submitter := &orderedProofSubmitter{started: make(chan struct{}), release: make(chan struct{})}
+	p := &Prover{
+		ctx:                         ctx,
+		cfg:                         &Config{ProofWindow: time.Hour},
+		proveValidProofCh:           make(chan *proofProducer.ProofWithHeader, 8),
+		proveInvalidProofCh:         make(chan *proofProducer.ProofWithHeader, 8),
+		submissionQueue:             NewSubmissionQueue(defaultSubmissionEscalationMargin),
+		submitProofConcurrencyGuard: newResizableSemaphore(1),
+		validProofSubmitter:         submitter,
+	}
+	p.wg.Add(1)
+	go p.dispatchProofSubmissions()
+
+	// The only submission slot is taken by the first proof.
+	p.enqueueProof(newTestProofWithHeader(1, time.Now()), proofClassValid)
+	<-submitter.started
+
+	p.enqueueProof(newTestProofWithHeader(2, time.Now()), proofClassInvalid)
+	p.enqueueProof(newTestProofWithHeader(3, time.Now()), proofClassInvalid)
+	p.enqueueProof(newTestProofWithHeader(4, time.Now()), proofClassValid)
+	p.enqueueProof(newTestProofWithHeader(5, time.Now()), proofClassValid)
+	// Proposed almost a whole proof window ago, so it is escalated.
+	p.enqueueProof(newTestProofWithHeader(6, time.Now().Add(-58*time.Minute)), proofClassInvalid)
+	close(submitter.release)
+
+	require.Eventually(t, func() bool {
+		submitter.mutex.Lock()
+		defer submitter.mutex.Unlock()
+		return len(submitter.order) == 6 && submitter.inFlight == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Equal(t, []int64{1, 6, 4, 5, 2, 3}, submitter.order)
+	require.Equal(t, 1, submitter.maxInUse)
+
+	cancel()
+	p.wg.Wait()
+}
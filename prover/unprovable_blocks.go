@@ -0,0 +1,150 @@
+package prover
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/metrics"
+)
+
+// UnprovableBlock is a block which the proof producer reports unsupported by its current circuits, e.g. using
+// an opcode or a precompile the circuits don't support, it is not requested again until the circuits change.
+type UnprovableBlock struct {
+	BlockID        uint64    `json:"blockID"`
+	CircuitVersion string    `json:"circuitVersion,omitempty"` // Circuit versions of the ZKEVM RPCD endpoints then
+	Error          string    `json:"error"`
+	MarkedAt       time.Time `json:"markedAt"`
+}
+
+// unprovableBlock is an UnprovableBlock along with its BlockProposed event, to request it again.
+type unprovableBlock struct {
+	UnprovableBlock
+	event *bindings.TaikoL1ClientBlockProposed
+}
+
+// unprovableBlocks is the set of the unprovable blocks, which are not verified yet.
+type unprovableBlocks struct {
+	mutex  sync.Mutex
+	blocks map[uint64]*unprovableBlock
+}
+
+// newUnprovableBlocks creates a new empty unprovableBlocks instance.
+func newUnprovableBlocks() *unprovableBlocks {
+	return &unprovableBlocks{blocks: make(map[uint64]*unprovableBlock)}
+}
+
+// Mark marks the given block unprovable by the circuits of the given versions.
+func (u *unprovableBlocks) Mark(event *bindings.TaikoL1ClientBlockProposed, circuitVersion string, err error) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	u.blocks[event.Id.Uint64()] = &unprovableBlock{
+		UnprovableBlock: UnprovableBlock{
+			BlockID:        event.Id.Uint64(),
+			CircuitVersion: circuitVersion,
+			Error:          err.Error(),
+			MarkedAt:       time.Now(),
+		},
+		event: event,
+	}
+}
+
+// Contains checks whether the given block is unprovable.
+func (u *unprovableBlocks) Contains(id uint64) bool {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	_, ok := u.blocks[id]
+	return ok
+}
+
+// RemoveUpTo removes all the blocks with IDs less than or equal to the given one, used when the blocks
+// have been verified.
+func (u *unprovableBlocks) RemoveUpTo(id uint64) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	for blockID := range u.blocks {
+		if blockID <= id {
+			delete(u.blocks, blockID)
+		}
+	}
+}
+
+// TakeChanged removes and returns the blocks marked unprovable by the circuits of other versions than the
+// given ones, ordered by ID.
+func (u *unprovableBlocks) TakeChanged(circuitVersion string) []*unprovableBlock {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	var changed []*unprovableBlock
+	for blockID, block := range u.blocks {
+		if block.CircuitVersion != circuitVersion {
+			changed = append(changed, block)
+			delete(u.blocks, blockID)
+		}
+	}
+	sort.Slice(changed, func(i, j int) bool { return changed[i].BlockID < changed[j].BlockID })
+
+	return changed
+}
+
+// restore puts back the given block taken by TakeChanged, unless it has been marked again meanwhile.
+func (u *unprovableBlocks) restore(block *unprovableBlock) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if _, ok := u.blocks[block.BlockID]; !ok {
+		u.blocks[block.BlockID] = block
+	}
+}
+
+// Entries returns all the unprovable blocks, ordered by ID.
+func (u *unprovableBlocks) Entries() []UnprovableBlock {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	entries := make([]UnprovableBlock, 0, len(u.blocks))
+	for _, block := range u.blocks {
+		entries = append(entries, block.UnprovableBlock)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].BlockID < entries[j].BlockID })
+
+	return entries
+}
+
+// markUnprovable marks the given block unprovable, so that it won't be requested again with the current
+// circuits of the ZKEVM RPCD endpoints.
+func (p *Prover) markUnprovable(event *bindings.TaikoL1ClientBlockProposed, err error, logger log.Logger) {
+	circuitVersion := p.rpcdHealth.circuitVersions()
+	logger.Warn("Block unsupported by the circuits, mark it unprovable", "circuitVersion", circuitVersion, "error", err)
+	metrics.ProverUnprovableBlockCounter.Inc(1)
+	p.unprovableBlocks.Mark(event, circuitVersion, err)
+}
+
+// retryUnprovableBlocks queues the unprovable blocks again, once the circuit versions of the ZKEVM RPCD
+// endpoints have changed since they were marked. The blocks rejected by the full proof queue are kept, to
+// be retried after the next probe.
+func (p *Prover) retryUnprovableBlocks(ctx context.Context) {
+	blocks := p.unprovableBlocks.TakeChanged(p.rpcdHealth.circuitVersions())
+	if len(blocks) == 0 {
+		return
+	}
+
+	for _, block := range blocks {
+		logger := p.blockLogger(block.event)
+		if err := p.proofQueue.Push(p.classifiedProofRequest(ctx, block.event, logger)); err != nil {
+			logger.Warn("Proof priority queue is full, retry the unprovable block later", "error", err)
+			p.unprovableBlocks.restore(block)
+			continue
+		}
+
+		logger.Info("Circuits changed, retry the unprovable block", "circuitVersion", block.CircuitVersion)
+		metrics.ProverUnprovableBlockRetriedCounter.Inc(1)
+	}
+	metrics.ProverPriorityQueueDepthGauge.Update(int64(p.proofQueue.Len()))
+}
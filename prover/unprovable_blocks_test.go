@@ -0,0 +1,51 @@
+package prover
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+func TestUnprovableBlocks(t *testing.T) {
+	u := newUnprovableBlocks()
+	require.Empty(t, u.Entries())
+
+	for _, id := range []int64{3, 1, 2} {
+		u.Mark(&bindings.TaikoL1ClientBlockProposed{Id: big.NewInt(id)}, "v1", errors.New("unsupported opcode"))
+	}
+	u.Mark(&bindings.TaikoL1ClientBlockProposed{Id: big.NewInt(5)}, "v2", errors.New("unsupported precompile"))
+	require.True(t, u.Contains(1))
+	require.False(t, u.Contains(4))
+
+	entries := u.Entries()
+	require.Len(t, entries, 4)
+	require.Equal(t, uint64(1), entries[0].BlockID)
+	require.Equal(t, "v1", entries[0].CircuitVersion)
+	require.Equal(t, "unsupported opcode", entries[0].Error)
+	require.Equal(t, uint64(5), entries[3].BlockID)
+
+	// Verified.
+	u.RemoveUpTo(1)
+	require.False(t, u.Contains(1))
+
+	// The blocks marked by the other circuit versions are taken.
+	changed := u.TakeChanged("v2")
+	require.Len(t, changed, 2)
+	require.Equal(t, uint64(2), changed[0].BlockID)
+	require.Equal(t, uint64(3), changed[1].BlockID)
+	require.Equal(t, uint64(3), changed[1].event.Id.Uint64())
+	require.Len(t, u.Entries(), 1)
+	require.Empty(t, u.TakeChanged("v2"))
+	require.True(t, u.Contains(5))
+
+	// The blocks marked again meanwhile are not restored.
+	u.Mark(changed[0].event, "v2", errors.New("unsupported opcode"))
+	u.restore(changed[0])
+	u.restore(changed[1])
+	require.Len(t, u.Entries(), 3)
+	require.Equal(t, "v2", u.Entries()[0].CircuitVersion)
+	require.Equal(t, "v1", u.Entries()[1].CircuitVersion)
+}
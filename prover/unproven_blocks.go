@@ -0,0 +1,137 @@
+package prover
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+)
+
+const (
+	// forkChoiceBatchSize is the maximum number of `getForkChoice` calls in one JSON-RPC batch request.
+	forkChoiceBatchSize = 100
+)
+
+// UnprovenBlock is a pending block which still lacks a proof from current prover.
+type UnprovenBlock struct {
+	BlockID          uint64         `json:"blockID"`
+	ParentHash       common.Hash    `json:"parentHash"`
+	ForkChoiceProver common.Address `json:"forkChoiceProver"`
+}
+
+// UnprovenBlocks returns all the pending blocks between the latest verified block and the next
+// block to be proposed, which still need a new proof from current prover, along with their current
+// fork choice provers.
+func (p *Prover) UnprovenBlocks(ctx context.Context) ([]*UnprovenBlock, error) {
+	stateVars, err := p.rpc.GetProtocolStateVariables(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get protocol state variables: %w", err)
+	}
+
+	var (
+		ids     []uint64
+		parents []*types.Header
+	)
+	for id := stateVars.LastVerifiedBlockId + 1; id < stateVars.NumBlocks; id++ {
+		parent, err := p.getParentHeader(ctx, new(big.Int).SetUint64(id))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch the L2 block's parent header: %w", err)
+		}
+
+		ids = append(ids, id)
+		parents = append(parents, parent)
+	}
+
+	unprovenBlocks := make([]*UnprovenBlock, 0)
+	for start := 0; start < len(ids); start += forkChoiceBatchSize {
+		end := start + forkChoiceBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		provers, err := p.batchGetForkChoiceProvers(ctx, ids[start:end], parents[start:end])
+		if err != nil {
+			return nil, err
+		}
+
+		for i, prover := range provers {
+			if prover == p.proverAddress {
+				continue
+			}
+
+			unprovenBlocks = append(unprovenBlocks, &UnprovenBlock{
+				BlockID:          ids[start+i],
+				ParentHash:       parents[start+i].Hash(),
+				ForkChoiceProver: prover,
+			})
+		}
+	}
+
+	return unprovenBlocks, nil
+}
+
+// batchGetForkChoiceProvers fetches the fork choice provers of the given blocks in one JSON-RPC batch
+// request, a zero address will be returned if there is no fork choice for that block.
+func (p *Prover) batchGetForkChoiceProvers(
+	ctx context.Context,
+	ids []uint64,
+	parents []*types.Header,
+) ([]common.Address, error) {
+	var (
+		results = make([]hexutil.Bytes, len(ids))
+		batch   = make([]rpc.BatchElem, len(ids))
+	)
+	for i, id := range ids {
+		data, err := encoding.TaikoL1ABI.Pack(
+			"getForkChoice",
+			new(big.Int).SetUint64(id),
+			parents[i].Hash(),
+			uint32(parents[i].GasUsed),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack getForkChoice calldata: %w", err)
+		}
+
+		batch[i] = rpc.BatchElem{
+			Method: "eth_call",
+			Args: []interface{}{
+				map[string]interface{}{"to": p.cfg.TaikoL1Address, "data": hexutil.Bytes(data)},
+				"latest",
+			},
+			Result: &results[i],
+		}
+	}
+
+	if err := p.rpc.L1RawRPC.BatchCallContext(ctx, batch); err != nil {
+		return nil, fmt.Errorf("failed to batch call getForkChoice: %w", err)
+	}
+
+	provers := make([]common.Address, len(ids))
+	for i, elem := range batch {
+		if elem.Error != nil {
+			err := encoding.TryParsingCustomError(elem.Error)
+			if strings.Contains(err.Error(), "L1_FORK_CHOICE_NOT_FOUND") {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get fork choice of block %d: %w", ids[i], err)
+		}
+
+		out, err := encoding.TaikoL1ABI.Unpack("getForkChoice", results[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to unpack getForkChoice result: %w", err)
+		}
+
+		provers[i] = abi.ConvertType(out[0], new(bindings.TaikoDataForkChoice)).(*bindings.TaikoDataForkChoice).Prover
+	}
+
+	return provers, nil
+}